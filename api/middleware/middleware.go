@@ -5,6 +5,7 @@ import (
 	"database/sql"
 	"fmt"
 	"net/http"
+	"strings"
 
 	"github.com/go-chi/chi/v5"
 	"github.com/go-chi/render"
@@ -69,21 +70,56 @@ func GetExecIDFromContext(ctx context.Context) string {
 	return execID
 }
 
-// WithAccountCtx is a helper middleware that fakes an authenticated account. It should only
-// be user for development or when self-hosting.
-func WithAccountCtx(next http.Handler) http.Handler {
-	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		ctx := r.Context()
-		userID := "uid_1234"
-		ctx = SetUserIDInContext(ctx, userID)
-		ctx = SetAccountIDInContext(ctx, userID)
-		ctx = log.With().Str(types.UserIDCtxKey, userID).Logger().WithContext(ctx)
-		next.ServeHTTP(w, r.WithContext(ctx))
-	})
+// Authenticator resolves a bearer token to the access token it was issued as.
+type Authenticator interface {
+	Authenticate(ctx context.Context, token string) (types.UserAccessToken, error)
+}
+
+// WithAccountCtx authenticates the request using the bearer token in the Authorization
+// header and sets the token's user and account in the context.
+func WithAccountCtx(auth Authenticator) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			ctx := r.Context()
+
+			token, ok := BearerToken(r)
+			if !ok {
+				render.Render(w, r.WithContext(ctx), &types.Error{
+					Code:       http.StatusUnauthorized,
+					Message:    "Missing access token",
+					Suggestion: "Pass an access token as a Bearer token in the Authorization header",
+				})
+				return
+			}
+
+			accessToken, err := auth.Authenticate(ctx, token)
+			if err != nil {
+				render.Render(w, r.WithContext(ctx), types.ErrHTTPError(err, "Failed to authenticate"))
+				return
+			}
+
+			// Accounts are single user for now so the user and account IDs are the same.
+			userID := accessToken.UserID
+			ctx = SetUserIDInContext(ctx, userID)
+			ctx = SetAccountIDInContext(ctx, userID)
+			ctx = log.With().Str(types.UserIDCtxKey, userID).Logger().WithContext(ctx)
+			next.ServeHTTP(w, r.WithContext(ctx))
+		})
+	}
+}
+
+// BearerToken returns the token in the request's Authorization header.
+func BearerToken(r *http.Request) (string, bool) {
+	header := r.Header.Get("Authorization")
+	scheme, token, found := strings.Cut(header, " ")
+	if !found || !strings.EqualFold(scheme, "Bearer") || token == "" {
+		return "", false
+	}
+	return strings.TrimSpace(token), true
 }
 
 // WithProjectCtx is a helper middleware that parsed the project id from the url and
-// verifies it exists in the db.
+// verifies it exists in the db and that the authenticated account has access to it.
 func WithProjectCtx(next http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		ctx := r.Context()
@@ -106,6 +142,25 @@ func WithProjectCtx(next http.Handler) http.Handler {
 			return
 		}
 
+		hasAccess, err := db.Q.ProjectAccountExists(ctx, db.ProjectAccountExistsParams{
+			ProjectID: projectID,
+			AccountID: GetAccountIDFromContext(ctx),
+		})
+		if err != nil {
+			err = fmt.Errorf("failed to check project access %q: %w", projectID, err)
+			render.Render(w, r.WithContext(ctx),
+				types.ErrInternalServer(err, "Failed to fetch project"))
+			return
+		}
+		if !hasAccess {
+			render.Render(w, r.WithContext(ctx), &types.Error{
+				Code:       http.StatusForbidden,
+				Message:    "You don't have access to this project",
+				Suggestion: "Ask the project owner to add you to the project",
+			})
+			return
+		}
+
 		ctx = context.WithValue(ctx, types.ProjectIDCtxKey, projectID)
 		ctx = log.With().Str(types.ProjectIDCtxKey, projectID).Logger().WithContext(ctx)
 
@@ -114,7 +169,8 @@ func WithProjectCtx(next http.Handler) http.Handler {
 }
 
 // WithExecCtx is a helper middleware that parsed the session id from the url and
-// verifies it exists in the db.
+// verifies it exists in the db and belongs to the project in the url. It must run after
+// WithProjectCtx.
 func WithExecCtx(next http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		ctx := r.Context()
@@ -136,8 +192,45 @@ func WithExecCtx(next http.Handler) http.Handler {
 			return
 		}
 
+		// Sessions of other projects are reported as missing to not leak their existence.
+		if exec.ProjectID != GetProjectIDFromContext(ctx) {
+			render.Render(w, r.WithContext(ctx), &types.Error{
+				Code:       http.StatusNotFound,
+				Message:    "Session not found",
+				Suggestion: "Make sure the session id is valid",
+			})
+			return
+		}
+
 		ctx = context.WithValue(ctx, types.ExecIDCtxKey, exec)
 		ctx = log.With().Str(types.ExecIDCtxKey, exec.ID).Logger().WithContext(ctx)
 		next.ServeHTTP(w, r.WithContext(ctx))
 	})
 }
+
+// WithAdminCtx only lets accounts in adminIDs through. It must run after WithAccountCtx.
+func WithAdminCtx(adminIDs []string) func(http.Handler) http.Handler {
+	admins := make(map[string]bool, len(adminIDs))
+	for _, id := range adminIDs {
+		if id = strings.TrimSpace(id); id != "" {
+			admins[id] = true
+		}
+	}
+
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			ctx := r.Context()
+
+			if !admins[GetAccountIDFromContext(ctx)] {
+				render.Render(w, r.WithContext(ctx), &types.Error{
+					Code:       http.StatusForbidden,
+					Message:    "Only admins can access this resource",
+					Suggestion: "Ask an admin to add your account to UNWEAVE_ADMIN_ACCOUNTS",
+				})
+				return
+			}
+
+			next.ServeHTTP(w, r.WithContext(ctx))
+		})
+	}
+}
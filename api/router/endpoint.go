@@ -116,7 +116,9 @@ func (e *EndpointRouter) EndpointEvalCheckStatus(w http.ResponseWriter, r *http.
 		return
 	}
 
-	status, err := e.endpoints.EndpointCheckStatus(ctx, checkID)
+	projectID := middleware.GetProjectIDFromContext(ctx)
+
+	status, err := e.endpoints.EndpointCheckStatus(ctx, projectID, checkID)
 	if err != nil {
 		_ = render.Render(w, r, types.ErrHTTPError(err, "check status"))
 
@@ -126,6 +128,21 @@ func (e *EndpointRouter) EndpointEvalCheckStatus(w http.ResponseWriter, r *http.
 	render.JSON(w, r, status)
 }
 
+func (e *EndpointRouter) EndpointEvalCheckCancel(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	checkID := chi.URLParam(r, "checkID")
+	projectID := middleware.GetProjectIDFromContext(ctx)
+
+	status, err := e.endpoints.EndpointCheckCancel(ctx, projectID, checkID)
+	if err != nil {
+		_ = render.Render(w, r, types.ErrHTTPError(err, "cancel check"))
+
+		return
+	}
+
+	render.JSON(w, r, status)
+}
+
 func (e *EndpointRouter) EndpointCreateVersion(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 	endpointID := chi.URLParam(r, "endpointRef")
@@ -138,7 +155,9 @@ func (e *EndpointRouter) EndpointCreateVersion(w http.ResponseWriter, r *http.Re
 		return
 	}
 
-	version, err := e.endpoints.EndpointVersionCreate(ctx, projectID, endpointID, req.ExecID, req.Promote)
+	userID := middleware.GetUserIDFromContext(ctx)
+
+	version, err := e.endpoints.EndpointVersionCreate(ctx, projectID, endpointID, userID, req)
 	if err != nil {
 		_ = render.Render(w, r, types.ErrHTTPError(err, "create endpoint version"))
 
@@ -147,3 +166,114 @@ func (e *EndpointRouter) EndpointCreateVersion(w http.ResponseWriter, r *http.Re
 
 	render.JSON(w, r, version)
 }
+
+func (e *EndpointRouter) EndpointTrafficSet(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	endpointID := chi.URLParam(r, "endpointRef")
+	projectID := middleware.GetProjectIDFromContext(ctx)
+
+	params := &types.EndpointTrafficPolicy{}
+	if err := render.Bind(r, params); err != nil {
+		_ = render.Render(w, r, types.ErrHTTPBadRequest(err, "invalid request body"))
+
+		return
+	}
+
+	policy, err := e.endpoints.EndpointTrafficSet(ctx, projectID, endpointID, *params)
+	if err != nil {
+		_ = render.Render(w, r, types.ErrHTTPError(err, "set endpoint traffic"))
+
+		return
+	}
+
+	render.JSON(w, r, policy)
+}
+
+func (e *EndpointRouter) EndpointCanaryStart(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	endpointID := chi.URLParam(r, "endpointRef")
+	projectID := middleware.GetProjectIDFromContext(ctx)
+
+	params := &types.EndpointCanaryCreateParams{}
+	if err := render.Bind(r, params); err != nil {
+		_ = render.Render(w, r, types.ErrHTTPBadRequest(err, "invalid request body"))
+
+		return
+	}
+
+	userID := middleware.GetUserIDFromContext(ctx)
+
+	canary, err := e.endpoints.EndpointCanaryStart(ctx, projectID, endpointID, userID, *params)
+	if err != nil {
+		_ = render.Render(w, r, types.ErrHTTPError(err, "start canary"))
+
+		return
+	}
+
+	render.JSON(w, r, canary)
+}
+
+func (e *EndpointRouter) EndpointCanaryAdvance(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	endpointID := chi.URLParam(r, "endpointRef")
+	projectID := middleware.GetProjectIDFromContext(ctx)
+
+	userID := middleware.GetUserIDFromContext(ctx)
+
+	canary, err := e.endpoints.EndpointCanaryAdvance(ctx, projectID, endpointID, userID)
+	if err != nil {
+		_ = render.Render(w, r, types.ErrHTTPError(err, "advance canary"))
+
+		return
+	}
+
+	render.JSON(w, r, canary)
+}
+
+func (e *EndpointRouter) EndpointCanaryRollback(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	endpointID := chi.URLParam(r, "endpointRef")
+	projectID := middleware.GetProjectIDFromContext(ctx)
+
+	canary, err := e.endpoints.EndpointCanaryRollback(ctx, projectID, endpointID)
+	if err != nil {
+		_ = render.Render(w, r, types.ErrHTTPError(err, "roll back canary"))
+
+		return
+	}
+
+	render.JSON(w, r, canary)
+}
+
+func (e *EndpointRouter) EndpointVersionPromote(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	endpointID := chi.URLParam(r, "endpointRef")
+	versionID := chi.URLParam(r, "versionID")
+	projectID := middleware.GetProjectIDFromContext(ctx)
+	userID := middleware.GetUserIDFromContext(ctx)
+
+	version, err := e.endpoints.EndpointVersionPromote(ctx, projectID, endpointID, versionID, userID)
+	if err != nil {
+		_ = render.Render(w, r, types.ErrHTTPError(err, "promote version"))
+
+		return
+	}
+
+	render.JSON(w, r, version)
+}
+
+func (e *EndpointRouter) EndpointRollback(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	endpointID := chi.URLParam(r, "endpointRef")
+	projectID := middleware.GetProjectIDFromContext(ctx)
+	userID := middleware.GetUserIDFromContext(ctx)
+
+	version, err := e.endpoints.EndpointRollback(ctx, projectID, endpointID, userID)
+	if err != nil {
+		_ = render.Render(w, r, types.ErrHTTPError(err, "roll back endpoint"))
+
+		return
+	}
+
+	render.JSON(w, r, version)
+}
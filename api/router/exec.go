@@ -127,6 +127,50 @@ func (e *ExecRouter) ExecGetHandler(w http.ResponseWriter, r *http.Request) {
 	render.JSON(w, r, exec)
 }
 
+func (e *ExecRouter) ExecResultHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	log.Ctx(ctx).Info().Msgf("Executing ExecResult request")
+
+	execID := chi.URLParam(r, "exec")
+	if execID == "" {
+		err := fmt.Errorf("missing execID")
+		render.Render(w, r.WithContext(ctx), types.ErrHTTPBadRequest(err, "Invalid request"))
+		return
+	}
+
+	exec, err := e.service.Get(ctx, execID)
+	if err != nil {
+		render.Render(w, r.WithContext(ctx), types.ErrHTTPError(err, "Failed to get session result"))
+		return
+	}
+	render.JSON(w, r, types.NewExecResult(exec))
+}
+
+func (e *ExecRouter) ExecHistoryHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	log.Ctx(ctx).Info().Msgf("Executing ExecHistory request")
+
+	execID := chi.URLParam(r, "exec")
+	if execID == "" {
+		err := fmt.Errorf("missing execID")
+		render.Render(w, r.WithContext(ctx), types.ErrHTTPBadRequest(err, "Invalid request"))
+		return
+	}
+
+	exec, err := e.service.Get(ctx, execID)
+	if err != nil {
+		render.Render(w, r.WithContext(ctx), types.ErrHTTPError(err, "Failed to get session"))
+		return
+	}
+
+	events, err := e.service.History(ctx, exec.ID)
+	if err != nil {
+		render.Render(w, r.WithContext(ctx), types.ErrHTTPError(err, "Failed to get session history"))
+		return
+	}
+	render.JSON(w, r, types.ExecHistoryResponse{Events: events})
+}
+
 func (e *ExecRouter) ExecListHandler(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 	log.Ctx(ctx).Info().Msgf("Executing ExecList request")
@@ -178,3 +222,43 @@ func (e *ExecRouter) ExecTerminateHandler(w http.ResponseWriter, r *http.Request
 
 	render.Status(r, http.StatusOK)
 }
+
+func (e *ExecRouter) ExecStopHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	log.Ctx(ctx).Info().Msgf("Executing ExecStop request")
+
+	execID := chi.URLParam(r, "exec")
+	if execID == "" {
+		err := fmt.Errorf("missing execID")
+		render.Render(w, r.WithContext(ctx), types.ErrHTTPBadRequest(err, "Invalid request"))
+		return
+	}
+
+	err := e.service.Stop(ctx, execID)
+	if err != nil {
+		render.Render(w, r.WithContext(ctx), types.ErrHTTPError(err, "Failed to stop session"))
+		return
+	}
+
+	render.Status(r, http.StatusOK)
+}
+
+func (e *ExecRouter) ExecStartHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	log.Ctx(ctx).Info().Msgf("Executing ExecStart request")
+
+	execID := chi.URLParam(r, "exec")
+	if execID == "" {
+		err := fmt.Errorf("missing execID")
+		render.Render(w, r.WithContext(ctx), types.ErrHTTPBadRequest(err, "Invalid request"))
+		return
+	}
+
+	err := e.service.Start(ctx, execID)
+	if err != nil {
+		render.Render(w, r.WithContext(ctx), types.ErrHTTPError(err, "Failed to start session"))
+		return
+	}
+
+	render.Status(r, http.StatusOK)
+}
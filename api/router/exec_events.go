@@ -0,0 +1,303 @@
+package router
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/render"
+	"github.com/rs/zerolog/log"
+	"github.com/unweave/unweave-v1/api/middleware"
+	"github.com/unweave/unweave-v1/api/types"
+	"github.com/unweave/unweave-v1/services/execsrv"
+)
+
+// execEventsRefreshInterval is how often the event streams re-read the watched sessions
+// to pick up connection info and new sessions. It also keeps idle connections alive.
+const execEventsRefreshInterval = 5 * time.Second
+
+// ExecEventsHandler streams the state changes of a session as server-sent events until the
+// session reaches a terminal state or the client disconnects.
+func (e *ExecRouter) ExecEventsHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	log.Ctx(ctx).Info().Msgf("Executing ExecEvents request")
+
+	execID := chi.URLParam(r, "exec")
+	if execID == "" {
+		err := fmt.Errorf("missing execID")
+		render.Render(w, r.WithContext(ctx), types.ErrHTTPBadRequest(err, "Invalid request"))
+		return
+	}
+
+	exec, err := e.service.Get(ctx, execID)
+	if err != nil {
+		render.Render(w, r.WithContext(ctx), types.ErrHTTPError(err, "Failed to get session"))
+		return
+	}
+
+	stream, ok := newExecEventStream(w, e.service)
+	if !ok {
+		err = fmt.Errorf("response writer does not support flushing")
+		render.Render(w, r.WithContext(ctx), types.ErrInternalServer(err, "Streaming not supported"))
+		return
+	}
+	defer stream.close()
+
+	stream.watch(ctx, exec)
+
+	ticker := time.NewTicker(execEventsRefreshInterval)
+	defer ticker.Stop()
+
+	for !stream.done() {
+		select {
+		case <-ctx.Done():
+			return
+		case event := <-stream.events():
+			stream.handle(ctx, event)
+		case <-ticker.C:
+			stream.refresh(ctx)
+		}
+	}
+}
+
+// ExecProjectEventsHandler streams the state changes of all sessions in the project as
+// server-sent events until the client disconnects. Sessions created after the client
+// connected are picked up on the next refresh.
+func (e *ExecRouter) ExecProjectEventsHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	log.Ctx(ctx).Info().Msgf("Executing ExecProjectEvents request")
+
+	projectID := middleware.GetProjectIDFromContext(ctx)
+
+	execs, err := e.service.List(ctx, projectID)
+	if err != nil {
+		render.Render(w, r.WithContext(ctx), types.ErrHTTPError(err, "Failed to list sessions"))
+		return
+	}
+
+	stream, ok := newExecEventStream(w, e.service)
+	if !ok {
+		err = fmt.Errorf("response writer does not support flushing")
+		render.Render(w, r.WithContext(ctx), types.ErrInternalServer(err, "Streaming not supported"))
+		return
+	}
+	defer stream.close()
+
+	watchActive := func(execs []types.Exec) {
+		for _, exec := range execs {
+			if exec.Status.IsTerminal() {
+				continue
+			}
+			stream.watch(ctx, exec)
+		}
+	}
+
+	watchActive(execs)
+
+	ticker := time.NewTicker(execEventsRefreshInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case event := <-stream.events():
+			stream.handle(ctx, event)
+		case <-ticker.C:
+			stream.refresh(ctx)
+
+			execs, err = e.service.List(ctx, projectID)
+			if err != nil {
+				log.Ctx(ctx).Warn().Err(err).Msg("Failed to list sessions for event stream")
+				continue
+			}
+			watchActive(execs)
+		}
+	}
+}
+
+// execEventStream writes server-sent events for a set of watched sessions. It registers an
+// observer on each session's state informer and stops watching a session once it reaches
+// a terminal state.
+type execEventStream struct {
+	w       http.ResponseWriter
+	flusher http.Flusher
+	service execsrv.Service
+	states  *execsrv.StateStream
+
+	watched   map[string]types.Exec
+	informers map[string]execsrv.StateInformer
+}
+
+func newExecEventStream(w http.ResponseWriter, service execsrv.Service) (*execEventStream, bool) {
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		return nil, false
+	}
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+	w.WriteHeader(http.StatusOK)
+	flusher.Flush()
+
+	return &execEventStream{
+		w:         w,
+		flusher:   flusher,
+		service:   service,
+		states:    execsrv.NewStateStream(),
+		watched:   make(map[string]types.Exec),
+		informers: make(map[string]execsrv.StateInformer),
+	}, true
+}
+
+func (s *execEventStream) events() <-chan execsrv.StateEvent {
+	return s.states.Events()
+}
+
+// done returns true once all watched sessions have reached a terminal state.
+func (s *execEventStream) done() bool {
+	return len(s.watched) == 0
+}
+
+// watch sends the current status and connection info of the session and starts streaming
+// its state changes.
+func (s *execEventStream) watch(ctx context.Context, exec types.Exec) {
+	if _, ok := s.watched[exec.ID]; ok {
+		return
+	}
+
+	s.send(ctx, types.ExecEventStatus, types.ExecStateEvent{ExecID: exec.ID, Status: exec.Status})
+	if exec.Network.Host != "" {
+		s.send(ctx, types.ExecEventConnection, connectionEvent(exec))
+	}
+
+	if exec.Status.IsTerminal() {
+		return
+	}
+
+	informer, err := s.service.StateInformer(ctx, exec.ID)
+	if err != nil {
+		s.send(ctx, types.ExecEventError, types.ExecStateEvent{
+			ExecID: exec.ID,
+			Status: exec.Status,
+			Error:  err.Error(),
+		})
+		return
+	}
+
+	informer.Register(s.states.Observer(exec.ID))
+
+	s.watched[exec.ID] = exec
+	s.informers[exec.ID] = informer
+}
+
+func (s *execEventStream) unwatch(execID string) {
+	if informer, ok := s.informers[execID]; ok {
+		informer.Unregister(s.states.Observer(execID))
+	}
+
+	delete(s.watched, execID)
+	delete(s.informers, execID)
+}
+
+// handle streams a state change reported by the informer.
+func (s *execEventStream) handle(ctx context.Context, event execsrv.StateEvent) {
+	prev, ok := s.watched[event.ExecID]
+	if !ok {
+		return
+	}
+
+	if event.State.Error != nil {
+		s.send(ctx, types.ExecEventError, types.ExecStateEvent{
+			ExecID: event.ExecID,
+			Status: event.State.Status,
+			Error:  event.State.Error.Error(),
+		})
+	}
+
+	if event.State.Status != prev.Status {
+		s.send(ctx, types.ExecEventStatus, types.ExecStateEvent{ExecID: event.ExecID, Status: event.State.Status})
+		prev.Status = event.State.Status
+		s.watched[event.ExecID] = prev
+	}
+
+	s.update(ctx, event.ExecID)
+
+	if event.State.Status.IsTerminal() {
+		s.unwatch(event.ExecID)
+	}
+}
+
+// refresh re-reads all watched sessions. Connection info is filled in by the state observer
+// after the informer reports the session as running, so it can land after the status event.
+func (s *execEventStream) refresh(ctx context.Context) {
+	for execID := range s.watched {
+		s.update(ctx, execID)
+	}
+
+	// Comments are ignored by clients but stop proxies from closing idle connections.
+	fmt.Fprint(s.w, ": ping\n\n")
+	s.flusher.Flush()
+}
+
+// update streams the changes between the watched and the stored session.
+func (s *execEventStream) update(ctx context.Context, execID string) {
+	prev := s.watched[execID]
+
+	exec, err := s.service.Get(ctx, execID)
+	if err != nil {
+		log.Ctx(ctx).Warn().Err(err).Str(types.ExecIDCtxKey, execID).Msg("Failed to get session for event stream")
+		return
+	}
+
+	if exec.Network.Host != "" && !sameConnection(exec.Network, prev.Network) {
+		s.send(ctx, types.ExecEventConnection, connectionEvent(exec))
+	}
+
+	if exec.Status.IsTerminal() && exec.Status != prev.Status {
+		s.send(ctx, types.ExecEventStatus, types.ExecStateEvent{ExecID: exec.ID, Status: exec.Status})
+	}
+
+	if exec.Status.IsTerminal() {
+		s.unwatch(execID)
+		return
+	}
+
+	// Keep the last status streamed, the store can lag behind the informer.
+	exec.Status = prev.Status
+	s.watched[execID] = exec
+}
+
+func (s *execEventStream) send(ctx context.Context, name string, event types.ExecStateEvent) {
+	event.Time = time.Now()
+
+	data, err := json.Marshal(event)
+	if err != nil {
+		log.Ctx(ctx).Error().Err(err).Msg("Failed to marshal session event")
+		return
+	}
+
+	fmt.Fprintf(s.w, "event: %s\ndata: %s\n\n", name, data)
+	s.flusher.Flush()
+}
+
+func (s *execEventStream) close() {
+	for execID := range s.watched {
+		s.unwatch(execID)
+	}
+	s.states.Close()
+}
+
+func connectionEvent(exec types.Exec) types.ExecStateEvent {
+	network := exec.Network
+
+	return types.ExecStateEvent{ExecID: exec.ID, Status: exec.Status, Network: &network}
+}
+
+func sameConnection(a, b types.ExecNetwork) bool {
+	return a.Host == b.Host && a.Port == b.Port && a.User == b.User
+}
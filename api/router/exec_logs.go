@@ -0,0 +1,149 @@
+package router
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/render"
+	"github.com/rs/zerolog/log"
+	"github.com/unweave/unweave-v1/api/middleware"
+	"github.com/unweave/unweave-v1/api/types"
+	"github.com/unweave/unweave-v1/services/execsrv"
+)
+
+// maxLogIngestBytes caps the size of a batch of logs shipped from a node.
+const maxLogIngestBytes = 4 << 20
+
+type ExecLogRouter struct {
+	service *execsrv.LogService
+}
+
+func NewExecLogRouter(service *execsrv.LogService) *ExecLogRouter {
+	return &ExecLogRouter{service: service}
+}
+
+// ExecLogsHandler returns the output of the session's command. The since query parameter
+// is either an RFC 3339 timestamp or a duration before now, e.g. 10m. Pass follow=true to
+// stream new lines as newline delimited JSON until the session terminates.
+func (e *ExecLogRouter) ExecLogsHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	log.Ctx(ctx).Info().Msgf("Executing ExecLogs request")
+
+	execID := chi.URLParam(r, "exec")
+	if execID == "" {
+		err := fmt.Errorf("missing execID")
+		render.Render(w, r.WithContext(ctx), types.ErrHTTPBadRequest(err, "Invalid request"))
+		return
+	}
+
+	since, err := parseSince(r.URL.Query().Get("since"))
+	if err != nil {
+		render.Render(w, r.WithContext(ctx), types.ErrHTTPBadRequest(err, "Invalid since"))
+		return
+	}
+
+	follow := false
+	if raw := r.URL.Query().Get("follow"); raw != "" {
+		follow, err = strconv.ParseBool(raw)
+		if err != nil {
+			render.Render(w, r.WithContext(ctx), types.ErrHTTPBadRequest(err, "Invalid follow"))
+			return
+		}
+	}
+
+	if !follow {
+		logs, err := e.service.Logs(ctx, execID, since)
+		if err != nil {
+			render.Render(w, r.WithContext(ctx), types.ErrHTTPError(err, "Failed to get session logs"))
+			return
+		}
+		if logs == nil {
+			logs = []types.ExecLogEntry{}
+		}
+		render.JSON(w, r, types.ExecLogsResponse{Logs: logs})
+		return
+	}
+
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		err = fmt.Errorf("response writer does not support flushing")
+		render.Render(w, r.WithContext(ctx), types.ErrInternalServer(err, "Streaming not supported"))
+		return
+	}
+
+	// Headers are only written with the first lines so that errors looking up the session
+	// can still be rendered.
+	started := false
+	enc := json.NewEncoder(w)
+
+	err = e.service.Follow(ctx, execID, since, func(logs []types.ExecLogEntry) error {
+		if !started {
+			w.Header().Set("Content-Type", "application/x-ndjson")
+			w.Header().Set("Cache-Control", "no-cache")
+			w.WriteHeader(http.StatusOK)
+			started = true
+		}
+
+		for _, entry := range logs {
+			if err := enc.Encode(entry); err != nil {
+				return err
+			}
+		}
+		flusher.Flush()
+
+		return nil
+	})
+
+	switch {
+	case err != nil && !started:
+		render.Render(w, r.WithContext(ctx), types.ErrHTTPError(err, "Failed to follow session logs"))
+	case err != nil:
+		log.Ctx(ctx).Warn().Err(err).Msg("Stopped following session logs")
+	case !started:
+		w.Header().Set("Content-Type", "application/x-ndjson")
+		w.WriteHeader(http.StatusOK)
+	}
+}
+
+// ExecLogsIngestHandler stores the log lines shipped from a session's node. Nodes
+// authenticate with the session's log ingest token instead of an access token.
+func (e *ExecLogRouter) ExecLogsIngestHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	execID := chi.URLParam(r, "exec")
+	token, _ := middleware.BearerToken(r)
+	body := http.MaxBytesReader(w, r.Body, maxLogIngestBytes)
+
+	if err := e.service.Ingest(ctx, execID, token, r.URL.Query().Get("stream"), body); err != nil {
+		render.Render(w, r.WithContext(ctx), types.ErrHTTPError(err, "Failed to ingest session logs"))
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func parseSince(raw string) (time.Time, error) {
+	if raw == "" {
+		return time.Time{}, nil
+	}
+
+	if d, err := time.ParseDuration(raw); err == nil {
+		return time.Now().Add(-d), nil
+	}
+
+	t, err := time.Parse(time.RFC3339, raw)
+	if err != nil {
+		return time.Time{}, &types.Error{
+			Code:       http.StatusBadRequest,
+			Message:    fmt.Sprintf("Invalid since %q", raw),
+			Suggestion: "Use an RFC 3339 timestamp, e.g. 2023-07-01T00:00:00Z, or a duration, e.g. 10m",
+			Err:        err,
+		}
+	}
+
+	return t, nil
+}
@@ -0,0 +1,22 @@
+package router
+
+import (
+	"net/http"
+
+	"github.com/go-chi/render"
+	"github.com/unweave/unweave-v1/providers/resilient"
+)
+
+type HealthRouter struct {
+	breakers *resilient.Breakers
+}
+
+func NewHealthRouter(breakers *resilient.Breakers) *HealthRouter {
+	return &HealthRouter{breakers: breakers}
+}
+
+// HealthHandler reports the state of the circuit breakers around the provider drivers.
+// It responds with 200 even if providers are degraded, the API itself is still serving.
+func (h *HealthRouter) HealthHandler(w http.ResponseWriter, r *http.Request) {
+	render.JSON(w, r, h.breakers.Health())
+}
@@ -0,0 +1,52 @@
+package router
+
+import (
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/render"
+	"github.com/rs/zerolog/log"
+	"github.com/unweave/unweave-v1/api/types"
+	"github.com/unweave/unweave-v1/services/projectsrv"
+)
+
+type ProjectRouter struct {
+	service *projectsrv.Service
+}
+
+func NewProjectRouter(service *projectsrv.Service) *ProjectRouter {
+	return &ProjectRouter{service: service}
+}
+
+// AdminProjectAccountAddHandler gives an account access to any project, see middleware.WithAdminCtx.
+func (p *ProjectRouter) AdminProjectAccountAddHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	log.Ctx(ctx).Info().Msgf("Executing AdminProjectAccountAdd request")
+
+	projectID := chi.URLParam(r, "project")
+	accountID := chi.URLParam(r, "account")
+
+	if err := p.service.AddAccount(ctx, projectID, accountID); err != nil {
+		render.Render(w, r.WithContext(ctx), types.ErrHTTPError(err, "Failed to add account to project"))
+		return
+	}
+
+	render.JSON(w, r, types.ProjectAccountResponse{Success: true})
+}
+
+// AdminProjectAccountRemoveHandler revokes an account's access to any project, see
+// middleware.WithAdminCtx.
+func (p *ProjectRouter) AdminProjectAccountRemoveHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	log.Ctx(ctx).Info().Msgf("Executing AdminProjectAccountRemove request")
+
+	projectID := chi.URLParam(r, "project")
+	accountID := chi.URLParam(r, "account")
+
+	if err := p.service.RemoveAccount(ctx, projectID, accountID); err != nil {
+		render.Render(w, r.WithContext(ctx), types.ErrHTTPError(err, "Failed to remove account from project"))
+		return
+	}
+
+	render.JSON(w, r, types.ProjectAccountResponse{Success: true})
+}
@@ -0,0 +1,69 @@
+package router
+
+import (
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/render"
+	"github.com/rs/zerolog/log"
+	"github.com/unweave/unweave-v1/api/middleware"
+	"github.com/unweave/unweave-v1/api/types"
+	"github.com/unweave/unweave-v1/services/quotasrv"
+)
+
+type QuotaRouter struct {
+	service *quotasrv.Service
+}
+
+func NewQuotaRouter(service *quotasrv.Service) *QuotaRouter {
+	return &QuotaRouter{service: service}
+}
+
+// QuotaGetHandler reports the project's quotas and its consumption against them.
+func (q *QuotaRouter) QuotaGetHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	log.Ctx(ctx).Info().Msgf("Executing QuotaGet request")
+
+	q.report(w, r, middleware.GetProjectIDFromContext(ctx))
+}
+
+// AdminQuotaGetHandler is QuotaGetHandler for any project, see middleware.WithAdminCtx.
+func (q *QuotaRouter) AdminQuotaGetHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	log.Ctx(ctx).Info().Msgf("Executing AdminQuotaGet request")
+
+	q.report(w, r, chi.URLParam(r, "project"))
+}
+
+// AdminQuotaSetHandler replaces the quotas of any project, see middleware.WithAdminCtx.
+func (q *QuotaRouter) AdminQuotaSetHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	log.Ctx(ctx).Info().Msgf("Executing AdminQuotaSet request")
+
+	params := &types.ProjectQuotasSetParams{}
+	if err := render.Bind(r, params); err != nil {
+		render.Render(w, r.WithContext(ctx), types.ErrHTTPBadRequest(err, "Invalid request body"))
+		return
+	}
+
+	projectID := chi.URLParam(r, "project")
+
+	if _, err := q.service.Set(ctx, projectID, params.ProjectQuotas); err != nil {
+		render.Render(w, r.WithContext(ctx), types.ErrHTTPError(err, "Failed to set quotas"))
+		return
+	}
+
+	q.report(w, r, projectID)
+}
+
+func (q *QuotaRouter) report(w http.ResponseWriter, r *http.Request, projectID string) {
+	ctx := r.Context()
+
+	res, err := q.service.Report(ctx, projectID)
+	if err != nil {
+		render.Render(w, r.WithContext(ctx), types.ErrHTTPError(err, "Failed to get quotas"))
+		return
+	}
+
+	render.JSON(w, r, res)
+}
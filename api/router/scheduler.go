@@ -0,0 +1,53 @@
+package router
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/go-chi/render"
+	"github.com/rs/zerolog/log"
+	"github.com/unweave/unweave-v1/api/middleware"
+	"github.com/unweave/unweave-v1/api/types"
+	"github.com/unweave/unweave-v1/services/schedulersrv"
+)
+
+type SchedulerRouter struct {
+	service *schedulersrv.Service
+}
+
+func NewSchedulerRouter(service *schedulersrv.Service) *SchedulerRouter {
+	return &SchedulerRouter{service: service}
+}
+
+// NodeTypesMatchHandler ranks the node types of every provider matching the JSON encoded
+// types.HardwareSpec in the spec query parameter.
+func (s *SchedulerRouter) NodeTypesMatchHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	log.Ctx(ctx).Info().Msgf("Executing NodeTypesMatch request")
+
+	userID := middleware.GetUserIDFromContext(ctx)
+
+	var spec types.HardwareSpec
+
+	if raw := r.URL.Query().Get("spec"); raw != "" {
+		if err := json.Unmarshal([]byte(raw), &spec); err != nil {
+			err = &types.Error{
+				Code:       http.StatusBadRequest,
+				Message:    "Invalid hardware spec",
+				Suggestion: "Pass the spec as a URL encoded JSON hardware spec",
+				Err:        err,
+			}
+			render.Render(w, r.WithContext(ctx), types.ErrHTTPBadRequest(err, "Invalid hardware spec"))
+
+			return
+		}
+	}
+
+	matches, err := s.service.Match(ctx, userID, spec)
+	if err != nil {
+		render.Render(w, r.WithContext(ctx), types.ErrHTTPError(err, "Failed to match node types"))
+		return
+	}
+
+	render.JSON(w, r, types.NodeTypesMatchResponse{Matches: matches})
+}
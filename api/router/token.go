@@ -0,0 +1,90 @@
+package router
+
+import (
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/render"
+	"github.com/rs/zerolog/log"
+	"github.com/unweave/unweave-v1/api/middleware"
+	"github.com/unweave/unweave-v1/api/types"
+	"github.com/unweave/unweave-v1/services/tokensrv"
+)
+
+type TokenRouter struct {
+	service *tokensrv.Service
+}
+
+func NewTokenRouter(service *tokensrv.Service) *TokenRouter {
+	return &TokenRouter{service: service}
+}
+
+func (t *TokenRouter) TokenCreateHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	log.Ctx(ctx).Info().Msg("Executing TokenCreate request")
+	accountID := middleware.GetAccountIDFromContext(ctx)
+
+	params := &types.AccessTokenCreateParams{}
+	if err := render.Bind(r, params); err != nil {
+		render.Render(w, r.WithContext(ctx), types.ErrHTTPBadRequest(err, "Invalid request body"))
+		return
+	}
+
+	res, err := t.service.Create(ctx, accountID, *params)
+	if err != nil {
+		render.Render(w, r.WithContext(ctx), types.ErrHTTPError(err, "Failed to create access token"))
+		return
+	}
+
+	render.JSON(w, r, res)
+}
+
+// AdminTokenCreateHandler creates a token for any account, creating the account if needed,
+// see middleware.WithAdminCtx.
+func (t *TokenRouter) AdminTokenCreateHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	log.Ctx(ctx).Info().Msg("Executing AdminTokenCreate request")
+	accountID := chi.URLParam(r, "account")
+
+	params := &types.AccessTokenCreateParams{}
+	if err := render.Bind(r, params); err != nil {
+		render.Render(w, r.WithContext(ctx), types.ErrHTTPBadRequest(err, "Invalid request body"))
+		return
+	}
+
+	res, err := t.service.Mint(ctx, accountID, *params)
+	if err != nil {
+		render.Render(w, r.WithContext(ctx), types.ErrHTTPError(err, "Failed to create access token"))
+		return
+	}
+
+	render.JSON(w, r, res)
+}
+
+func (t *TokenRouter) TokenListHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	log.Ctx(ctx).Info().Msg("Executing TokenList request")
+	accountID := middleware.GetAccountIDFromContext(ctx)
+
+	tokens, err := t.service.List(ctx, accountID)
+	if err != nil {
+		render.Render(w, r.WithContext(ctx), types.ErrHTTPError(err, "Failed to list access tokens"))
+		return
+	}
+
+	render.JSON(w, r, types.AccessTokensListResponse{Tokens: tokens})
+}
+
+func (t *TokenRouter) TokenRevokeHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	log.Ctx(ctx).Info().Msg("Executing TokenRevoke request")
+	accountID := middleware.GetAccountIDFromContext(ctx)
+	tokenID := chi.URLParam(r, "tokenID")
+
+	if err := t.service.Revoke(ctx, accountID, tokenID); err != nil {
+		render.Render(w, r.WithContext(ctx), types.ErrHTTPError(err, "Failed to revoke access token"))
+		return
+	}
+
+	render.JSON(w, r, types.AccessTokensDeleteResponse{Success: true})
+}
@@ -0,0 +1,87 @@
+package router
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/go-chi/render"
+	"github.com/rs/zerolog/log"
+	"github.com/unweave/unweave-v1/api/middleware"
+	"github.com/unweave/unweave-v1/api/types"
+	"github.com/unweave/unweave-v1/services/costsrv"
+)
+
+type UsageRouter struct {
+	service *costsrv.Service
+}
+
+func NewUsageRouter(service *costsrv.Service) *UsageRouter {
+	return &UsageRouter{service: service}
+}
+
+// UsageHandler reports the project's usage between the RFC 3339 from and to query
+// parameters. The window defaults to the current month until now. Pass format=csv to
+// export the sessions as CSV.
+func (u *UsageRouter) UsageHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	log.Ctx(ctx).Info().Msgf("Executing Usage request")
+
+	projectID := middleware.GetProjectIDFromContext(ctx)
+
+	now := time.Now().UTC()
+	from := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, time.UTC)
+	to := now
+
+	for _, param := range []struct {
+		name string
+		dst  *time.Time
+	}{{"from", &from}, {"to", &to}} {
+		raw := r.URL.Query().Get(param.name)
+		if raw == "" {
+			continue
+		}
+
+		t, err := time.Parse(time.RFC3339, raw)
+		if err != nil {
+			err = &types.Error{
+				Code:       http.StatusBadRequest,
+				Message:    fmt.Sprintf("Invalid %q time", param.name),
+				Suggestion: "Use an RFC 3339 timestamp, e.g. 2023-07-01T00:00:00Z",
+				Err:        err,
+			}
+			render.Render(w, r.WithContext(ctx), types.ErrHTTPBadRequest(err, "Invalid usage window"))
+
+			return
+		}
+
+		*param.dst = t
+	}
+
+	if !to.After(from) {
+		err := &types.Error{Code: http.StatusBadRequest, Message: "'to' must be after 'from'"}
+		render.Render(w, r.WithContext(ctx), types.ErrHTTPBadRequest(err, "Invalid usage window"))
+
+		return
+	}
+
+	report, err := u.service.Usage(ctx, projectID, from, to)
+	if err != nil {
+		render.Render(w, r.WithContext(ctx), types.ErrHTTPError(err, "Failed to get usage"))
+		return
+	}
+
+	if r.URL.Query().Get("format") != "csv" {
+		render.JSON(w, r, report)
+		return
+	}
+
+	filename := fmt.Sprintf("usage-%s-%s.csv", from.Format("20060102"), to.Format("20060102"))
+
+	w.Header().Set("Content-Type", "text/csv")
+	w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=%q", filename))
+
+	if err = costsrv.WriteCSV(w, report); err != nil {
+		log.Ctx(ctx).Error().Err(err).Msg("Failed to write usage CSV")
+	}
+}
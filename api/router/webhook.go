@@ -0,0 +1,119 @@
+package router
+
+import (
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/render"
+	"github.com/rs/zerolog/log"
+	"github.com/unweave/unweave-v1/api/middleware"
+	"github.com/unweave/unweave-v1/api/types"
+	"github.com/unweave/unweave-v1/services/webhooksrv"
+)
+
+type WebhookRouter struct {
+	service *webhooksrv.Service
+}
+
+func NewWebhookRouter(service *webhooksrv.Service) *WebhookRouter {
+	return &WebhookRouter{service: service}
+}
+
+func (h *WebhookRouter) WebhookCreateHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	log.Ctx(ctx).Info().Msg("Executing WebhookCreate request")
+	projectID := middleware.GetProjectIDFromContext(ctx)
+
+	params := &types.WebhookCreateParams{}
+	if err := render.Bind(r, params); err != nil {
+		render.Render(w, r.WithContext(ctx), types.ErrHTTPBadRequest(err, "Invalid request body"))
+		return
+	}
+
+	res, err := h.service.Create(ctx, projectID, *params)
+	if err != nil {
+		render.Render(w, r.WithContext(ctx), types.ErrHTTPError(err, "Failed to create webhook"))
+		return
+	}
+
+	render.JSON(w, r, res)
+}
+
+func (h *WebhookRouter) WebhookListHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	log.Ctx(ctx).Info().Msg("Executing WebhookList request")
+	projectID := middleware.GetProjectIDFromContext(ctx)
+
+	webhooks, err := h.service.List(ctx, projectID)
+	if err != nil {
+		render.Render(w, r.WithContext(ctx), types.ErrHTTPError(err, "Failed to list webhooks"))
+		return
+	}
+
+	render.JSON(w, r, types.WebhooksListResponse{Webhooks: webhooks})
+}
+
+func (h *WebhookRouter) WebhookGetHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	log.Ctx(ctx).Info().Msg("Executing WebhookGet request")
+	projectID := middleware.GetProjectIDFromContext(ctx)
+	webhookID := chi.URLParam(r, "webhookID")
+
+	webhook, err := h.service.Get(ctx, projectID, webhookID)
+	if err != nil {
+		render.Render(w, r.WithContext(ctx), types.ErrHTTPError(err, "Failed to get webhook"))
+		return
+	}
+
+	render.JSON(w, r, types.WebhookGetResponse{Webhook: webhook})
+}
+
+func (h *WebhookRouter) WebhookUpdateHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	log.Ctx(ctx).Info().Msg("Executing WebhookUpdate request")
+	projectID := middleware.GetProjectIDFromContext(ctx)
+	webhookID := chi.URLParam(r, "webhookID")
+
+	params := &types.WebhookUpdateParams{}
+	if err := render.Bind(r, params); err != nil {
+		render.Render(w, r.WithContext(ctx), types.ErrHTTPBadRequest(err, "Invalid request body"))
+		return
+	}
+
+	webhook, err := h.service.Update(ctx, projectID, webhookID, *params)
+	if err != nil {
+		render.Render(w, r.WithContext(ctx), types.ErrHTTPError(err, "Failed to update webhook"))
+		return
+	}
+
+	render.JSON(w, r, types.WebhookGetResponse{Webhook: webhook})
+}
+
+func (h *WebhookRouter) WebhookDeleteHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	log.Ctx(ctx).Info().Msg("Executing WebhookDelete request")
+	projectID := middleware.GetProjectIDFromContext(ctx)
+	webhookID := chi.URLParam(r, "webhookID")
+
+	if err := h.service.Delete(ctx, projectID, webhookID); err != nil {
+		render.Render(w, r.WithContext(ctx), types.ErrHTTPError(err, "Failed to delete webhook"))
+		return
+	}
+
+	render.JSON(w, r, types.WebhookDeleteResponse{Success: true})
+}
+
+func (h *WebhookRouter) WebhookDeliveriesHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	log.Ctx(ctx).Info().Msg("Executing WebhookDeliveries request")
+	projectID := middleware.GetProjectIDFromContext(ctx)
+	webhookID := chi.URLParam(r, "webhookID")
+
+	deliveries, err := h.service.Deliveries(ctx, projectID, webhookID)
+	if err != nil {
+		render.Render(w, r.WithContext(ctx), types.ErrHTTPError(err, "Failed to list webhook deliveries"))
+		return
+	}
+
+	render.JSON(w, r, types.WebhookDeliveriesListResponse{Deliveries: deliveries})
+}
@@ -3,6 +3,7 @@ package server
 import (
 	"context"
 	"net/http"
+	"strings"
 
 	"github.com/go-chi/chi/v5"
 	"github.com/go-chi/chi/v5/middleware"
@@ -18,11 +19,64 @@ import (
 type Config struct {
 	APIPort string    `json:"port" env:"UNWEAVE_API_PORT"`
 	DB      db.Config `json:"db"`
+
+	// IdleTimeout and IdleThreshold are the default idle policy for execs, see
+	// types.IdlePolicy. Idle execs are only terminated if both are set here or on the exec.
+	IdleTimeout   int     `json:"idleTimeout" env:"UNWEAVE_IDLE_TIMEOUT"`
+	IdleThreshold float64 `json:"idleThreshold" env:"UNWEAVE_IDLE_THRESHOLD"`
+
+	// AdminAccountIDs is a comma separated list of the accounts allowed to use the admin
+	// API.
+	AdminAccountIDs string `json:"adminAccountIDs" env:"UNWEAVE_ADMIN_ACCOUNTS"`
+
+	// PublicURL is the URL nodes reach the API on. Nodes only ship the output of their
+	// sessions if both it and LogIngestKey are set.
+	PublicURL    string `json:"publicURL" env:"UNWEAVE_PUBLIC_URL"`
+	LogIngestKey string `json:"-" env:"UNWEAVE_LOG_INGEST_KEY"`
+
+	// EndpointDomain enables the built-in endpoint proxy. Endpoints are served on
+	// subdomains of it on EndpointProxyPort, which defaults to 8080.
+	EndpointDomain    string `json:"endpointDomain" env:"UNWEAVE_ENDPOINT_DOMAIN"`
+	EndpointProxyPort string `json:"endpointProxyPort" env:"UNWEAVE_ENDPOINT_PROXY_PORT"`
+
+	// LocalProvider registers the local Docker provider, which runs sessions on the API
+	// host. It's never scheduled, sessions only run on it when requested by name.
+	LocalProvider bool `json:"localProvider" env:"UNWEAVE_LOCAL_PROVIDER"`
+}
+
+type Routers struct {
+	Exec      *router.ExecRouter
+	ExecLog   *router.ExecLogRouter
+	Health    *router.HealthRouter
+	SSHKeys   *router.SSHKeysRouter
+	Endpoint  *router.EndpointRouter
+	Eval      *router.EvalRouter
+	Volume    *router.VolumeRouter
+	Provider  *router.ProviderRouter
+	Token     *router.TokenRouter
+	Webhook   *router.WebhookRouter
+	Scheduler *router.SchedulerRouter
+	Usage     *router.UsageRouter
+	Quota     *router.QuotaRouter
+	Project   *router.ProjectRouter
 }
 
-func API(cfg Config, rti runtime.Initializer, execRouter *router.ExecRouter, sshKeysService *router.SSHKeysRouter) {
+func API(cfg Config, rti runtime.Initializer, auth middleware2.Authenticator, routers Routers) {
 	zerolog.TimeFieldFormat = zerolog.TimeFormatUnix
 
+	r := Handler(cfg, rti, auth, routers)
+
+	ctx := context.Background()
+	ctx = log.With().Logger().WithContext(ctx)
+
+	log.Info().Msgf("🚀 API listening on %s", cfg.APIPort)
+	if err := http.ListenAndServe(":"+cfg.APIPort, r); err != nil {
+		panic(err)
+	}
+}
+
+// Handler builds the API router. It panics if two routes are mounted on the same path.
+func Handler(cfg Config, rti runtime.Initializer, auth middleware2.Authenticator, routers Routers) http.Handler {
 	r := chi.NewRouter()
 	r.Use(middleware.Recoverer)
 	r.Use(middleware.RequestLogger(&middleware.DefaultLogFormatter{
@@ -47,40 +101,124 @@ func API(cfg Config, rti runtime.Initializer, execRouter *router.ExecRouter, ssh
 		},
 	}))
 
-	r.Use(middleware2.WithAccountCtx) // fakes an authenticated user
-	r.Route("/projects/{owner}/{project}", func(r chi.Router) {
-		r.Use(middleware2.WithProjectCtx)
+	r.Get("/health", routers.Health.HealthHandler)
+
+	// Nodes ship their logs with a per session token instead of an access token.
+	r.Post("/ingest/sessions/{exec}/logs", routers.ExecLog.ExecLogsIngestHandler)
+
+	r.Group(func(r chi.Router) {
+		r.Use(middleware2.WithAccountCtx(auth))
 
-		r.Route("/builds", func(r chi.Router) {
-			r.Post("/", BuildsCreate(rti))
-			r.Get("/{buildID}", BuildsGet(rti))
+		r.Route("/account/tokens", func(r chi.Router) {
+			r.Post("/", routers.Token.TokenCreateHandler)
+			r.Get("/", routers.Token.TokenListHandler)
+			r.Delete("/{tokenID}", routers.Token.TokenRevokeHandler)
 		})
 
-		r.Route("/sessions", func(r chi.Router) {
-			r.Post("/", execRouter.ExecCreateHandler)
+		r.Route("/projects/{owner}/{project}", func(r chi.Router) {
+			r.Use(middleware2.WithProjectCtx)
+
+			r.Route("/builds", func(r chi.Router) {
+				r.Post("/", BuildsCreate(rti))
+				r.Get("/{buildID}", BuildsGet(rti))
+			})
+
+			r.Route("/sessions", func(r chi.Router) {
+				r.Post("/", routers.Exec.ExecCreateHandler)
+				r.Get("/", routers.Exec.ExecListHandler)
+				r.Get("/events", routers.Exec.ExecProjectEventsHandler)
+
+				r.Route("/{exec}", func(r chi.Router) {
+					r.Use(middleware2.WithExecCtx)
+					r.Get("/", routers.Exec.ExecGetHandler)
+					r.Get("/events", routers.Exec.ExecEventsHandler)
+					r.Get("/history", routers.Exec.ExecHistoryHandler)
+					r.Get("/logs", routers.ExecLog.ExecLogsHandler)
+					r.Get("/result", routers.Exec.ExecResultHandler)
+					r.Put("/terminate", routers.Exec.ExecTerminateHandler)
+					r.Put("/stop", routers.Exec.ExecStopHandler)
+					r.Put("/start", routers.Exec.ExecStartHandler)
+				})
+			})
+
+			r.Get("/usage", routers.Usage.UsageHandler)
+			r.Get("/quotas", routers.Quota.QuotaGetHandler)
+
+			r.Route("/webhooks", func(r chi.Router) {
+				r.Post("/", routers.Webhook.WebhookCreateHandler)
+				r.Get("/", routers.Webhook.WebhookListHandler)
+
+				r.Route("/{webhookID}", func(r chi.Router) {
+					r.Get("/", routers.Webhook.WebhookGetHandler)
+					r.Put("/", routers.Webhook.WebhookUpdateHandler)
+					r.Delete("/", routers.Webhook.WebhookDeleteHandler)
+					r.Get("/deliveries", routers.Webhook.WebhookDeliveriesHandler)
+				})
+			})
+
+			r.Route("/endpoints", func(r chi.Router) {
+				r.Post("/", routers.Endpoint.EndpointCreate)
+				r.Get("/", routers.Endpoint.EndpointList)
+
+				r.Route("/{endpointRef}", func(r chi.Router) {
+					r.Get("/", routers.Endpoint.EndpointGet)
+					r.Post("/evals", routers.Endpoint.EndpointEvalAttach)
+					r.Post("/checks", routers.Endpoint.EndpointRunCheckHandler)
+					r.Post("/versions", routers.Endpoint.EndpointCreateVersion)
+					r.Post("/versions/{versionID}/promote", routers.Endpoint.EndpointVersionPromote)
+					r.Post("/rollback", routers.Endpoint.EndpointRollback)
+					r.Put("/traffic", routers.Endpoint.EndpointTrafficSet)
+					r.Post("/canary", routers.Endpoint.EndpointCanaryStart)
+					r.Post("/canary/advance", routers.Endpoint.EndpointCanaryAdvance)
+					r.Post("/canary/rollback", routers.Endpoint.EndpointCanaryRollback)
+				})
+			})
+
+			r.Route("/checks", func(r chi.Router) {
+				r.Get("/{checkID}", routers.Endpoint.EndpointEvalCheckStatus)
+				r.Delete("/{checkID}", routers.Endpoint.EndpointEvalCheckCancel)
+			})
+
+			r.Route("/evals", func(r chi.Router) {
+				r.Post("/", routers.Eval.EvalCreate)
+				r.Get("/", routers.Eval.EvalList)
+			})
+
+			r.Route("/volumes", func(r chi.Router) {
+				r.Post("/", routers.Volume.VolumeCreateHandler)
+				r.Get("/", routers.Volume.VolumeListHandler)
+				r.Put("/", routers.Volume.VolumeResizeHandler)
+				r.Get("/{volumeRef}", routers.Volume.VolumeGetHandler)
+				r.Delete("/{volumeRef}", routers.Volume.VolumeDeleteHandler)
+			})
 		})
 
-		r.Route("/sessions", func(r chi.Router) {
-			r.Get("/", execRouter.ExecListHandler)
+		r.Route("/admin", func(r chi.Router) {
+			r.Use(middleware2.WithAdminCtx(strings.Split(cfg.AdminAccountIDs, ",")))
 
-			r.Route("/{exec}", func(r chi.Router) {
-				r.Use(middleware2.WithExecCtx)
-				r.Get("/", execRouter.ExecGetHandler)
-				r.Put("/terminate", execRouter.ExecTerminateHandler)
+			r.Route("/projects/{project}/quotas", func(r chi.Router) {
+				r.Get("/", routers.Quota.AdminQuotaGetHandler)
+				r.Put("/", routers.Quota.AdminQuotaSetHandler)
 			})
+			r.Route("/projects/{project}/accounts/{account}", func(r chi.Router) {
+				r.Put("/", routers.Project.AdminProjectAccountAddHandler)
+				r.Delete("/", routers.Project.AdminProjectAccountRemoveHandler)
+			})
+			r.Post("/accounts/{account}/tokens", routers.Token.AdminTokenCreateHandler)
 		})
-	})
 
-	r.Route("/ssh-keys/{owner}", func(r chi.Router) {
-		r.Post("/", sshKeysService.SSHKeysAddHandler)
-		r.Get("/", sshKeysService.SSHKeysListHandler)
-		r.Post("/generate", sshKeysService.SSHKeysGenerateHandler)
+		r.Get("/node-types/match", routers.Scheduler.NodeTypesMatchHandler)
+
+		r.Route("/providers/{provider}", func(r chi.Router) {
+			r.Get("/node-types", routers.Provider.ProviderListNodeTypesHandler)
+		})
+
+		r.Route("/ssh-keys/{owner}", func(r chi.Router) {
+			r.Post("/", routers.SSHKeys.SSHKeysAddHandler)
+			r.Get("/", routers.SSHKeys.SSHKeysListHandler)
+			r.Post("/generate", routers.SSHKeys.SSHKeysGenerateHandler)
+		})
 	})
-	ctx := context.Background()
-	ctx = log.With().Logger().WithContext(ctx)
 
-	log.Info().Msgf("🚀 API listening on %s", cfg.APIPort)
-	if err := http.ListenAndServe(":"+cfg.APIPort, r); err != nil {
-		panic(err)
-	}
+	return r
 }
@@ -0,0 +1,56 @@
+package server_test
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/stretchr/testify/require"
+	"github.com/unweave/unweave-v1/api/router"
+	"github.com/unweave/unweave-v1/api/server"
+)
+
+func TestHandler_MountsAllRoutes(t *testing.T) {
+	routers := server.Routers{
+		Exec:      &router.ExecRouter{},
+		ExecLog:   &router.ExecLogRouter{},
+		Health:    &router.HealthRouter{},
+		SSHKeys:   &router.SSHKeysRouter{},
+		Endpoint:  &router.EndpointRouter{},
+		Eval:      &router.EvalRouter{},
+		Volume:    &router.VolumeRouter{},
+		Provider:  &router.ProviderRouter{},
+		Token:     &router.TokenRouter{},
+		Webhook:   &router.WebhookRouter{},
+		Scheduler: &router.SchedulerRouter{},
+		Usage:     &router.UsageRouter{},
+		Quota:     &router.QuotaRouter{},
+		Project:   &router.ProjectRouter{},
+	}
+
+	var h http.Handler
+	require.NotPanics(t, func() {
+		h = server.Handler(server.Config{}, nil, nil, routers)
+	})
+
+	routes := map[string]bool{}
+	err := chi.Walk(h.(chi.Routes), func(method, route string, _ http.Handler, _ ...func(http.Handler) http.Handler) error {
+		routes[method+" "+route] = true
+		return nil
+	})
+	require.NoError(t, err)
+
+	for _, route := range []string{
+		"POST /projects/{owner}/{project}/sessions/",
+		"GET /projects/{owner}/{project}/sessions/",
+		"GET /projects/{owner}/{project}/sessions/{exec}/",
+		"GET /projects/{owner}/{project}/endpoints/",
+		"GET /projects/{owner}/{project}/evals/",
+		"GET /projects/{owner}/{project}/volumes/",
+		"GET /providers/{provider}/node-types",
+		"PUT /admin/projects/{project}/accounts/{account}/",
+		"POST /admin/accounts/{account}/tokens",
+	} {
+		require.True(t, routes[route], "route %q not mounted", route)
+	}
+}
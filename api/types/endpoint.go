@@ -13,6 +13,9 @@ type EndpointCreateParams struct {
 type EndpointVersionCreateParams struct {
 	ExecID  string `json:"execID"`
 	Promote bool   `json:"promote"`
+	// PromoteOnPass runs the endpoint's evals against the version and promotes it only if
+	// they pass. The version is pending until then and failed if they don't.
+	PromoteOnPass bool `json:"promoteOnPass"`
 }
 
 type EndpointEvalAttach struct {
@@ -46,6 +49,11 @@ type Endpoint struct {
 	Status      EndpointStatus    `json:"status"`
 	Versions    []EndpointVersion `json:"versions"`
 	CreatedAt   time.Time         `json:"createdAt"`
+
+	Traffic *EndpointTrafficPolicy `json:"traffic,omitempty"`
+	Canary  *EndpointCanary        `json:"canary,omitempty"`
+	// Promotions are the versions promoted to primary, oldest first.
+	Promotions []EndpointPromotion `json:"promotions"`
 }
 
 type EndpointGetResponse struct {
@@ -59,6 +67,112 @@ type EndpointVersion struct {
 	Status      EndpointStatus `json:"status"`
 	Primary     bool           `json:"primary"`
 	CreatedAt   time.Time      `json:"createdAt"`
+	// CheckID is the check gating the promotion of the version, if any.
+	CheckID string `json:"checkID,omitempty"`
+}
+
+// EndpointTrafficPolicy routes the requests of an endpoint across its versions. Endpoints
+// without a policy route all requests to their primary version.
+type EndpointTrafficPolicy struct {
+	// Weights split the requests across versions by percentage and must sum to 100.
+	Weights []EndpointTrafficWeight `json:"weights"`
+	// Headers route requests with a matching header to a version, ahead of the weights.
+	Headers []EndpointHeaderRoute `json:"headers,omitempty"`
+	// Mirror copies a share of the requests to a version and discards its responses.
+	Mirror *EndpointTrafficMirror `json:"mirror,omitempty"`
+}
+
+type EndpointTrafficWeight struct {
+	VersionID string `json:"versionID"`
+	Weight    int    `json:"weight"`
+}
+
+type EndpointHeaderRoute struct {
+	Header    string `json:"header"`
+	Value     string `json:"value"`
+	VersionID string `json:"versionID"`
+}
+
+type EndpointTrafficMirror struct {
+	VersionID string `json:"versionID"`
+	Percent   int    `json:"percent"`
+}
+
+// VersionIDs returns the versions the policy routes to.
+func (p EndpointTrafficPolicy) VersionIDs() []string {
+	ids := make([]string, 0, len(p.Weights)+len(p.Headers)+1)
+
+	for _, w := range p.Weights {
+		ids = append(ids, w.VersionID)
+	}
+
+	for _, h := range p.Headers {
+		ids = append(ids, h.VersionID)
+	}
+
+	if p.Mirror != nil {
+		ids = append(ids, p.Mirror.VersionID)
+	}
+
+	return ids
+}
+
+type CanaryStatus string
+
+const (
+	CanaryProgressing CanaryStatus = "progressing"
+	CanaryPromoted    CanaryStatus = "promoted"
+	CanaryRolledBack  CanaryStatus = "rolled_back"
+)
+
+// DefaultCanarySteps are the percentages of traffic a canary is rolled out to if none are
+// given.
+var DefaultCanarySteps = []int{10, 50, 100}
+
+// EndpointCanary shifts the traffic of an endpoint from its stable version to a new
+// version one step at a time. The endpoint's evals run against the new version at every
+// step and the canary is rolled back if they fail.
+type EndpointCanary struct {
+	VersionID       string       `json:"versionID"`
+	StableVersionID string       `json:"stableVersionID"`
+	Steps           []int        `json:"steps"`
+	Step            int          `json:"step"`
+	CheckID         string       `json:"checkID,omitempty"`
+	Status          CanaryStatus `json:"status"`
+	Reason          string       `json:"reason,omitempty"`
+}
+
+// Weight returns the percentage of traffic routed to the canary at its current step.
+func (c EndpointCanary) Weight() int {
+	return c.Steps[c.Step]
+}
+
+type EndpointCanaryCreateParams struct {
+	VersionID string `json:"versionID"`
+	// Steps are the increasing percentages of traffic to roll out to, ending at 100.
+	// Defaults to DefaultCanarySteps.
+	Steps []int `json:"steps,omitempty"`
+}
+
+type PromotionReason string
+
+const (
+	PromotionCreate       PromotionReason = "create"
+	PromotionManual       PromotionReason = "promote"
+	PromotionRollback     PromotionReason = "rollback"
+	PromotionChecksPassed PromotionReason = "checks_passed"
+	PromotionCanary       PromotionReason = "canary"
+)
+
+// EndpointPromotion records a version becoming the primary version of its endpoint.
+type EndpointPromotion struct {
+	ID                string          `json:"id"`
+	VersionID         string          `json:"versionID"`
+	PreviousVersionID string          `json:"previousVersionID,omitempty"`
+	Reason            PromotionReason `json:"reason"`
+	// PromotedBy is the user who requested the promotion.
+	PromotedBy string    `json:"promotedBy,omitempty"`
+	CreatedAt  time.Time `json:"createdAt"`
 }
 
 type EndpointStatus string
@@ -100,4 +214,6 @@ type EndpointCheckStep struct {
 	Assertion  string
 	Status     CheckStatus
 	Conclusion *CheckConclusion `json:"conclusion,omitempty"`
+	// Error is why the step couldn't run to an assertion, e.g. the endpoint timing out.
+	Error string `json:"error,omitempty"`
 }
@@ -1,6 +1,9 @@
 package types
 
-import "net/http"
+import (
+	"fmt"
+	"net/http"
+)
 
 func (e *EndpointCreateParams) Bind(_ *http.Request) error {
 	if e.ExecID == "" {
@@ -33,3 +36,101 @@ func (e *EndpointVersionCreateParams) Bind(_ *http.Request) error {
 
 	return nil
 }
+
+func (p *EndpointTrafficPolicy) Bind(_ *http.Request) error {
+	if len(p.Weights) == 0 {
+		return &Error{
+			Code:       http.StatusBadRequest,
+			Message:    "Missing weights",
+			Suggestion: "Weights must route traffic to at least one version",
+		}
+	}
+
+	total := 0
+	seen := map[string]bool{}
+
+	for _, w := range p.Weights {
+		if w.VersionID == "" || w.Weight < 0 || w.Weight > 100 {
+			return &Error{
+				Code:       http.StatusBadRequest,
+				Message:    "Invalid weight",
+				Suggestion: "Weights must have a version ID and be between 0 and 100",
+			}
+		}
+
+		if seen[w.VersionID] {
+			return &Error{
+				Code:    http.StatusBadRequest,
+				Message: fmt.Sprintf("Version %q is weighted more than once", w.VersionID),
+			}
+		}
+
+		seen[w.VersionID] = true
+		total += w.Weight
+	}
+
+	if total != 100 {
+		return &Error{
+			Code:       http.StatusBadRequest,
+			Message:    fmt.Sprintf("Weights sum to %d", total),
+			Suggestion: "Weights must sum to 100",
+		}
+	}
+
+	for _, h := range p.Headers {
+		if h.Header == "" || h.Value == "" || h.VersionID == "" {
+			return &Error{
+				Code:       http.StatusBadRequest,
+				Message:    "Invalid header route",
+				Suggestion: "Header routes must have a header, value and version ID",
+			}
+		}
+	}
+
+	if p.Mirror != nil && (p.Mirror.VersionID == "" || p.Mirror.Percent <= 0 || p.Mirror.Percent > 100) {
+		return &Error{
+			Code:       http.StatusBadRequest,
+			Message:    "Invalid mirror",
+			Suggestion: "Mirrors must have a version ID and a percent between 1 and 100",
+		}
+	}
+
+	return nil
+}
+
+func (c *EndpointCanaryCreateParams) Bind(_ *http.Request) error {
+	if c.VersionID == "" {
+		return &Error{
+			Code:       http.StatusBadRequest,
+			Message:    "Missing version ID",
+			Suggestion: "Version ID must be provided",
+		}
+	}
+
+	if len(c.Steps) == 0 {
+		c.Steps = DefaultCanarySteps
+	}
+
+	prev := 0
+	for _, step := range c.Steps {
+		if step <= prev || step > 100 {
+			return &Error{
+				Code:       http.StatusBadRequest,
+				Message:    "Invalid canary steps",
+				Suggestion: "Steps must be increasing percentages between 1 and 100",
+			}
+		}
+
+		prev = step
+	}
+
+	if prev != 100 {
+		return &Error{
+			Code:       http.StatusBadRequest,
+			Message:    "Invalid canary steps",
+			Suggestion: "The last step must route 100% of traffic to the canary",
+		}
+	}
+
+	return nil
+}
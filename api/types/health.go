@@ -0,0 +1,33 @@
+package types
+
+import "time"
+
+// BreakerState is the state of the circuit breaker around the calls to a provider.
+type BreakerState string
+
+const (
+	BreakerClosed   BreakerState = "closed"
+	BreakerOpen     BreakerState = "open"
+	BreakerHalfOpen BreakerState = "half-open"
+)
+
+const (
+	HealthOK       = "ok"
+	HealthDegraded = "degraded"
+)
+
+// ProviderHealth reports whether calls to a provider are going through. OpenedAt is set
+// while the breaker isn't closed.
+type ProviderHealth struct {
+	Provider            Provider     `json:"provider"`
+	State               BreakerState `json:"state"`
+	ConsecutiveFailures int          `json:"consecutiveFailures"`
+	LastError           string       `json:"lastError,omitempty"`
+	OpenedAt            *time.Time   `json:"openedAt,omitempty"`
+}
+
+// HealthResponse is degraded if any provider's breaker isn't closed.
+type HealthResponse struct {
+	Status    string           `json:"status"`
+	Providers []ProviderHealth `json:"providers"`
+}
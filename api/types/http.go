@@ -6,6 +6,7 @@ import (
 	"io"
 	"mime/multipart"
 	"net/http"
+	"time"
 
 	"github.com/rs/zerolog/log"
 	"golang.org/x/crypto/ssh"
@@ -96,14 +97,30 @@ type NodeTypesListResponse struct {
 	NodeTypes []NodeType `json:"nodeTypes"`
 }
 
+// NodeTypeMatch is a node type that satisfies a requested hardware spec.
+type NodeTypeMatch struct {
+	NodeType  NodeType `json:"nodeType"`
+	Available bool     `json:"available"`
+}
+
+type NodeTypesMatchResponse struct {
+	Matches []NodeTypeMatch `json:"matches"`
+}
+
 type VolumeAttachParams struct {
 	VolumeRef string `json:"volumeRef"`
 	MountPath string `json:"mountPath"`
 }
 
 type ExecCreateParams struct {
-	Name         string               `json:"name,omitempty"`
-	Provider     Provider             `json:"provider"`
+	Name string `json:"name,omitempty"`
+	// Provider to create the exec on. When both Provider and Providers are empty the
+	// exec is created on the cheapest available node type matching the spec.
+	Provider Provider `json:"provider"`
+	// Providers is an ordered list of acceptable providers. Each is tried in turn until
+	// one creates the exec. AnyProvider stands for every remaining provider. Takes
+	// precedence over Provider.
+	Providers    []Provider           `json:"providers,omitempty"`
 	Spec         HardwareSpec         `json:"hardwareSpec,omitempty"`
 	SSHKeyName   string               `json:"sshKeyName"`
 	SSHPublicKey string               `json:"sshPublicKey"`
@@ -115,6 +132,102 @@ type ExecCreateParams struct {
 	Source       *SourceContext       `json:"source,omitempty"`
 	Volumes      []VolumeAttachParams `json:"volumes,omitempty"`
 	InternalPort int32                `json:"internal_port"`
+	// IdleTimeout is the number of seconds an exec can stay idle before it's terminated.
+	// Zero uses the server default.
+	IdleTimeout int `json:"idleTimeout,omitempty"`
+	// IdleThreshold is the CPU and GPU utilisation percentage below which an exec is
+	// considered idle. Zero uses the server default.
+	IdleThreshold float64 `json:"idleThreshold,omitempty"`
+	// MaxDuration is the maximum lifetime of the exec in seconds. The exec is terminated
+	// once it's reached. Mutually exclusive with ExpiresAt.
+	MaxDuration int `json:"maxDuration,omitempty"`
+	// ExpiresAt is the absolute time at which the exec is terminated. Mutually exclusive
+	// with MaxDuration.
+	ExpiresAt *time.Time `json:"expiresAt,omitempty"`
+	// Job runs Command to completion instead of starting an interactive session. The exec
+	// finishes as success or failed depending on the command's exit code and its node is
+	// terminated. Requires Command.
+	Job bool `json:"job,omitempty"`
+	// ProviderAttempts is set by the delegating service to the providers that failed
+	// before the one the params are passed to.
+	ProviderAttempts []ProviderAttempt `json:"-"`
+}
+
+// Expiry returns when the exec created at createdAt should be terminated or nil if it
+// doesn't expire.
+func (s *ExecCreateParams) Expiry(createdAt time.Time) *time.Time {
+	if s.ExpiresAt != nil {
+		return s.ExpiresAt
+	}
+	if s.MaxDuration > 0 {
+		expiresAt := createdAt.Add(time.Duration(s.MaxDuration) * time.Second)
+		return &expiresAt
+	}
+
+	return nil
+}
+
+func (s *ExecCreateParams) validateExpiry() error {
+	if s.MaxDuration < 0 {
+		return &Error{
+			Code:    http.StatusBadRequest,
+			Message: "Invalid request body: field 'maxDuration' cannot be negative",
+		}
+	}
+	if s.MaxDuration > 0 && s.ExpiresAt != nil {
+		return &Error{
+			Code:       http.StatusBadRequest,
+			Message:    "Invalid request body: only one of 'maxDuration' and 'expiresAt' can be set",
+			Suggestion: "Use maxDuration for a relative lifetime or expiresAt for an absolute one",
+		}
+	}
+	if s.ExpiresAt != nil && !s.ExpiresAt.After(time.Now()) {
+		return &Error{
+			Code:    http.StatusBadRequest,
+			Message: "Invalid request body: field 'expiresAt' must be in the future",
+		}
+	}
+
+	return nil
+}
+
+// IdlePolicy returns the idle policy requested for the exec or nil if none was set.
+func (s *ExecCreateParams) IdlePolicy() *IdlePolicy {
+	if s.IdleTimeout == 0 && s.IdleThreshold == 0 {
+		return nil
+	}
+
+	return &IdlePolicy{Timeout: s.IdleTimeout, Threshold: s.IdleThreshold}
+}
+
+func (s *ExecCreateParams) validateJob() error {
+	if s.Job && len(s.Command) == 0 {
+		return &Error{
+			Code:       http.StatusBadRequest,
+			Message:    "Invalid request body: field 'command' is required for jobs",
+			Suggestion: "Set the command the job should run",
+		}
+	}
+
+	return nil
+}
+
+func (s *ExecCreateParams) validateIdlePolicy() error {
+	if s.IdleTimeout < 0 {
+		return &Error{
+			Code:    http.StatusBadRequest,
+			Message: "Invalid request body: field 'idleTimeout' cannot be negative",
+		}
+	}
+	if s.IdleThreshold < 0 || s.IdleThreshold > 100 {
+		return &Error{
+			Code:       http.StatusBadRequest,
+			Message:    "Invalid request body: field 'idleThreshold' must be between 0 and 100",
+			Suggestion: "The threshold is a utilisation percentage",
+		}
+	}
+
+	return nil
 }
 
 func (s *ExecCreateParams) Bind(r *http.Request) error {
@@ -132,7 +245,13 @@ func (s *ExecCreateParams) Bind(r *http.Request) error {
 				Err:        err,
 			}
 		}
-		return nil
+		if err := s.validateIdlePolicy(); err != nil {
+			return err
+		}
+		if err := s.validateJob(); err != nil {
+			return err
+		}
+		return s.validateExpiry()
 	}
 
 	jsonStr := r.FormValue("params")
@@ -144,12 +263,6 @@ func (s *ExecCreateParams) Bind(r *http.Request) error {
 			Err:        err,
 		}
 	}
-	if s.Provider == "" {
-		return &Error{
-			Code:    http.StatusBadRequest,
-			Message: "Invalid request body: field 'provider' is required",
-		}
-	}
 	if s.SSHPublicKey == "" || s.SSHKeyName == "" {
 		return &Error{
 			Code:    http.StatusBadRequest,
@@ -166,6 +279,16 @@ func (s *ExecCreateParams) Bind(r *http.Request) error {
 		}
 	}
 
+	if err := s.validateIdlePolicy(); err != nil {
+		return err
+	}
+	if err := s.validateJob(); err != nil {
+		return err
+	}
+	if err := s.validateExpiry(); err != nil {
+		return err
+	}
+
 	if len(s.Volumes) > 0 {
 		for _, v := range s.Volumes {
 			if v.VolumeRef == "" {
@@ -225,6 +348,32 @@ type ExecTerminateResponse struct {
 	Success bool `json:"success"`
 }
 
+type ExecHistoryResponse struct {
+	Events []ExecEvent `json:"events"`
+}
+
+type ExecLogsResponse struct {
+	Logs []ExecLogEntry `json:"logs"`
+}
+
+// Server-sent event names used by the session event streams.
+const (
+	ExecEventStatus     = "status"
+	ExecEventConnection = "connection"
+	ExecEventError      = "error"
+)
+
+// ExecStateEvent is the payload of a server-sent event streamed for a session. Status events
+// carry the new status, connection events the session's network and error events the
+// error reported with the state change.
+type ExecStateEvent struct {
+	ExecID  string       `json:"sessionID"`
+	Status  Status       `json:"status"`
+	Network *ExecNetwork `json:"network,omitempty"`
+	Error   string       `json:"error,omitempty"`
+	Time    time.Time    `json:"time"`
+}
+
 type SSHKeyAddParams struct {
 	Name      *string `json:"name"`
 	PublicKey string  `json:"publicKey"`
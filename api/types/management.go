@@ -10,6 +10,8 @@ const projectNameRegex = `^[\w.-]+$`
 
 type AccessTokenCreateParams struct {
 	Name string `json:"name"`
+	// ExpiresAt is optional. Tokens without an expiry use the server default lifetime.
+	ExpiresAt *time.Time `json:"expiresAt,omitempty"`
 }
 
 func (p *AccessTokenCreateParams) Bind(r *http.Request) error {
@@ -19,6 +21,12 @@ func (p *AccessTokenCreateParams) Bind(r *http.Request) error {
 			Message: "Name is required",
 		}
 	}
+	if p.ExpiresAt != nil && !p.ExpiresAt.After(time.Now()) {
+		return &Error{
+			Code:    http.StatusBadRequest,
+			Message: "Expiry must be in the future",
+		}
+	}
 	return nil
 }
 
@@ -29,6 +37,10 @@ type AccessTokenCreateResponse struct {
 	ExpiresAt time.Time `json:"expiresAt"`
 }
 
+type AccessTokensListResponse struct {
+	Tokens []UserAccessToken `json:"tokens"`
+}
+
 type AccessTokensDeleteResponse struct {
 	Success bool `json:"success"`
 }
@@ -100,3 +112,7 @@ type ProjectListResponse struct {
 type ProjectGetResponse struct {
 	Project Project `json:"project"`
 }
+
+type ProjectAccountResponse struct {
+	Success bool `json:"success"`
+}
@@ -14,6 +14,7 @@ const (
 	StatusPending      Status = "pending"
 	StatusInitializing Status = "initializing"
 	StatusRunning      Status = "running"
+	StatusStopped      Status = "stopped"
 	StatusTerminated   Status = "terminated"
 	StatusError        Status = "error"
 	StatusFailed       Status = "failed"
@@ -48,6 +49,9 @@ const (
 	LambdaLabsProvider Provider = "lambdalabs"
 	UnweaveProvider    Provider = "unweave"
 	AWSProvider        Provider = "aws"
+	LocalProvider      Provider = "local"
+	// AnyProvider lets the server pick any provider that can create the exec.
+	AnyProvider Provider = "any"
 )
 
 func (r Provider) DisplayName() string {
@@ -58,6 +62,10 @@ func (r Provider) DisplayName() string {
 		return "Unweave"
 	case AWSProvider:
 		return "AWS"
+	case LocalProvider:
+		return "Local"
+	case AnyProvider:
+		return "Any"
 	default:
 		return "Unknown"
 	}
@@ -148,24 +156,116 @@ type ExecVolume struct {
 	MountPath string `json:"mountPath"`
 }
 
+// IdlePolicy configures when an exec is terminated for sitting idle.
+type IdlePolicy struct {
+	// Timeout is how long, in seconds, utilisation must stay below the threshold before
+	// the exec is terminated.
+	Timeout int `json:"timeout"`
+	// Threshold is the CPU and GPU utilisation percentage below which an exec is idle.
+	Threshold float64 `json:"threshold"`
+}
+
 type Exec struct {
-	ID        string       `json:"id"`
-	Name      string       `json:"name"`
-	CreatedAt time.Time    `json:"createdAt,omitempty"`
-	ExitedAt  *time.Time   `json:"exitedAt,omitempty"`
-	CreatedBy string       `json:"createdBy,omitempty"`
-	Image     string       `json:"image,omitempty"`
-	BuildID   *string      `json:"buildID,omitempty"`
-	Status    Status       `json:"status"`
-	Command   []string     `json:"command"`
-	Keys      []SSHKey     `json:"keys"`
-	Volumes   []ExecVolume `json:"volumes"`
-	Network   ExecNetwork  `json:"network"`
-	Spec      HardwareSpec `json:"spec"`
-	CommitID  *string      `json:"commitID,omitempty"`
-	GitURL    *string      `json:"gitURL,omitempty"`
-	Region    string       `json:"region"`
-	Provider  Provider     `json:"provider"`
+	ID                string       `json:"id"`
+	Name              string       `json:"name"`
+	CreatedAt         time.Time    `json:"createdAt,omitempty"`
+	ReadyAt           *time.Time   `json:"readyAt,omitempty"`
+	ExitedAt          *time.Time   `json:"exitedAt,omitempty"`
+	CreatedBy         string       `json:"createdBy,omitempty"`
+	Image             string       `json:"image,omitempty"`
+	BuildID           *string      `json:"buildID,omitempty"`
+	Status            Status       `json:"status"`
+	Command           []string     `json:"command"`
+	Keys              []SSHKey     `json:"keys"`
+	Volumes           []ExecVolume `json:"volumes"`
+	Network           ExecNetwork  `json:"network"`
+	Spec              HardwareSpec `json:"spec"`
+	CommitID          *string      `json:"commitID,omitempty"`
+	GitURL            *string      `json:"gitURL,omitempty"`
+	Region            string       `json:"region"`
+	Provider          Provider     `json:"provider"`
+	IdlePolicy        *IdlePolicy  `json:"idlePolicy,omitempty"`
+	TerminationReason string       `json:"terminationReason,omitempty"`
+	ExpiresAt         *time.Time   `json:"expiresAt,omitempty"`
+	// ProviderAttempts are the providers that failed to create the exec before Provider
+	// succeeded. Only set when the exec was created with provider fallback.
+	ProviderAttempts []ProviderAttempt `json:"providerAttempts,omitempty"`
+	// HourlyPrice is the price of the exec's node type in cents per hour when it was
+	// created. Nil if the provider doesn't publish prices.
+	HourlyPrice *int `json:"hourlyPrice,omitempty"`
+	// Job execs run their command to completion and are terminated once it exits, see
+	// ExecCreateParams.Job.
+	Job bool `json:"job,omitempty"`
+	// ExitCode is the exit code of a job's command once it has exited.
+	ExitCode *int `json:"exitCode,omitempty"`
+}
+
+// ExecResult is the outcome of an exec's command. Duration is in seconds and is measured
+// from when the exec was ready, or created if it never was, until it exited.
+type ExecResult struct {
+	ExecID     string     `json:"sessionID"`
+	Job        bool       `json:"job"`
+	Status     Status     `json:"status"`
+	ExitCode   *int       `json:"exitCode,omitempty"`
+	CreatedAt  time.Time  `json:"createdAt"`
+	StartedAt  *time.Time `json:"startedAt,omitempty"`
+	FinishedAt *time.Time `json:"finishedAt,omitempty"`
+	Duration   *float64   `json:"duration,omitempty"`
+}
+
+// NewExecResult returns the result of the exec. Duration is nil until the exec exits.
+func NewExecResult(exec Exec) ExecResult {
+	res := ExecResult{
+		ExecID:     exec.ID,
+		Job:        exec.Job,
+		Status:     exec.Status,
+		ExitCode:   exec.ExitCode,
+		CreatedAt:  exec.CreatedAt,
+		StartedAt:  exec.ReadyAt,
+		FinishedAt: exec.ExitedAt,
+	}
+
+	if exec.ExitedAt != nil {
+		start := exec.CreatedAt
+		if exec.ReadyAt != nil {
+			start = *exec.ReadyAt
+		}
+
+		duration := exec.ExitedAt.Sub(start).Seconds()
+		res.Duration = &duration
+	}
+
+	return res
+}
+
+// ProviderAttempt is a failed attempt to create an exec on a provider.
+type ProviderAttempt struct {
+	Provider Provider `json:"provider"`
+	Error    string   `json:"error"`
+}
+
+// ExecEvent is an entry in an exec's history. Events are recorded for every status
+// transition, termination and informer failure so that it's possible to tell after the
+// fact why an exec ended up in its current state.
+type ExecEvent struct {
+	ExecID    string    `json:"sessionID"`
+	Status    Status    `json:"status"`
+	Source    string    `json:"source"`
+	Error     string    `json:"error,omitempty"`
+	CreatedAt time.Time `json:"createdAt"`
+}
+
+// The output streams of an exec's command.
+const (
+	ExecLogStdout = "stdout"
+	ExecLogStderr = "stderr"
+)
+
+// ExecLogEntry is a line written by an exec's command to one of its output streams.
+type ExecLogEntry struct {
+	TimeStamp time.Time `json:"timestamp"`
+	Stream    string    `json:"stream"`
+	Message   string    `json:"message"`
 }
 
 type ExecConfig struct {
@@ -233,6 +333,8 @@ var (
 	CheckSuccess CheckConclusion = "success"
 	CheckFailure CheckConclusion = "failure"
 	CheckError   CheckConclusion = "error"
+	// CheckCancelled concludes a check that was cancelled before all of its steps ran.
+	CheckCancelled CheckConclusion = "cancelled"
 )
 
 func (c CheckConclusion) String() string {
@@ -0,0 +1,60 @@
+package types
+
+import (
+	"fmt"
+	"net/http"
+)
+
+// ProjectQuotas are the limits enforced when sessions of a project are created. A nil
+// quota is unlimited.
+type ProjectQuotas struct {
+	MaxConcurrentSessions *int `json:"maxConcurrentSessions"`
+	MaxGPUs               *int `json:"maxGPUs"`
+	// MonthlySpendLimit is in US dollars. It's checked against the cost accrued by the
+	// project's sessions since the start of the calendar month (UTC).
+	MonthlySpendLimit *float64 `json:"monthlySpendLimit"`
+}
+
+// ProjectQuotasSetParams replaces all the quotas of a project. Omitted quotas are
+// unlimited.
+type ProjectQuotasSetParams struct {
+	ProjectQuotas
+}
+
+func (p *ProjectQuotasSetParams) Bind(r *http.Request) error {
+	for name, v := range map[string]*int{
+		"maxConcurrentSessions": p.MaxConcurrentSessions,
+		"maxGPUs":               p.MaxGPUs,
+	} {
+		if v != nil && *v < 0 {
+			return invalidQuota(name)
+		}
+	}
+
+	if p.MonthlySpendLimit != nil && *p.MonthlySpendLimit < 0 {
+		return invalidQuota("monthlySpendLimit")
+	}
+
+	return nil
+}
+
+func invalidQuota(name string) error {
+	return &Error{
+		Code:       http.StatusBadRequest,
+		Message:    fmt.Sprintf("Invalid %q quota", name),
+		Suggestion: "Quotas can't be negative, omit a quota to make it unlimited",
+	}
+}
+
+// QuotaUsage is the current consumption of a project counted against its quotas.
+type QuotaUsage struct {
+	ConcurrentSessions int `json:"concurrentSessions"`
+	GPUs               int `json:"gpus"`
+	// MonthlySpend is the cost in US dollars accrued since the start of the month.
+	MonthlySpend float64 `json:"monthlySpend"`
+}
+
+type ProjectQuotasResponse struct {
+	Quotas ProjectQuotas `json:"quotas"`
+	Usage  QuotaUsage    `json:"usage"`
+}
@@ -85,15 +85,21 @@ func setDefaultMinGPUCount(gpu GPU) int {
 }
 
 type NodeMetadataV1 struct {
-	VCPUs          int              `json:"vcpus"`
-	Memory         int              `json:"memory"`
-	HDD            int              `json:"hdd"`
-	GpuType        string           `json:"gpuType"`
-	CpuType        string           `json:"cpuType"`
-	GPUCount       int              `json:"gpuCount"`
-	GPUMemory      int              `json:"gpuMemory"`
-	ConnectionInfo ConnectionInfoV1 `json:"connection_info"`
-	HTTPService    *HTTPService     `json:"http_service,omitempty"`
+	VCPUs             int               `json:"vcpus"`
+	Memory            int               `json:"memory"`
+	HDD               int               `json:"hdd"`
+	GpuType           string            `json:"gpuType"`
+	CpuType           string            `json:"cpuType"`
+	GPUCount          int               `json:"gpuCount"`
+	GPUMemory         int               `json:"gpuMemory"`
+	ConnectionInfo    ConnectionInfoV1  `json:"connection_info"`
+	HTTPService       *HTTPService      `json:"http_service,omitempty"`
+	IdlePolicy        *IdlePolicy       `json:"idle_policy,omitempty"`
+	TerminationReason string            `json:"termination_reason,omitempty"`
+	ProviderAttempts  []ProviderAttempt `json:"provider_attempts,omitempty"`
+	HourlyPrice       *int              `json:"hourly_price,omitempty"`
+	Job               bool              `json:"job,omitempty"`
+	ExitCode          *int              `json:"exit_code,omitempty"`
 }
 
 func (m *NodeMetadataV1) GetHardwareSpec() HardwareSpec {
@@ -0,0 +1,40 @@
+package types
+
+import "time"
+
+// UsageSession is the usage of a single exec within a report's window. Costs are in US
+// dollars.
+type UsageSession struct {
+	ExecID    string    `json:"sessionID"`
+	Name      string    `json:"name"`
+	CreatedBy string    `json:"createdBy"`
+	Provider  Provider  `json:"provider"`
+	GPUType   string    `json:"gpuType"`
+	Start     time.Time `json:"start"`
+	End       time.Time `json:"end"`
+	Hours     float64   `json:"hours"`
+	// HourlyPrice is nil if the provider doesn't publish the price of the node type. The
+	// session is then not included in the cost.
+	HourlyPrice *float64 `json:"hourlyPrice,omitempty"`
+	Cost        float64  `json:"cost"`
+	Running     bool     `json:"running"`
+}
+
+// UsageBreakdown aggregates the usage of the sessions sharing the same key.
+type UsageBreakdown struct {
+	Key      string  `json:"key"`
+	Sessions int     `json:"sessions"`
+	Hours    float64 `json:"hours"`
+	Cost     float64 `json:"cost"`
+}
+
+type UsageReport struct {
+	From       time.Time        `json:"from"`
+	To         time.Time        `json:"to"`
+	Cost       float64          `json:"cost"`
+	Hours      float64          `json:"hours"`
+	ByUser     []UsageBreakdown `json:"byUser"`
+	ByProvider []UsageBreakdown `json:"byProvider"`
+	ByGPUType  []UsageBreakdown `json:"byGPUType"`
+	Sessions   []UsageSession   `json:"sessions"`
+}
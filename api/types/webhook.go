@@ -0,0 +1,161 @@
+package types
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+type WebhookEvent string
+
+const (
+	WebhookEventExecRunning            WebhookEvent = "session.running"
+	WebhookEventExecTerminated         WebhookEvent = "session.terminated"
+	WebhookEventExecFailed             WebhookEvent = "session.failed"
+	WebhookEventExecExpiring           WebhookEvent = "session.expiring"
+	WebhookEventEndpointCheckCompleted WebhookEvent = "endpoint.check.completed"
+)
+
+// WebhookEvents are all the events a webhook can subscribe to.
+var WebhookEvents = []WebhookEvent{
+	WebhookEventExecRunning,
+	WebhookEventExecTerminated,
+	WebhookEventExecFailed,
+	WebhookEventExecExpiring,
+	WebhookEventEndpointCheckCompleted,
+}
+
+func (e WebhookEvent) valid() bool {
+	for _, event := range WebhookEvents {
+		if e == event {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Webhook is a project level subscription to lifecycle events. Subscribed events are
+// POSTed to the URL as a WebhookPayload signed with the webhook's secret.
+type Webhook struct {
+	ID        string         `json:"id"`
+	URL       string         `json:"url"`
+	Events    []WebhookEvent `json:"events"`
+	CreatedAt time.Time      `json:"createdAt"`
+}
+
+// WebhookPayload is the body of a webhook delivery. The X-Unweave-Signature header holds
+// the hex encoded HMAC-SHA256, keyed with the webhook's secret, of the X-Unweave-Timestamp
+// header and the body joined by a dot. Receivers should reject deliveries whose timestamp
+// is more than five minutes away from their clock.
+type WebhookPayload struct {
+	ID        string          `json:"id"`
+	Event     WebhookEvent    `json:"event"`
+	ProjectID string          `json:"projectID"`
+	CreatedAt time.Time       `json:"createdAt"`
+	Data      json.RawMessage `json:"data"`
+}
+
+// WebhookExecData is the payload data of session events.
+type WebhookExecData struct {
+	Session Exec   `json:"session"`
+	Error   string `json:"error,omitempty"`
+}
+
+// WebhookEndpointCheckData is the payload data of endpoint check events.
+type WebhookEndpointCheckData struct {
+	EndpointID string        `json:"endpointID"`
+	Check      EndpointCheck `json:"check"`
+}
+
+type WebhookDelivery struct {
+	ID         string       `json:"id"`
+	Event      WebhookEvent `json:"event"`
+	Attempt    int          `json:"attempt"`
+	StatusCode int          `json:"statusCode,omitempty"`
+	Error      string       `json:"error,omitempty"`
+	CreatedAt  time.Time    `json:"createdAt"`
+}
+
+type WebhookCreateParams struct {
+	URL string `json:"url"`
+	// Events the webhook subscribes to. Subscribes to all events if empty.
+	Events []WebhookEvent `json:"events,omitempty"`
+}
+
+func (p *WebhookCreateParams) Bind(r *http.Request) error {
+	if err := validateWebhookURL(p.URL); err != nil {
+		return err
+	}
+	if len(p.Events) == 0 {
+		p.Events = WebhookEvents
+	}
+
+	return validateWebhookEvents(p.Events)
+}
+
+type WebhookUpdateParams struct {
+	URL    *string        `json:"url,omitempty"`
+	Events []WebhookEvent `json:"events,omitempty"`
+}
+
+func (p *WebhookUpdateParams) Bind(r *http.Request) error {
+	if p.URL != nil {
+		if err := validateWebhookURL(*p.URL); err != nil {
+			return err
+		}
+	}
+
+	return validateWebhookEvents(p.Events)
+}
+
+type WebhookCreateResponse struct {
+	Webhook Webhook `json:"webhook"`
+	// Secret signs the deliveries of the webhook. It's only ever returned on creation.
+	Secret string `json:"secret"`
+}
+
+type WebhookGetResponse struct {
+	Webhook Webhook `json:"webhook"`
+}
+
+type WebhooksListResponse struct {
+	Webhooks []Webhook `json:"webhooks"`
+}
+
+type WebhookDeleteResponse struct {
+	Success bool `json:"success"`
+}
+
+type WebhookDeliveriesListResponse struct {
+	Deliveries []WebhookDelivery `json:"deliveries"`
+}
+
+func validateWebhookURL(raw string) error {
+	u, err := url.Parse(raw)
+	if err != nil || u.Scheme != "https" || u.Host == "" {
+		return &Error{
+			Code:       http.StatusBadRequest,
+			Message:    "Invalid webhook url",
+			Suggestion: "Use an absolute https url",
+		}
+	}
+
+	return nil
+}
+
+func validateWebhookEvents(events []WebhookEvent) error {
+	for _, event := range events {
+		if !event.valid() {
+			return &Error{
+				Code:       http.StatusBadRequest,
+				Message:    fmt.Sprintf("Invalid webhook event %q", event),
+				Suggestion: fmt.Sprintf("Valid events are: %v", WebhookEvents),
+			}
+		}
+	}
+
+	return nil
+}
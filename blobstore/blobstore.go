@@ -57,6 +57,8 @@ type S3Client interface {
 type Store interface {
 	Download(ctx context.Context, remoteDir, remoteKey, localDir string, overwrite bool) error
 	List(ctx context.Context, prefix string) ([]string, error)
+	// ListAfter lists the keys under prefix that sort after the startAfter key.
+	ListAfter(ctx context.Context, prefix, startAfter string) ([]string, error)
 	RemoteObjectMD5(ctx context.Context, key string) (string, error)
 	Upload(ctx context.Context, key string, content io.Reader, overwrite bool) error
 	UploadFromPath(ctx context.Context, key, localPath string, overwrite bool) error
@@ -70,10 +72,17 @@ type BlobStore struct {
 }
 
 func (b *BlobStore) List(ctx context.Context, prefix string) ([]string, error) {
+	return b.ListAfter(ctx, prefix, "")
+}
+
+func (b *BlobStore) ListAfter(ctx context.Context, prefix, startAfter string) ([]string, error) {
 	input := &s3.ListObjectsV2Input{
 		Bucket: aws.String(b.bucket),
 		Prefix: aws.String(prefix),
 	}
+	if startAfter != "" {
+		input.StartAfter = aws.String(startAfter)
+	}
 
 	var objectKeys []string
 
@@ -22,6 +22,10 @@ func (s *CASStore) List(ctx context.Context, prefix string) ([]string, error) {
 	return s.blobstore.List(ctx, prefix)
 }
 
+func (s *CASStore) ListAfter(ctx context.Context, prefix, startAfter string) ([]string, error) {
+	return s.blobstore.ListAfter(ctx, prefix, startAfter)
+}
+
 func (s *CASStore) Download(ctx context.Context, remoteDir, remoteKey, localDir string, overwrite bool) error {
 	return nil
 }
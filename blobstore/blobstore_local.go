@@ -6,6 +6,7 @@ import (
 	"encoding/hex"
 	"io"
 	"os"
+	"path"
 	"path/filepath"
 )
 
@@ -39,6 +40,25 @@ func (l *LocalBlobStore) List(ctx context.Context, prefix string) ([]string, err
 	return objectKeys, nil
 }
 
+// ListAfter returns the keys relative to prefix, like List, but compares the full keys
+// with startAfter.
+func (l *LocalBlobStore) ListAfter(ctx context.Context, prefix, startAfter string) ([]string, error) {
+	keys, err := l.List(ctx, prefix)
+	if err != nil {
+		return nil, err
+	}
+
+	var objectKeys []string
+
+	for _, key := range keys {
+		if path.Join(prefix, filepath.ToSlash(key)) > startAfter {
+			objectKeys = append(objectKeys, key)
+		}
+	}
+
+	return objectKeys, nil
+}
+
 func (l *LocalBlobStore) Download(ctx context.Context, remoteDir, remoteKey, localDir string, overwrite bool) error {
 	localPath := filepath.Join(localDir, filepath.FromSlash(remoteKey))
 	remotePath := filepath.Join(l.rootDir, remoteKey)
@@ -79,6 +99,10 @@ func (l *LocalBlobStore) RemoteObjectMD5(ctx context.Context, key string) (strin
 func (l *LocalBlobStore) Upload(ctx context.Context, key string, content io.Reader, overwrite bool) error {
 	dst := filepath.Join(l.rootDir, key)
 
+	if err := os.MkdirAll(filepath.Dir(dst), os.ModePerm); err != nil {
+		return err
+	}
+
 	dstFile, err := os.Create(dst)
 	if err != nil {
 		return err
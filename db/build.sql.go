@@ -70,7 +70,7 @@ func (q *Queries) BuildGet(ctx context.Context, id string) (UnweaveBuild, error)
 }
 
 const BuildGetUsedBy = `-- name: BuildGetUsedBy :many
-select s.id, s.name, s.region, s.created_by, s.created_at, s.ready_at, s.exited_at, s.status, s.project_id, s.error, s.build_id, s.spec, s.commit_id, s.git_remote_url, s.command, s.metadata, s.image, s.provider, n.provider
+select s.id, s.name, s.region, s.created_by, s.created_at, s.ready_at, s.exited_at, s.status, s.project_id, s.error, s.build_id, s.spec, s.commit_id, s.git_remote_url, s.command, s.metadata, s.image, s.provider, s.expires_at, n.provider
 from (select id from unweave.build as ub where ub.id = $1) as b
          join unweave.exec s
               on s.build_id = b.id
@@ -96,6 +96,7 @@ type BuildGetUsedByRow struct {
 	Metadata     json.RawMessage   `json:"metadata"`
 	Image        string            `json:"image"`
 	Provider     string            `json:"provider"`
+	ExpiresAt    sql.NullTime      `json:"expiresAt"`
 	Provider_2   string            `json:"provider2"`
 }
 
@@ -127,6 +128,7 @@ func (q *Queries) BuildGetUsedBy(ctx context.Context, id string) ([]BuildGetUsed
 			&i.Metadata,
 			&i.Image,
 			&i.Provider,
+			&i.ExpiresAt,
 			&i.Provider_2,
 		); err != nil {
 			return nil, err
@@ -0,0 +1,64 @@
+// Package dbtest connects tests to a real Postgres database with the unweave schema
+// migrated. Tests using it are skipped unless UNWEAVE_TEST_POSTGRES is set, the connection
+// itself is configured with the usual UNWEAVE_DB_* variables.
+package dbtest
+
+import (
+	"context"
+	"database/sql"
+	"os"
+	"testing"
+
+	_ "github.com/jackc/pgx/v4/stdlib" // registers the pgx driver
+	"github.com/unweave/unweave-v1/db"
+	"github.com/unweave/unweave-v1/tools/gonfig"
+	"github.com/unweave/unweave-v1/tools/random"
+)
+
+const enableEnvVar = "UNWEAVE_TEST_POSTGRES"
+
+// Connect returns a connection to the test database or skips the test if Postgres tests
+// are not enabled.
+func Connect(t *testing.T) *sql.DB {
+	t.Helper()
+
+	if os.Getenv(enableEnvVar) == "" {
+		t.Skipf("Skipping Postgres test, set %s to run it", enableEnvVar)
+	}
+
+	cfg := db.Config{}
+	gonfig.GetFromEnvVariables(&cfg)
+
+	conn, err := db.Connect(cfg)
+	if err != nil {
+		t.Fatalf("failed to connect to test database: %v", err)
+	}
+
+	t.Cleanup(func() { _ = conn.Close() })
+
+	return conn
+}
+
+// CreateAccount inserts a new account and returns its ID.
+func CreateAccount(t *testing.T, conn *sql.DB) string {
+	t.Helper()
+
+	id := "acc_" + random.GenerateRandomLower(12)
+	if _, err := conn.ExecContext(context.Background(), "insert into unweave.account (id) values ($1)", id); err != nil {
+		t.Fatalf("failed to create account: %v", err)
+	}
+
+	return id
+}
+
+// CreateProject inserts a new project and returns its ID.
+func CreateProject(t *testing.T, conn *sql.DB) string {
+	t.Helper()
+
+	id := "pr_" + random.GenerateRandomLower(12)
+	if _, err := conn.ExecContext(context.Background(), "insert into unweave.project (id) values ($1)", id); err != nil {
+		t.Fatalf("failed to create project: %v", err)
+	}
+
+	return id
+}
@@ -12,7 +12,7 @@ import (
 )
 
 const EndpointCheck = `-- name: EndpointCheck :one
-SELECT id, endpoint_id, project_id, created_at FROM unweave.endpoint_check WHERE id = $1
+SELECT id, endpoint_id, project_id, created_at, status FROM unweave.endpoint_check WHERE id = $1
 `
 
 func (q *Queries) EndpointCheck(ctx context.Context, id string) (UnweaveEndpointCheck, error) {
@@ -23,6 +23,7 @@ func (q *Queries) EndpointCheck(ctx context.Context, id string) (UnweaveEndpoint
 		&i.EndpointID,
 		&i.ProjectID,
 		&i.CreatedAt,
+		&i.Status,
 	)
 	return i, err
 }
@@ -42,6 +43,22 @@ func (q *Queries) EndpointCheckCreate(ctx context.Context, arg EndpointCheckCrea
 	return err
 }
 
+const EndpointCheckStatusUpdate = `-- name: EndpointCheckStatusUpdate :exec
+UPDATE unweave.endpoint_check
+SET status = $2
+WHERE id = $1 AND status <> 'cancelled'
+`
+
+type EndpointCheckStatusUpdateParams struct {
+	ID     string `json:"id"`
+	Status string `json:"status"`
+}
+
+func (q *Queries) EndpointCheckStatusUpdate(ctx context.Context, arg EndpointCheckStatusUpdateParams) error {
+	_, err := q.db.ExecContext(ctx, EndpointCheckStatusUpdate, arg.ID, arg.Status)
+	return err
+}
+
 const EndpointCheckStepCreate = `-- name: EndpointCheckStepCreate :exec
 INSERT INTO unweave.endpoint_check_step (id, check_id, eval_id, input) VALUES ($1, $2, $3, $4)
 `
@@ -67,14 +84,16 @@ const EndpointCheckStepUpdate = `-- name: EndpointCheckStepUpdate :exec
 UPDATE unweave.endpoint_check_step
 SET input = coalesce($1, input),
     output = coalesce($2, output),
-    assertion = coalesce($3, assertion)
-WHERE id = $4
+    assertion = coalesce($3, assertion),
+    error = coalesce($4, error)
+WHERE id = $5
 `
 
 type EndpointCheckStepUpdateParams struct {
 	Input     sql.NullString `json:"input"`
 	Output    sql.NullString `json:"output"`
 	Assertion sql.NullString `json:"assertion"`
+	Error     sql.NullString `json:"error"`
 	ID        sql.NullString `json:"id"`
 }
 
@@ -83,13 +102,14 @@ func (q *Queries) EndpointCheckStepUpdate(ctx context.Context, arg EndpointCheck
 		arg.Input,
 		arg.Output,
 		arg.Assertion,
+		arg.Error,
 		arg.ID,
 	)
 	return err
 }
 
 const EndpointCheckSteps = `-- name: EndpointCheckSteps :many
-SELECT id, check_id, eval_id, input, output, assertion
+SELECT id, check_id, eval_id, input, output, assertion, error
 FROM unweave.endpoint_check_step
 WHERE check_id = $1
 `
@@ -110,6 +130,7 @@ func (q *Queries) EndpointCheckSteps(ctx context.Context, checkID string) ([]Unw
 			&i.Input,
 			&i.Output,
 			&i.Assertion,
+			&i.Error,
 		); err != nil {
 			return nil, err
 		}
@@ -225,6 +246,43 @@ func (q *Queries) EndpointGet(ctx context.Context, arg EndpointGetParams) (Unwea
 	return i, err
 }
 
+const EndpointList = `-- name: EndpointList :many
+SELECT id, name, icon, project_id, http_address, created_at, deleted_at
+FROM unweave.endpoint
+WHERE deleted_at IS NULL
+`
+
+func (q *Queries) EndpointList(ctx context.Context) ([]UnweaveEndpoint, error) {
+	rows, err := q.db.QueryContext(ctx, EndpointList)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []UnweaveEndpoint
+	for rows.Next() {
+		var i UnweaveEndpoint
+		if err := rows.Scan(
+			&i.ID,
+			&i.Name,
+			&i.Icon,
+			&i.ProjectID,
+			&i.HttpAddress,
+			&i.CreatedAt,
+			&i.DeletedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const EndpointsForProject = `-- name: EndpointsForProject :many
 SELECT id, name, icon, project_id, http_address, created_at, deleted_at
 FROM unweave.endpoint
@@ -7,11 +7,58 @@ package db
 
 import (
 	"context"
+	"database/sql"
 	"time"
 )
 
+const EndpointRoutes = `-- name: EndpointRoutes :many
+SELECT v.id, v.endpoint_id, v.exec_id, v.http_address, v.primary_version, e.http_address AS endpoint_http_address
+FROM unweave.endpoint_version v
+JOIN unweave.endpoint e ON e.id = v.endpoint_id
+WHERE v.deleted_at IS NULL AND e.deleted_at IS NULL
+`
+
+type EndpointRoutesRow struct {
+	ID                  string `json:"id"`
+	EndpointID          string `json:"endpointID"`
+	ExecID              string `json:"execID"`
+	HttpAddress         string `json:"httpAddress"`
+	PrimaryVersion      bool   `json:"primaryVersion"`
+	EndpointHttpAddress string `json:"endpointHttpAddress"`
+}
+
+func (q *Queries) EndpointRoutes(ctx context.Context) ([]EndpointRoutesRow, error) {
+	rows, err := q.db.QueryContext(ctx, EndpointRoutes)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []EndpointRoutesRow
+	for rows.Next() {
+		var i EndpointRoutesRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.EndpointID,
+			&i.ExecID,
+			&i.HttpAddress,
+			&i.PrimaryVersion,
+			&i.EndpointHttpAddress,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const EndpointVersion = `-- name: EndpointVersion :one
-SELECT id, endpoint_id, exec_id, project_id, http_address, primary_version, created_at, deleted_at FROM unweave.endpoint_version WHERE id = $1
+SELECT id, endpoint_id, exec_id, project_id, http_address, primary_version, created_at, deleted_at, status, check_id FROM unweave.endpoint_version WHERE id = $1
 `
 
 func (q *Queries) EndpointVersion(ctx context.Context, id string) (UnweaveEndpointVersion, error) {
@@ -26,21 +73,25 @@ func (q *Queries) EndpointVersion(ctx context.Context, id string) (UnweaveEndpoi
 		&i.PrimaryVersion,
 		&i.CreatedAt,
 		&i.DeletedAt,
+		&i.Status,
+		&i.CheckID,
 	)
 	return i, err
 }
 
 const EndpointVersionCreate = `-- name: EndpointVersionCreate :exec
-INSERT INTO unweave.endpoint_version (id, endpoint_id, exec_id, project_id, http_address, created_at) VALUES ($1, $2, $3, $4, $5, $6)
+INSERT INTO unweave.endpoint_version (id, endpoint_id, exec_id, project_id, http_address, created_at, status, check_id) VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
 `
 
 type EndpointVersionCreateParams struct {
-	ID          string    `json:"id"`
-	EndpointID  string    `json:"endpointID"`
-	ExecID      string    `json:"execID"`
-	ProjectID   string    `json:"projectID"`
-	HttpAddress string    `json:"httpAddress"`
-	CreatedAt   time.Time `json:"createdAt"`
+	ID          string         `json:"id"`
+	EndpointID  string         `json:"endpointID"`
+	ExecID      string         `json:"execID"`
+	ProjectID   string         `json:"projectID"`
+	HttpAddress string         `json:"httpAddress"`
+	CreatedAt   time.Time      `json:"createdAt"`
+	Status      string         `json:"status"`
+	CheckID     sql.NullString `json:"checkID"`
 }
 
 func (q *Queries) EndpointVersionCreate(ctx context.Context, arg EndpointVersionCreateParams) error {
@@ -51,6 +102,8 @@ func (q *Queries) EndpointVersionCreate(ctx context.Context, arg EndpointVersion
 		arg.ProjectID,
 		arg.HttpAddress,
 		arg.CreatedAt,
+		arg.Status,
+		arg.CheckID,
 	)
 	return err
 }
@@ -67,7 +120,7 @@ func (q *Queries) EndpointVersionDemote(ctx context.Context, endpointID string)
 }
 
 const EndpointVersionList = `-- name: EndpointVersionList :many
-SELECT id, endpoint_id, exec_id, project_id, http_address, primary_version, created_at, deleted_at FROM unweave.endpoint_version WHERE endpoint_id = $1
+SELECT id, endpoint_id, exec_id, project_id, http_address, primary_version, created_at, deleted_at, status, check_id FROM unweave.endpoint_version WHERE endpoint_id = $1
 `
 
 func (q *Queries) EndpointVersionList(ctx context.Context, endpointID string) ([]UnweaveEndpointVersion, error) {
@@ -88,6 +141,8 @@ func (q *Queries) EndpointVersionList(ctx context.Context, endpointID string) ([
 			&i.PrimaryVersion,
 			&i.CreatedAt,
 			&i.DeletedAt,
+			&i.Status,
+			&i.CheckID,
 		); err != nil {
 			return nil, err
 		}
@@ -112,3 +167,19 @@ func (q *Queries) EndpointVersionPromote(ctx context.Context, id string) error {
 	_, err := q.db.ExecContext(ctx, EndpointVersionPromote, id)
 	return err
 }
+
+const EndpointVersionStatusUpdate = `-- name: EndpointVersionStatusUpdate :exec
+UPDATE unweave.endpoint_version
+SET status = $2
+WHERE id = $1
+`
+
+type EndpointVersionStatusUpdateParams struct {
+	ID     string `json:"id"`
+	Status string `json:"status"`
+}
+
+func (q *Queries) EndpointVersionStatusUpdate(ctx context.Context, arg EndpointVersionStatusUpdateParams) error {
+	_, err := q.db.ExecContext(ctx, EndpointVersionStatusUpdate, arg.ID, arg.Status)
+	return err
+}
@@ -9,6 +9,7 @@ import (
 	"context"
 	"database/sql"
 	"encoding/json"
+	"time"
 
 	"github.com/lib/pq"
 )
@@ -17,8 +18,8 @@ const ExecCreate = `-- name: ExecCreate :exec
 insert into unweave.exec (id, created_by, project_id,
                           region, name, spec, metadata, commit_id, git_remote_url,
                           command,
-                          build_id, image, provider)
-values ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13)
+                          build_id, image, provider, expires_at)
+values ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14)
 `
 
 type ExecCreateParams struct {
@@ -35,6 +36,7 @@ type ExecCreateParams struct {
 	BuildID      sql.NullString  `json:"buildID"`
 	Image        string          `json:"image"`
 	Provider     string          `json:"provider"`
+	ExpiresAt    sql.NullTime    `json:"expiresAt"`
 }
 
 func (q *Queries) ExecCreate(ctx context.Context, arg ExecCreateParams) error {
@@ -52,12 +54,13 @@ func (q *Queries) ExecCreate(ctx context.Context, arg ExecCreateParams) error {
 		arg.BuildID,
 		arg.Image,
 		arg.Provider,
+		arg.ExpiresAt,
 	)
 	return err
 }
 
 const ExecGet = `-- name: ExecGet :one
-select id, name, region, created_by, created_at, ready_at, exited_at, status, project_id, error, build_id, spec, commit_id, git_remote_url, command, metadata, image, provider
+select id, name, region, created_by, created_at, ready_at, exited_at, status, project_id, error, build_id, spec, commit_id, git_remote_url, command, metadata, image, provider, expires_at
 from unweave.exec
 where id = $1
    or name = $1
@@ -85,12 +88,13 @@ func (q *Queries) ExecGet(ctx context.Context, idOrName string) (UnweaveExec, er
 		&i.Metadata,
 		&i.Image,
 		&i.Provider,
+		&i.ExpiresAt,
 	)
 	return i, err
 }
 
 const ExecGetAllActive = `-- name: ExecGetAllActive :many
-select id, name, region, created_by, created_at, ready_at, exited_at, status, project_id, error, build_id, spec, commit_id, git_remote_url, command, metadata, image, provider
+select id, name, region, created_by, created_at, ready_at, exited_at, status, project_id, error, build_id, spec, commit_id, git_remote_url, command, metadata, image, provider, expires_at
 from unweave.exec
 where status = 'initializing'
    or status = 'running'
@@ -124,6 +128,7 @@ func (q *Queries) ExecGetAllActive(ctx context.Context) ([]UnweaveExec, error) {
 			&i.Metadata,
 			&i.Image,
 			&i.Provider,
+			&i.ExpiresAt,
 		); err != nil {
 			return nil, err
 		}
@@ -139,7 +144,7 @@ func (q *Queries) ExecGetAllActive(ctx context.Context) ([]UnweaveExec, error) {
 }
 
 const ExecList = `-- name: ExecList :many
-select id, name, region, created_by, created_at, ready_at, exited_at, status, project_id, error, build_id, spec, commit_id, git_remote_url, command, metadata, image, provider
+select id, name, region, created_by, created_at, ready_at, exited_at, status, project_id, error, build_id, spec, commit_id, git_remote_url, command, metadata, image, provider, expires_at
 from unweave.exec as e
 where (e.provider = coalesce($1, e.provider))
   and project_id = coalesce($2, project_id)
@@ -181,6 +186,7 @@ func (q *Queries) ExecList(ctx context.Context, arg ExecListParams) ([]UnweaveEx
 			&i.Metadata,
 			&i.Image,
 			&i.Provider,
+			&i.ExpiresAt,
 		); err != nil {
 			return nil, err
 		}
@@ -196,7 +202,7 @@ func (q *Queries) ExecList(ctx context.Context, arg ExecListParams) ([]UnweaveEx
 }
 
 const ExecListActiveByProvider = `-- name: ExecListActiveByProvider :many
-select id, name, region, created_by, created_at, ready_at, exited_at, status, project_id, error, build_id, spec, commit_id, git_remote_url, command, metadata, image, provider
+select id, name, region, created_by, created_at, ready_at, exited_at, status, project_id, error, build_id, spec, commit_id, git_remote_url, command, metadata, image, provider, expires_at
 from unweave.exec as e
 where provider = $1
   and (status = 'initializing'
@@ -231,6 +237,7 @@ func (q *Queries) ExecListActiveByProvider(ctx context.Context, provider string)
 			&i.Metadata,
 			&i.Image,
 			&i.Provider,
+			&i.ExpiresAt,
 		); err != nil {
 			return nil, err
 		}
@@ -246,7 +253,7 @@ func (q *Queries) ExecListActiveByProvider(ctx context.Context, provider string)
 }
 
 const ExecListByProvider = `-- name: ExecListByProvider :many
-select id, name, region, created_by, created_at, ready_at, exited_at, status, project_id, error, build_id, spec, commit_id, git_remote_url, command, metadata, image, provider
+select id, name, region, created_by, created_at, ready_at, exited_at, status, project_id, error, build_id, spec, commit_id, git_remote_url, command, metadata, image, provider, expires_at
 from unweave.exec as e
 where e.provider = $1
 `
@@ -279,6 +286,114 @@ func (q *Queries) ExecListByProvider(ctx context.Context, provider string) ([]Un
 			&i.Metadata,
 			&i.Image,
 			&i.Provider,
+			&i.ExpiresAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const ExecListInWindow = `-- name: ExecListInWindow :many
+select id, name, region, created_by, created_at, ready_at, exited_at, status, project_id, error, build_id, spec, commit_id, git_remote_url, command, metadata, image, provider, expires_at
+from unweave.exec as e
+where e.project_id = $1
+  and e.created_at <= $2::timestamptz
+  and (e.exited_at is null or e.exited_at >= $3::timestamptz)
+`
+
+type ExecListInWindowParams struct {
+	ProjectID string    `json:"projectID"`
+	ToTime    time.Time `json:"toTime"`
+	FromTime  time.Time `json:"fromTime"`
+}
+
+func (q *Queries) ExecListInWindow(ctx context.Context, arg ExecListInWindowParams) ([]UnweaveExec, error) {
+	rows, err := q.db.QueryContext(ctx, ExecListInWindow, arg.ProjectID, arg.ToTime, arg.FromTime)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []UnweaveExec
+	for rows.Next() {
+		var i UnweaveExec
+		if err := rows.Scan(
+			&i.ID,
+			&i.Name,
+			&i.Region,
+			&i.CreatedBy,
+			&i.CreatedAt,
+			&i.ReadyAt,
+			&i.ExitedAt,
+			&i.Status,
+			&i.ProjectID,
+			&i.Error,
+			&i.BuildID,
+			&i.Spec,
+			&i.CommitID,
+			&i.GitRemoteUrl,
+			pq.Array(&i.Command),
+			&i.Metadata,
+			&i.Image,
+			&i.Provider,
+			&i.ExpiresAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const ExecListUnfinished = `-- name: ExecListUnfinished :many
+select id, name, region, created_by, created_at, ready_at, exited_at, status, project_id, error, build_id, spec, commit_id, git_remote_url, command, metadata, image, provider, expires_at
+from unweave.exec as e
+where e.provider = coalesce($1, e.provider)
+  and status in ('pending', 'initializing', 'running', 'stopped')
+`
+
+func (q *Queries) ExecListUnfinished(ctx context.Context, filterProvider sql.NullString) ([]UnweaveExec, error) {
+	rows, err := q.db.QueryContext(ctx, ExecListUnfinished, filterProvider)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []UnweaveExec
+	for rows.Next() {
+		var i UnweaveExec
+		if err := rows.Scan(
+			&i.ID,
+			&i.Name,
+			&i.Region,
+			&i.CreatedBy,
+			&i.CreatedAt,
+			&i.ReadyAt,
+			&i.ExitedAt,
+			&i.Status,
+			&i.ProjectID,
+			&i.Error,
+			&i.BuildID,
+			&i.Spec,
+			&i.CommitID,
+			&i.GitRemoteUrl,
+			pq.Array(&i.Command),
+			&i.Metadata,
+			&i.Image,
+			&i.Provider,
+			&i.ExpiresAt,
 		); err != nil {
 			return nil, err
 		}
@@ -295,8 +410,9 @@ func (q *Queries) ExecListByProvider(ctx context.Context, provider string) ([]Un
 
 const ExecSetError = `-- name: ExecSetError :exec
 update unweave.exec
-set status = 'error'::unweave.exec_status,
-    error  = $2
+set status    = 'error'::unweave.exec_status,
+    error     = $2,
+    exited_at = coalesce(exited_at, now())
 where id = $1
 `
 
@@ -312,8 +428,9 @@ func (q *Queries) ExecSetError(ctx context.Context, arg ExecSetErrorParams) erro
 
 const ExecSetFailed = `-- name: ExecSetFailed :exec
 update unweave.exec
-set status = 'failed'::unweave.exec_status,
-    error  = $2
+set status    = 'failed'::unweave.exec_status,
+    error     = $2,
+    exited_at = coalesce(exited_at, now())
 where id = $1
 `
 
@@ -368,6 +485,22 @@ func (q *Queries) ExecUpdateConnectionInfo(ctx context.Context, arg ExecUpdateCo
 	return err
 }
 
+const ExecUpdateExitCode = `-- name: ExecUpdateExitCode :exec
+update unweave.exec
+set metadata = jsonb_set(metadata, '{exit_code}', to_jsonb($2::int))
+where id = $1
+`
+
+type ExecUpdateExitCodeParams struct {
+	ID       string `json:"id"`
+	ExitCode int32  `json:"exitCode"`
+}
+
+func (q *Queries) ExecUpdateExitCode(ctx context.Context, arg ExecUpdateExitCodeParams) error {
+	_, err := q.db.ExecContext(ctx, ExecUpdateExitCode, arg.ID, arg.ExitCode)
+	return err
+}
+
 const ExecUpdateNetwork = `-- name: ExecUpdateNetwork :exec
 update unweave.exec
 set metadata = jsonb_set(metadata, '{http_service}', $2::jsonb)
@@ -383,3 +516,19 @@ func (q *Queries) ExecUpdateNetwork(ctx context.Context, arg ExecUpdateNetworkPa
 	_, err := q.db.ExecContext(ctx, ExecUpdateNetwork, arg.ID, arg.HttpService)
 	return err
 }
+
+const ExecUpdateTerminationReason = `-- name: ExecUpdateTerminationReason :exec
+update unweave.exec
+set metadata = jsonb_set(metadata, '{termination_reason}', to_jsonb($2::text))
+where id = $1
+`
+
+type ExecUpdateTerminationReasonParams struct {
+	ID                string `json:"id"`
+	TerminationReason string `json:"terminationReason"`
+}
+
+func (q *Queries) ExecUpdateTerminationReason(ctx context.Context, arg ExecUpdateTerminationReasonParams) error {
+	_, err := q.db.ExecContext(ctx, ExecUpdateTerminationReason, arg.ID, arg.TerminationReason)
+	return err
+}
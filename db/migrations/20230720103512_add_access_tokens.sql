@@ -0,0 +1,29 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE unweave.access_token (
+    id text NOT NULL PRIMARY KEY,
+    name text NOT NULL,
+    account_id text NOT NULL REFERENCES unweave.account (id),
+    token_hash text NOT NULL UNIQUE,
+    display_text text NOT NULL,
+    expires_at timestamp with time zone NOT NULL,
+    created_at timestamp with time zone DEFAULT now() NOT NULL,
+    last_used_at timestamp with time zone,
+    revoked_at timestamp with time zone
+);
+
+CREATE INDEX unweave_access_token_account_id_idx ON unweave.access_token USING btree (account_id);
+
+CREATE TABLE unweave.project_account (
+    project_id text NOT NULL REFERENCES unweave.project (id),
+    account_id text NOT NULL REFERENCES unweave.account (id),
+    created_at timestamp with time zone DEFAULT now() NOT NULL,
+    PRIMARY KEY (project_id, account_id)
+);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE unweave.project_account;
+DROP TABLE unweave.access_token;
+-- +goose StatementEnd
@@ -0,0 +1,11 @@
+-- +goose Up
+-- +goose StatementBegin
+alter table unweave.exec
+    add column expires_at timestamptz;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+alter table unweave.exec
+    drop column if exists expires_at;
+-- +goose StatementEnd
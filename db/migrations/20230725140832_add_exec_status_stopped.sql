@@ -0,0 +1,10 @@
+-- +goose Up
+-- +goose StatementBegin
+alter type unweave.exec_status add value if not exists 'stopped';
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+-- Postgres can't drop enum values.
+SELECT 'down SQL query';
+-- +goose StatementEnd
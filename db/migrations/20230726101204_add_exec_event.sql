@@ -0,0 +1,18 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE unweave.exec_event (
+    id text DEFAULT ('eev_'::text || public.nanoid()) NOT NULL PRIMARY KEY,
+    exec_id text NOT NULL REFERENCES unweave.exec (id),
+    status text NOT NULL,
+    source text NOT NULL,
+    error text,
+    created_at timestamp with time zone DEFAULT now() NOT NULL
+);
+
+CREATE INDEX unweave_exec_event_exec_id_idx ON unweave.exec_event USING btree (exec_id, created_at);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE unweave.exec_event;
+-- +goose StatementEnd
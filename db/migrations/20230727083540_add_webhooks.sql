@@ -0,0 +1,33 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE unweave.webhook (
+    id text NOT NULL PRIMARY KEY,
+    project_id text NOT NULL REFERENCES unweave.project (id),
+    url text NOT NULL,
+    secret text NOT NULL,
+    events text[] NOT NULL,
+    created_at timestamp with time zone DEFAULT now() NOT NULL,
+    deleted_at timestamp with time zone
+);
+
+CREATE INDEX unweave_webhook_project_id_idx ON unweave.webhook USING btree (project_id);
+
+CREATE TABLE unweave.webhook_delivery (
+    id text NOT NULL PRIMARY KEY,
+    webhook_id text NOT NULL REFERENCES unweave.webhook (id),
+    event text NOT NULL,
+    payload jsonb NOT NULL,
+    attempt integer NOT NULL,
+    status_code integer,
+    error text,
+    created_at timestamp with time zone DEFAULT now() NOT NULL
+);
+
+CREATE INDEX unweave_webhook_delivery_webhook_id_idx ON unweave.webhook_delivery USING btree (webhook_id, created_at);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE unweave.webhook_delivery;
+DROP TABLE unweave.webhook;
+-- +goose StatementEnd
@@ -0,0 +1,15 @@
+-- +goose Up
+-- +goose StatementBegin
+alter table unweave.project
+    add column max_concurrent_sessions integer,
+    add column max_gpus integer,
+    add column monthly_spend_limit_cents integer;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+alter table unweave.project
+    drop column if exists max_concurrent_sessions,
+    drop column if exists max_gpus,
+    drop column if exists monthly_spend_limit_cents;
+-- +goose StatementEnd
@@ -0,0 +1,14 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE unweave.endpoint_traffic (
+    endpoint_id text NOT NULL PRIMARY KEY REFERENCES unweave.endpoint (id),
+    policy jsonb NOT NULL,
+    canary jsonb DEFAULT 'null'::jsonb NOT NULL,
+    updated_at timestamp with time zone DEFAULT now() NOT NULL
+);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE unweave.endpoint_traffic;
+-- +goose StatementEnd
@@ -0,0 +1,13 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE unweave.endpoint_version
+    ADD COLUMN status text DEFAULT 'deployed' NOT NULL,
+    ADD COLUMN check_id text;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE unweave.endpoint_version
+    DROP COLUMN IF EXISTS status,
+    DROP COLUMN IF EXISTS check_id;
+-- +goose StatementEnd
@@ -0,0 +1,19 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE unweave.endpoint_promotion (
+    id text NOT NULL PRIMARY KEY,
+    endpoint_id text NOT NULL REFERENCES unweave.endpoint (id),
+    version_id text NOT NULL REFERENCES unweave.endpoint_version (id),
+    previous_version_id text REFERENCES unweave.endpoint_version (id),
+    promoted_by text,
+    reason text NOT NULL,
+    created_at timestamp with time zone DEFAULT now() NOT NULL
+);
+
+CREATE INDEX unweave_endpoint_promotion_endpoint_id_idx ON unweave.endpoint_promotion USING btree (endpoint_id, created_at);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE unweave.endpoint_promotion;
+-- +goose StatementEnd
@@ -0,0 +1,22 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE unweave.endpoint_check
+    ADD COLUMN status text DEFAULT 'pending' NOT NULL;
+
+-- Checks created before statuses were tracked have run to completion or were lost on a
+-- restart, neither of which can be resumed or cancelled.
+UPDATE unweave.endpoint_check
+SET status = 'completed';
+
+ALTER TABLE unweave.endpoint_check_step
+    ADD COLUMN error text;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE unweave.endpoint_check
+    DROP COLUMN IF EXISTS status;
+
+ALTER TABLE unweave.endpoint_check_step
+    DROP COLUMN IF EXISTS error;
+-- +goose StatementEnd
@@ -0,0 +1,11 @@
+-- +goose Up
+-- +goose StatementBegin
+alter type unweave.exec_status add value if not exists 'success';
+alter type unweave.exec_status add value if not exists 'failed';
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+-- Postgres can't drop enum values.
+SELECT 'down SQL query';
+-- +goose StatementEnd
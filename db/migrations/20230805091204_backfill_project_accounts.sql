@@ -0,0 +1,20 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Projects were open to every account before access tokens, give the accounts that
+-- created sessions in a project access to it.
+INSERT INTO unweave.project_account (project_id, account_id)
+SELECT DISTINCT exec.project_id, exec.created_by
+FROM unweave.exec
+         JOIN unweave.account ON account.id = exec.created_by
+ON CONFLICT (project_id, account_id) DO NOTHING;
+
+-- The seed used to insert a token with a well known secret, drop it wherever it was applied.
+DELETE
+FROM unweave.access_token
+WHERE id = 'tok_local_development';
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+-- Backfilled rows can't be told apart from ones added since.
+-- +goose StatementEnd
@@ -0,0 +1,9 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE INDEX unweave_exec_project_id_created_at_idx ON unweave.exec USING btree (project_id, created_at);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX unweave.unweave_exec_project_id_created_at_idx;
+-- +goose StatementEnd
@@ -0,0 +1,14 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Execs that errored or failed didn't record when they exited, end them at their last event.
+UPDATE unweave.exec AS e
+SET exited_at = coalesce((SELECT max(ev.created_at) FROM unweave.exec_event AS ev WHERE ev.exec_id = e.id),
+                         e.created_at)
+WHERE e.exited_at IS NULL
+  AND e.status IN ('terminated', 'error', 'failed', 'success');
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+-- Backfilled exit times can't be told apart from recorded ones.
+-- +goose StatementEnd
@@ -68,6 +68,9 @@ const (
 	UnweaveExecStatusError        UnweaveExecStatus = "error"
 	UnweaveExecStatusSnapshotting UnweaveExecStatus = "snapshotting"
 	UnweaveExecStatusPending      UnweaveExecStatus = "pending"
+	UnweaveExecStatusStopped      UnweaveExecStatus = "stopped"
+	UnweaveExecStatusSuccess      UnweaveExecStatus = "success"
+	UnweaveExecStatusFailed       UnweaveExecStatus = "failed"
 )
 
 func (e *UnweaveExecStatus) Scan(src interface{}) error {
@@ -105,6 +108,18 @@ func (ns NullUnweaveExecStatus) Value() (driver.Value, error) {
 	return string(ns.UnweaveExecStatus), nil
 }
 
+type UnweaveAccessToken struct {
+	ID          string       `json:"id"`
+	Name        string       `json:"name"`
+	AccountID   string       `json:"accountID"`
+	TokenHash   string       `json:"tokenHash"`
+	DisplayText string       `json:"displayText"`
+	ExpiresAt   time.Time    `json:"expiresAt"`
+	CreatedAt   time.Time    `json:"createdAt"`
+	LastUsedAt  sql.NullTime `json:"lastUsedAt"`
+	RevokedAt   sql.NullTime `json:"revokedAt"`
+}
+
 type UnweaveAccount struct {
 	ID string `json:"id"`
 }
@@ -138,6 +153,7 @@ type UnweaveEndpointCheck struct {
 	EndpointID string    `json:"endpointID"`
 	ProjectID  string    `json:"projectID"`
 	CreatedAt  time.Time `json:"createdAt"`
+	Status     string    `json:"status"`
 }
 
 type UnweaveEndpointCheckStep struct {
@@ -147,6 +163,7 @@ type UnweaveEndpointCheckStep struct {
 	Input     sql.NullString `json:"input"`
 	Output    sql.NullString `json:"output"`
 	Assertion sql.NullString `json:"assertion"`
+	Error     sql.NullString `json:"error"`
 }
 
 type UnweaveEndpointEval struct {
@@ -154,15 +171,34 @@ type UnweaveEndpointEval struct {
 	EvalID     string `json:"evalID"`
 }
 
+type UnweaveEndpointPromotion struct {
+	ID                string         `json:"id"`
+	EndpointID        string         `json:"endpointID"`
+	VersionID         string         `json:"versionID"`
+	PreviousVersionID sql.NullString `json:"previousVersionID"`
+	PromotedBy        sql.NullString `json:"promotedBy"`
+	Reason            string         `json:"reason"`
+	CreatedAt         time.Time      `json:"createdAt"`
+}
+
+type UnweaveEndpointTraffic struct {
+	EndpointID string          `json:"endpointID"`
+	Policy     json.RawMessage `json:"policy"`
+	Canary     json.RawMessage `json:"canary"`
+	UpdatedAt  time.Time       `json:"updatedAt"`
+}
+
 type UnweaveEndpointVersion struct {
-	ID             string       `json:"id"`
-	EndpointID     string       `json:"endpointID"`
-	ExecID         string       `json:"execID"`
-	ProjectID      string       `json:"projectID"`
-	HttpAddress    string       `json:"httpAddress"`
-	PrimaryVersion bool         `json:"primaryVersion"`
-	CreatedAt      time.Time    `json:"createdAt"`
-	DeletedAt      sql.NullTime `json:"deletedAt"`
+	ID             string         `json:"id"`
+	EndpointID     string         `json:"endpointID"`
+	ExecID         string         `json:"execID"`
+	ProjectID      string         `json:"projectID"`
+	HttpAddress    string         `json:"httpAddress"`
+	PrimaryVersion bool           `json:"primaryVersion"`
+	CreatedAt      time.Time      `json:"createdAt"`
+	DeletedAt      sql.NullTime   `json:"deletedAt"`
+	Status         string         `json:"status"`
+	CheckID        sql.NullString `json:"checkID"`
 }
 
 type UnweaveEval struct {
@@ -192,6 +228,16 @@ type UnweaveExec struct {
 	Metadata     json.RawMessage   `json:"metadata"`
 	Image        string            `json:"image"`
 	Provider     string            `json:"provider"`
+	ExpiresAt    sql.NullTime      `json:"expiresAt"`
+}
+
+type UnweaveExecEvent struct {
+	ID        string         `json:"id"`
+	ExecID    string         `json:"execID"`
+	Status    string         `json:"status"`
+	Source    string         `json:"source"`
+	Error     sql.NullString `json:"error"`
+	CreatedAt time.Time      `json:"createdAt"`
 }
 
 type UnweaveExecSshKey struct {
@@ -224,8 +270,17 @@ type UnweaveNodeSshKey struct {
 }
 
 type UnweaveProject struct {
-	ID             string         `json:"id"`
-	DefaultBuildID sql.NullString `json:"defaultBuildID"`
+	ID                     string         `json:"id"`
+	DefaultBuildID         sql.NullString `json:"defaultBuildID"`
+	MaxConcurrentSessions  sql.NullInt32  `json:"maxConcurrentSessions"`
+	MaxGpus                sql.NullInt32  `json:"maxGpus"`
+	MonthlySpendLimitCents sql.NullInt32  `json:"monthlySpendLimitCents"`
+}
+
+type UnweaveProjectAccount struct {
+	ProjectID string    `json:"projectID"`
+	AccountID string    `json:"accountID"`
+	CreatedAt time.Time `json:"createdAt"`
 }
 
 type UnweaveSshKey struct {
@@ -247,3 +302,24 @@ type UnweaveVolume struct {
 	UpdatedAt time.Time    `json:"updatedAt"`
 	DeletedAt sql.NullTime `json:"deletedAt"`
 }
+
+type UnweaveWebhook struct {
+	ID        string       `json:"id"`
+	ProjectID string       `json:"projectID"`
+	Url       string       `json:"url"`
+	Secret    string       `json:"secret"`
+	Events    []string     `json:"events"`
+	CreatedAt time.Time    `json:"createdAt"`
+	DeletedAt sql.NullTime `json:"deletedAt"`
+}
+
+type UnweaveWebhookDelivery struct {
+	ID         string          `json:"id"`
+	WebhookID  string          `json:"webhookID"`
+	Event      string          `json:"event"`
+	Payload    json.RawMessage `json:"payload"`
+	Attempt    int32           `json:"attempt"`
+	StatusCode sql.NullInt32   `json:"statusCode"`
+	Error      sql.NullString  `json:"error"`
+	CreatedAt  time.Time       `json:"createdAt"`
+}
@@ -7,10 +7,66 @@ package db
 
 import (
 	"context"
+	"database/sql"
 )
 
+const ProjectAccountAdd = `-- name: ProjectAccountAdd :exec
+insert into unweave.project_account (project_id, account_id)
+values ($1, $2)
+on conflict (project_id, account_id) do nothing
+`
+
+type ProjectAccountAddParams struct {
+	ProjectID string `json:"projectID"`
+	AccountID string `json:"accountID"`
+}
+
+func (q *Queries) ProjectAccountAdd(ctx context.Context, arg ProjectAccountAddParams) error {
+	_, err := q.db.ExecContext(ctx, ProjectAccountAdd, arg.ProjectID, arg.AccountID)
+	return err
+}
+
+const ProjectAccountExists = `-- name: ProjectAccountExists :one
+select exists(select 1
+              from unweave.project_account
+              where project_id = $1
+                and account_id = $2)
+`
+
+type ProjectAccountExistsParams struct {
+	ProjectID string `json:"projectID"`
+	AccountID string `json:"accountID"`
+}
+
+func (q *Queries) ProjectAccountExists(ctx context.Context, arg ProjectAccountExistsParams) (bool, error) {
+	row := q.db.QueryRowContext(ctx, ProjectAccountExists, arg.ProjectID, arg.AccountID)
+	var exists bool
+	err := row.Scan(&exists)
+	return exists, err
+}
+
+const ProjectAccountRemove = `-- name: ProjectAccountRemove :execrows
+delete
+from unweave.project_account
+where project_id = $1
+  and account_id = $2
+`
+
+type ProjectAccountRemoveParams struct {
+	ProjectID string `json:"projectID"`
+	AccountID string `json:"accountID"`
+}
+
+func (q *Queries) ProjectAccountRemove(ctx context.Context, arg ProjectAccountRemoveParams) (int64, error) {
+	result, err := q.db.ExecContext(ctx, ProjectAccountRemove, arg.ProjectID, arg.AccountID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
 const ProjectGet = `-- name: ProjectGet :one
-select id, default_build_id
+select id, default_build_id, max_concurrent_sessions, max_gpus, monthly_spend_limit_cents
 from unweave.project
 where id = $1
 `
@@ -18,6 +74,40 @@ where id = $1
 func (q *Queries) ProjectGet(ctx context.Context, id string) (UnweaveProject, error) {
 	row := q.db.QueryRowContext(ctx, ProjectGet, id)
 	var i UnweaveProject
-	err := row.Scan(&i.ID, &i.DefaultBuildID)
+	err := row.Scan(
+		&i.ID,
+		&i.DefaultBuildID,
+		&i.MaxConcurrentSessions,
+		&i.MaxGpus,
+		&i.MonthlySpendLimitCents,
+	)
 	return i, err
 }
+
+const ProjectQuotasUpdate = `-- name: ProjectQuotasUpdate :execrows
+update unweave.project
+set max_concurrent_sessions   = $2,
+    max_gpus                  = $3,
+    monthly_spend_limit_cents = $4
+where id = $1
+`
+
+type ProjectQuotasUpdateParams struct {
+	ID                     string        `json:"id"`
+	MaxConcurrentSessions  sql.NullInt32 `json:"maxConcurrentSessions"`
+	MaxGpus                sql.NullInt32 `json:"maxGpus"`
+	MonthlySpendLimitCents sql.NullInt32 `json:"monthlySpendLimitCents"`
+}
+
+func (q *Queries) ProjectQuotasUpdate(ctx context.Context, arg ProjectQuotasUpdateParams) (int64, error) {
+	result, err := q.db.ExecContext(ctx, ProjectQuotasUpdate,
+		arg.ID,
+		arg.MaxConcurrentSessions,
+		arg.MaxGpus,
+		arg.MonthlySpendLimitCents,
+	)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
@@ -6,15 +6,23 @@ package db
 
 import (
 	"context"
+	"database/sql"
 )
 
 type Querier interface {
+	AccessTokenCreate(ctx context.Context, arg AccessTokenCreateParams) error
+	AccessTokenGetByHash(ctx context.Context, tokenHash string) (UnweaveAccessToken, error)
+	AccessTokenList(ctx context.Context, accountID string) ([]UnweaveAccessToken, error)
+	AccessTokenRevoke(ctx context.Context, arg AccessTokenRevokeParams) (int64, error)
+	AccessTokenTouch(ctx context.Context, id string) error
+	AccountCreate(ctx context.Context, id string) error
 	BuildCreate(ctx context.Context, arg BuildCreateParams) (string, error)
 	BuildGet(ctx context.Context, id string) (UnweaveBuild, error)
 	BuildGetUsedBy(ctx context.Context, id string) ([]BuildGetUsedByRow, error)
 	BuildUpdate(ctx context.Context, arg BuildUpdateParams) error
 	EndpointCheck(ctx context.Context, id string) (UnweaveEndpointCheck, error)
 	EndpointCheckCreate(ctx context.Context, arg EndpointCheckCreateParams) error
+	EndpointCheckStatusUpdate(ctx context.Context, arg EndpointCheckStatusUpdateParams) error
 	EndpointCheckStepCreate(ctx context.Context, arg EndpointCheckStepCreateParams) error
 	EndpointCheckStepUpdate(ctx context.Context, arg EndpointCheckStepUpdateParams) error
 	EndpointCheckSteps(ctx context.Context, checkID string) ([]UnweaveEndpointCheckStep, error)
@@ -23,11 +31,20 @@ type Querier interface {
 	EndpointEval(ctx context.Context, endpointID string) ([]UnweaveEndpointEval, error)
 	EndpointEvalAttach(ctx context.Context, arg EndpointEvalAttachParams) error
 	EndpointGet(ctx context.Context, arg EndpointGetParams) (UnweaveEndpoint, error)
+	EndpointList(ctx context.Context) ([]UnweaveEndpoint, error)
+	EndpointPromotionCreate(ctx context.Context, arg EndpointPromotionCreateParams) error
+	EndpointPromotionList(ctx context.Context, endpointID string) ([]UnweaveEndpointPromotion, error)
+	EndpointRoutes(ctx context.Context) ([]EndpointRoutesRow, error)
+	EndpointTraffic(ctx context.Context, endpointID string) (UnweaveEndpointTraffic, error)
+	EndpointTrafficDelete(ctx context.Context, endpointID string) error
+	EndpointTrafficList(ctx context.Context) ([]UnweaveEndpointTraffic, error)
+	EndpointTrafficSet(ctx context.Context, arg EndpointTrafficSetParams) error
 	EndpointVersion(ctx context.Context, id string) (UnweaveEndpointVersion, error)
 	EndpointVersionCreate(ctx context.Context, arg EndpointVersionCreateParams) error
 	EndpointVersionDemote(ctx context.Context, endpointID string) error
 	EndpointVersionList(ctx context.Context, endpointID string) ([]UnweaveEndpointVersion, error)
 	EndpointVersionPromote(ctx context.Context, id string) error
+	EndpointVersionStatusUpdate(ctx context.Context, arg EndpointVersionStatusUpdateParams) error
 	EndpointsForProject(ctx context.Context, projectID string) ([]UnweaveEndpoint, error)
 	EvalCreate(ctx context.Context, arg EvalCreateParams) error
 	EvalDelete(ctx context.Context, id string) error
@@ -35,11 +52,16 @@ type Querier interface {
 	EvalList(ctx context.Context, dollar_1 []string) ([]EvalListRow, error)
 	EvalListForProject(ctx context.Context, projectID string) ([]EvalListForProjectRow, error)
 	ExecCreate(ctx context.Context, arg ExecCreateParams) error
+	ExecEventCreate(ctx context.Context, arg ExecEventCreateParams) error
+	ExecEventList(ctx context.Context, execID string) ([]UnweaveExecEvent, error)
+	ExecEventListByExecs(ctx context.Context, execIds []string) ([]UnweaveExecEvent, error)
 	ExecGet(ctx context.Context, idOrName string) (UnweaveExec, error)
 	ExecGetAllActive(ctx context.Context) ([]UnweaveExec, error)
 	ExecList(ctx context.Context, arg ExecListParams) ([]UnweaveExec, error)
 	ExecListActiveByProvider(ctx context.Context, provider string) ([]UnweaveExec, error)
 	ExecListByProvider(ctx context.Context, provider string) ([]UnweaveExec, error)
+	ExecListInWindow(ctx context.Context, arg ExecListInWindowParams) ([]UnweaveExec, error)
+	ExecListUnfinished(ctx context.Context, filterProvider sql.NullString) ([]UnweaveExec, error)
 	ExecSSHKeyDelete(ctx context.Context, arg ExecSSHKeyDeleteParams) error
 	ExecSSHKeyGet(ctx context.Context, arg ExecSSHKeyGetParams) (UnweaveExecSshKey, error)
 	ExecSSHKeyInsert(ctx context.Context, arg ExecSSHKeyInsertParams) error
@@ -48,7 +70,9 @@ type Querier interface {
 	ExecSetFailed(ctx context.Context, arg ExecSetFailedParams) error
 	ExecStatusUpdate(ctx context.Context, arg ExecStatusUpdateParams) error
 	ExecUpdateConnectionInfo(ctx context.Context, arg ExecUpdateConnectionInfoParams) error
+	ExecUpdateExitCode(ctx context.Context, arg ExecUpdateExitCodeParams) error
 	ExecUpdateNetwork(ctx context.Context, arg ExecUpdateNetworkParams) error
+	ExecUpdateTerminationReason(ctx context.Context, arg ExecUpdateTerminationReasonParams) error
 	ExecVolumeCreate(ctx context.Context, arg ExecVolumeCreateParams) error
 	ExecVolumeDelete(ctx context.Context, execID string) error
 	ExecVolumeGet(ctx context.Context, execID string) ([]UnweaveExecVolume, error)
@@ -59,7 +83,11 @@ type Querier interface {
 	MxExecsGet(ctx context.Context, projectID string) ([]MxExecsGetRow, error)
 	NodeCreate(ctx context.Context, arg NodeCreateParams) error
 	NodeStatusUpdate(ctx context.Context, arg NodeStatusUpdateParams) error
+	ProjectAccountAdd(ctx context.Context, arg ProjectAccountAddParams) error
+	ProjectAccountExists(ctx context.Context, arg ProjectAccountExistsParams) (bool, error)
+	ProjectAccountRemove(ctx context.Context, arg ProjectAccountRemoveParams) (int64, error)
 	ProjectGet(ctx context.Context, id string) (UnweaveProject, error)
+	ProjectQuotasUpdate(ctx context.Context, arg ProjectQuotasUpdateParams) (int64, error)
 	SSHKeyAdd(ctx context.Context, arg SSHKeyAddParams) error
 	SSHKeyGetByName(ctx context.Context, arg SSHKeyGetByNameParams) (UnweaveSshKey, error)
 	SSHKeyGetByPublicKey(ctx context.Context, arg SSHKeyGetByPublicKeyParams) (UnweaveSshKey, error)
@@ -70,6 +98,14 @@ type Querier interface {
 	VolumeGet(ctx context.Context, arg VolumeGetParams) (UnweaveVolume, error)
 	VolumeList(ctx context.Context, projectID string) ([]UnweaveVolume, error)
 	VolumeUpdate(ctx context.Context, arg VolumeUpdateParams) error
+	WebhookCreate(ctx context.Context, arg WebhookCreateParams) error
+	WebhookDelete(ctx context.Context, arg WebhookDeleteParams) (int64, error)
+	WebhookDeliveryCreate(ctx context.Context, arg WebhookDeliveryCreateParams) error
+	WebhookDeliveryList(ctx context.Context, webhookID string) ([]UnweaveWebhookDelivery, error)
+	WebhookGet(ctx context.Context, arg WebhookGetParams) (UnweaveWebhook, error)
+	WebhookList(ctx context.Context, projectID string) ([]UnweaveWebhook, error)
+	WebhookListForEvent(ctx context.Context, arg WebhookListForEventParams) ([]UnweaveWebhook, error)
+	WebhookUpdate(ctx context.Context, arg WebhookUpdateParams) (int64, error)
 }
 
 var _ Querier = (*Queries)(nil)
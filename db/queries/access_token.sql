@@ -0,0 +1,28 @@
+-- name: AccessTokenCreate :exec
+insert into unweave.access_token (id, name, account_id, token_hash, display_text, expires_at)
+values ($1, $2, $3, $4, $5, $6);
+
+-- name: AccessTokenGetByHash :one
+select *
+from unweave.access_token
+where token_hash = $1
+  and revoked_at is null;
+
+-- name: AccessTokenList :many
+select *
+from unweave.access_token
+where account_id = $1
+  and revoked_at is null
+order by created_at desc;
+
+-- name: AccessTokenRevoke :execrows
+update unweave.access_token
+set revoked_at = now()
+where id = $1
+  and account_id = $2
+  and revoked_at is null;
+
+-- name: AccessTokenTouch :exec
+update unweave.access_token
+set last_used_at = now()
+where id = $1;
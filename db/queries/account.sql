@@ -0,0 +1,4 @@
+-- name: AccountCreate :exec
+insert into unweave.account (id)
+values ($1)
+on conflict (id) do nothing;
@@ -9,6 +9,11 @@ WHERE id = $1 OR (name = $1 AND project_id = $2);
 -- name: EndpointDelete :exec
 DELETE FROM unweave.endpoint WHERE id = $1;
 
+-- name: EndpointList :many
+SELECT id, name, icon, project_id, http_address, created_at, deleted_at
+FROM unweave.endpoint
+WHERE deleted_at IS NULL;
+
 -- name: EndpointsForProject :many
 SELECT id, name, icon, project_id, http_address, created_at, deleted_at
 FROM unweave.endpoint
@@ -24,7 +29,12 @@ INSERT INTO unweave.endpoint_eval (endpoint_id, eval_id) VALUES ($1, $2);
 INSERT INTO unweave.endpoint_check (id, endpoint_id, project_id) VALUES ($1, $2, $3);
 
 -- name: EndpointCheck :one
-SELECT id, endpoint_id, project_id, created_at FROM unweave.endpoint_check WHERE id = $1;
+SELECT id, endpoint_id, project_id, created_at, status FROM unweave.endpoint_check WHERE id = $1;
+
+-- name: EndpointCheckStatusUpdate :exec
+UPDATE unweave.endpoint_check
+SET status = $2
+WHERE id = $1 AND status <> 'cancelled';
 
 -- name: EndpointCheckStepCreate :exec
 INSERT INTO unweave.endpoint_check_step (id, check_id, eval_id, input) VALUES ($1, $2, $3, $4);
@@ -33,11 +43,12 @@ INSERT INTO unweave.endpoint_check_step (id, check_id, eval_id, input) VALUES ($
 UPDATE unweave.endpoint_check_step
 SET input = coalesce(sqlc.narg('input'), input),
     output = coalesce(sqlc.narg('output'), output),
-    assertion = coalesce(sqlc.narg('assertion'), assertion)
+    assertion = coalesce(sqlc.narg('assertion'), assertion),
+    error = coalesce(sqlc.narg('error'), error)
 WHERE id = sqlc.narg('id');
 
 -- name: EndpointCheckSteps :many
-SELECT id, check_id, eval_id, input, output, assertion
+SELECT id, check_id, eval_id, input, output, assertion, error
 FROM unweave.endpoint_check_step
 WHERE check_id = $1;
 
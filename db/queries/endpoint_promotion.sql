@@ -0,0 +1,9 @@
+-- name: EndpointPromotionCreate :exec
+INSERT INTO unweave.endpoint_promotion (id, endpoint_id, version_id, previous_version_id, promoted_by, reason)
+VALUES ($1, $2, $3, $4, $5, $6);
+
+-- name: EndpointPromotionList :many
+SELECT id, endpoint_id, version_id, previous_version_id, promoted_by, reason, created_at
+FROM unweave.endpoint_promotion
+WHERE endpoint_id = $1
+ORDER BY created_at;
@@ -0,0 +1,17 @@
+-- name: EndpointTraffic :one
+SELECT endpoint_id, policy, canary, updated_at FROM unweave.endpoint_traffic WHERE endpoint_id = $1;
+
+-- name: EndpointTrafficDelete :exec
+DELETE FROM unweave.endpoint_traffic WHERE endpoint_id = $1;
+
+-- name: EndpointTrafficList :many
+SELECT t.endpoint_id, t.policy, t.canary, t.updated_at
+FROM unweave.endpoint_traffic t
+JOIN unweave.endpoint e ON e.id = t.endpoint_id
+WHERE e.deleted_at IS NULL;
+
+-- name: EndpointTrafficSet :exec
+INSERT INTO unweave.endpoint_traffic (endpoint_id, policy, canary, updated_at)
+VALUES ($1, $2, $3, now())
+ON CONFLICT (endpoint_id) DO UPDATE
+SET policy = excluded.policy, canary = excluded.canary, updated_at = excluded.updated_at;
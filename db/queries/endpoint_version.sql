@@ -1,11 +1,17 @@
+-- name: EndpointRoutes :many
+SELECT v.id, v.endpoint_id, v.exec_id, v.http_address, v.primary_version, e.http_address AS endpoint_http_address
+FROM unweave.endpoint_version v
+JOIN unweave.endpoint e ON e.id = v.endpoint_id
+WHERE v.deleted_at IS NULL AND e.deleted_at IS NULL;
+
 -- name: EndpointVersion :one
-SELECT id, endpoint_id, exec_id, project_id, http_address, primary_version, created_at, deleted_at FROM unweave.endpoint_version WHERE id = $1;
+SELECT id, endpoint_id, exec_id, project_id, http_address, primary_version, created_at, deleted_at, status, check_id FROM unweave.endpoint_version WHERE id = $1;
 
 -- name: EndpointVersionList :many
-SELECT id, endpoint_id, exec_id, project_id, http_address, primary_version, created_at, deleted_at FROM unweave.endpoint_version WHERE endpoint_id = $1;
+SELECT id, endpoint_id, exec_id, project_id, http_address, primary_version, created_at, deleted_at, status, check_id FROM unweave.endpoint_version WHERE endpoint_id = $1;
 
 -- name: EndpointVersionCreate :exec
-INSERT INTO unweave.endpoint_version (id, endpoint_id, exec_id, project_id, http_address, created_at) VALUES ($1, $2, $3, $4, $5, $6);
+INSERT INTO unweave.endpoint_version (id, endpoint_id, exec_id, project_id, http_address, created_at, status, check_id) VALUES ($1, $2, $3, $4, $5, $6, $7, $8);
 
 -- name: EndpointVersionDemote :exec
 UPDATE unweave.endpoint_version
@@ -16,3 +22,8 @@ WHERE endpoint_id = $1;
 UPDATE unweave.endpoint_version
 SET primary_version = TRUE
 WHERE id = $1;
+
+-- name: EndpointVersionStatusUpdate :exec
+UPDATE unweave.endpoint_version
+SET status = $2
+WHERE id = $1;
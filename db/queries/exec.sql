@@ -2,8 +2,8 @@
 insert into unweave.exec (id, created_by, project_id,
                           region, name, spec, metadata, commit_id, git_remote_url,
                           command,
-                          build_id, image, provider)
-values ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13);
+                          build_id, image, provider, expires_at)
+values ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14);
 
 -- name: ExecGet :one
 select *
@@ -30,6 +30,19 @@ where (e.provider = coalesce(sqlc.narg('filter_provider'), e.provider))
   and ((@filter_active = true and (status = 'pending' or status = 'initializing' or status = 'running'))
     or @filter_active = false);
 
+-- name: ExecListUnfinished :many
+select *
+from unweave.exec as e
+where e.provider = coalesce(sqlc.narg('filter_provider'), e.provider)
+  and status in ('pending', 'initializing', 'running', 'stopped');
+
+-- name: ExecListInWindow :many
+select *
+from unweave.exec as e
+where e.project_id = @project_id
+  and e.created_at <= @to_time::timestamptz
+  and (e.exited_at is null or e.exited_at >= @from_time::timestamptz);
+
 -- name: ExecListActiveByProvider :many
 select *
 from unweave.exec as e
@@ -42,21 +55,33 @@ update unweave.exec
 set metadata = jsonb_set(metadata, '{connection_info}', @connection_info::jsonb)
 where id = $1;
 
+-- name: ExecUpdateExitCode :exec
+update unweave.exec
+set metadata = jsonb_set(metadata, '{exit_code}', to_jsonb(@exit_code::int))
+where id = $1;
+
 -- name: ExecUpdateNetwork :exec
 update unweave.exec
 set metadata = jsonb_set(metadata, '{http_service}', @http_service::jsonb)
 where id = $1;
 
+-- name: ExecUpdateTerminationReason :exec
+update unweave.exec
+set metadata = jsonb_set(metadata, '{termination_reason}', to_jsonb(@termination_reason::text))
+where id = $1;
+
 -- name: ExecSetError :exec
 update unweave.exec
-set status = 'error'::unweave.exec_status,
-    error  = $2
+set status    = 'error'::unweave.exec_status,
+    error     = $2,
+    exited_at = coalesce(exited_at, now())
 where id = $1;
 
 -- name: ExecSetFailed :exec
 update unweave.exec
-set status = 'failed'::unweave.exec_status,
-    error  = $2
+set status    = 'failed'::unweave.exec_status,
+    error     = $2,
+    exited_at = coalesce(exited_at, now())
 where id = $1;
 
 -- name: ExecStatusUpdate :exec
@@ -0,0 +1,15 @@
+-- name: ExecEventCreate :exec
+insert into unweave.exec_event (exec_id, status, source, error)
+values ($1, $2, $3, $4);
+
+-- name: ExecEventList :many
+select *
+from unweave.exec_event
+where exec_id = $1
+order by created_at, id;
+
+-- name: ExecEventListByExecs :many
+select *
+from unweave.exec_event
+where exec_id = any(@exec_ids::text[])
+order by exec_id, created_at, id;
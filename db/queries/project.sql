@@ -2,3 +2,27 @@
 select *
 from unweave.project
 where id = $1;
+
+-- name: ProjectAccountAdd :exec
+insert into unweave.project_account (project_id, account_id)
+values ($1, $2)
+on conflict (project_id, account_id) do nothing;
+
+-- name: ProjectAccountExists :one
+select exists(select 1
+              from unweave.project_account
+              where project_id = $1
+                and account_id = $2);
+
+-- name: ProjectAccountRemove :execrows
+delete
+from unweave.project_account
+where project_id = $1
+  and account_id = $2;
+
+-- name: ProjectQuotasUpdate :execrows
+update unweave.project
+set max_concurrent_sessions   = $2,
+    max_gpus                  = $3,
+    monthly_spend_limit_cents = $4
+where id = $1;
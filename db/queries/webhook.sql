@@ -0,0 +1,50 @@
+-- name: WebhookCreate :exec
+insert into unweave.webhook (id, project_id, url, secret, events)
+values ($1, $2, $3, $4, $5);
+
+-- name: WebhookDelete :execrows
+update unweave.webhook
+set deleted_at = now()
+where id = $1
+  and project_id = $2
+  and deleted_at is null;
+
+-- name: WebhookDeliveryCreate :exec
+insert into unweave.webhook_delivery (id, webhook_id, event, payload, attempt, status_code, error)
+values ($1, $2, $3, $4, $5, $6, $7);
+
+-- name: WebhookDeliveryList :many
+select *
+from unweave.webhook_delivery
+where webhook_id = $1
+order by created_at desc
+limit 100;
+
+-- name: WebhookGet :one
+select *
+from unweave.webhook
+where id = $1
+  and project_id = $2
+  and deleted_at is null;
+
+-- name: WebhookList :many
+select *
+from unweave.webhook
+where project_id = $1
+  and deleted_at is null
+order by created_at;
+
+-- name: WebhookListForEvent :many
+select *
+from unweave.webhook
+where project_id = $1
+  and sqlc.arg(event)::text = any(events)
+  and deleted_at is null;
+
+-- name: WebhookUpdate :execrows
+update unweave.webhook
+set url    = $3,
+    events = $4
+where id = $1
+  and project_id = $2
+  and deleted_at is null;
@@ -31,7 +31,10 @@ CREATE TYPE unweave.exec_status AS ENUM (
     'terminated',
     'error',
     'snapshotting',
-    'pending'
+    'pending',
+    'stopped',
+    'success',
+    'failed'
 );
 
 ALTER TYPE unweave.exec_status OWNER TO postgres;
@@ -40,6 +43,20 @@ SET default_tablespace = '';
 
 SET default_table_access_method = heap;
 
+CREATE TABLE unweave.access_token (
+    id text NOT NULL,
+    name text NOT NULL,
+    account_id text NOT NULL,
+    token_hash text NOT NULL,
+    display_text text NOT NULL,
+    expires_at timestamp with time zone NOT NULL,
+    created_at timestamp with time zone DEFAULT now() NOT NULL,
+    last_used_at timestamp with time zone,
+    revoked_at timestamp with time zone
+);
+
+ALTER TABLE unweave.access_token OWNER TO postgres;
+
 CREATE TABLE unweave.build (
     id text DEFAULT ('bld_'::text || public.nanoid()) NOT NULL,
     project_id text NOT NULL,
@@ -76,6 +93,7 @@ CREATE TABLE unweave.exec (
     metadata jsonb DEFAULT '{}'::jsonb NOT NULL,
     image text DEFAULT 'ubuntu:latest'::text NOT NULL,
     provider text NOT NULL,
+    expires_at timestamp with time zone,
     CONSTRAINT session_id_check CHECK ((length(id) > 11))
 );
 
@@ -84,11 +102,22 @@ ALTER TABLE unweave.exec OWNER TO postgres;
 CREATE TABLE unweave.project (
     id text DEFAULT ('pr_'::text || public.nanoid()) NOT NULL,
     default_build_id text,
+    max_concurrent_sessions integer,
+    max_gpus integer,
+    monthly_spend_limit_cents integer,
     CONSTRAINT project_id_check CHECK ((length(id) > 11))
 );
 
 ALTER TABLE unweave.project OWNER TO postgres;
 
+CREATE TABLE unweave.project_account (
+    project_id text NOT NULL,
+    account_id text NOT NULL,
+    created_at timestamp with time zone DEFAULT now() NOT NULL
+);
+
+ALTER TABLE unweave.project_account OWNER TO postgres;
+
 CREATE TABLE unweave.ssh_key (
     id text DEFAULT ('ss_'::text || public.nanoid()) NOT NULL,
     name text NOT NULL,
@@ -123,7 +152,8 @@ CREATE TABLE unweave.endpoint_check (
     id text NOT NULL,
     endpoint_id text NOT NULL,
     project_id text NOT NULL,
-    created_at timestamp with time zone DEFAULT now() NOT NULL
+    created_at timestamp with time zone DEFAULT now() NOT NULL,
+    status text DEFAULT 'pending'::text NOT NULL
 );
 
 ALTER TABLE unweave.endpoint_check OWNER TO postgres;
@@ -134,7 +164,8 @@ CREATE TABLE unweave.endpoint_check_step (
     eval_id text NOT NULL,
     input text,
     output text,
-    assertion text
+    assertion text,
+    error text
 );
 
 ALTER TABLE unweave.endpoint_check_step OWNER TO postgres;
@@ -154,11 +185,34 @@ CREATE TABLE unweave.endpoint_version (
     http_address text NOT NULL,
     primary_version boolean DEFAULT false NOT NULL,
     created_at timestamp with time zone DEFAULT now() NOT NULL,
-    deleted_at timestamp with time zone
+    deleted_at timestamp with time zone,
+    status text DEFAULT 'deployed'::text NOT NULL,
+    check_id text
 );
 
 ALTER TABLE unweave.endpoint_version OWNER TO postgres;
 
+CREATE TABLE unweave.endpoint_promotion (
+    id text NOT NULL,
+    endpoint_id text NOT NULL,
+    version_id text NOT NULL,
+    previous_version_id text,
+    promoted_by text,
+    reason text NOT NULL,
+    created_at timestamp with time zone DEFAULT now() NOT NULL
+);
+
+ALTER TABLE unweave.endpoint_promotion OWNER TO postgres;
+
+CREATE TABLE unweave.endpoint_traffic (
+    endpoint_id text NOT NULL,
+    policy jsonb NOT NULL,
+    canary jsonb DEFAULT 'null'::jsonb NOT NULL,
+    updated_at timestamp with time zone DEFAULT now() NOT NULL
+);
+
+ALTER TABLE unweave.endpoint_traffic OWNER TO postgres;
+
 CREATE TABLE unweave.eval (
     id text NOT NULL,
     exec_id text NOT NULL,
@@ -176,6 +230,17 @@ CREATE TABLE unweave.exec_ssh_key (
 
 ALTER TABLE unweave.exec_ssh_key OWNER TO postgres;
 
+CREATE TABLE unweave.exec_event (
+    id text DEFAULT ('eev_'::text || public.nanoid()) NOT NULL,
+    exec_id text NOT NULL,
+    status text NOT NULL,
+    source text NOT NULL,
+    error text,
+    created_at timestamp with time zone DEFAULT now() NOT NULL
+);
+
+ALTER TABLE unweave.exec_event OWNER TO postgres;
+
 CREATE TABLE unweave.exec_volume (
     exec_id text NOT NULL,
     volume_id text NOT NULL,
@@ -197,6 +262,37 @@ CREATE TABLE unweave.volume (
 
 ALTER TABLE unweave.volume OWNER TO postgres;
 
+CREATE TABLE unweave.webhook (
+    id text NOT NULL,
+    project_id text NOT NULL,
+    url text NOT NULL,
+    secret text NOT NULL,
+    events text[] NOT NULL,
+    created_at timestamp with time zone DEFAULT now() NOT NULL,
+    deleted_at timestamp with time zone
+);
+
+ALTER TABLE unweave.webhook OWNER TO postgres;
+
+CREATE TABLE unweave.webhook_delivery (
+    id text NOT NULL,
+    webhook_id text NOT NULL,
+    event text NOT NULL,
+    payload jsonb NOT NULL,
+    attempt integer NOT NULL,
+    status_code integer,
+    error text,
+    created_at timestamp with time zone DEFAULT now() NOT NULL
+);
+
+ALTER TABLE unweave.webhook_delivery OWNER TO postgres;
+
+ALTER TABLE ONLY unweave.access_token
+    ADD CONSTRAINT access_token_pkey PRIMARY KEY (id);
+
+ALTER TABLE ONLY unweave.access_token
+    ADD CONSTRAINT access_token_token_hash_key UNIQUE (token_hash);
+
 ALTER TABLE ONLY unweave.account
     ADD CONSTRAINT account_pkey PRIMARY KEY (id);
 
@@ -218,15 +314,27 @@ ALTER TABLE ONLY unweave.endpoint
 ALTER TABLE ONLY unweave.endpoint_version
     ADD CONSTRAINT endpoint_version_pkey PRIMARY KEY (id);
 
+ALTER TABLE ONLY unweave.endpoint_promotion
+    ADD CONSTRAINT endpoint_promotion_pkey PRIMARY KEY (id);
+
+ALTER TABLE ONLY unweave.endpoint_traffic
+    ADD CONSTRAINT endpoint_traffic_pkey PRIMARY KEY (endpoint_id);
+
 ALTER TABLE ONLY unweave.eval
     ADD CONSTRAINT eval_pkey PRIMARY KEY (id);
 
+ALTER TABLE ONLY unweave.exec_event
+    ADD CONSTRAINT exec_event_pkey PRIMARY KEY (id);
+
 ALTER TABLE ONLY unweave.exec_ssh_key
     ADD CONSTRAINT exec_ssh_key_pkey PRIMARY KEY (exec_id, ssh_key_id);
 
 ALTER TABLE ONLY unweave.exec_volume
     ADD CONSTRAINT exec_volume_pkey PRIMARY KEY (exec_id, volume_id, mount_path);
 
+ALTER TABLE ONLY unweave.project_account
+    ADD CONSTRAINT project_account_pkey PRIMARY KEY (project_id, account_id);
+
 ALTER TABLE ONLY unweave.project
     ADD CONSTRAINT project_pkey PRIMARY KEY (id);
 
@@ -245,8 +353,27 @@ ALTER TABLE ONLY unweave.ssh_key
 ALTER TABLE ONLY unweave.volume
     ADD CONSTRAINT volume_pkey PRIMARY KEY (id);
 
+ALTER TABLE ONLY unweave.webhook_delivery
+    ADD CONSTRAINT webhook_delivery_pkey PRIMARY KEY (id);
+
+ALTER TABLE ONLY unweave.webhook
+    ADD CONSTRAINT webhook_pkey PRIMARY KEY (id);
+
+CREATE INDEX unweave_access_token_account_id_idx ON unweave.access_token USING btree (account_id);
+
 CREATE INDEX unweave_endpoint_name_idx ON unweave.endpoint USING btree (name);
 
+CREATE INDEX unweave_endpoint_promotion_endpoint_id_idx ON unweave.endpoint_promotion USING btree (endpoint_id, created_at);
+
+CREATE INDEX unweave_exec_event_exec_id_idx ON unweave.exec_event USING btree (exec_id, created_at);
+
+CREATE INDEX unweave_webhook_delivery_webhook_id_idx ON unweave.webhook_delivery USING btree (webhook_id, created_at);
+
+CREATE INDEX unweave_webhook_project_id_idx ON unweave.webhook USING btree (project_id);
+
+ALTER TABLE ONLY unweave.access_token
+    ADD CONSTRAINT access_token_account_id_fkey FOREIGN KEY (account_id) REFERENCES unweave.account(id);
+
 ALTER TABLE ONLY unweave.build
     ADD CONSTRAINT build_created_by_fkey FOREIGN KEY (created_by) REFERENCES unweave.account(id);
 
@@ -271,6 +398,18 @@ ALTER TABLE ONLY unweave.endpoint_check_step
 ALTER TABLE ONLY unweave.endpoint_eval
     ADD CONSTRAINT endpoint_eval_eval_id_fkey FOREIGN KEY (eval_id) REFERENCES unweave.eval(id);
 
+ALTER TABLE ONLY unweave.endpoint_promotion
+    ADD CONSTRAINT endpoint_promotion_endpoint_id_fkey FOREIGN KEY (endpoint_id) REFERENCES unweave.endpoint(id);
+
+ALTER TABLE ONLY unweave.endpoint_promotion
+    ADD CONSTRAINT endpoint_promotion_previous_version_id_fkey FOREIGN KEY (previous_version_id) REFERENCES unweave.endpoint_version(id);
+
+ALTER TABLE ONLY unweave.endpoint_promotion
+    ADD CONSTRAINT endpoint_promotion_version_id_fkey FOREIGN KEY (version_id) REFERENCES unweave.endpoint_version(id);
+
+ALTER TABLE ONLY unweave.endpoint_traffic
+    ADD CONSTRAINT endpoint_traffic_endpoint_id_fkey FOREIGN KEY (endpoint_id) REFERENCES unweave.endpoint(id);
+
 ALTER TABLE ONLY unweave.endpoint_version
     ADD CONSTRAINT endpoint_version_endpoint_id_fkey FOREIGN KEY (endpoint_id) REFERENCES unweave.endpoint(id);
 
@@ -292,6 +431,9 @@ ALTER TABLE ONLY unweave.exec
 ALTER TABLE ONLY unweave.exec
     ADD CONSTRAINT exec_project_id_fkey FOREIGN KEY (project_id) REFERENCES unweave.project(id);
 
+ALTER TABLE ONLY unweave.exec_event
+    ADD CONSTRAINT exec_event_exec_id_fkey FOREIGN KEY (exec_id) REFERENCES unweave.exec(id);
+
 ALTER TABLE ONLY unweave.exec_ssh_key
     ADD CONSTRAINT exec_ssh_key_exec_id_fkey FOREIGN KEY (exec_id) REFERENCES unweave.exec(id);
 
@@ -304,6 +446,12 @@ ALTER TABLE ONLY unweave.exec_volume
 ALTER TABLE ONLY unweave.exec_volume
     ADD CONSTRAINT exec_volume_volume_id_fkey FOREIGN KEY (volume_id) REFERENCES unweave.volume(id);
 
+ALTER TABLE ONLY unweave.project_account
+    ADD CONSTRAINT project_account_account_id_fkey FOREIGN KEY (account_id) REFERENCES unweave.account(id);
+
+ALTER TABLE ONLY unweave.project_account
+    ADD CONSTRAINT project_account_project_id_fkey FOREIGN KEY (project_id) REFERENCES unweave.project(id);
+
 ALTER TABLE ONLY unweave.project
     ADD CONSTRAINT project_default_build_id_fkey FOREIGN KEY (default_build_id) REFERENCES unweave.build(id);
 
@@ -313,3 +461,9 @@ ALTER TABLE ONLY unweave.ssh_key
 ALTER TABLE ONLY unweave.volume
     ADD CONSTRAINT volume_project_id_fkey FOREIGN KEY (project_id) REFERENCES unweave.project(id);
 
+ALTER TABLE ONLY unweave.webhook_delivery
+    ADD CONSTRAINT webhook_delivery_webhook_id_fkey FOREIGN KEY (webhook_id) REFERENCES unweave.webhook(id);
+
+ALTER TABLE ONLY unweave.webhook
+    ADD CONSTRAINT webhook_project_id_fkey FOREIGN KEY (project_id) REFERENCES unweave.project(id);
+
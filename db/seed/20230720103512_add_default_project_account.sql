@@ -0,0 +1,13 @@
+-- +goose Up
+-- +goose StatementBegin
+
+insert into unweave.project_account (project_id, account_id)
+values ('00000000-0000-0000-0000-000000000002', '00000000-0000-0000-0000-000000000001')
+on conflict (project_id, account_id) do nothing;
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+
+-- +goose StatementEnd
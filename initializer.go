@@ -5,11 +5,17 @@ package main
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
+	"strings"
 
+	"github.com/aws/aws-sdk-go-v2/config"
+	"github.com/unweave/unweave-v1/blobstore"
 	"github.com/unweave/unweave-v1/builder"
 	"github.com/unweave/unweave-v1/builder/docker"
 	"github.com/unweave/unweave-v1/builder/fslogs"
+	"github.com/unweave/unweave-v1/providers/awsprov"
+	"github.com/unweave/unweave-v1/services/execsrv"
 	"github.com/unweave/unweave-v1/tools/gonfig"
 	"github.com/unweave/unweave-v1/vault"
 )
@@ -21,8 +27,31 @@ type providerConfig struct {
 	LambdaLabsAPIKey string `env:"LAMBDALABS_API_KEY"`
 }
 
+type awsConfig struct {
+	// Accounts is a JSON list of awsAccountConfig. When unset a single account is
+	// configured from the standard AWS environment variables.
+	Accounts        string `env:"UNWEAVE_AWS_ACCOUNTS"`
+	AccessKeyID     string `env:"AWS_ACCESS_KEY_ID"`
+	SecretAccessKey string `env:"AWS_SECRET_ACCESS_KEY"`
+	Region          string `env:"AWS_REGION"`
+}
+
+type awsAccountConfig struct {
+	ID              string   `json:"id"`
+	AccessKeyID     string   `json:"accessKeyID"`
+	SecretAccessKey string   `json:"secretAccessKey"`
+	Regions         []string `json:"regions"`
+}
+
+type execLogsConfig struct {
+	Dir    string `env:"UNWEAVE_EXEC_LOGS_DIR"`
+	Bucket string `env:"UNWEAVE_EXEC_LOGS_BUCKET"`
+}
+
 type builderConfig struct {
-	RegistryURI string `env:"UNWEAVE_CONTAINER_REGISTRY_URI"`
+	RegistryURI      string `env:"UNWEAVE_CONTAINER_REGISTRY_URI"`
+	RegistryUsername string `env:"UNWEAVE_CONTAINER_REGISTRY_USERNAME"`
+	RegistryPassword string `env:"UNWEAVE_CONTAINER_REGISTRY_PASSWORD"`
 }
 
 func (i *EnvInitializer) InitializeBuilder(ctx context.Context, userID string, builderType string) (builder.Builder, error) {
@@ -41,3 +70,106 @@ func (i *EnvInitializer) InitializeBuilder(ctx context.Context, userID string, b
 func (i *EnvInitializer) InitializeVault(ctx context.Context) (vault.Vault, error) {
 	return vault.NewMemVault(), nil
 }
+
+// InitializeAWSAccounts stores the credentials of the configured AWS accounts in the vault.
+// The first region of the first account is the default region.
+func (i *EnvInitializer) InitializeAWSAccounts(ctx context.Context, v vault.Vault) ([]awsprov.Account, string, error) {
+	var cfg awsConfig
+	gonfig.GetFromEnvVariables(&cfg)
+
+	var accountCfgs []awsAccountConfig
+
+	if cfg.Accounts != "" {
+		if err := json.Unmarshal([]byte(cfg.Accounts), &accountCfgs); err != nil {
+			return nil, "", fmt.Errorf("parse aws accounts: %w", err)
+		}
+	} else {
+		region := cfg.Region
+		if region == "" {
+			region = "us-east-1"
+		}
+
+		accountCfgs = []awsAccountConfig{{
+			ID:              "default",
+			AccessKeyID:     cfg.AccessKeyID,
+			SecretAccessKey: cfg.SecretAccessKey,
+			Regions:         []string{region},
+		}}
+	}
+
+	if len(accountCfgs) == 0 || len(accountCfgs[0].Regions) == 0 {
+		return nil, "", fmt.Errorf("at least one aws account with a region must be configured")
+	}
+
+	accounts := make([]awsprov.Account, len(accountCfgs))
+
+	for idx, a := range accountCfgs {
+		secretID, err := awsprov.StoreCredentials(ctx, v, awsprov.Credentials{
+			AccessKeyID:     a.AccessKeyID,
+			SecretAccessKey: a.SecretAccessKey,
+		})
+		if err != nil {
+			return nil, "", fmt.Errorf("store credentials of aws account %q: %w", a.ID, err)
+		}
+
+		accounts[idx] = awsprov.Account{
+			ID:                  a.ID,
+			CredentialsSecretID: secretID,
+			Regions:             a.Regions,
+		}
+	}
+
+	return accounts, accountCfgs[0].Regions[0], nil
+}
+
+// InitializeRegistryCredentials stores the credentials execs pull images from the
+// container registry with in the vault. It returns an empty secret ID if no credentials
+// are configured.
+func (i *EnvInitializer) InitializeRegistryCredentials(ctx context.Context, v vault.Vault) (string, error) {
+	var cfg builderConfig
+	gonfig.GetFromEnvVariables(&cfg)
+
+	if cfg.RegistryUsername == "" {
+		return "", nil
+	}
+
+	// Images are pushed to Docker Hub unless the URI starts with a registry host.
+	server, _, _ := strings.Cut(cfg.RegistryURI, "/")
+	if !strings.ContainsAny(server, ".:") && server != "localhost" {
+		server = ""
+	}
+
+	secretID, err := awsprov.StoreRegistryCredentials(ctx, v, awsprov.RegistryCredentials{
+		Server:   server,
+		Username: cfg.RegistryUsername,
+		Password: cfg.RegistryPassword,
+	})
+	if err != nil {
+		return "", fmt.Errorf("store registry credentials: %w", err)
+	}
+
+	return secretID, nil
+}
+
+// InitializeExecLogDriver stores the logs of execs in an S3 bucket if one is configured
+// and on the filesystem otherwise.
+func (i *EnvInitializer) InitializeExecLogDriver(ctx context.Context) (execsrv.ExecLogDriver, error) {
+	var cfg execLogsConfig
+	gonfig.GetFromEnvVariables(&cfg)
+
+	if cfg.Bucket != "" {
+		awsCfg, err := config.LoadDefaultConfig(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("load aws config: %w", err)
+		}
+
+		return execsrv.NewBlobLogDriver(blobstore.NewBlobStore(cfg.Bucket, awsCfg), "exec-logs"), nil
+	}
+
+	dir := cfg.Dir
+	if dir == "" {
+		dir = "/tmp/unweave/exec-logs"
+	}
+
+	return execsrv.NewFsLogDriver(dir), nil
+}
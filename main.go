@@ -1,6 +1,8 @@
 package main
 
 import (
+	"context"
+	"net/http"
 	"os"
 	"time"
 
@@ -9,12 +11,25 @@ import (
 	"github.com/rs/zerolog/log"
 	"github.com/unweave/unweave-v1/api/router"
 	"github.com/unweave/unweave-v1/api/server"
+	"github.com/unweave/unweave-v1/api/types"
 	"github.com/unweave/unweave-v1/db"
 	"github.com/unweave/unweave-v1/providers/awsprov"
 	"github.com/unweave/unweave-v1/providers/lambdalabs"
+	"github.com/unweave/unweave-v1/providers/local"
+	"github.com/unweave/unweave-v1/providers/proxy"
+	"github.com/unweave/unweave-v1/providers/resilient"
+	"github.com/unweave/unweave-v1/services/costsrv"
+	"github.com/unweave/unweave-v1/services/endpointsrv"
+	"github.com/unweave/unweave-v1/services/evalsrv"
 	"github.com/unweave/unweave-v1/services/execsrv"
+	"github.com/unweave/unweave-v1/services/projectsrv"
+	"github.com/unweave/unweave-v1/services/providersrv"
+	"github.com/unweave/unweave-v1/services/quotasrv"
+	"github.com/unweave/unweave-v1/services/schedulersrv"
 	"github.com/unweave/unweave-v1/services/sshkeys"
+	"github.com/unweave/unweave-v1/services/tokensrv"
 	"github.com/unweave/unweave-v1/services/volumesrv"
+	"github.com/unweave/unweave-v1/services/webhooksrv"
 	"github.com/unweave/unweave-v1/tools/gonfig"
 )
 
@@ -34,60 +49,271 @@ func main() {
 	}
 	db.Q = db.New(conn)
 
+	if len(os.Args) > 1 && os.Args[1] == "token" {
+		os.Exit(runTokenCommand(context.Background(), os.Args[2:]))
+	}
+
 	// Initialize unweave from environment variables
 	runtimeCfg := &EnvInitializer{}
 	execStore := execsrv.NewPostgresStore()
 	volStore := volumesrv.NewPostgresStore()
 
-	lls := lambdaLabsService(execStore, volStore)
-	awss := awsService(execStore, volStore)
+	idlePolicy := types.IdlePolicy{Timeout: cfg.IdleTimeout, Threshold: cfg.IdleThreshold}
+	webhookSrv := webhooksrv.NewService(db.Q)
+
+	breakers := resilient.NewBreakers(resilient.DefaultPolicy)
+
+	lls, llVolumeSrv, llProviderSrv := lambdaLabsServices(execStore, volStore, idlePolicy, webhookSrv, breakers)
+	awss, awsVolumeSrv, awsProviderSrv := awsServices(cfg, runtimeCfg, execStore, volStore, idlePolicy, webhookSrv, breakers)
+
+	execSrvs := []execsrv.Service{lls, awss}
+	volumeSrvs := []volumesrv.Service{llVolumeSrv, awsVolumeSrv}
+	providerSrvs := []*providersrv.ProviderService{llProviderSrv, awsProviderSrv}
+
+	if cfg.LocalProvider {
+		locals, localVolumeSrv, localProviderSrv := localServices(execStore, volStore, idlePolicy, webhookSrv, breakers)
+
+		execSrvs = append(execSrvs, locals)
+		volumeSrvs = append(volumeSrvs, localVolumeSrv)
+		providerSrvs = append(providerSrvs, localProviderSrv)
+	}
+
+	delegatingExecSrv := execsrv.NewDelegatingService(execStore, execSrvs...)
+	delegatingExecSrv = execsrv.WithNodeTypeListers(delegatingExecSrv, toNodeTypeListers(providerSrvs)...)
+
+	// The local provider is left out of scheduling, it's only used when requested by name.
+	schedulerSrv := schedulersrv.NewService(llProviderSrv, awsProviderSrv)
+	delegatingExecSrv = execsrv.WithScheduler(delegatingExecSrv, schedulerSrv)
 
-	delegatingExecSrv := execsrv.NewDelegatingService(execStore, lls, awss)
-	execRouter := router.NewExecRouter(runtimeCfg, execStore, delegatingExecSrv)
-	sshKeysRouter := router.NewSSHKeysRouter(sshkeys.NewService())
+	quotaSrv := quotasrv.NewService(db.Q, execStore)
+	execSrv := quotasrv.NewEnforcingService(delegatingExecSrv, quotaSrv)
+	delegatingVolumeSrv := volumesrv.NewDelegatingService(volStore, volumeSrvs...)
 
-	server.API(cfg, runtimeCfg, execRouter, sshKeysRouter)
+	endpointDriver := resilient.NewEndpointDriver(endpointDriver(cfg, execStore), breakers)
+	evalSrv := evalsrv.NewEvalService(db.Q, execSrv, endpointDriver)
+	endpointSrv := endpointsrv.NewEndpointService(db.Q, evalSrv, execSrv, endpointDriver)
+	endpointSrv = endpointsrv.WithNotifier(endpointSrv, webhookSrv)
+
+	tokenSrv := tokensrv.NewService(db.Q)
+
+	execLogDriver, err := runtimeCfg.InitializeExecLogDriver(context.Background())
+	if err != nil {
+		log.Fatal().Err(err).Msg("failed to initialize exec log driver")
+	}
+	execLogSrv := execsrv.NewLogService(execStore, execLogDriver, []byte(cfg.LogIngestKey))
+
+	routers := server.Routers{
+		Exec:      router.NewExecRouter(runtimeCfg, execStore, execSrv),
+		ExecLog:   router.NewExecLogRouter(execLogSrv),
+		Health:    router.NewHealthRouter(breakers),
+		SSHKeys:   router.NewSSHKeysRouter(sshkeys.NewService()),
+		Endpoint:  router.NewEndpointRouter(endpointSrv),
+		Eval:      router.NewEvalRouter(evalSrv),
+		Volume:    router.NewVolumeRouter(delegatingVolumeSrv),
+		Provider:  router.NewProviderRouter(providerSrvs...),
+		Token:     router.NewTokenRouter(tokenSrv),
+		Webhook:   router.NewWebhookRouter(webhookSrv),
+		Scheduler: router.NewSchedulerRouter(schedulerSrv),
+		Usage:     router.NewUsageRouter(costsrv.NewService(execStore)),
+		Quota:     router.NewQuotaRouter(quotaSrv),
+		Project:   router.NewProjectRouter(projectsrv.NewService(db.Q)),
+	}
+
+	server.API(cfg, runtimeCfg, tokenSrv, routers)
 }
 
-func lambdaLabsService(execStore execsrv.Store, volStore volumesrv.Store) execsrv.Service {
+func lambdaLabsServices(
+	execStore execsrv.Store,
+	volStore volumesrv.Store,
+	idlePolicy types.IdlePolicy,
+	webhookSrv *webhooksrv.Service,
+	breakers *resilient.Breakers,
+) (execsrv.Service, volumesrv.Service, *providersrv.ProviderService) {
 	llDriver, err := lambdalabs.NewAuthenticatedLambdaLabsDriver("")
 	if err != nil {
 		panic(err)
 	}
 
-	llStateInf := execsrv.NewPollingStateInformerManager(execStore, llDriver)
-	llStatsInf := execsrv.NewPollingStatsInformerManager(execStore, llDriver)
-	llHeartbeatInf := execsrv.NewPollingHeartbeatInformerManager(llDriver, 10)
+	llExecDriver := resilient.NewExecDriver(llDriver, breakers)
 
-	llVolumeSrv := volumesrv.NewService(volStore, llDriver)
+	llStateInf := execsrv.NewPollingStateInformerManager(execStore, llExecDriver)
+	llStatsInf := execsrv.NewPollingStatsInformerManager(execStore, llExecDriver)
+	llHeartbeatInf := execsrv.NewPollingHeartbeatInformerManager(llExecDriver, 10)
 
-	lls := execsrv.NewService(execStore, llDriver, llVolumeSrv, llStateInf, llStatsInf, llHeartbeatInf)
+	llVolumeSrv := volumesrv.NewService(volStore, resilient.NewVolumeDriver(llDriver, breakers))
+
+	lls := execsrv.NewService(execStore, llExecDriver, llVolumeSrv, llStateInf, llStatsInf, llHeartbeatInf)
 	lls = execsrv.WithStateObserver(lls, execsrv.NewStateObserverFactory(lls))
 
+	llIdle := execsrv.NewIdleObserverFactory(lls, execStore, idlePolicy)
+	lls = execsrv.WithStatsObserver(lls, llIdle.Stats())
+	lls = execsrv.WithHeartbeatObserver(lls, llIdle.Heartbeat())
+
+	llHistory := execsrv.NewHistoryObserverFactory(execStore)
+	lls = execsrv.WithStateObserver(lls, llHistory.State())
+	lls = execsrv.WithHeartbeatObserver(lls, llHistory.Heartbeat())
+	lls = execsrv.WithStateObserver(lls, webhooksrv.NewExecObserverFactory(webhookSrv, lls, db.Q))
+	lls = execsrv.WithExpiryObserver(lls, webhooksrv.NewExpiryObserver(webhookSrv, lls, db.Q))
+
+	llProviderSrv := providersrv.NewProviderService(llDriver)
+	lls = execsrv.WithPricer(lls, costsrv.NewPricer(llProviderSrv))
+
 	if err = lls.Init(); err != nil {
 		panic(err)
 	}
 
-	return lls
+	return lls, llVolumeSrv, llProviderSrv
 }
 
-func awsService(execStore execsrv.Store, volStore volumesrv.Store) execsrv.Service {
-	ec2, sts, iam, err := awsprov.NewAwsApis("", "", "")
+func awsServices(
+	cfg server.Config,
+	runtimeCfg *EnvInitializer,
+	execStore execsrv.Store,
+	volStore volumesrv.Store,
+	idlePolicy types.IdlePolicy,
+	webhookSrv *webhooksrv.Service,
+	breakers *resilient.Breakers,
+) (execsrv.Service, volumesrv.Service, *providersrv.ProviderService) {
+	ctx := context.Background()
+
+	vlt, err := runtimeCfg.InitializeVault(ctx)
+	if err != nil {
+		panic(err)
+	}
+
+	accounts, defaultRegion, err := runtimeCfg.InitializeAWSAccounts(ctx, vlt)
+	if err != nil {
+		panic(err)
+	}
+
+	regions, err := awsprov.NewRegionAPIs(ctx, vlt, accounts, awsprov.NewAPIs)
+	if err != nil {
+		panic(err)
+	}
+
+	execDriver, err := awsprov.NewMultiRegionExecDriver(defaultRegion, "", regions, execStore)
+	if err != nil {
+		panic(err)
+	}
+
+	registrySecretID, err := runtimeCfg.InitializeRegistryCredentials(ctx, vlt)
 	if err != nil {
 		panic(err)
 	}
 
-	execDriver := awsprov.NewExecDriverAPI("", "", ec2, sts, iam)
-	volDriver := awsprov.NewVolumeDriverAPI("", "", ec2)
+	if registrySecretID != "" {
+		execDriver = awsprov.WithRegistryCredentials(execDriver, vlt, registrySecretID)
+	}
+
+	if cfg.PublicURL != "" && cfg.LogIngestKey != "" {
+		execDriver = awsprov.WithLogShipping(execDriver, cfg.PublicURL, []byte(cfg.LogIngestKey))
+	}
+
+	awsExecDriver := resilient.NewExecDriver(execDriver, breakers)
+	volDriver := resilient.NewVolumeDriver(awsprov.NewVolumeDriverAPI(defaultRegion, "", regions[defaultRegion].EC2), breakers)
 
-	awsStateInf := execsrv.NewPollingStateInformerManager(execStore, execDriver)
-	awsStatsInf := execsrv.NewPollingStatsInformerManager(execStore, execDriver)
-	awsHeartbeatInf := execsrv.NewPollingHeartbeatInformerManager(execDriver, 10)
+	awsStateInf := execsrv.NewPollingStateInformerManager(execStore, awsExecDriver)
+	awsStatsInf := execsrv.NewPollingStatsInformerManager(execStore, awsExecDriver)
+	awsHeartbeatInf := execsrv.NewPollingHeartbeatInformerManager(awsExecDriver, 10)
 
 	awsVolumeSrv := volumesrv.NewService(volStore, volDriver)
 
-	awss := execsrv.NewService(execStore, execDriver, awsVolumeSrv, awsStateInf, awsStatsInf, awsHeartbeatInf)
+	awss := execsrv.NewService(execStore, awsExecDriver, awsVolumeSrv, awsStateInf, awsStatsInf, awsHeartbeatInf)
 	awss = execsrv.WithStateObserver(awss, execsrv.NewStateObserverFactory(awss))
 
-	return awss
+	awsIdle := execsrv.NewIdleObserverFactory(awss, execStore, idlePolicy)
+	awss = execsrv.WithStatsObserver(awss, awsIdle.Stats())
+	awss = execsrv.WithHeartbeatObserver(awss, awsIdle.Heartbeat())
+
+	awsHistory := execsrv.NewHistoryObserverFactory(execStore)
+	awss = execsrv.WithStateObserver(awss, awsHistory.State())
+	awss = execsrv.WithHeartbeatObserver(awss, awsHistory.Heartbeat())
+	awss = execsrv.WithStateObserver(awss, webhooksrv.NewExecObserverFactory(webhookSrv, awss, db.Q))
+	awss = execsrv.WithExpiryObserver(awss, webhooksrv.NewExpiryObserver(webhookSrv, awss, db.Q))
+
+	awsProviderSrv := providersrv.NewProviderService(awsprov.NewProviderDriverDefault())
+	awss = execsrv.WithPricer(awss, costsrv.NewPricer(awsProviderSrv))
+
+	if err = awss.Init(); err != nil {
+		panic(err)
+	}
+
+	return awss, awsVolumeSrv, awsProviderSrv
+}
+
+func localServices(
+	execStore execsrv.Store,
+	volStore volumesrv.Store,
+	idlePolicy types.IdlePolicy,
+	webhookSrv *webhooksrv.Service,
+	breakers *resilient.Breakers,
+) (execsrv.Service, volumesrv.Service, *providersrv.ProviderService) {
+	docker := local.NewDockerAPI()
+
+	execDriver := resilient.NewExecDriver(local.NewExecDriver(docker, "", execStore), breakers)
+	volDriver := resilient.NewVolumeDriver(local.NewVolumeDriver(docker), breakers)
+
+	localStateInf := execsrv.NewPollingStateInformerManager(execStore, execDriver)
+	localStatsInf := execsrv.NewPollingStatsInformerManager(execStore, execDriver)
+	localHeartbeatInf := execsrv.NewPollingHeartbeatInformerManager(execDriver, 10)
+
+	localVolumeSrv := volumesrv.NewService(volStore, volDriver)
+
+	locals := execsrv.NewService(execStore, execDriver, localVolumeSrv, localStateInf, localStatsInf, localHeartbeatInf)
+	locals = execsrv.WithStateObserver(locals, execsrv.NewStateObserverFactory(locals))
+
+	localIdle := execsrv.NewIdleObserverFactory(locals, execStore, idlePolicy)
+	locals = execsrv.WithStatsObserver(locals, localIdle.Stats())
+	locals = execsrv.WithHeartbeatObserver(locals, localIdle.Heartbeat())
+
+	localHistory := execsrv.NewHistoryObserverFactory(execStore)
+	locals = execsrv.WithStateObserver(locals, localHistory.State())
+	locals = execsrv.WithHeartbeatObserver(locals, localHistory.Heartbeat())
+	locals = execsrv.WithStateObserver(locals, webhooksrv.NewExecObserverFactory(webhookSrv, locals, db.Q))
+	locals = execsrv.WithExpiryObserver(locals, webhooksrv.NewExpiryObserver(webhookSrv, locals, db.Q))
+
+	localProviderSrv := providersrv.NewProviderService(local.NewProviderDriver())
+	locals = execsrv.WithPricer(locals, costsrv.NewPricer(localProviderSrv))
+
+	if err := locals.Init(); err != nil {
+		panic(err)
+	}
+
+	return locals, localVolumeSrv, localProviderSrv
+}
+
+// endpointDriver serves endpoints from the built-in proxy if a domain is configured.
+// Otherwise the AWS driver stubs them out since no provider supports them yet.
+func endpointDriver(cfg server.Config, execStore execsrv.Store) endpointsrv.Driver {
+	if cfg.EndpointDomain == "" {
+		return &awsprov.EndpointDriver{}
+	}
+
+	driver := proxy.NewEndpointDriver(cfg.EndpointDomain, execStore)
+	if err := driver.Load(context.Background(), db.Q); err != nil {
+		log.Fatal().Err(err).Msg("failed to load endpoint routes")
+	}
+
+	port := cfg.EndpointProxyPort
+	if port == "" {
+		port = "8080"
+	}
+
+	go func() {
+		log.Info().Msgf("🚀 Endpoint proxy listening on :%s for *.%s", port, cfg.EndpointDomain)
+		if err := http.ListenAndServe(":"+port, driver); err != nil {
+			log.Fatal().Err(err).Msg("endpoint proxy stopped")
+		}
+	}()
+
+	return driver
+}
+
+func toNodeTypeListers(providers []*providersrv.ProviderService) []execsrv.NodeTypeLister {
+	listers := make([]execsrv.NodeTypeLister, len(providers))
+	for i, p := range providers {
+		listers[i] = p
+	}
+
+	return listers
 }
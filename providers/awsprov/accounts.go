@@ -0,0 +1,100 @@
+package awsprov
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/unweave/unweave-v1/vault"
+)
+
+// Account is an AWS account execs can be placed in. Its credentials are kept in the vault
+// under CredentialsSecretID, see StoreCredentials.
+type Account struct {
+	ID                  string
+	CredentialsSecretID string
+	Regions             []string
+}
+
+type Credentials struct {
+	AccessKeyID     string `json:"accessKeyID"`
+	SecretAccessKey string `json:"secretAccessKey"`
+}
+
+// APIs are the clients of one account in one region.
+type APIs struct {
+	EC2        Ec2API
+	STS        StsAPI
+	IAM        IamAPI
+	CloudWatch CloudWatchAPI
+	SSM        SsmAPI
+}
+
+// APIsFactory creates the clients of a region with the given credentials.
+type APIsFactory func(region string, creds Credentials) (APIs, error)
+
+// NewAPIs is the APIsFactory backed by the AWS SDK.
+func NewAPIs(region string, creds Credentials) (APIs, error) {
+	ec2API, stsAPI, iamAPI, cwAPI, ssmAPI, err := NewAwsApis(region, creds.AccessKeyID, creds.SecretAccessKey)
+	if err != nil {
+		return APIs{}, err
+	}
+
+	return APIs{EC2: ec2API, STS: stsAPI, IAM: iamAPI, CloudWatch: cwAPI, SSM: ssmAPI}, nil
+}
+
+// StoreCredentials stores the credentials of an account in the vault and returns the
+// secret ID to set on the Account.
+func StoreCredentials(ctx context.Context, v vault.Vault, creds Credentials) (string, error) {
+	secret, err := json.Marshal(creds)
+	if err != nil {
+		return "", fmt.Errorf("marshal credentials: %w", err)
+	}
+
+	id, err := v.SetSecret(ctx, string(secret), nil)
+	if err != nil {
+		return "", fmt.Errorf("set secret: %w", err)
+	}
+
+	return id, nil
+}
+
+// NewRegionAPIs loads the credentials of every account from the vault and creates the
+// clients of each of their regions. A region can only belong to one account.
+func NewRegionAPIs(
+	ctx context.Context,
+	v vault.Vault,
+	accounts []Account,
+	factory APIsFactory,
+) (map[string]APIs, error) {
+	regions := make(map[string]APIs)
+	owners := make(map[string]string)
+
+	for _, account := range accounts {
+		secret, err := v.GetSecret(ctx, account.CredentialsSecretID)
+		if err != nil {
+			return nil, fmt.Errorf("get credentials of account %q: %w", account.ID, err)
+		}
+
+		var creds Credentials
+		if err = json.Unmarshal([]byte(secret), &creds); err != nil {
+			return nil, fmt.Errorf("unmarshal credentials of account %q: %w", account.ID, err)
+		}
+
+		for _, region := range account.Regions {
+			if owner, ok := owners[region]; ok {
+				return nil, fmt.Errorf("region %q is configured for both account %q and %q", region, owner, account.ID)
+			}
+
+			apis, err := factory(region, creds)
+			if err != nil {
+				return nil, fmt.Errorf("create apis of account %q in %q: %w", account.ID, region, err)
+			}
+
+			regions[region] = apis
+			owners[region] = account.ID
+		}
+	}
+
+	return regions, nil
+}
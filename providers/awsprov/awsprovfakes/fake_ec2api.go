@@ -130,6 +130,36 @@ type FakeEc2API struct {
 		result1 *ec2.RunInstancesOutput
 		result2 error
 	}
+	StartInstancesStub        func(context.Context, *ec2.StartInstancesInput, ...func(*ec2.Options)) (*ec2.StartInstancesOutput, error)
+	startInstancesMutex       sync.RWMutex
+	startInstancesArgsForCall []struct {
+		arg1 context.Context
+		arg2 *ec2.StartInstancesInput
+		arg3 []func(*ec2.Options)
+	}
+	startInstancesReturns struct {
+		result1 *ec2.StartInstancesOutput
+		result2 error
+	}
+	startInstancesReturnsOnCall map[int]struct {
+		result1 *ec2.StartInstancesOutput
+		result2 error
+	}
+	StopInstancesStub        func(context.Context, *ec2.StopInstancesInput, ...func(*ec2.Options)) (*ec2.StopInstancesOutput, error)
+	stopInstancesMutex       sync.RWMutex
+	stopInstancesArgsForCall []struct {
+		arg1 context.Context
+		arg2 *ec2.StopInstancesInput
+		arg3 []func(*ec2.Options)
+	}
+	stopInstancesReturns struct {
+		result1 *ec2.StopInstancesOutput
+		result2 error
+	}
+	stopInstancesReturnsOnCall map[int]struct {
+		result1 *ec2.StopInstancesOutput
+		result2 error
+	}
 	TerminateInstancesStub        func(context.Context, *ec2.TerminateInstancesInput, ...func(*ec2.Options)) (*ec2.TerminateInstancesOutput, error)
 	terminateInstancesMutex       sync.RWMutex
 	terminateInstancesArgsForCall []struct {
@@ -677,6 +707,138 @@ func (fake *FakeEc2API) RunInstancesReturnsOnCall(i int, result1 *ec2.RunInstanc
 	}{result1, result2}
 }
 
+func (fake *FakeEc2API) StartInstances(arg1 context.Context, arg2 *ec2.StartInstancesInput, arg3 ...func(*ec2.Options)) (*ec2.StartInstancesOutput, error) {
+	fake.startInstancesMutex.Lock()
+	ret, specificReturn := fake.startInstancesReturnsOnCall[len(fake.startInstancesArgsForCall)]
+	fake.startInstancesArgsForCall = append(fake.startInstancesArgsForCall, struct {
+		arg1 context.Context
+		arg2 *ec2.StartInstancesInput
+		arg3 []func(*ec2.Options)
+	}{arg1, arg2, arg3})
+	stub := fake.StartInstancesStub
+	fakeReturns := fake.startInstancesReturns
+	fake.recordInvocation("StartInstances", []interface{}{arg1, arg2, arg3})
+	fake.startInstancesMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3...)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeEc2API) StartInstancesCallCount() int {
+	fake.startInstancesMutex.RLock()
+	defer fake.startInstancesMutex.RUnlock()
+	return len(fake.startInstancesArgsForCall)
+}
+
+func (fake *FakeEc2API) StartInstancesCalls(stub func(context.Context, *ec2.StartInstancesInput, ...func(*ec2.Options)) (*ec2.StartInstancesOutput, error)) {
+	fake.startInstancesMutex.Lock()
+	defer fake.startInstancesMutex.Unlock()
+	fake.StartInstancesStub = stub
+}
+
+func (fake *FakeEc2API) StartInstancesArgsForCall(i int) (context.Context, *ec2.StartInstancesInput, []func(*ec2.Options)) {
+	fake.startInstancesMutex.RLock()
+	defer fake.startInstancesMutex.RUnlock()
+	argsForCall := fake.startInstancesArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *FakeEc2API) StartInstancesReturns(result1 *ec2.StartInstancesOutput, result2 error) {
+	fake.startInstancesMutex.Lock()
+	defer fake.startInstancesMutex.Unlock()
+	fake.StartInstancesStub = nil
+	fake.startInstancesReturns = struct {
+		result1 *ec2.StartInstancesOutput
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeEc2API) StartInstancesReturnsOnCall(i int, result1 *ec2.StartInstancesOutput, result2 error) {
+	fake.startInstancesMutex.Lock()
+	defer fake.startInstancesMutex.Unlock()
+	fake.StartInstancesStub = nil
+	if fake.startInstancesReturnsOnCall == nil {
+		fake.startInstancesReturnsOnCall = make(map[int]struct {
+			result1 *ec2.StartInstancesOutput
+			result2 error
+		})
+	}
+	fake.startInstancesReturnsOnCall[i] = struct {
+		result1 *ec2.StartInstancesOutput
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeEc2API) StopInstances(arg1 context.Context, arg2 *ec2.StopInstancesInput, arg3 ...func(*ec2.Options)) (*ec2.StopInstancesOutput, error) {
+	fake.stopInstancesMutex.Lock()
+	ret, specificReturn := fake.stopInstancesReturnsOnCall[len(fake.stopInstancesArgsForCall)]
+	fake.stopInstancesArgsForCall = append(fake.stopInstancesArgsForCall, struct {
+		arg1 context.Context
+		arg2 *ec2.StopInstancesInput
+		arg3 []func(*ec2.Options)
+	}{arg1, arg2, arg3})
+	stub := fake.StopInstancesStub
+	fakeReturns := fake.stopInstancesReturns
+	fake.recordInvocation("StopInstances", []interface{}{arg1, arg2, arg3})
+	fake.stopInstancesMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3...)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeEc2API) StopInstancesCallCount() int {
+	fake.stopInstancesMutex.RLock()
+	defer fake.stopInstancesMutex.RUnlock()
+	return len(fake.stopInstancesArgsForCall)
+}
+
+func (fake *FakeEc2API) StopInstancesCalls(stub func(context.Context, *ec2.StopInstancesInput, ...func(*ec2.Options)) (*ec2.StopInstancesOutput, error)) {
+	fake.stopInstancesMutex.Lock()
+	defer fake.stopInstancesMutex.Unlock()
+	fake.StopInstancesStub = stub
+}
+
+func (fake *FakeEc2API) StopInstancesArgsForCall(i int) (context.Context, *ec2.StopInstancesInput, []func(*ec2.Options)) {
+	fake.stopInstancesMutex.RLock()
+	defer fake.stopInstancesMutex.RUnlock()
+	argsForCall := fake.stopInstancesArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *FakeEc2API) StopInstancesReturns(result1 *ec2.StopInstancesOutput, result2 error) {
+	fake.stopInstancesMutex.Lock()
+	defer fake.stopInstancesMutex.Unlock()
+	fake.StopInstancesStub = nil
+	fake.stopInstancesReturns = struct {
+		result1 *ec2.StopInstancesOutput
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeEc2API) StopInstancesReturnsOnCall(i int, result1 *ec2.StopInstancesOutput, result2 error) {
+	fake.stopInstancesMutex.Lock()
+	defer fake.stopInstancesMutex.Unlock()
+	fake.StopInstancesStub = nil
+	if fake.stopInstancesReturnsOnCall == nil {
+		fake.stopInstancesReturnsOnCall = make(map[int]struct {
+			result1 *ec2.StopInstancesOutput
+			result2 error
+		})
+	}
+	fake.stopInstancesReturnsOnCall[i] = struct {
+		result1 *ec2.StopInstancesOutput
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakeEc2API) TerminateInstances(arg1 context.Context, arg2 *ec2.TerminateInstancesInput, arg3 ...func(*ec2.Options)) (*ec2.TerminateInstancesOutput, error) {
 	fake.terminateInstancesMutex.Lock()
 	ret, specificReturn := fake.terminateInstancesReturnsOnCall[len(fake.terminateInstancesArgsForCall)]
@@ -762,6 +924,10 @@ func (fake *FakeEc2API) Invocations() map[string][][]interface{} {
 	defer fake.modifyVolumeMutex.RUnlock()
 	fake.runInstancesMutex.RLock()
 	defer fake.runInstancesMutex.RUnlock()
+	fake.startInstancesMutex.RLock()
+	defer fake.startInstancesMutex.RUnlock()
+	fake.stopInstancesMutex.RLock()
+	defer fake.stopInstancesMutex.RUnlock()
 	fake.terminateInstancesMutex.RLock()
 	defer fake.terminateInstancesMutex.RUnlock()
 	copiedInvocations := map[string][][]interface{}{}
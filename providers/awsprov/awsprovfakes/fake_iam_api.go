@@ -100,6 +100,21 @@ type FakeIamAPI struct {
 		result1 *iam.GetInstanceProfileOutput
 		result2 error
 	}
+	PutRolePolicyStub        func(context.Context, *iam.PutRolePolicyInput, ...func(*iam.Options)) (*iam.PutRolePolicyOutput, error)
+	putRolePolicyMutex       sync.RWMutex
+	putRolePolicyArgsForCall []struct {
+		arg1 context.Context
+		arg2 *iam.PutRolePolicyInput
+		arg3 []func(*iam.Options)
+	}
+	putRolePolicyReturns struct {
+		result1 *iam.PutRolePolicyOutput
+		result2 error
+	}
+	putRolePolicyReturnsOnCall map[int]struct {
+		result1 *iam.PutRolePolicyOutput
+		result2 error
+	}
 	invocations      map[string][][]interface{}
 	invocationsMutex sync.RWMutex
 }
@@ -500,6 +515,72 @@ func (fake *FakeIamAPI) GetInstanceProfileReturnsOnCall(i int, result1 *iam.GetI
 	}{result1, result2}
 }
 
+func (fake *FakeIamAPI) PutRolePolicy(arg1 context.Context, arg2 *iam.PutRolePolicyInput, arg3 ...func(*iam.Options)) (*iam.PutRolePolicyOutput, error) {
+	fake.putRolePolicyMutex.Lock()
+	ret, specificReturn := fake.putRolePolicyReturnsOnCall[len(fake.putRolePolicyArgsForCall)]
+	fake.putRolePolicyArgsForCall = append(fake.putRolePolicyArgsForCall, struct {
+		arg1 context.Context
+		arg2 *iam.PutRolePolicyInput
+		arg3 []func(*iam.Options)
+	}{arg1, arg2, arg3})
+	stub := fake.PutRolePolicyStub
+	fakeReturns := fake.putRolePolicyReturns
+	fake.recordInvocation("PutRolePolicy", []interface{}{arg1, arg2, arg3})
+	fake.putRolePolicyMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3...)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeIamAPI) PutRolePolicyCallCount() int {
+	fake.putRolePolicyMutex.RLock()
+	defer fake.putRolePolicyMutex.RUnlock()
+	return len(fake.putRolePolicyArgsForCall)
+}
+
+func (fake *FakeIamAPI) PutRolePolicyCalls(stub func(context.Context, *iam.PutRolePolicyInput, ...func(*iam.Options)) (*iam.PutRolePolicyOutput, error)) {
+	fake.putRolePolicyMutex.Lock()
+	defer fake.putRolePolicyMutex.Unlock()
+	fake.PutRolePolicyStub = stub
+}
+
+func (fake *FakeIamAPI) PutRolePolicyArgsForCall(i int) (context.Context, *iam.PutRolePolicyInput, []func(*iam.Options)) {
+	fake.putRolePolicyMutex.RLock()
+	defer fake.putRolePolicyMutex.RUnlock()
+	argsForCall := fake.putRolePolicyArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *FakeIamAPI) PutRolePolicyReturns(result1 *iam.PutRolePolicyOutput, result2 error) {
+	fake.putRolePolicyMutex.Lock()
+	defer fake.putRolePolicyMutex.Unlock()
+	fake.PutRolePolicyStub = nil
+	fake.putRolePolicyReturns = struct {
+		result1 *iam.PutRolePolicyOutput
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeIamAPI) PutRolePolicyReturnsOnCall(i int, result1 *iam.PutRolePolicyOutput, result2 error) {
+	fake.putRolePolicyMutex.Lock()
+	defer fake.putRolePolicyMutex.Unlock()
+	fake.PutRolePolicyStub = nil
+	if fake.putRolePolicyReturnsOnCall == nil {
+		fake.putRolePolicyReturnsOnCall = make(map[int]struct {
+			result1 *iam.PutRolePolicyOutput
+			result2 error
+		})
+	}
+	fake.putRolePolicyReturnsOnCall[i] = struct {
+		result1 *iam.PutRolePolicyOutput
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakeIamAPI) Invocations() map[string][][]interface{} {
 	fake.invocationsMutex.RLock()
 	defer fake.invocationsMutex.RUnlock()
@@ -515,6 +596,8 @@ func (fake *FakeIamAPI) Invocations() map[string][][]interface{} {
 	defer fake.createRoleMutex.RUnlock()
 	fake.getInstanceProfileMutex.RLock()
 	defer fake.getInstanceProfileMutex.RUnlock()
+	fake.putRolePolicyMutex.RLock()
+	defer fake.putRolePolicyMutex.RUnlock()
 	copiedInvocations := map[string][][]interface{}{}
 	for key, value := range fake.invocations {
 		copiedInvocations[key] = value
@@ -7,8 +7,10 @@ import (
 	"github.com/aws/aws-sdk-go-v2/aws"
 	"github.com/aws/aws-sdk-go-v2/config"
 	"github.com/aws/aws-sdk-go-v2/credentials"
+	"github.com/aws/aws-sdk-go-v2/service/cloudwatch"
 	"github.com/aws/aws-sdk-go-v2/service/ec2"
 	"github.com/aws/aws-sdk-go-v2/service/iam"
+	"github.com/aws/aws-sdk-go-v2/service/ssm"
 	"github.com/aws/aws-sdk-go-v2/service/sts"
 )
 
@@ -16,6 +18,8 @@ import (
 //counterfeiter:generate  . StsAPI
 //counterfeiter:generate  . Ec2API
 //counterfeiter:generate  . IamAPI
+//counterfeiter:generate  . CloudWatchAPI
+//counterfeiter:generate  . SsmAPI
 
 type StsAPI interface {
 	GetCallerIdentity(ctx context.Context,
@@ -47,6 +51,26 @@ type IamAPI interface {
 	AddRoleToInstanceProfile(ctx context.Context,
 		params *iam.AddRoleToInstanceProfileInput,
 		optFns ...func(*iam.Options)) (*iam.AddRoleToInstanceProfileOutput, error)
+
+	PutRolePolicy(ctx context.Context,
+		params *iam.PutRolePolicyInput,
+		optFns ...func(*iam.Options)) (*iam.PutRolePolicyOutput, error)
+}
+
+type SsmAPI interface {
+	PutParameter(ctx context.Context,
+		params *ssm.PutParameterInput,
+		optFns ...func(*ssm.Options)) (*ssm.PutParameterOutput, error)
+
+	DeleteParameters(ctx context.Context,
+		params *ssm.DeleteParametersInput,
+		optFns ...func(*ssm.Options)) (*ssm.DeleteParametersOutput, error)
+}
+
+type CloudWatchAPI interface {
+	GetMetricStatistics(ctx context.Context,
+		params *cloudwatch.GetMetricStatisticsInput,
+		optFns ...func(*cloudwatch.Options)) (*cloudwatch.GetMetricStatisticsOutput, error)
 }
 
 type Ec2API interface {
@@ -70,6 +94,14 @@ type Ec2API interface {
 		params *ec2.TerminateInstancesInput,
 		optFns ...func(*ec2.Options)) (*ec2.TerminateInstancesOutput, error)
 
+	StopInstances(ctx context.Context,
+		params *ec2.StopInstancesInput,
+		optFns ...func(*ec2.Options)) (*ec2.StopInstancesOutput, error)
+
+	StartInstances(ctx context.Context,
+		params *ec2.StartInstancesInput,
+		optFns ...func(*ec2.Options)) (*ec2.StartInstancesOutput, error)
+
 	CreateTags(ctx context.Context,
 		params *ec2.CreateTagsInput,
 		optFns ...func(*ec2.Options)) (*ec2.CreateTagsOutput, error)
@@ -87,7 +119,7 @@ type Ec2API interface {
 		optFns ...func(*ec2.Options)) (*ec2.ModifyVolumeOutput, error)
 }
 
-func NewAwsApis(region, accessKey, secretKey string) (Ec2API, StsAPI, IamAPI, error) {
+func NewAwsApis(region, accessKey, secretKey string) (Ec2API, StsAPI, IamAPI, CloudWatchAPI, SsmAPI, error) {
 	creds := aws.NewCredentialsCache(credentials.NewStaticCredentialsProvider(accessKey, secretKey, ""))
 
 	cfg, err := config.LoadDefaultConfig(
@@ -96,12 +128,14 @@ func NewAwsApis(region, accessKey, secretKey string) (Ec2API, StsAPI, IamAPI, er
 		config.WithCredentialsProvider(creds),
 	)
 	if err != nil {
-		return nil, nil, nil, fmt.Errorf("load aws config: %w", err)
+		return nil, nil, nil, nil, nil, fmt.Errorf("load aws config: %w", err)
 	}
 
 	ec2API := ec2.NewFromConfig(cfg)
 	stsAPI := sts.NewFromConfig(cfg)
 	iamAPI := iam.NewFromConfig(cfg)
+	cwAPI := cloudwatch.NewFromConfig(cfg)
+	ssmAPI := ssm.NewFromConfig(cfg)
 
-	return ec2API, stsAPI, iamAPI, nil
+	return ec2API, stsAPI, iamAPI, cwAPI, ssmAPI, nil
 }
@@ -7,6 +7,8 @@ import (
 	"github.com/unweave/unweave-v1/api/types"
 )
 
+var errEndpointsUnsupported = errors.New("endpoints unsupported for aws provider")
+
 type EndpointDriver struct{}
 
 func (e *EndpointDriver) EndpointDriverName() string {
@@ -17,6 +19,22 @@ func (e *EndpointDriver) EndpointProvider() types.Provider {
 	return types.AWSProvider
 }
 
-func (e *EndpointDriver) EndpointCreate(_ context.Context, _, _, _, _ string) (string, error) {
-	return "", errors.New("endpoints unsupported for aws provider")
+func (e *EndpointDriver) EndpointCreate(_ context.Context, _, _, _ string) (string, error) {
+	return "", errEndpointsUnsupported
+}
+
+func (e *EndpointDriver) EndpointVersionCreate(_ context.Context, _, _, _, _ string, _ int32) (string, error) {
+	return "", errEndpointsUnsupported
+}
+
+func (e *EndpointDriver) EndpointVersionDelete(_ context.Context, _, _ string) error {
+	return errEndpointsUnsupported
+}
+
+func (e *EndpointDriver) EndpointVersionPromote(_ context.Context, _, _ string, _ int32) error {
+	return errEndpointsUnsupported
+}
+
+func (e *EndpointDriver) EndpointTrafficSet(_ context.Context, _ string, _ types.EndpointTrafficPolicy) error {
+	return errEndpointsUnsupported
 }
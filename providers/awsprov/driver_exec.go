@@ -5,43 +5,193 @@ import (
 	"errors"
 	"fmt"
 	"net/http"
+	"sort"
+	"strconv"
 	"strings"
+	"sync"
+	"time"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/cloudwatch"
+	cwtypes "github.com/aws/aws-sdk-go-v2/service/cloudwatch/types"
 	"github.com/aws/aws-sdk-go-v2/service/ec2"
 	ec2types "github.com/aws/aws-sdk-go-v2/service/ec2/types"
 	"github.com/aws/aws-sdk-go-v2/service/iam"
 	iamtypes "github.com/aws/aws-sdk-go-v2/service/iam/types"
 	"github.com/aws/aws-sdk-go-v2/service/sts"
+	"github.com/aws/smithy-go"
 	"github.com/rs/zerolog/log"
 	"github.com/unweave/unweave-v1/api/types"
 	"github.com/unweave/unweave-v1/providers/awsprov/internal/nodes"
 	"github.com/unweave/unweave-v1/services/execsrv"
 	"github.com/unweave/unweave-v1/tools/random"
+	"github.com/unweave/unweave-v1/vault"
 )
 
+const (
+	// EC2 basic monitoring publishes CPUUtilization every 5 minutes. Look back far enough
+	// to always find at least one datapoint for a running instance.
+	statsPeriodSeconds = 300
+	statsWindow        = 15 * time.Minute
+
+	// DefaultMachineImage is the AMI execs boot when the driver isn't configured with
+	// another one. It resolves to the latest Deep Learning AMI, which ships with Docker and
+	// the NVIDIA container runtime.
+	DefaultMachineImage = "resolve:ssm:/aws/service/deeplearning/ami/x86_64/" +
+		"base-oss-nvidia-driver-gpu-amazon-linux-2/latest/ami-id"
+
+	// containerStatusTag is set on the instance by its user data as the container starts
+	// and exits.
+	containerStatusTag     = "unweave.io/container"
+	containerStatusPulling = "pulling"
+	containerStatusRunning = "running"
+	containerStatusExited  = "exited"
+	containerStatusFailed  = "failed"
+
+	// exitCodeTag is set on the instance by its user data once the container exits.
+	exitCodeTag = "unweave.io/exit-code"
+)
+
+// ExecStore looks up the region an exec was placed in.
+type ExecStore interface {
+	Get(id string) (types.Exec, error)
+}
+
 type ExecDriver struct {
-	userID string
-	ec2API Ec2API
-	stsAPI StsAPI
-	iamAPI IamAPI
-	region string
+	userID        string
+	defaultRegion string
+	regions       map[string]APIs
+	store         ExecStore
+
+	machineImage     string
+	vault            vault.Vault
+	registrySecretID string
+	apiURL           string
+	logIngestKey     []byte
+
+	// nodePolicies holds the regions nodePolicy was put in, see putNodePolicy.
+	nodePolicies sync.Map
+}
+
+// WithMachineImage boots execs from the AMI instead of DefaultMachineImage. The AMI must
+// have Docker installed, and the NVIDIA container runtime for GPU execs.
+func WithMachineImage(d *ExecDriver, ami string) *ExecDriver {
+	d.machineImage = ami
+	return d
+}
+
+// WithRegistryCredentials logs execs into a container registry with the credentials stored
+// in the vault under secretID, see StoreRegistryCredentials.
+func WithRegistryCredentials(d *ExecDriver, v vault.Vault, secretID string) *ExecDriver {
+	d.vault = v
+	d.registrySecretID = secretID
+	return d
+}
+
+// WithLogShipping ships the output of execs to the log ingest endpoint of the API at
+// apiURL. Nodes authenticate with tokens derived from key, see execsrv.LogIngestToken.
+func WithLogShipping(d *ExecDriver, apiURL string, key []byte) *ExecDriver {
+	d.apiURL = strings.TrimSuffix(apiURL, "/")
+	d.logIngestKey = key
+	return d
+}
+
+// NewExecDriverAPI returns a driver that places every exec in a single region.
+func NewExecDriverAPI(
+	region, userID string,
+	ec2API Ec2API,
+	stsAPI StsAPI,
+	iamAPI IamAPI,
+	cwAPI CloudWatchAPI,
+) *ExecDriver {
+	return &ExecDriver{
+		userID:        userID,
+		defaultRegion: region,
+		regions: map[string]APIs{
+			region: {EC2: ec2API, STS: stsAPI, IAM: iamAPI, CloudWatch: cwAPI},
+		},
+	}
 }
 
-func NewExecDriverAPI(region, userID string, ec2API Ec2API, stsAPI StsAPI, iamAPI IamAPI) *ExecDriver {
+// NewMultiRegionExecDriver returns a driver that can place execs in any of the regions.
+// Execs created without a region go to the default region. The region of existing execs
+// is read from the store.
+func NewMultiRegionExecDriver(
+	defaultRegion, userID string,
+	regions map[string]APIs,
+	store ExecStore,
+) (*ExecDriver, error) {
+	if _, ok := regions[defaultRegion]; !ok {
+		return nil, fmt.Errorf("default region %q is not configured", defaultRegion)
+	}
+
 	return &ExecDriver{
-		region: region,
-		userID: userID,
-		ec2API: ec2API,
-		stsAPI: stsAPI,
-		iamAPI: iamAPI,
+		userID:        userID,
+		defaultRegion: defaultRegion,
+		regions:       regions,
+		store:         store,
+	}, nil
+}
+
+// Regions returns the regions execs can be placed in.
+func (d *ExecDriver) Regions() []string {
+	regions := make([]string, 0, len(d.regions))
+	for region := range d.regions {
+		regions = append(regions, region)
 	}
+
+	sort.Strings(regions)
+
+	return regions
+}
+
+// DefaultRegion is the region execs created without a region are placed in.
+func (d *ExecDriver) DefaultRegion() string {
+	return d.defaultRegion
+}
+
+func (d *ExecDriver) regionAPIs(region string) (APIs, error) {
+	if region == "" {
+		region = d.defaultRegion
+	}
+
+	apis, ok := d.regions[region]
+	if !ok {
+		return APIs{}, &types.Error{
+			Code:       http.StatusBadRequest,
+			Message:    fmt.Sprintf("Region %q is not available", region),
+			Suggestion: fmt.Sprintf("Use one of: %s", strings.Join(d.Regions(), ", ")),
+			Provider:   types.AWSProvider,
+		}
+	}
+
+	return apis, nil
+}
+
+// execAPIs returns the clients of the region the exec was placed in. Execs stored
+// without a region were placed in the default region.
+func (d *ExecDriver) execAPIs(execID string) (APIs, error) {
+	region := d.defaultRegion
+
+	if d.store != nil {
+		exec, err := d.store.Get(execID)
+		if err != nil {
+			return APIs{}, fmt.Errorf("get exec: %w", err)
+		}
+
+		if exec.Region != "" {
+			region = exec.Region
+		}
+	}
+
+	return d.regionAPIs(region)
 }
 
 func (d *ExecDriver) ExecCreate(
 	ctx context.Context,
 	project string,
 	image string,
+	command []string,
 	spec types.HardwareSpec,
 	network types.ExecNetwork,
 	volumes []types.ExecVolume,
@@ -59,6 +209,27 @@ func (d *ExecDriver) ExecCreate(
 		}
 	}
 
+	placement := d.defaultRegion
+	if region != nil && *region != "" {
+		placement = *region
+	}
+
+	apis, err := d.regionAPIs(placement)
+	if err != nil {
+		return "", err
+	}
+
+	// Volumes are created by the volume driver in the default region and EBS volumes can
+	// only be attached to instances in the same availability zone.
+	if len(volumes) > 0 && placement != d.defaultRegion {
+		return "", &types.Error{
+			Code:       http.StatusBadRequest,
+			Message:    fmt.Sprintf("Volumes are only available in region %q", d.defaultRegion),
+			Suggestion: "Remove the volumes or create the exec in the default region",
+			Provider:   types.AWSProvider,
+		}
+	}
+
 	instanceType := nodes.NodeType(spec)
 	if instanceType == "" {
 		return "", fmt.Errorf("could not find node matching spec")
@@ -69,33 +240,66 @@ func (d *ExecDriver) ExecCreate(
 		return "", fmt.Errorf("generate exec ID: %w", err)
 	}
 
-	uData, err := UserData(*region, pubKeys, volumes)
+	container := Container{Image: image, Command: command, GPUs: spec.GPU.Count.Min}
+
+	if d.registrySecretID != "" {
+		creds, err := getRegistryCredentials(ctx, d.vault, d.registrySecretID)
+		if err != nil {
+			return "", err
+		}
+
+		param, err := putExecParameter(ctx, apis.SSM, execID, registryPasswordParameter, creds.Password)
+		if err != nil {
+			return "", fmt.Errorf("store registry password: %w", err)
+		}
+
+		container.Registry = &RegistryLogin{Server: creds.Server, Username: creds.Username, PasswordParameter: param}
+	}
+
+	if d.apiURL != "" {
+		param, err := putExecParameter(ctx, apis.SSM, execID, logTokenParameter, execsrv.LogIngestToken(d.logIngestKey, execID))
+		if err != nil {
+			return "", fmt.Errorf("store log ingest token: %w", err)
+		}
+
+		container.Logs = &LogShipping{
+			URL:            fmt.Sprintf("%s/ingest/sessions/%s/logs", d.apiURL, execID),
+			TokenParameter: param,
+		}
+	}
+
+	uData, err := UserData(placement, container, pubKeys, volumes)
 	if err != nil {
 		return "", fmt.Errorf("failed to build user data: %w", err)
 	}
 
-	arn, err := d.setupIamPermissions(ctx)
+	arn, err := d.setupIamPermissions(ctx, placement, apis.IAM)
 	if err != nil {
 		return "", fmt.Errorf("setup iam permissions: %w", err)
 	}
 
+	machineImage := d.machineImage
+	if machineImage == "" {
+		machineImage = DefaultMachineImage
+	}
+
 	minMaxCount := int32(1)
 	input := &ec2.RunInstancesInput{
-		ImageId:           &image,
+		ImageId:           &machineImage,
 		InstanceType:      instanceType,
 		MinCount:          &minMaxCount,
 		MaxCount:          &minMaxCount,
 		UserData:          &uData,
 		TagSpecifications: d.tags(project, execID),
 		Placement: &ec2types.Placement{
-			AvailabilityZone: aws.String(d.region + "a"),
+			AvailabilityZone: aws.String(placement + "a"),
 		},
 		IamInstanceProfile: &ec2types.IamInstanceProfileSpecification{
 			Arn: &arn,
 		},
 	}
 
-	_, err = d.ec2API.RunInstances(ctx, input)
+	_, err = apis.EC2.RunInstances(ctx, input)
 	if err != nil {
 		return "", fmt.Errorf("failed to start instance: %w", err)
 	}
@@ -120,6 +324,8 @@ const trustPolicy = `{
     ]
 }`
 
+// rolePolicy lets instances attach their volumes. It's only attached when the instance
+// profile is first created.
 const rolePolicy = `{
     "Version": "2012-10-17",
     "Statement": [
@@ -135,14 +341,45 @@ const rolePolicy = `{
     ]
 }`
 
-func (d *ExecDriver) setupIamPermissions(ctx context.Context) (string, error) {
-	getipOut, err := d.iamAPI.GetInstanceProfile(
+// nodePolicy lets instances report their container's status and read their secrets from
+// the parameter store. It's an inline policy of the role, put on every start up so that
+// roles created before it was added get it too.
+const nodePolicy = `{
+    "Version": "2012-10-17",
+    "Statement": [
+        {
+            "Sid": "UnweaveEc2ContainerStatus",
+            "Effect": "Allow",
+            "Action": [
+                "ec2:CreateTags"
+            ],
+            "Resource": "*"
+        },
+        {
+            "Sid": "UnweaveExecParameters",
+            "Effect": "Allow",
+            "Action": [
+                "ssm:GetParameter"
+            ],
+            "Resource": "arn:aws:ssm:*:*:parameter/unweave/execs/*"
+        }
+    ]
+}`
+
+const execRoleName = "UnweaveEc2ExecRole"
+
+func (d *ExecDriver) setupIamPermissions(ctx context.Context, region string, iamAPI IamAPI) (string, error) {
+	getipOut, err := iamAPI.GetInstanceProfile(
 		ctx,
 		&iam.GetInstanceProfileInput{InstanceProfileName: aws.String("UnweaveEc2ExecInstanceProfile")},
 	)
 	if err == nil {
 		log.Debug().Msg("Instance profile already exists, skipping")
 
+		if err = d.putNodePolicy(ctx, region, iamAPI); err != nil {
+			return "", err
+		}
+
 		return *getipOut.InstanceProfile.Arn, nil
 	}
 
@@ -157,16 +394,16 @@ func (d *ExecDriver) setupIamPermissions(ctx context.Context) (string, error) {
 
 	createRoleInput := iam.CreateRoleInput{
 		AssumeRolePolicyDocument: aws.String(trustPolicy),
-		RoleName:                 aws.String("UnweaveEc2ExecRole"),
+		RoleName:                 aws.String(execRoleName),
 		Description:              aws.String("Role for Ec2Execs to assume"),
 	}
 
-	crOut, err := d.iamAPI.CreateRole(ctx, &createRoleInput)
+	crOut, err := iamAPI.CreateRole(ctx, &createRoleInput)
 	if err != nil {
 		return "", fmt.Errorf("create role: %w", err)
 	}
 
-	cpOut, err := d.iamAPI.CreatePolicy(
+	cpOut, err := iamAPI.CreatePolicy(
 		ctx,
 		&iam.CreatePolicyInput{
 			PolicyDocument: aws.String(rolePolicy),
@@ -177,7 +414,7 @@ func (d *ExecDriver) setupIamPermissions(ctx context.Context) (string, error) {
 		return "", fmt.Errorf("create policy: %w", err)
 	}
 
-	_, err = d.iamAPI.AttachRolePolicy(
+	_, err = iamAPI.AttachRolePolicy(
 		ctx,
 		&iam.AttachRolePolicyInput{
 			PolicyArn: cpOut.Policy.Arn,
@@ -188,7 +425,7 @@ func (d *ExecDriver) setupIamPermissions(ctx context.Context) (string, error) {
 		return "", fmt.Errorf("attach policy to role: %w", err)
 	}
 
-	cipOut, err := d.iamAPI.CreateInstanceProfile(
+	cipOut, err := iamAPI.CreateInstanceProfile(
 		ctx,
 		&iam.CreateInstanceProfileInput{
 			InstanceProfileName: aws.String("UnweaveEc2ExecInstanceProfile"),
@@ -198,7 +435,7 @@ func (d *ExecDriver) setupIamPermissions(ctx context.Context) (string, error) {
 		return "", fmt.Errorf("create instance profile: %w", err)
 	}
 
-	_, err = d.iamAPI.AddRoleToInstanceProfile(
+	_, err = iamAPI.AddRoleToInstanceProfile(
 		ctx,
 		&iam.AddRoleToInstanceProfileInput{
 			InstanceProfileName: cipOut.InstanceProfile.InstanceProfileName,
@@ -209,9 +446,33 @@ func (d *ExecDriver) setupIamPermissions(ctx context.Context) (string, error) {
 		return "", fmt.Errorf("add role to instance profile: %w", err)
 	}
 
+	if err = d.putNodePolicy(ctx, region, iamAPI); err != nil {
+		return "", err
+	}
+
 	return "", nil
 }
 
+// putNodePolicy puts nodePolicy on the role once per region after the driver starts.
+func (d *ExecDriver) putNodePolicy(ctx context.Context, region string, iamAPI IamAPI) error {
+	if _, done := d.nodePolicies.Load(region); done {
+		return nil
+	}
+
+	_, err := iamAPI.PutRolePolicy(ctx, &iam.PutRolePolicyInput{
+		RoleName:       aws.String(execRoleName),
+		PolicyName:     aws.String("UnweaveEc2ExecNode"),
+		PolicyDocument: aws.String(nodePolicy),
+	})
+	if err != nil {
+		return fmt.Errorf("put node policy: %w", err)
+	}
+
+	d.nodePolicies.Store(region, true)
+
+	return nil
+}
+
 func (d *ExecDriver) tags(project, execID string) []ec2types.TagSpecification {
 	return []ec2types.TagSpecification{
 		{
@@ -229,6 +490,10 @@ func (d *ExecDriver) tags(project, execID string) []ec2types.TagSpecification {
 					Key:   aws.String("unweave.io/exec"),
 					Value: &execID,
 				},
+				{
+					Key:   aws.String(containerStatusTag),
+					Value: aws.String(containerStatusPulling),
+				},
 			},
 		},
 	}
@@ -239,7 +504,12 @@ func (d *ExecDriver) ExecDriverName() string {
 }
 
 func (d *ExecDriver) ExecGetStatus(ctx context.Context, execID string) (types.Status, error) {
-	_, status, err := d.instanceState(ctx, execID)
+	apis, err := d.execAPIs(execID)
+	if err != nil {
+		return types.StatusUnknown, err
+	}
+
+	_, status, err := d.instanceState(ctx, apis.EC2, execID, d.isJob(execID))
 	if err != nil {
 		return types.StatusUnknown, fmt.Errorf("failed to get state: %w", err)
 	}
@@ -247,8 +517,25 @@ func (d *ExecDriver) ExecGetStatus(ctx context.Context, execID string) (types.St
 	return status, nil
 }
 
-func (d *ExecDriver) instanceState(ctx context.Context, execID string) (string, types.Status, error) {
-	instance, err := d.instance(ctx, execID)
+// isJob returns whether the exec runs its command to completion, see types.Exec.Job. Execs
+// are only known to be jobs if the driver has a store.
+func (d *ExecDriver) isJob(execID string) bool {
+	if d.store == nil {
+		return false
+	}
+
+	exec, err := d.store.Get(execID)
+
+	return err == nil && exec.Job
+}
+
+func (d *ExecDriver) instanceState(
+	ctx context.Context,
+	ec2API Ec2API,
+	execID string,
+	job bool,
+) (string, types.Status, error) {
+	instance, err := d.instance(ctx, ec2API, execID)
 	if err != nil {
 		return "", types.StatusUnknown, fmt.Errorf("get instance: %w", err)
 	}
@@ -263,14 +550,19 @@ func (d *ExecDriver) instanceState(ctx context.Context, execID string) (string,
 	case ec2types.InstanceStateNameRunning:
 		// Check the health checks to make sure
 		// the instance is both running and contactable
-		status, err = d.instanceSummaryStatus(ctx, *instance.InstanceId)
+		status, err = d.instanceSummaryStatus(ctx, ec2API, *instance.InstanceId)
 		if err != nil {
 			return "", types.StatusError, fmt.Errorf("summary status: %w", err)
 		}
-	case ec2types.InstanceStateNameShuttingDown,
-		ec2types.InstanceStateNameTerminated,
-		ec2types.InstanceStateNameStopping,
+
+		if status == types.StatusRunning {
+			status = containerStatus(instance, job)
+		}
+	case ec2types.InstanceStateNameStopping,
 		ec2types.InstanceStateNameStopped:
+		status = types.StatusStopped
+	case ec2types.InstanceStateNameShuttingDown,
+		ec2types.InstanceStateNameTerminated:
 		status = types.StatusTerminated
 	default:
 		status = types.StatusUnknown
@@ -279,8 +571,76 @@ func (d *ExecDriver) instanceState(ctx context.Context, execID string) (string,
 	return *instance.InstanceId, status, nil
 }
 
-func (d *ExecDriver) instanceSummaryStatus(ctx context.Context, instanceID string) (types.Status, error) {
-	out, err := d.ec2API.DescribeInstanceStatus(
+// containerStatus returns the status of a running instance's container. Exited
+// containers leave their instance running so it can be inspected over SSH until the exec
+// is terminated. Only jobs succeed or fail, other execs whose container exited are
+// terminated, or in error if it failed.
+func containerStatus(instance ec2types.Instance, job bool) types.Status {
+	tagged := false
+
+	for _, tag := range instance.Tags {
+		if aws.ToString(tag.Key) != containerStatusTag {
+			continue
+		}
+
+		tagged = true
+
+		switch aws.ToString(tag.Value) {
+		case containerStatusRunning:
+			return types.StatusRunning
+		case containerStatusExited:
+			if job {
+				return types.StatusSuccess
+			}
+
+			return types.StatusTerminated
+		case containerStatusFailed:
+			if job {
+				return types.StatusFailed
+			}
+
+			return types.StatusError
+		}
+	}
+
+	// Instances created before execs ran containers aren't tagged.
+	if !tagged {
+		return types.StatusRunning
+	}
+
+	return types.StatusInitializing
+}
+
+// ExecExitCode returns the exit code of the exec's container once it has exited.
+func (d *ExecDriver) ExecExitCode(ctx context.Context, execID string) (int, error) {
+	apis, err := d.execAPIs(execID)
+	if err != nil {
+		return 0, err
+	}
+
+	instance, err := d.instance(ctx, apis.EC2, execID)
+	if err != nil {
+		return 0, fmt.Errorf("exit code: %w", err)
+	}
+
+	for _, tag := range instance.Tags {
+		if aws.ToString(tag.Key) != exitCodeTag {
+			continue
+		}
+
+		code, err := strconv.Atoi(aws.ToString(tag.Value))
+		if err != nil {
+			return 0, fmt.Errorf("parse exit code %q: %w", aws.ToString(tag.Value), err)
+		}
+
+		return code, nil
+	}
+
+	return 0, fmt.Errorf("container of exec %s hasn't exited", execID)
+}
+
+func (d *ExecDriver) instanceSummaryStatus(ctx context.Context, ec2API Ec2API, instanceID string) (types.Status, error) {
+	out, err := ec2API.DescribeInstanceStatus(
 		ctx,
 		&ec2.DescribeInstanceStatusInput{
 			InstanceIds:         []string{instanceID},
@@ -309,7 +669,7 @@ func (d *ExecDriver) instanceSummaryStatus(ctx context.Context, instanceID strin
 	return types.StatusUnknown, nil
 }
 
-func (d *ExecDriver) instance(ctx context.Context, execID string) (ec2types.Instance, error) {
+func (d *ExecDriver) instance(ctx context.Context, ec2API Ec2API, execID string) (ec2types.Instance, error) {
 	input := &ec2.DescribeInstancesInput{
 		Filters: []ec2types.Filter{
 			{
@@ -319,7 +679,7 @@ func (d *ExecDriver) instance(ctx context.Context, execID string) (ec2types.Inst
 		},
 	}
 
-	output, err := d.ec2API.DescribeInstances(ctx, input)
+	output, err := ec2API.DescribeInstances(ctx, input)
 	if err != nil {
 		return ec2types.Instance{}, fmt.Errorf("failed to get exec instance: %w", err)
 	}
@@ -338,40 +698,203 @@ func (d *ExecDriver) ExecProvider() types.Provider {
 }
 
 func (d *ExecDriver) ExecTerminate(ctx context.Context, execID string) error {
-	instanceID, _, err := d.instanceState(ctx, execID)
+	apis, err := d.execAPIs(execID)
+	if err != nil {
+		return err
+	}
+
+	instanceID, _, err := d.instanceState(ctx, apis.EC2, execID, false)
 	if err != nil {
 		return fmt.Errorf("failed to get instance: %w", err)
 	}
 
-	_, err = d.ec2API.TerminateInstances(ctx, &ec2.TerminateInstancesInput{InstanceIds: []string{instanceID}})
+	_, err = apis.EC2.TerminateInstances(ctx, &ec2.TerminateInstancesInput{InstanceIds: []string{instanceID}})
 	if err != nil {
 		return fmt.Errorf("failed to terminate: %w", err)
 	}
 
+	if err = deleteExecParameters(ctx, apis.SSM, execID); err != nil {
+		log.Ctx(ctx).Warn().Err(err).Str(types.ExecIDCtxKey, execID).Msg("Failed to delete exec parameters")
+	}
+
 	return nil
 }
 
+// ExecStop stops the exec's instance. The EBS root volume is kept so the instance can be
+// started again with ExecStart. The container's unit is stopped before docker on the way
+// down, so the container's exit isn't recorded and it's started again on the next boot,
+// see UserData.
+func (d *ExecDriver) ExecStop(ctx context.Context, execID string) error {
+	apis, err := d.execAPIs(execID)
+	if err != nil {
+		return err
+	}
+
+	instance, err := d.instance(ctx, apis.EC2, execID)
+	if err != nil {
+		return fmt.Errorf("failed to get instance: %w", err)
+	}
+
+	_, err = apis.EC2.StopInstances(ctx, &ec2.StopInstancesInput{InstanceIds: []string{*instance.InstanceId}})
+	if err != nil {
+		return instanceStateError(err, execID, "stop")
+	}
+
+	return nil
+}
+
+// ExecStart starts the exec's stopped instance. The container status is reset first so
+// the result of the previous boot isn't reported until the container's unit reports again.
+func (d *ExecDriver) ExecStart(ctx context.Context, execID string) error {
+	apis, err := d.execAPIs(execID)
+	if err != nil {
+		return err
+	}
+
+	instance, err := d.instance(ctx, apis.EC2, execID)
+	if err != nil {
+		return fmt.Errorf("failed to get instance: %w", err)
+	}
+
+	if instance.State == nil || instance.State.Name != ec2types.InstanceStateNameStopped {
+		state := ec2types.InstanceStateName("unknown")
+		if instance.State != nil {
+			state = instance.State.Name
+		}
+
+		return &types.Error{
+			Code:       http.StatusConflict,
+			Message:    fmt.Sprintf("Cannot start exec %s while its instance is %s", execID, state),
+			Suggestion: "Wait for the instance to stop",
+			Provider:   types.AWSProvider,
+		}
+	}
+
+	_, err = apis.EC2.CreateTags(ctx, &ec2.CreateTagsInput{
+		Resources: []string{*instance.InstanceId},
+		Tags: []ec2types.Tag{
+			{
+				Key:   aws.String(containerStatusTag),
+				Value: aws.String(containerStatusPulling),
+			},
+		},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to reset container status: %w", err)
+	}
+
+	_, err = apis.EC2.StartInstances(ctx, &ec2.StartInstancesInput{InstanceIds: []string{*instance.InstanceId}})
+	if err != nil {
+		return instanceStateError(err, execID, "start")
+	}
+
+	return nil
+}
+
+// instanceStateError returns a conflict if EC2 refused to stop or start the instance in
+// its current state, for example starting an instance that is still stopping.
+func instanceStateError(err error, execID, action string) error {
+	var apiErr smithy.APIError
+	if errors.As(err, &apiErr) && apiErr.ErrorCode() == "IncorrectInstanceState" {
+		return &types.Error{
+			Code:       http.StatusConflict,
+			Message:    fmt.Sprintf("Cannot %s exec %s in its current state", action, execID),
+			Suggestion: "Wait for the instance to finish stopping or starting and try again",
+			Provider:   types.AWSProvider,
+		}
+	}
+
+	return fmt.Errorf("failed to %s: %w", action, err)
+}
+
 func (d *ExecDriver) ExecSpec(_ context.Context, _ string) (types.HardwareSpec, error) {
 	panic("not implemented")
 }
 
-func (d *ExecDriver) ExecStats(_ context.Context, _ string) (execsrv.Stats, error) {
-	panic("not implemented")
+// ExecStats returns the latest CPU utilization reported by CloudWatch for the exec's
+// instance. EC2 doesn't publish memory, disk or GPU metrics without the CloudWatch agent
+// so those are left at zero.
+func (d *ExecDriver) ExecStats(ctx context.Context, execID string) (execsrv.Stats, error) {
+	apis, err := d.execAPIs(execID)
+	if err != nil {
+		return execsrv.Stats{}, err
+	}
+
+	instance, err := d.instance(ctx, apis.EC2, execID)
+	if err != nil {
+		return execsrv.Stats{}, fmt.Errorf("stats: %w", err)
+	}
+
+	now := time.Now()
+
+	out, err := apis.CloudWatch.GetMetricStatistics(ctx, &cloudwatch.GetMetricStatisticsInput{
+		Namespace:  aws.String("AWS/EC2"),
+		MetricName: aws.String("CPUUtilization"),
+		Dimensions: []cwtypes.Dimension{
+			{
+				Name:  aws.String("InstanceId"),
+				Value: instance.InstanceId,
+			},
+		},
+		StartTime:  aws.Time(now.Add(-statsWindow)),
+		EndTime:    aws.Time(now),
+		Period:     aws.Int32(statsPeriodSeconds),
+		Statistics: []cwtypes.Statistic{cwtypes.StatisticAverage},
+	})
+	if err != nil {
+		return execsrv.Stats{}, fmt.Errorf("failed to get cpu utilization: %w", err)
+	}
+
+	latest, ok := latestDatapoint(out.Datapoints)
+	if !ok {
+		return execsrv.Stats{}, fmt.Errorf("no cpu utilization reported for exec %s yet", execID)
+	}
+
+	return execsrv.Stats{CPU: aws.ToFloat64(latest.Average)}, nil
+}
+
+// latestDatapoint returns the most recent datapoint with an average. CloudWatch doesn't
+// guarantee the order of the returned datapoints.
+func latestDatapoint(points []cwtypes.Datapoint) (cwtypes.Datapoint, bool) {
+	var (
+		latest cwtypes.Datapoint
+		found  bool
+	)
+
+	for _, p := range points {
+		if p.Average == nil || p.Timestamp == nil {
+			continue
+		}
+
+		if !found || p.Timestamp.After(*latest.Timestamp) {
+			latest = p
+			found = true
+		}
+	}
+
+	return latest, found
 }
 
 // ExecPing pings the driver availability on behalf of a user. This can be used to
 // check if the driver is configured correctly and healthy.
 func (d *ExecDriver) ExecPing(ctx context.Context, _ *string) error {
-	_, err := d.stsAPI.GetCallerIdentity(ctx, &sts.GetCallerIdentityInput{})
-	if err != nil {
-		return fmt.Errorf("failed to ping: %w", err)
+	for _, region := range d.Regions() {
+		_, err := d.regions[region].STS.GetCallerIdentity(ctx, &sts.GetCallerIdentityInput{})
+		if err != nil {
+			return fmt.Errorf("failed to ping %s: %w", region, err)
+		}
 	}
 
 	return nil
 }
 
 func (d *ExecDriver) ExecConnectionInfo(ctx context.Context, execID string) (types.ConnectionInfo, error) {
-	instance, err := d.instance(ctx, execID)
+	apis, err := d.execAPIs(execID)
+	if err != nil {
+		return types.ConnectionInfo{}, err
+	}
+
+	instance, err := d.instance(ctx, apis.EC2, execID)
 	if err != nil {
 		return types.ConnectionInfo{}, fmt.Errorf("connection info: %w", err)
 	}
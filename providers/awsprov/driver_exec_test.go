@@ -0,0 +1,318 @@
+package awsprov_test
+
+import (
+	"context"
+	"encoding/base64"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/cloudwatch"
+	cwtypes "github.com/aws/aws-sdk-go-v2/service/cloudwatch/types"
+	"github.com/aws/aws-sdk-go-v2/service/ec2"
+	ec2types "github.com/aws/aws-sdk-go-v2/service/ec2/types"
+	"github.com/aws/aws-sdk-go-v2/service/iam"
+	iamtypes "github.com/aws/aws-sdk-go-v2/service/iam/types"
+	"github.com/aws/smithy-go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/unweave/unweave-v1/api/types"
+	"github.com/unweave/unweave-v1/providers/awsprov"
+	"github.com/unweave/unweave-v1/providers/awsprov/awsprovfakes"
+	"github.com/unweave/unweave-v1/services/execsrv"
+	"github.com/unweave/unweave-v1/vault"
+)
+
+func TestExecDriver_ExecStats(t *testing.T) {
+	t.Parallel()
+
+	ec2API := new(awsprovfakes.FakeEc2API)
+	ec2API.DescribeInstancesReturns(&ec2.DescribeInstancesOutput{
+		Reservations: []ec2types.Reservation{
+			{Instances: []ec2types.Instance{{InstanceId: aws.String("i-123")}}},
+		},
+	}, nil)
+
+	now := time.Now()
+	cwAPI := new(awsprovfakes.FakeCloudWatchAPI)
+	cwAPI.GetMetricStatisticsReturns(&cloudwatch.GetMetricStatisticsOutput{
+		Datapoints: []cwtypes.Datapoint{
+			{Average: aws.Float64(80), Timestamp: aws.Time(now.Add(-10 * time.Minute))},
+			{Average: aws.Float64(12.5), Timestamp: aws.Time(now.Add(-5 * time.Minute))},
+		},
+	}, nil)
+
+	driver := awsprov.NewExecDriverAPI("us-east-1", "", ec2API, nil, nil, cwAPI)
+
+	stats, err := driver.ExecStats(context.Background(), "exc_123")
+	require.NoError(t, err)
+	assert.Equal(t, 12.5, stats.CPU)
+
+	_, input, _ := cwAPI.GetMetricStatisticsArgsForCall(0)
+	assert.Equal(t, "AWS/EC2", aws.ToString(input.Namespace))
+	assert.Equal(t, "CPUUtilization", aws.ToString(input.MetricName))
+	assert.Equal(t, "i-123", aws.ToString(input.Dimensions[0].Value))
+
+	cwAPI.GetMetricStatisticsReturns(&cloudwatch.GetMetricStatisticsOutput{}, nil)
+
+	_, err = driver.ExecStats(context.Background(), "exc_123")
+	assert.Error(t, err, "should fail when no datapoints are reported yet")
+}
+
+func TestExecDriver_ExecStopStart(t *testing.T) {
+	t.Parallel()
+
+	ec2API := new(awsprovfakes.FakeEc2API)
+	ec2API.DescribeInstancesReturns(&ec2.DescribeInstancesOutput{
+		Reservations: []ec2types.Reservation{
+			{Instances: []ec2types.Instance{{
+				InstanceId: aws.String("i-123"),
+				State:      &ec2types.InstanceState{Name: ec2types.InstanceStateNameStopped},
+			}}},
+		},
+	}, nil)
+
+	driver := awsprov.NewExecDriverAPI("us-east-1", "", ec2API, nil, nil, nil)
+
+	require.NoError(t, driver.ExecStop(context.Background(), "exc_123"))
+	_, stopInput, _ := ec2API.StopInstancesArgsForCall(0)
+	assert.Equal(t, []string{"i-123"}, stopInput.InstanceIds)
+
+	status, err := driver.ExecGetStatus(context.Background(), "exc_123")
+	require.NoError(t, err)
+	assert.Equal(t, types.StatusStopped, status)
+	assert.Zero(t, ec2API.CreateTagsCallCount(), "should not record the stopped container's exit")
+
+	require.NoError(t, driver.ExecStart(context.Background(), "exc_123"))
+	_, startInput, _ := ec2API.StartInstancesArgsForCall(0)
+	assert.Equal(t, []string{"i-123"}, startInput.InstanceIds)
+
+	_, tagsInput, _ := ec2API.CreateTagsArgsForCall(0)
+	assert.Equal(t, []string{"i-123"}, tagsInput.Resources)
+	assert.Equal(t, []ec2types.Tag{containerTag("pulling")}, tagsInput.Tags, "should reset the previous boot's container status")
+
+	ec2API.StartInstancesReturns(nil, &smithy.GenericAPIError{Code: "IncorrectInstanceState"})
+
+	var e *types.Error
+
+	err = driver.ExecStart(context.Background(), "exc_123")
+	require.True(t, errors.As(err, &e))
+	assert.Equal(t, http.StatusConflict, e.Code)
+
+	ec2API.DescribeInstancesReturns(&ec2.DescribeInstancesOutput{
+		Reservations: []ec2types.Reservation{
+			{Instances: []ec2types.Instance{{
+				InstanceId: aws.String("i-123"),
+				State:      &ec2types.InstanceState{Name: ec2types.InstanceStateNameStopping},
+			}}},
+		},
+	}, nil)
+
+	err = driver.ExecStart(context.Background(), "exc_123")
+	require.True(t, errors.As(err, &e))
+	assert.Equal(t, http.StatusConflict, e.Code)
+	assert.Equal(t, 2, ec2API.StartInstancesCallCount(), "should not start an instance that is still stopping")
+}
+
+type execStore map[string]types.Exec
+
+func (s execStore) Get(id string) (types.Exec, error) {
+	exec, ok := s[id]
+	if !ok {
+		return types.Exec{}, errors.New("not found")
+	}
+
+	return exec, nil
+}
+
+func TestExecDriver_MultiRegion(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	v := vault.NewMemVault()
+
+	usCreds, err := awsprov.StoreCredentials(ctx, v, awsprov.Credentials{AccessKeyID: "us"})
+	require.NoError(t, err)
+	euCreds, err := awsprov.StoreCredentials(ctx, v, awsprov.Credentials{AccessKeyID: "eu"})
+	require.NoError(t, err)
+
+	accounts := []awsprov.Account{
+		{ID: "us", CredentialsSecretID: usCreds, Regions: []string{"us-east-1"}},
+		{ID: "eu", CredentialsSecretID: euCreds, Regions: []string{"eu-west-1"}},
+	}
+
+	ec2APIs := map[string]*awsprovfakes.FakeEc2API{}
+
+	regions, err := awsprov.NewRegionAPIs(ctx, v, accounts, func(region string, creds awsprov.Credentials) (awsprov.APIs, error) {
+		// Each region must be created with the credentials of its account.
+		assert.Equal(t, region[:2], creds.AccessKeyID)
+
+		ec2API := new(awsprovfakes.FakeEc2API)
+		ec2API.DescribeInstancesReturns(&ec2.DescribeInstancesOutput{
+			Reservations: []ec2types.Reservation{
+				{Instances: []ec2types.Instance{{
+					InstanceId: aws.String("i-" + region),
+					State:      &ec2types.InstanceState{Name: ec2types.InstanceStateNameStopped},
+				}}},
+			},
+		}, nil)
+		ec2APIs[region] = ec2API
+
+		iamAPI := new(awsprovfakes.FakeIamAPI)
+		iamAPI.GetInstanceProfileReturns(&iam.GetInstanceProfileOutput{
+			InstanceProfile: &iamtypes.InstanceProfile{Arn: aws.String("arn")},
+		}, nil)
+
+		return awsprov.APIs{EC2: ec2API, IAM: iamAPI}, nil
+	})
+	require.NoError(t, err)
+
+	store := execStore{}
+	driver, err := awsprov.NewMultiRegionExecDriver("us-east-1", "", regions, store)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"eu-west-1", "us-east-1"}, driver.Regions())
+
+	spec := types.HardwareSpec{CPU: types.CPU{Type: "t3.micro"}}
+
+	execID, err := driver.ExecCreate(ctx, "pr_1", "ubuntu:latest", nil, spec, types.ExecNetwork{}, nil, nil, aws.String("eu-west-1"))
+	require.NoError(t, err)
+	assert.Equal(t, 1, ec2APIs["eu-west-1"].RunInstancesCallCount())
+	assert.Equal(t, 0, ec2APIs["us-east-1"].RunInstancesCallCount())
+
+	_, input, _ := ec2APIs["eu-west-1"].RunInstancesArgsForCall(0)
+	assert.Equal(t, "eu-west-1a", aws.ToString(input.Placement.AvailabilityZone))
+
+	_, err = driver.ExecCreate(ctx, "pr_1", "ubuntu:latest", nil, spec, types.ExecNetwork{}, nil, nil, aws.String("ap-south-1"))
+	var e *types.Error
+	require.ErrorAs(t, err, &e, "should reject regions that aren't configured")
+	assert.Equal(t, http.StatusBadRequest, e.Code)
+
+	store[execID] = types.Exec{ID: execID, Region: "eu-west-1"}
+
+	status, err := driver.ExecGetStatus(ctx, execID)
+	require.NoError(t, err)
+	assert.Equal(t, types.StatusStopped, status)
+	assert.Equal(t, 1, ec2APIs["eu-west-1"].DescribeInstancesCallCount())
+	assert.Equal(t, 0, ec2APIs["us-east-1"].DescribeInstancesCallCount())
+
+	require.NoError(t, driver.ExecTerminate(ctx, execID))
+	_, terminateInput, _ := ec2APIs["eu-west-1"].TerminateInstancesArgsForCall(0)
+	assert.Equal(t, []string{"i-eu-west-1"}, terminateInput.InstanceIds)
+
+	// Execs stored without a region were placed in the default region.
+	store["exc_old"] = types.Exec{ID: "exc_old"}
+
+	require.NoError(t, driver.ExecTerminate(ctx, "exc_old"))
+	_, terminateInput, _ = ec2APIs["us-east-1"].TerminateInstancesArgsForCall(0)
+	assert.Equal(t, []string{"i-us-east-1"}, terminateInput.InstanceIds)
+}
+
+func TestExecDriver_ContainerStatus(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+
+	ec2API := new(awsprovfakes.FakeEc2API)
+	ec2API.DescribeInstanceStatusReturns(&ec2.DescribeInstanceStatusOutput{
+		InstanceStatuses: []ec2types.InstanceStatus{{
+			InstanceStatus: &ec2types.InstanceStatusSummary{Status: ec2types.SummaryStatusOk},
+		}},
+	}, nil)
+
+	iamAPI := new(awsprovfakes.FakeIamAPI)
+	iamAPI.GetInstanceProfileReturns(&iam.GetInstanceProfileOutput{
+		InstanceProfile: &iamtypes.InstanceProfile{Arn: aws.String("arn")},
+	}, nil)
+
+	ssmAPI := new(awsprovfakes.FakeSsmAPI)
+
+	v := vault.NewMemVault()
+	secretID, err := awsprov.StoreRegistryCredentials(ctx, v, awsprov.RegistryCredentials{
+		Server: "ghcr.io", Username: "unweave", Password: "s3cret",
+	})
+	require.NoError(t, err)
+
+	store := execStore{}
+	regions := map[string]awsprov.APIs{"us-east-1": {EC2: ec2API, IAM: iamAPI, SSM: ssmAPI}}
+
+	driver, err := awsprov.NewMultiRegionExecDriver("us-east-1", "", regions, store)
+	require.NoError(t, err)
+	driver = awsprov.WithRegistryCredentials(driver, v, secretID)
+	driver = awsprov.WithLogShipping(driver, "https://api.unweave.io", []byte("key"))
+
+	spec := types.HardwareSpec{CPU: types.CPU{Type: "t3.micro"}}
+
+	var execIDs []string
+
+	for i := 0; i < 2; i++ {
+		execID, err := driver.ExecCreate(ctx, "pr_1", "ubuntu:latest", []string{"sleep", "1"}, spec, types.ExecNetwork{}, nil, nil, nil)
+		require.NoError(t, err)
+
+		execIDs = append(execIDs, execID)
+	}
+
+	_, input, _ := ec2API.RunInstancesArgsForCall(0)
+	assert.Equal(t, awsprov.DefaultMachineImage, aws.ToString(input.ImageId), "should boot the machine image, not the container image")
+
+	userData, err := base64.StdEncoding.DecodeString(aws.ToString(input.UserData))
+	require.NoError(t, err)
+	assert.NotContains(t, string(userData), "s3cret", "should not put the registry password in the user data")
+	assert.Contains(t, string(userData), "systemctl enable --now --no-block unweave-container", "should start the container on every boot")
+
+	_, param, _ := ssmAPI.PutParameterArgsForCall(0)
+	assert.Equal(t, "s3cret", aws.ToString(param.Value))
+	assert.Contains(t, string(userData), aws.ToString(param.Name))
+
+	token := execsrv.LogIngestToken([]byte("key"), execIDs[0])
+	assert.NotContains(t, string(userData), token, "should not put the log ingest token in the user data")
+
+	_, param, _ = ssmAPI.PutParameterArgsForCall(1)
+	assert.Equal(t, token, aws.ToString(param.Value))
+	assert.Contains(t, string(userData), aws.ToString(param.Name))
+
+	assert.Equal(t, 1, iamAPI.PutRolePolicyCallCount(), "should put the node policy on existing roles once")
+
+	store["exc_job"] = types.Exec{ID: "exc_job", Job: true}
+	store["exc_session"] = types.Exec{ID: "exc_session"}
+
+	for _, tc := range []struct {
+		tags   []ec2types.Tag
+		job    types.Status
+		status types.Status
+	}{
+		{tags: nil, job: types.StatusRunning, status: types.StatusRunning},
+		{tags: []ec2types.Tag{containerTag("pulling")}, job: types.StatusInitializing, status: types.StatusInitializing},
+		{tags: []ec2types.Tag{containerTag("running")}, job: types.StatusRunning, status: types.StatusRunning},
+		{tags: []ec2types.Tag{containerTag("exited")}, job: types.StatusSuccess, status: types.StatusTerminated},
+		{tags: []ec2types.Tag{containerTag("failed")}, job: types.StatusFailed, status: types.StatusError},
+	} {
+		ec2API.DescribeInstancesReturns(&ec2.DescribeInstancesOutput{
+			Reservations: []ec2types.Reservation{
+				{Instances: []ec2types.Instance{{
+					InstanceId: aws.String("i-123"),
+					State:      &ec2types.InstanceState{Name: ec2types.InstanceStateNameRunning},
+					Tags:       tc.tags,
+				}}},
+			},
+		}, nil)
+
+		status, err := driver.ExecGetStatus(ctx, "exc_job")
+		require.NoError(t, err)
+		assert.Equal(t, tc.job, status)
+
+		status, err = driver.ExecGetStatus(ctx, "exc_session")
+		require.NoError(t, err)
+		assert.Equal(t, tc.status, status)
+	}
+
+	require.NoError(t, driver.ExecTerminate(ctx, "exc_job"))
+
+	_, deleted, _ := ssmAPI.DeleteParametersArgsForCall(0)
+	assert.Equal(t, []string{"/unweave/execs/exc_job/registry-password", "/unweave/execs/exc_job/log-token"}, deleted.Names)
+}
+
+func containerTag(status string) ec2types.Tag {
+	return ec2types.Tag{Key: aws.String("unweave.io/container"), Value: aws.String(status)}
+}
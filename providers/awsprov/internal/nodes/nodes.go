@@ -10,13 +10,18 @@ import (
 type AwsNodeType struct {
 	NodeID   ec2types.InstanceType
 	NodeName string
-	Cost     float64
+	Cost     float64 // on-demand hourly cost in USD (us-east-1)
 	GPUMem   int
 	GPUCount int
 	CPUCount int
 	CPUMem   float64
 }
 
+// priceCentsPerHour converts the node's on-demand hourly cost in USD to cents.
+func priceCentsPerHour(node *AwsNodeType) int {
+	return int(math.Ceil(node.Cost * 100))
+}
+
 type nodeOptions []AwsNodeType
 
 func (opts nodeOptions) atLeastGPUs(count int) nodeOptions {
@@ -131,71 +136,71 @@ func BestFit(nodes []AwsNodeType, spec types.HardwareSpec) ec2types.InstanceType
 
 func GeneralPurposeNodes() []AwsNodeType {
 	return []AwsNodeType{
-		{NodeName: "General purpose", NodeID: ec2types.InstanceTypeT3Nano, CPUCount: 2, CPUMem: 0.5},
-		{NodeName: "General purpose", NodeID: ec2types.InstanceTypeT3Micro, CPUCount: 2, CPUMem: 1},
-		{NodeName: "General purpose", NodeID: ec2types.InstanceTypeT3Small, CPUCount: 2, CPUMem: 2},
-		{NodeName: "General purpose", NodeID: ec2types.InstanceTypeT3Medium, CPUCount: 2, CPUMem: 4},
-		{NodeName: "General purpose", NodeID: ec2types.InstanceTypeM6iLarge, CPUCount: 2, CPUMem: 8},
-		{NodeName: "General purpose", NodeID: ec2types.InstanceTypeM6iXlarge, CPUCount: 4, CPUMem: 16},
-		{NodeName: "General purpose", NodeID: ec2types.InstanceTypeM6i2xlarge, CPUCount: 8, CPUMem: 32},
-		{NodeName: "General purpose", NodeID: ec2types.InstanceTypeM6i4xlarge, CPUCount: 16, CPUMem: 64},
-		{NodeName: "General purpose", NodeID: ec2types.InstanceTypeM6i8xlarge, CPUCount: 32, CPUMem: 128},
-		{NodeName: "General purpose", NodeID: ec2types.InstanceTypeM6i12xlarge, CPUCount: 48, CPUMem: 192},
-		{NodeName: "General purpose", NodeID: ec2types.InstanceTypeM6i16xlarge, CPUCount: 64, CPUMem: 256},
-		{NodeName: "General purpose", NodeID: ec2types.InstanceTypeM6i24xlarge, CPUCount: 96, CPUMem: 384},
-		{NodeName: "General purpose", NodeID: ec2types.InstanceTypeM6i32xlarge, CPUCount: 128, CPUMem: 512},
+		{NodeName: "General purpose", NodeID: ec2types.InstanceTypeT3Nano, Cost: 0.0052, CPUCount: 2, CPUMem: 0.5},
+		{NodeName: "General purpose", NodeID: ec2types.InstanceTypeT3Micro, Cost: 0.0104, CPUCount: 2, CPUMem: 1},
+		{NodeName: "General purpose", NodeID: ec2types.InstanceTypeT3Small, Cost: 0.0208, CPUCount: 2, CPUMem: 2},
+		{NodeName: "General purpose", NodeID: ec2types.InstanceTypeT3Medium, Cost: 0.0416, CPUCount: 2, CPUMem: 4},
+		{NodeName: "General purpose", NodeID: ec2types.InstanceTypeM6iLarge, Cost: 0.096, CPUCount: 2, CPUMem: 8},
+		{NodeName: "General purpose", NodeID: ec2types.InstanceTypeM6iXlarge, Cost: 0.192, CPUCount: 4, CPUMem: 16},
+		{NodeName: "General purpose", NodeID: ec2types.InstanceTypeM6i2xlarge, Cost: 0.384, CPUCount: 8, CPUMem: 32},
+		{NodeName: "General purpose", NodeID: ec2types.InstanceTypeM6i4xlarge, Cost: 0.768, CPUCount: 16, CPUMem: 64},
+		{NodeName: "General purpose", NodeID: ec2types.InstanceTypeM6i8xlarge, Cost: 1.536, CPUCount: 32, CPUMem: 128},
+		{NodeName: "General purpose", NodeID: ec2types.InstanceTypeM6i12xlarge, Cost: 2.304, CPUCount: 48, CPUMem: 192},
+		{NodeName: "General purpose", NodeID: ec2types.InstanceTypeM6i16xlarge, Cost: 3.072, CPUCount: 64, CPUMem: 256},
+		{NodeName: "General purpose", NodeID: ec2types.InstanceTypeM6i24xlarge, Cost: 4.608, CPUCount: 96, CPUMem: 384},
+		{NodeName: "General purpose", NodeID: ec2types.InstanceTypeM6i32xlarge, Cost: 6.144, CPUCount: 128, CPUMem: 512},
 	}
 }
 
 func GPUNodes() map[string][]AwsNodeType {
 	return map[string][]AwsNodeType{
 		"tesla_m60": {
-			{NodeName: "Tesla M60", NodeID: ec2types.InstanceTypeG3sXlarge, Cost: 0, GPUCount: 1, GPUMem: 8, CPUCount: 4, CPUMem: 30.5},
-			{NodeName: "Tesla M60", NodeID: ec2types.InstanceTypeG34xlarge, Cost: 0, GPUCount: 1, GPUMem: 8, CPUCount: 16, CPUMem: 122},
-			{NodeName: "Tesla M60", NodeID: ec2types.InstanceTypeG38xlarge, Cost: 0, GPUCount: 1, GPUMem: 8, CPUCount: 32, CPUMem: 244},
-			{NodeName: "Tesla M60", NodeID: ec2types.InstanceTypeG316xlarge, Cost: 0, GPUCount: 1, GPUMem: 8, CPUCount: 64, CPUMem: 488},
+			{NodeName: "Tesla M60", NodeID: ec2types.InstanceTypeG3sXlarge, Cost: 0.75, GPUCount: 1, GPUMem: 8, CPUCount: 4, CPUMem: 30.5},
+			{NodeName: "Tesla M60", NodeID: ec2types.InstanceTypeG34xlarge, Cost: 1.14, GPUCount: 1, GPUMem: 8, CPUCount: 16, CPUMem: 122},
+			{NodeName: "Tesla M60", NodeID: ec2types.InstanceTypeG38xlarge, Cost: 2.28, GPUCount: 1, GPUMem: 8, CPUCount: 32, CPUMem: 244},
+			{NodeName: "Tesla M60", NodeID: ec2types.InstanceTypeG316xlarge, Cost: 4.56, GPUCount: 1, GPUMem: 8, CPUCount: 64, CPUMem: 488},
 		},
 		"t4_tensor": {
-			{NodeName: "T4 Tensor", NodeID: ec2types.InstanceTypeG4dnXlarge, Cost: 0, GPUCount: 1, CPUCount: 4, CPUMem: 16, GPUMem: 16},
-			{NodeName: "T4 Tensor", NodeID: ec2types.InstanceTypeG4dn2xlarge, Cost: 0, GPUCount: 1, CPUCount: 8, CPUMem: 32, GPUMem: 16},
-			{NodeName: "T4 Tensor", NodeID: ec2types.InstanceTypeG4dn4xlarge, Cost: 0, GPUCount: 1, CPUCount: 16, CPUMem: 64, GPUMem: 16},
-			{NodeName: "T4 Tensor", NodeID: ec2types.InstanceTypeG4dn8xlarge, Cost: 0, GPUCount: 1, CPUCount: 32, CPUMem: 128, GPUMem: 16},
-			{NodeName: "T4 Tensor", NodeID: ec2types.InstanceTypeG4dn16xlarge, Cost: 0, GPUCount: 1, CPUCount: 64, CPUMem: 256, GPUMem: 16},
-			{NodeName: "T4 Tensor", NodeID: ec2types.InstanceTypeG4dn12xlarge, Cost: 0, GPUCount: 4, CPUCount: 48, CPUMem: 192, GPUMem: 64},
+			{NodeName: "T4 Tensor", NodeID: ec2types.InstanceTypeG4dnXlarge, Cost: 0.526, GPUCount: 1, CPUCount: 4, CPUMem: 16, GPUMem: 16},
+			{NodeName: "T4 Tensor", NodeID: ec2types.InstanceTypeG4dn2xlarge, Cost: 0.752, GPUCount: 1, CPUCount: 8, CPUMem: 32, GPUMem: 16},
+			{NodeName: "T4 Tensor", NodeID: ec2types.InstanceTypeG4dn4xlarge, Cost: 1.204, GPUCount: 1, CPUCount: 16, CPUMem: 64, GPUMem: 16},
+			{NodeName: "T4 Tensor", NodeID: ec2types.InstanceTypeG4dn8xlarge, Cost: 2.176, GPUCount: 1, CPUCount: 32, CPUMem: 128, GPUMem: 16},
+			{NodeName: "T4 Tensor", NodeID: ec2types.InstanceTypeG4dn16xlarge, Cost: 4.352, GPUCount: 1, CPUCount: 64, CPUMem: 256, GPUMem: 16},
+			{NodeName: "T4 Tensor", NodeID: ec2types.InstanceTypeG4dn12xlarge, Cost: 3.912, GPUCount: 4, CPUCount: 48, CPUMem: 192, GPUMem: 64},
 		},
 		"aws_inferentia": {
-			{NodeName: "AWS Inferentia", NodeID: ec2types.InstanceTypeInf1Xlarge, Cost: 0, GPUCount: 1, CPUCount: 4, CPUMem: 8, GPUMem: 8},
-			{NodeName: "AWS Inferentia", NodeID: ec2types.InstanceTypeInf12xlarge, Cost: 0, GPUCount: 1, CPUCount: 8, CPUMem: 16, GPUMem: 16},
-			{NodeName: "AWS Inferentia", NodeID: ec2types.InstanceTypeInf16xlarge, Cost: 0, GPUCount: 4, CPUCount: 24, CPUMem: 48, GPUMem: 48},
-			{NodeName: "AWS Inferentia", NodeID: ec2types.InstanceTypeInf124xlarge, Cost: 0, GPUCount: 16, CPUCount: 96, CPUMem: 192, GPUMem: 192},
+			{NodeName: "AWS Inferentia", NodeID: ec2types.InstanceTypeInf1Xlarge, Cost: 0.228, GPUCount: 1, CPUCount: 4, CPUMem: 8, GPUMem: 8},
+			{NodeName: "AWS Inferentia", NodeID: ec2types.InstanceTypeInf12xlarge, Cost: 0.362, GPUCount: 1, CPUCount: 8, CPUMem: 16, GPUMem: 16},
+			{NodeName: "AWS Inferentia", NodeID: ec2types.InstanceTypeInf16xlarge, Cost: 1.18, GPUCount: 4, CPUCount: 24, CPUMem: 48, GPUMem: 48},
+			{NodeName: "AWS Inferentia", NodeID: ec2types.InstanceTypeInf124xlarge, Cost: 4.721, GPUCount: 16, CPUCount: 96, CPUMem: 192, GPUMem: 192},
 		},
 		"aws_inferentia2": {
-			{NodeName: "AWS Inferentia2", NodeID: ec2types.InstanceTypeInf2Xlarge, Cost: 0, GPUCount: 1, GPUMem: 32, CPUCount: 4, CPUMem: 16},
-			{NodeName: "AWS Inferentia2", NodeID: ec2types.InstanceTypeInf28xlarge, Cost: 0, GPUCount: 1, GPUMem: 32, CPUCount: 32, CPUMem: 128},
-			{NodeName: "AWS Inferentia2", NodeID: ec2types.InstanceTypeInf224xlarge, Cost: 0, GPUCount: 6, GPUMem: 192, CPUCount: 96, CPUMem: 384},
-			{NodeName: "AWS Inferentia2", NodeID: ec2types.InstanceTypeInf248xlarge, Cost: 0, GPUCount: 12, GPUMem: 384, CPUCount: 192, CPUMem: 768},
+			{NodeName: "AWS Inferentia2", NodeID: ec2types.InstanceTypeInf2Xlarge, Cost: 0.7582, GPUCount: 1, GPUMem: 32, CPUCount: 4, CPUMem: 16},
+			{NodeName: "AWS Inferentia2", NodeID: ec2types.InstanceTypeInf28xlarge, Cost: 1.9679, GPUCount: 1, GPUMem: 32, CPUCount: 32, CPUMem: 128},
+			{NodeName: "AWS Inferentia2", NodeID: ec2types.InstanceTypeInf224xlarge, Cost: 6.4906, GPUCount: 6, GPUMem: 192, CPUCount: 96, CPUMem: 384},
+			{NodeName: "AWS Inferentia2", NodeID: ec2types.InstanceTypeInf248xlarge, Cost: 12.9813, GPUCount: 12, GPUMem: 384, CPUCount: 192, CPUMem: 768},
 		},
 		"aws_trainium": {
-			{NodeName: "AWS Trainium", NodeID: ec2types.InstanceTypeTrn12xlarge, Cost: 0, GPUCount: 1, GPUMem: 32, CPUCount: 8, CPUMem: 32},
-			{NodeName: "AWS Trainium", NodeID: ec2types.InstanceTypeTrn132xlarge, Cost: 0, GPUCount: 16, GPUMem: 512, CPUCount: 128, CPUMem: 512},
-			{NodeName: "AWS Trainium", NodeID: ec2types.InstanceTypeTrn1n32xlarge, Cost: 0, GPUCount: 16, GPUMem: 512, CPUCount: 128, CPUMem: 512},
+			{NodeName: "AWS Trainium", NodeID: ec2types.InstanceTypeTrn12xlarge, Cost: 1.3438, GPUCount: 1, GPUMem: 32, CPUCount: 8, CPUMem: 32},
+			{NodeName: "AWS Trainium", NodeID: ec2types.InstanceTypeTrn132xlarge, Cost: 21.5, GPUCount: 16, GPUMem: 512, CPUCount: 128, CPUMem: 512},
+			{NodeName: "AWS Trainium", NodeID: ec2types.InstanceTypeTrn1n32xlarge, Cost: 24.78, GPUCount: 16, GPUMem: 512, CPUCount: 128, CPUMem: 512},
 		},
 		"gaudi": {
-			{NodeName: "Gaudi", NodeID: ec2types.InstanceTypeDl124xlarge, Cost: 0, CPUCount: 96, GPUCount: 8, CPUMem: 768, GPUMem: 768},
+			{NodeName: "Gaudi", NodeID: ec2types.InstanceTypeDl124xlarge, Cost: 13.109, CPUCount: 96, GPUCount: 8, CPUMem: 768, GPUMem: 768},
 		},
 		"k80": {
-			{NodeName: "K80", NodeID: ec2types.InstanceTypeP2Xlarge, Cost: 0, GPUCount: 1, CPUCount: 4, CPUMem: 61, GPUMem: 12},
-			{NodeName: "K80", NodeID: ec2types.InstanceTypeP28xlarge, Cost: 0, GPUCount: 8, CPUCount: 32, CPUMem: 488, GPUMem: 96},
-			{NodeName: "K80", NodeID: ec2types.InstanceTypeP216xlarge, Cost: 0, GPUCount: 16, CPUCount: 64, CPUMem: 732, GPUMem: 192},
+			{NodeName: "K80", NodeID: ec2types.InstanceTypeP2Xlarge, Cost: 0.9, GPUCount: 1, CPUCount: 4, CPUMem: 61, GPUMem: 12},
+			{NodeName: "K80", NodeID: ec2types.InstanceTypeP28xlarge, Cost: 7.2, GPUCount: 8, CPUCount: 32, CPUMem: 488, GPUMem: 96},
+			{NodeName: "K80", NodeID: ec2types.InstanceTypeP216xlarge, Cost: 14.4, GPUCount: 16, CPUCount: 64, CPUMem: 732, GPUMem: 192},
 		},
 		"tesla_v100": {
-			{NodeName: "Tesla V100", NodeID: ec2types.InstanceTypeP32xlarge, Cost: 0, GPUCount: 1, CPUCount: 8, CPUMem: 61, GPUMem: 16},
-			{NodeName: "Tesla V100", NodeID: ec2types.InstanceTypeP38xlarge, Cost: 0, GPUCount: 4, CPUCount: 32, CPUMem: 244, GPUMem: 64},
-			{NodeName: "Tesla V100", NodeID: ec2types.InstanceTypeP316xlarge, Cost: 0, GPUCount: 8, CPUCount: 64, CPUMem: 488, GPUMem: 128},
-			{NodeName: "Tesla V100", NodeID: ec2types.InstanceTypeP3dn24xlarge, Cost: 0, GPUCount: 8, CPUCount: 96, CPUMem: 768, GPUMem: 256},
+			{NodeName: "Tesla V100", NodeID: ec2types.InstanceTypeP32xlarge, Cost: 3.06, GPUCount: 1, CPUCount: 8, CPUMem: 61, GPUMem: 16},
+			{NodeName: "Tesla V100", NodeID: ec2types.InstanceTypeP38xlarge, Cost: 12.24, GPUCount: 4, CPUCount: 32, CPUMem: 244, GPUMem: 64},
+			{NodeName: "Tesla V100", NodeID: ec2types.InstanceTypeP316xlarge, Cost: 24.48, GPUCount: 8, CPUCount: 64, CPUMem: 488, GPUMem: 128},
+			{NodeName: "Tesla V100", NodeID: ec2types.InstanceTypeP3dn24xlarge, Cost: 31.212, GPUCount: 8, CPUCount: 96, CPUMem: 768, GPUMem: 256},
 		},
 		"a100": {
-			{NodeName: "A100 Tensor", NodeID: ec2types.InstanceTypeP4d24xlarge, Cost: 0, GPUCount: 8, CPUCount: 96, CPUMem: 1152, GPUMem: 320},
+			{NodeName: "A100 Tensor", NodeID: ec2types.InstanceTypeP4d24xlarge, Cost: 32.7726, GPUCount: 8, CPUCount: 96, CPUMem: 1152, GPUMem: 320},
 		},
 	}
 }
@@ -221,12 +226,13 @@ func CPUNodeTypes() types.NodeType {
 func toNodeType(nodeType string, nodeID string, cpuType string, gpuType string, cpuNodes []AwsNodeType) types.NodeType {
 	smallest := cpuNodes[0]
 	largest := cpuNodes[len(cpuNodes)-1]
+	price := priceCentsPerHour(nodeOptions(cpuNodes).cheapest())
 
 	return types.NodeType{
 		Type:     nodeType,
 		ID:       nodeID,
 		Name:     &smallest.NodeName,
-		Price:    nil,
+		Price:    &price,
 		Regions:  []string{},
 		Provider: types.AWSProvider,
 		Specs: types.HardwareSpec{
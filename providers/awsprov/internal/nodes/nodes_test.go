@@ -88,3 +88,14 @@ func gpuSpec(gpuType string, gpuCount, gpuRAM, cpuCount, ram int) types.Hardware
 		},
 	}
 }
+
+func TestNodeTypesPrice(t *testing.T) {
+	cpu := nodes.CPUNodeTypes()
+	assert.NotNil(t, cpu.Price)
+	assert.Equal(t, 1, *cpu.Price, "should price the cheapest general purpose node in cents")
+
+	for _, nt := range nodes.ToNodeTypesGPU(nodes.GPUNodes()) {
+		assert.NotNil(t, nt.Price, nt.ID)
+		assert.Positive(t, *nt.Price, nt.ID)
+	}
+}
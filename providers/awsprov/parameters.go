@@ -0,0 +1,67 @@
+package awsprov
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/ssm"
+	ssmtypes "github.com/aws/aws-sdk-go-v2/service/ssm/types"
+)
+
+// The secrets of an exec are kept in SecureString parameters under execParametersPath and
+// read by its instance at boot with the instance role. Anything in the user data can be
+// read by any process on the instance from the metadata service.
+const (
+	execParametersPath = "/unweave/execs/"
+
+	registryPasswordParameter = "registry-password"
+	logTokenParameter         = "log-token"
+)
+
+// execParameters are the names of all the parameters an exec can have.
+var execParameters = []string{registryPasswordParameter, logTokenParameter}
+
+func execParameterName(execID, name string) string {
+	return execParametersPath + execID + "/" + name
+}
+
+// putExecParameter stores the secret value of an exec and returns the name of its parameter.
+func putExecParameter(ctx context.Context, ssmAPI SsmAPI, execID, name, value string) (string, error) {
+	if ssmAPI == nil {
+		return "", fmt.Errorf("parameter store isn't configured")
+	}
+
+	param := execParameterName(execID, name)
+
+	_, err := ssmAPI.PutParameter(ctx, &ssm.PutParameterInput{
+		Name:      aws.String(param),
+		Value:     aws.String(value),
+		Type:      ssmtypes.ParameterTypeSecureString,
+		Overwrite: aws.Bool(true),
+	})
+	if err != nil {
+		return "", fmt.Errorf("put parameter %s: %w", param, err)
+	}
+
+	return param, nil
+}
+
+// deleteExecParameters deletes the parameters of an exec. Parameters the exec doesn't
+// have are ignored.
+func deleteExecParameters(ctx context.Context, ssmAPI SsmAPI, execID string) error {
+	if ssmAPI == nil {
+		return nil
+	}
+
+	names := make([]string, len(execParameters))
+	for i, name := range execParameters {
+		names[i] = execParameterName(execID, name)
+	}
+
+	if _, err := ssmAPI.DeleteParameters(ctx, &ssm.DeleteParametersInput{Names: names}); err != nil {
+		return fmt.Errorf("delete parameters: %w", err)
+	}
+
+	return nil
+}
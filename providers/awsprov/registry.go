@@ -0,0 +1,47 @@
+package awsprov
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/unweave/unweave-v1/vault"
+)
+
+// RegistryCredentials log execs into the container registry their images are pulled
+// from. Server is the registry host, e.g. ghcr.io. Docker Hub is used if it's empty.
+type RegistryCredentials struct {
+	Server   string `json:"server"`
+	Username string `json:"username"`
+	Password string `json:"password"`
+}
+
+// StoreRegistryCredentials stores the registry credentials in the vault and returns the
+// secret ID to pass to WithRegistryCredentials.
+func StoreRegistryCredentials(ctx context.Context, v vault.Vault, creds RegistryCredentials) (string, error) {
+	secret, err := json.Marshal(creds)
+	if err != nil {
+		return "", fmt.Errorf("marshal registry credentials: %w", err)
+	}
+
+	id, err := v.SetSecret(ctx, string(secret), nil)
+	if err != nil {
+		return "", fmt.Errorf("set secret: %w", err)
+	}
+
+	return id, nil
+}
+
+func getRegistryCredentials(ctx context.Context, v vault.Vault, secretID string) (*RegistryCredentials, error) {
+	secret, err := v.GetSecret(ctx, secretID)
+	if err != nil {
+		return nil, fmt.Errorf("get registry credentials: %w", err)
+	}
+
+	var creds RegistryCredentials
+	if err = json.Unmarshal([]byte(secret), &creds); err != nil {
+		return nil, fmt.Errorf("unmarshal registry credentials: %w", err)
+	}
+
+	return &creds, nil
+}
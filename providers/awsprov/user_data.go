@@ -4,9 +4,9 @@ import (
 	"bytes"
 	"encoding/base64"
 	"fmt"
+	"strings"
 	"text/template"
 
-	"github.com/rs/zerolog/log"
 	"github.com/unweave/unweave-v1/api/types"
 )
 
@@ -16,11 +16,41 @@ type volume struct {
 	MountPath  string
 }
 
+// Container is the container an exec runs on its instance.
+type Container struct {
+	Image   string
+	Command []string
+	GPUs    int
+	// Registry is used to log in before pulling the image if it's set.
+	Registry *RegistryLogin
+	// Logs ships the output of the container to the API if it's set.
+	Logs *LogShipping
+}
+
+// RegistryLogin logs the instance into a container registry. The password is read from
+// the PasswordParameter in the parameter store at boot, see putExecParameter.
+type RegistryLogin struct {
+	Server            string
+	Username          string
+	PasswordParameter string
+}
+
+// LogShipping is where a container's output is shipped to, see execsrv.LogService.
+type LogShipping struct {
+	// URL is the exec's log ingest endpoint. The stream is appended as a query parameter.
+	URL string
+	// TokenParameter is the parameter the ingest token is read from at boot.
+	TokenParameter string
+}
+
 type userDataInput struct {
-	DeviceName string
-	Region     string
-	PubKeys    []string
-	Volumes    []volume
+	DeviceName  string
+	Region      string
+	PubKeys     []string
+	Volumes     []volume
+	Container   Container
+	StatusTag   string
+	ExitCodeTag string
 }
 
 const userDataTemplate = `#!/bin/bash
@@ -63,15 +93,112 @@ sudo chown -R unweave:unweave /home/unweave
 echo "{{.}}" >> /home/ec2-user/.ssh/authorized_keys
 echo "{{.}}" >> /home/unweave/.ssh/authorized_keys
 {{end}}
+##
+## Create the container and run it with a unit that starts it on every boot
+##
+set_container_status() {
+    aws ec2 create-tags --resources $OUTPUT --tags Key={{.StatusTag}},Value=$1 --region {{.Region}}
+}
+if ! command -v docker >/dev/null 2>&1; then
+    yum install -y docker || (apt-get update && apt-get install -y docker.io)
+fi
+systemctl enable --now docker
+usermod -aG docker unweave
+{{- with .Container.Registry}}
+aws ssm get-parameter --name {{quote .PasswordParameter}} --with-decryption --query Parameter.Value --output text --region {{$.Region}} |
+    docker login --username {{quote .Username}} --password-stdin{{if .Server}} {{quote .Server}}{{end}} || { set_container_status failed; exit 1; }
+{{- end}}
+docker pull {{quote .Container.Image}} || { set_container_status failed; exit 1; }
+docker create -i --name unweave --network host{{if .Container.GPUs}} --gpus all{{end}}{{range .Volumes}} -v {{.MountPath}}:{{.MountPath}}{{end}} {{quote .Container.Image}}{{range .Container.Command}} {{quote .}}{{end}} || { set_container_status failed; exit 1; }
+cat > /usr/local/bin/unweave-container <<'UNWEAVE'
+#!/bin/bash
+exec >> /logs/container.log 2>&1
+set -x
+TOKEN=$(curl -X PUT "http://169.254.169.254/latest/api/token" -H "X-aws-ec2-metadata-token-ttl-seconds: 21600")
+OUTPUT=$(curl -H "X-aws-ec2-metadata-token: $TOKEN" http://169.254.169.254/latest/meta-data/instance-id)
+set_container_status() {
+    aws ec2 create-tags --resources $OUTPUT --tags Key={{.StatusTag}},Value=$1 --region {{.Region}}
+}
+record_exit() {
+    aws ec2 create-tags --resources $OUTPUT --tags Key={{.ExitCodeTag}},Value=$1 --region {{.Region}}
+    if [ "$1" = "0" ]; then set_container_status exited; else set_container_status failed; fi
+}
+# The container doesn't run again once it exited on its own, report its exit again since
+# the status is reset when the instance is started.
+if [ -f /var/lib/unweave/exit-code ]; then
+    record_exit $(cat /var/lib/unweave/exit-code)
+    exit 0
+fi
+{{- range .Volumes}}
+mountpoint -q {{.MountPath}} || mount {{.DeviceName}} {{.MountPath}}
+{{- end}}
+SINCE=$(date -u +%Y-%m-%dT%H:%M:%S)
+docker start unweave || { set_container_status failed; exit 1; }
+set_container_status running
+{{- with .Container.Logs}}
+set +x
+LOG_TOKEN=$(aws ssm get-parameter --name {{quote .TokenParameter}} --with-decryption --query Parameter.Value --output text --region {{$.Region}})
+set -x
+ship_logs() {
+    set +x
+    local line batch code
+    while :; do
+        batch=""
+        while :; do
+            IFS= read -r -t 2 line
+            code=$?
+            [ -n "$line" ] && batch+="$line"$'\n'
+            [ $code -ne 0 ] || [ ${#batch} -ge 1048576 ] && break
+        done
+        if [ -n "$batch" ]; then
+            printf '%s' "$batch" | curl -sS -X POST -H "Authorization: Bearer $LOG_TOKEN" -H 'Content-Type: text/plain' --data-binary @- {{quote .URL}}"?stream=$1"
+        fi
+        [ $code -ne 0 ] && [ $code -le 128 ] && return
+    done
+}
+docker logs -f --timestamps --since $SINCE unweave 2>/dev/null | ship_logs stdout &
+docker logs -f --timestamps --since $SINCE unweave 2>&1 >/dev/null | ship_logs stderr &
+{{- end}}
+code=$(docker wait unweave)
+# The unit is stopped before docker when the instance shuts down. Containers stopped by
+# docker on the way down didn't exit on their own.
+[ "$(systemctl is-system-running)" = stopping ] && exit 0
+mkdir -p /var/lib/unweave
+echo $code > /var/lib/unweave/exit-code
+record_exit $code
+UNWEAVE
+chmod +x /usr/local/bin/unweave-container
+cat > /etc/systemd/system/unweave-container.service <<'UNWEAVE'
+[Unit]
+Description=Unweave exec container
+Requires=docker.service
+After=docker.service network-online.target
+Wants=network-online.target
 
+[Service]
+ExecStart=/usr/local/bin/unweave-container
+
+[Install]
+WantedBy=multi-user.target
+UNWEAVE
+systemctl daemon-reload
+systemctl enable --now --no-block unweave-container
 `
 
 var (
-	tmpl     = template.Must(template.New("user-data").Parse(userDataTemplate))
+	tmpl = template.Must(template.New("user-data").
+		Funcs(template.FuncMap{"quote": shellQuote}).
+		Parse(userDataTemplate))
 	alphabet = []rune("fghijklmnop")
 )
 
-func UserData(region string, pubKeys []string, volumes []types.ExecVolume) (string, error) {
+// UserData returns the base64 encoded script that sets up an exec's instance and runs its
+// container. User data only runs on the first boot, so the container is run by a systemd
+// unit that starts it again each time the instance is started until it exits on its own.
+// The container's status is reported in the containerStatusTag of the instance. Its
+// output is shipped in batches of lines, read for up to two seconds each, if the
+// container has LogShipping.
+func UserData(region string, container Container, pubKeys []string, volumes []types.ExecVolume) (string, error) {
 	userData := &bytes.Buffer{}
 	base64Enc := base64.NewEncoder(base64.StdEncoding, userData)
 
@@ -85,16 +212,26 @@ func UserData(region string, pubKeys []string, volumes []types.ExecVolume) (stri
 	}
 
 	input := userDataInput{
-		Region:  region,
-		PubKeys: pubKeys,
-		Volumes: userDataVolumes,
+		Region:      region,
+		PubKeys:     pubKeys,
+		Volumes:     userDataVolumes,
+		Container:   container,
+		StatusTag:   containerStatusTag,
+		ExitCodeTag: exitCodeTag,
 	}
 
 	if err := tmpl.Execute(base64Enc, input); err != nil {
 		return "", fmt.Errorf("template userdata: %w", err)
 	}
 
-	log.Debug().Str("user_data", userData.String()).Msg("base64 user data script")
+	if err := base64Enc.Close(); err != nil {
+		return "", fmt.Errorf("encode userdata: %w", err)
+	}
 
 	return userData.String(), nil
 }
+
+// shellQuote quotes s as a single shell word.
+func shellQuote(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", `'\''`) + "'"
+}
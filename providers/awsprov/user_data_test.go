@@ -5,6 +5,7 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	"github.com/unweave/unweave-v1/api/types"
 	"github.com/unweave/unweave-v1/providers/awsprov"
 )
@@ -63,13 +64,112 @@ echo "ssh-key abc==" >> /home/unweave/.ssh/authorized_keys
 echo "ssh-key def==" >> /home/ec2-user/.ssh/authorized_keys
 echo "ssh-key def==" >> /home/unweave/.ssh/authorized_keys
 
-
+##
+## Create the container and run it with a unit that starts it on every boot
+##
+set_container_status() {
+    aws ec2 create-tags --resources $OUTPUT --tags Key=unweave.io/container,Value=$1 --region us-west-1
+}
+if ! command -v docker >/dev/null 2>&1; then
+    yum install -y docker || (apt-get update && apt-get install -y docker.io)
+fi
+systemctl enable --now docker
+usermod -aG docker unweave
+aws ssm get-parameter --name '/unweave/execs/exc_1/registry-password' --with-decryption --query Parameter.Value --output text --region us-west-1 |
+    docker login --username 'unweave' --password-stdin 'ghcr.io' || { set_container_status failed; exit 1; }
+docker pull 'ghcr.io/unweave/train:latest' || { set_container_status failed; exit 1; }
+docker create -i --name unweave --network host --gpus all -v /data/foo:/data/foo -v /data/bar:/data/bar 'ghcr.io/unweave/train:latest' 'python' '-c' 'print('\''hi'\'')' || { set_container_status failed; exit 1; }
+cat > /usr/local/bin/unweave-container <<'UNWEAVE'
+#!/bin/bash
+exec >> /logs/container.log 2>&1
+set -x
+TOKEN=$(curl -X PUT "http://169.254.169.254/latest/api/token" -H "X-aws-ec2-metadata-token-ttl-seconds: 21600")
+OUTPUT=$(curl -H "X-aws-ec2-metadata-token: $TOKEN" http://169.254.169.254/latest/meta-data/instance-id)
+set_container_status() {
+    aws ec2 create-tags --resources $OUTPUT --tags Key=unweave.io/container,Value=$1 --region us-west-1
+}
+record_exit() {
+    aws ec2 create-tags --resources $OUTPUT --tags Key=unweave.io/exit-code,Value=$1 --region us-west-1
+    if [ "$1" = "0" ]; then set_container_status exited; else set_container_status failed; fi
+}
+# The container doesn't run again once it exited on its own, report its exit again since
+# the status is reset when the instance is started.
+if [ -f /var/lib/unweave/exit-code ]; then
+    record_exit $(cat /var/lib/unweave/exit-code)
+    exit 0
+fi
+mountpoint -q /data/foo || mount /dev/sdf /data/foo
+mountpoint -q /data/bar || mount /dev/sdg /data/bar
+SINCE=$(date -u +%Y-%m-%dT%H:%M:%S)
+docker start unweave || { set_container_status failed; exit 1; }
+set_container_status running
+set +x
+LOG_TOKEN=$(aws ssm get-parameter --name '/unweave/execs/exc_1/log-token' --with-decryption --query Parameter.Value --output text --region us-west-1)
+set -x
+ship_logs() {
+    set +x
+    local line batch code
+    while :; do
+        batch=""
+        while :; do
+            IFS= read -r -t 2 line
+            code=$?
+            [ -n "$line" ] && batch+="$line"$'\n'
+            [ $code -ne 0 ] || [ ${#batch} -ge 1048576 ] && break
+        done
+        if [ -n "$batch" ]; then
+            printf '%s' "$batch" | curl -sS -X POST -H "Authorization: Bearer $LOG_TOKEN" -H 'Content-Type: text/plain' --data-binary @- 'https://api.unweave.io/ingest/sessions/exc_1/logs'"?stream=$1"
+        fi
+        [ $code -ne 0 ] && [ $code -le 128 ] && return
+    done
+}
+docker logs -f --timestamps --since $SINCE unweave 2>/dev/null | ship_logs stdout &
+docker logs -f --timestamps --since $SINCE unweave 2>&1 >/dev/null | ship_logs stderr &
+code=$(docker wait unweave)
+# The unit is stopped before docker when the instance shuts down. Containers stopped by
+# docker on the way down didn't exit on their own.
+[ "$(systemctl is-system-running)" = stopping ] && exit 0
+mkdir -p /var/lib/unweave
+echo $code > /var/lib/unweave/exit-code
+record_exit $code
+UNWEAVE
+chmod +x /usr/local/bin/unweave-container
+cat > /etc/systemd/system/unweave-container.service <<'UNWEAVE'
+[Unit]
+Description=Unweave exec container
+Requires=docker.service
+After=docker.service network-online.target
+Wants=network-online.target
+
+[Service]
+ExecStart=/usr/local/bin/unweave-container
+
+[Install]
+WantedBy=multi-user.target
+UNWEAVE
+systemctl daemon-reload
+systemctl enable --now --no-block unweave-container
 `
 
 func TestUserData(t *testing.T) {
 	t.Parallel()
 
-	data, _ := awsprov.UserData("us-west-1", []string{"ssh-key abc==", "ssh-key def=="}, []types.ExecVolume{
+	container := awsprov.Container{
+		Image:   "ghcr.io/unweave/train:latest",
+		Command: []string{"python", "-c", "print('hi')"},
+		GPUs:    1,
+		Registry: &awsprov.RegistryLogin{
+			Server:            "ghcr.io",
+			Username:          "unweave",
+			PasswordParameter: "/unweave/execs/exc_1/registry-password",
+		},
+		Logs: &awsprov.LogShipping{
+			URL:            "https://api.unweave.io/ingest/sessions/exc_1/logs",
+			TokenParameter: "/unweave/execs/exc_1/log-token",
+		},
+	}
+
+	data, err := awsprov.UserData("us-west-1", container, []string{"ssh-key abc==", "ssh-key def=="}, []types.ExecVolume{
 		{
 			VolumeID:  "abc123",
 			MountPath: "/data/foo",
@@ -79,8 +179,10 @@ func TestUserData(t *testing.T) {
 			MountPath: "/data/bar",
 		},
 	})
+	require.NoError(t, err)
 
-	u, _ := base64.StdEncoding.DecodeString(data)
+	u, err := base64.StdEncoding.DecodeString(data)
+	require.NoError(t, err)
 
 	t.Log(string(u))
 
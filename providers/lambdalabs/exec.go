@@ -3,6 +3,7 @@ package lambdalabs
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"net/http"
 	"strings"
@@ -15,7 +16,18 @@ import (
 	"github.com/unweave/unweave-v1/tools/random"
 )
 
-func (d *Driver) ExecCreate(ctx context.Context, project, image string, spec types.HardwareSpec, network types.ExecNetwork, volumes []types.ExecVolume, pubKeys []string, region *string) (string, error) {
+var (
+	errStatsUnsupported = errors.New("exec stats unsupported for lambdalabs provider")
+
+	errStopStartUnsupported = &types.Error{
+		Code:       http.StatusNotImplemented,
+		Message:    "Stopping and starting sessions is not supported by LambdaLabs",
+		Suggestion: "Terminate the session instead or use a provider that supports stopping",
+		Provider:   types.LambdaLabsProvider,
+	}
+)
+
+func (d *Driver) ExecCreate(ctx context.Context, project, image string, _ []string, spec types.HardwareSpec, network types.ExecNetwork, volumes []types.ExecVolume, pubKeys []string, region *string) (string, error) {
 	if len(pubKeys) == 0 {
 		return "", fmt.Errorf("no ssh keys provided")
 	}
@@ -352,8 +364,20 @@ func (d *Driver) ExecSpec(ctx context.Context, execID string) (types.HardwareSpe
 	return spec, nil
 }
 
+// ExecStats always fails, LambdaLabs doesn't expose instance metrics through its API.
+// Returning an error rather than panicking lets the stats informer back off.
 func (d *Driver) ExecStats(_ context.Context, _ string) (execsrv.Stats, error) {
-	panic("implement me")
+	return execsrv.Stats{}, errStatsUnsupported
+}
+
+// ExecStop always fails, LambdaLabs instances can only be terminated.
+func (d *Driver) ExecStop(_ context.Context, _ string) error {
+	return errStopStartUnsupported
+}
+
+// ExecStart always fails, LambdaLabs instances can only be terminated.
+func (d *Driver) ExecStart(_ context.Context, _ string) error {
+	return errStopStartUnsupported
 }
 
 func (d *Driver) ExecTerminate(_ context.Context, _ string) error {
@@ -0,0 +1,225 @@
+package local
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os/exec"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// cliDockerAPI implements DockerAPI by shelling out to the docker CLI. This avoids pulling
+// in the Docker SDK and works with any daemon the CLI is configured for (DOCKER_HOST etc).
+type cliDockerAPI struct {
+	bin string
+}
+
+func NewDockerAPI() DockerAPI {
+	return &cliDockerAPI{bin: "docker"}
+}
+
+func (c *cliDockerAPI) run(ctx context.Context, args ...string) ([]byte, error) {
+	var stdout, stderr bytes.Buffer
+
+	cmd := exec.CommandContext(ctx, c.bin, args...)
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+
+	if err := cmd.Run(); err != nil {
+		msg := strings.TrimSpace(stderr.String())
+		if strings.Contains(msg, "No such container") || strings.Contains(msg, "No such object") {
+			return nil, ErrContainerNotFound
+		}
+
+		return nil, fmt.Errorf("docker %s: %s: %w", args[0], msg, err)
+	}
+
+	return stdout.Bytes(), nil
+}
+
+func (c *cliDockerAPI) ContainerRun(ctx context.Context, opts ContainerRunOptions) (string, error) {
+	args := []string{"run", "--detach", "--name", opts.Name}
+
+	for _, k := range sortedKeys(opts.Labels) {
+		args = append(args, "--label", k+"="+opts.Labels[k])
+	}
+
+	for _, e := range opts.Env {
+		args = append(args, "--env", e)
+	}
+
+	for _, p := range opts.Ports {
+		args = append(args, "--publish", strconv.Itoa(p))
+	}
+
+	for _, v := range opts.Volumes {
+		args = append(args, "--volume", v.Name+":"+v.MountPath)
+	}
+
+	if opts.HealthCmd != "" {
+		args = append(args, "--health-cmd", opts.HealthCmd, "--health-interval", "2s")
+	}
+
+	if opts.GPUs > 0 {
+		args = append(args, "--gpus", strconv.Itoa(opts.GPUs))
+	}
+
+	if opts.CPUs > 0 {
+		args = append(args, "--cpus", strconv.Itoa(opts.CPUs))
+	}
+
+	if opts.MemoryGB > 0 {
+		args = append(args, "--memory", strconv.Itoa(opts.MemoryGB)+"g")
+	}
+
+	if opts.Entrypoint != "" {
+		args = append(args, "--entrypoint", opts.Entrypoint)
+	}
+
+	args = append(args, opts.Image)
+	args = append(args, opts.Command...)
+
+	out, err := c.run(ctx, args...)
+	if err != nil {
+		return "", err
+	}
+
+	return strings.TrimSpace(string(out)), nil
+}
+
+type inspectOutput struct {
+	ID    string `json:"Id"`
+	State struct {
+		Status   string `json:"Status"`
+		ExitCode int    `json:"ExitCode"`
+		Health   *struct {
+			Status string `json:"Status"`
+		} `json:"Health"`
+	} `json:"State"`
+	Config struct {
+		Labels map[string]string `json:"Labels"`
+	} `json:"Config"`
+	NetworkSettings struct {
+		Ports map[string][]struct {
+			HostPort string `json:"HostPort"`
+		} `json:"Ports"`
+	} `json:"NetworkSettings"`
+}
+
+func (c *cliDockerAPI) ContainerInspect(ctx context.Context, name string) (Container, error) {
+	out, err := c.run(ctx, "container", "inspect", "--format", "{{json .}}", name)
+	if err != nil {
+		return Container{}, err
+	}
+
+	var res inspectOutput
+	if err = json.Unmarshal(out, &res); err != nil {
+		return Container{}, fmt.Errorf("decode inspect output: %w", err)
+	}
+
+	container := Container{
+		ID:       res.ID,
+		State:    res.State.Status,
+		ExitCode: res.State.ExitCode,
+		Labels:   res.Config.Labels,
+		Ports:    make(map[int]int),
+	}
+
+	if res.State.Health != nil {
+		container.Health = res.State.Health.Status
+	}
+
+	for port, bindings := range res.NetworkSettings.Ports {
+		if len(bindings) == 0 {
+			continue
+		}
+
+		containerPort, err := strconv.Atoi(strings.TrimSuffix(port, "/tcp"))
+		if err != nil {
+			continue
+		}
+
+		hostPort, err := strconv.Atoi(bindings[0].HostPort)
+		if err != nil {
+			continue
+		}
+
+		container.Ports[containerPort] = hostPort
+	}
+
+	return container, nil
+}
+
+func (c *cliDockerAPI) ContainerRemove(ctx context.Context, name string) error {
+	_, err := c.run(ctx, "rm", "--force", name)
+
+	return err
+}
+
+func (c *cliDockerAPI) ContainerStats(ctx context.Context, name string) (ContainerStats, error) {
+	out, err := c.run(ctx, "stats", "--no-stream", "--format", "{{json .}}", name)
+	if err != nil {
+		return ContainerStats{}, err
+	}
+
+	var res struct {
+		CPUPerc string `json:"CPUPerc"`
+		MemPerc string `json:"MemPerc"`
+	}
+
+	if err = json.Unmarshal(out, &res); err != nil {
+		return ContainerStats{}, fmt.Errorf("decode stats output: %w", err)
+	}
+
+	return ContainerStats{
+		CPUPercent: parsePercent(res.CPUPerc),
+		MemPercent: parsePercent(res.MemPerc),
+	}, nil
+}
+
+func (c *cliDockerAPI) VolumeCreate(ctx context.Context, name string, labels map[string]string) error {
+	args := []string{"volume", "create"}
+
+	for _, k := range sortedKeys(labels) {
+		args = append(args, "--label", k+"="+labels[k])
+	}
+
+	_, err := c.run(ctx, append(args, name)...)
+
+	return err
+}
+
+func (c *cliDockerAPI) VolumeRemove(ctx context.Context, name string) error {
+	_, err := c.run(ctx, "volume", "rm", name)
+
+	return err
+}
+
+func (c *cliDockerAPI) Ping(ctx context.Context) error {
+	_, err := c.run(ctx, "info", "--format", "{{json .ServerVersion}}")
+
+	return err
+}
+
+func parsePercent(s string) float64 {
+	v, err := strconv.ParseFloat(strings.TrimSuffix(strings.TrimSpace(s), "%"), 64)
+	if err != nil {
+		return 0
+	}
+
+	return v
+}
+
+func sortedKeys(m map[string]string) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+
+	sort.Strings(keys)
+
+	return keys
+}
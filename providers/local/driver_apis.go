@@ -0,0 +1,62 @@
+package local
+
+import (
+	"context"
+	"errors"
+)
+
+//go:generate go run github.com/maxbrunsfeld/counterfeiter/v6 -generate
+//counterfeiter:generate  . DockerAPI
+
+var ErrContainerNotFound = errors.New("container not found")
+
+// ContainerRunOptions are the options used to start a detached container.
+type ContainerRunOptions struct {
+	Name       string
+	Image      string
+	Entrypoint string
+	Command    []string
+	Env        []string
+	Labels     map[string]string
+	// Ports are container ports published on random host ports.
+	Ports   []int
+	Volumes []VolumeMount
+	// HealthCmd is run inside the container to determine if it is ready.
+	HealthCmd string
+	GPUs      int
+	CPUs      int
+	MemoryGB  int
+}
+
+type VolumeMount struct {
+	Name      string
+	MountPath string
+}
+
+// Container is the inspected state of a container.
+type Container struct {
+	ID     string
+	State  string
+	Health string
+	// ExitCode is the exit code of the main process once the container exited.
+	ExitCode int
+	Labels   map[string]string
+	// Ports maps container ports to the host ports they are published on.
+	Ports map[int]int
+}
+
+type ContainerStats struct {
+	CPUPercent float64
+	MemPercent float64
+}
+
+// DockerAPI is the subset of the Docker daemon API used by the local drivers.
+type DockerAPI interface {
+	ContainerRun(ctx context.Context, opts ContainerRunOptions) (string, error)
+	ContainerInspect(ctx context.Context, name string) (Container, error)
+	ContainerRemove(ctx context.Context, name string) error
+	ContainerStats(ctx context.Context, name string) (ContainerStats, error)
+	VolumeCreate(ctx context.Context, name string, labels map[string]string) error
+	VolumeRemove(ctx context.Context, name string) error
+	Ping(ctx context.Context) error
+}
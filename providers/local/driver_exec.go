@@ -0,0 +1,307 @@
+package local
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/rs/zerolog/log"
+	"github.com/unweave/unweave-v1/api/types"
+	"github.com/unweave/unweave-v1/services/execsrv"
+	"github.com/unweave/unweave-v1/tools/random"
+)
+
+const (
+	sshPort = 22
+
+	labelExec    = "unweave.io/exec"
+	labelProject = "unweave.io/project"
+	labelSpec    = "unweave.io/spec"
+	// labelCommand is set on containers running the exec's command instead of sshd.
+	labelCommand = "unweave.io/command"
+)
+
+var errStopStartUnsupported = &types.Error{
+	Code:       http.StatusNotImplemented,
+	Message:    "Stopping and starting sessions is not supported by the local provider",
+	Suggestion: "Terminate the session instead or use a provider that supports stopping",
+	Provider:   types.LocalProvider,
+}
+
+// sshEntrypoint installs sshd if the image doesn't ship with it and authorizes the exec's
+// public keys. It then runs its arguments as the exec's command with sshd in the
+// background, or sshd in the foreground to keep the container alive if there are none.
+const sshEntrypoint = `set -e
+if ! command -v sshd >/dev/null 2>&1; then
+  apt-get update -qq && DEBIAN_FRONTEND=noninteractive apt-get install -y -qq openssh-server
+fi
+mkdir -p /run/sshd /root/.ssh
+printf '%s\n' "$UNWEAVE_SSH_KEYS" > /root/.ssh/authorized_keys
+chmod 700 /root/.ssh && chmod 600 /root/.ssh/authorized_keys
+if [ "$#" -gt 0 ]; then
+  "$(command -v sshd)" -e
+  exec "$@"
+fi
+exec "$(command -v sshd)" -D -e`
+
+// ExecStore looks up whether an exec is a job.
+type ExecStore interface {
+	Get(id string) (types.Exec, error)
+}
+
+// ExecDriver runs execs as containers on the local Docker daemon. Each container runs
+// sshd so that execs can be connected to the same way as on cloud providers, as its main
+// process unless the exec has a command.
+type ExecDriver struct {
+	docker DockerAPI
+	host   string
+	store  ExecStore
+}
+
+// NewExecDriver returns a driver that creates containers through the given DockerAPI.
+// Host is the address clients should use to reach the published container ports. The
+// store is optional, without it no exec is known to be a job.
+func NewExecDriver(docker DockerAPI, host string, store ExecStore) *ExecDriver {
+	if host == "" {
+		host = "localhost"
+	}
+
+	return &ExecDriver{
+		docker: docker,
+		host:   host,
+		store:  store,
+	}
+}
+
+func (d *ExecDriver) ExecCreate(
+	ctx context.Context,
+	project string,
+	image string,
+	command []string,
+	spec types.HardwareSpec,
+	network types.ExecNetwork,
+	volumes []types.ExecVolume,
+	pubKeys []string,
+	_ *string,
+) (string, error) {
+	if len(pubKeys) == 0 {
+		return "", fmt.Errorf("no ssh keys provided")
+	}
+
+	execID, err := newExecID()
+	if err != nil {
+		return "", fmt.Errorf("generate exec ID: %w", err)
+	}
+
+	specJSON, err := json.Marshal(spec)
+	if err != nil {
+		return "", fmt.Errorf("marshal spec: %w", err)
+	}
+
+	ports := []int{sshPort}
+	if network.HTTPService != nil {
+		ports = append(ports, int(network.HTTPService.InternalPort))
+	}
+
+	mounts := make([]VolumeMount, len(volumes))
+	for idx, v := range volumes {
+		mounts[idx] = VolumeMount{Name: v.VolumeID, MountPath: v.MountPath}
+	}
+
+	opts := ContainerRunOptions{
+		Name:       execID,
+		Image:      image,
+		Entrypoint: "/bin/sh",
+		Command:    append([]string{"-c", sshEntrypoint, "sh"}, command...),
+		Env:        []string{"UNWEAVE_SSH_KEYS=" + strings.Join(pubKeys, "\n")},
+		Labels: map[string]string{
+			labelExec:    execID,
+			labelProject: project,
+			labelSpec:    string(specJSON),
+		},
+		Ports:     ports,
+		Volumes:   mounts,
+		HealthCmd: "test -f /run/sshd.pid",
+		GPUs:      spec.GPU.Count.Min,
+		CPUs:      spec.CPU.Min,
+		MemoryGB:  spec.RAM.Min,
+	}
+
+	if len(command) > 0 {
+		opts.Labels[labelCommand] = "true"
+	}
+
+	if _, err = d.docker.ContainerRun(ctx, opts); err != nil {
+		return "", fmt.Errorf("failed to start container: %w", err)
+	}
+
+	return execID, nil
+}
+
+func (d *ExecDriver) ExecDriverName() string {
+	return "local"
+}
+
+func (d *ExecDriver) ExecGetStatus(ctx context.Context, execID string) (types.Status, error) {
+	container, err := d.docker.ContainerInspect(ctx, execID)
+	if err != nil {
+		if errors.Is(err, ErrContainerNotFound) {
+			return types.StatusTerminated, nil
+		}
+
+		return types.StatusUnknown, fmt.Errorf("failed to inspect container: %w", err)
+	}
+
+	return containerStatus(container, d.isJob(execID)), nil
+}
+
+// isJob returns whether the exec runs its command to completion, see types.Exec.Job.
+func (d *ExecDriver) isJob(execID string) bool {
+	if d.store == nil {
+		return false
+	}
+
+	exec, err := d.store.Get(execID)
+
+	return err == nil && exec.Job
+}
+
+// containerStatus returns the status of the exec's container. Only jobs succeed or fail,
+// other execs whose container exited are terminated.
+func containerStatus(container Container, job bool) types.Status {
+	switch container.State {
+	case "created", "restarting":
+		return types.StatusInitializing
+	case "running":
+		switch container.Health {
+		case "starting":
+			return types.StatusInitializing
+		case "unhealthy":
+			return types.StatusError
+		default:
+			return types.StatusRunning
+		}
+	case "exited":
+		if !job {
+			return types.StatusTerminated
+		}
+
+		if container.ExitCode == 0 {
+			return types.StatusSuccess
+		}
+
+		return types.StatusFailed
+	case "paused":
+		return types.StatusStopped
+	case "removing":
+		return types.StatusTerminated
+	case "dead":
+		return types.StatusError
+	default:
+		return types.StatusUnknown
+	}
+}
+
+// ExecExitCode returns the exit code of the exec's command once its container exited.
+func (d *ExecDriver) ExecExitCode(ctx context.Context, execID string) (int, error) {
+	container, err := d.docker.ContainerInspect(ctx, execID)
+	if err != nil {
+		return 0, fmt.Errorf("failed to inspect container: %w", err)
+	}
+
+	if container.State != "exited" {
+		return 0, fmt.Errorf("container of exec %s hasn't exited", execID)
+	}
+
+	return container.ExitCode, nil
+}
+
+func (d *ExecDriver) ExecProvider() types.Provider {
+	return types.LocalProvider
+}
+
+func (d *ExecDriver) ExecTerminate(ctx context.Context, execID string) error {
+	err := d.docker.ContainerRemove(ctx, execID)
+	if err != nil && !errors.Is(err, ErrContainerNotFound) {
+		return fmt.Errorf("failed to remove container: %w", err)
+	}
+
+	return nil
+}
+
+// ExecStop always fails, local execs can only be terminated.
+func (d *ExecDriver) ExecStop(_ context.Context, _ string) error {
+	return errStopStartUnsupported
+}
+
+// ExecStart always fails, local execs can only be terminated.
+func (d *ExecDriver) ExecStart(_ context.Context, _ string) error {
+	return errStopStartUnsupported
+}
+
+func (d *ExecDriver) ExecSpec(ctx context.Context, execID string) (types.HardwareSpec, error) {
+	container, err := d.docker.ContainerInspect(ctx, execID)
+	if err != nil {
+		return types.HardwareSpec{}, fmt.Errorf("failed to inspect container: %w", err)
+	}
+
+	spec, err := types.HardwareSpecFromJSON([]byte(container.Labels[labelSpec]))
+	if err != nil {
+		return types.HardwareSpec{}, fmt.Errorf("parse spec label: %w", err)
+	}
+
+	return *spec, nil
+}
+
+func (d *ExecDriver) ExecStats(ctx context.Context, execID string) (execsrv.Stats, error) {
+	stats, err := d.docker.ContainerStats(ctx, execID)
+	if err != nil {
+		return execsrv.Stats{}, fmt.Errorf("failed to get container stats: %w", err)
+	}
+
+	return execsrv.Stats{
+		CPU: stats.CPUPercent,
+		Mem: stats.MemPercent,
+	}, nil
+}
+
+// ExecPing checks that the Docker daemon is reachable.
+func (d *ExecDriver) ExecPing(ctx context.Context, _ *string) error {
+	if err := d.docker.Ping(ctx); err != nil {
+		return fmt.Errorf("failed to ping docker daemon: %w", err)
+	}
+
+	return nil
+}
+
+func (d *ExecDriver) ExecConnectionInfo(ctx context.Context, execID string) (types.ConnectionInfo, error) {
+	container, err := d.docker.ContainerInspect(ctx, execID)
+	if err != nil {
+		return types.ConnectionInfo{}, fmt.Errorf("connection info: %w", err)
+	}
+
+	port, ok := container.Ports[sshPort]
+	if !ok {
+		log.Ctx(ctx).Warn().Str(types.ExecIDCtxKey, execID).Msg("SSH port not published for container")
+
+		return types.ConnectionInfo{}, fmt.Errorf("ssh port not published for exec %s", execID)
+	}
+
+	return types.ConnectionInfo{
+		Host: d.host,
+		Port: port,
+		User: "root",
+	}, nil
+}
+
+func newExecID() (string, error) {
+	str, err := random.GenerateRandomString(11)
+	if err != nil {
+		return "", fmt.Errorf("failed to generate random string, %w", err)
+	}
+
+	return "exc_" + strings.ToLower(str), nil
+}
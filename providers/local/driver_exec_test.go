@@ -0,0 +1,138 @@
+package local_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/unweave/unweave-v1/api/types"
+	"github.com/unweave/unweave-v1/providers/local"
+	"github.com/unweave/unweave-v1/providers/local/localfakes"
+)
+
+func TestExecDriver_ExecCreate(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	docker := &localfakes.FakeDockerAPI{}
+	driver := local.NewExecDriver(docker, "", nil)
+
+	spec := types.HardwareSpec{CPU: types.CPU{HardwareRequestRange: types.HardwareRequestRange{Min: 2}}}
+	network := types.ExecNetwork{HTTPService: &types.HTTPService{InternalPort: 8080}}
+	volumes := []types.ExecVolume{{VolumeID: "uwv_abc", MountPath: "/data"}}
+
+	execID, err := driver.ExecCreate(ctx, "prj_1", "ubuntu:latest", nil, spec, network, volumes, []string{"ssh-rsa AAA"}, nil)
+	require.NoError(t, err)
+	require.Equal(t, 1, docker.ContainerRunCallCount())
+
+	_, opts := docker.ContainerRunArgsForCall(0)
+	require.Equal(t, execID, opts.Name)
+	require.Equal(t, "ubuntu:latest", opts.Image)
+	require.Equal(t, []int{22, 8080}, opts.Ports)
+	require.Equal(t, []local.VolumeMount{{Name: "uwv_abc", MountPath: "/data"}}, opts.Volumes)
+	require.Equal(t, []string{"UNWEAVE_SSH_KEYS=ssh-rsa AAA"}, opts.Env)
+	require.Equal(t, execID, opts.Labels["unweave.io/exec"])
+	require.Equal(t, "prj_1", opts.Labels["unweave.io/project"])
+	require.Equal(t, 2, opts.CPUs)
+	require.Empty(t, opts.Labels["unweave.io/command"])
+
+	_, err = driver.ExecCreate(ctx, "prj_1", "ubuntu:latest", []string{"python", "train.py"}, spec, types.ExecNetwork{}, nil, []string{"ssh-rsa AAA"}, nil)
+	require.NoError(t, err)
+
+	_, opts = docker.ContainerRunArgsForCall(1)
+	require.Equal(t, []string{"python", "train.py"}, opts.Command[len(opts.Command)-2:], "should pass the command to the entrypoint")
+	require.Equal(t, "true", opts.Labels["unweave.io/command"])
+
+	_, err = driver.ExecCreate(ctx, "prj_1", "ubuntu:latest", nil, spec, types.ExecNetwork{}, nil, nil, nil)
+	require.Error(t, err, "ssh keys are required")
+}
+
+func TestExecDriver_ExecGetStatus(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		name      string
+		container local.Container
+		job       bool
+		err       error
+		want      types.Status
+	}{
+		{name: "created", container: local.Container{State: "created"}, want: types.StatusInitializing},
+		{name: "sshd starting", container: local.Container{State: "running", Health: "starting"}, want: types.StatusInitializing},
+		{name: "healthy", container: local.Container{State: "running", Health: "healthy"}, want: types.StatusRunning},
+		{name: "unhealthy", container: local.Container{State: "running", Health: "unhealthy"}, want: types.StatusError},
+		{name: "exited", container: local.Container{State: "exited"}, want: types.StatusTerminated},
+		{name: "command exited", container: commandContainer(2), want: types.StatusTerminated},
+		{name: "job succeeded", container: commandContainer(0), job: true, want: types.StatusSuccess},
+		{name: "job failed", container: commandContainer(2), job: true, want: types.StatusFailed},
+		{name: "paused", container: local.Container{State: "paused"}, want: types.StatusStopped},
+		{name: "dead", container: local.Container{State: "dead"}, want: types.StatusError},
+		{name: "removed", err: local.ErrContainerNotFound, want: types.StatusTerminated},
+	}
+
+	for _, tc := range cases {
+		tc := tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			docker := &localfakes.FakeDockerAPI{}
+			docker.ContainerInspectReturns(tc.container, tc.err)
+
+			store := execStore{"exc_123": types.Exec{ID: "exc_123", Job: tc.job}}
+
+			status, err := local.NewExecDriver(docker, "", store).ExecGetStatus(context.Background(), "exc_123")
+			require.NoError(t, err)
+			require.Equal(t, tc.want, status)
+		})
+	}
+}
+
+type execStore map[string]types.Exec
+
+func (s execStore) Get(id string) (types.Exec, error) {
+	exec, ok := s[id]
+	if !ok {
+		return types.Exec{}, errors.New("not found")
+	}
+
+	return exec, nil
+}
+
+func commandContainer(exitCode int) local.Container {
+	return local.Container{
+		State:    "exited",
+		ExitCode: exitCode,
+		Labels:   map[string]string{"unweave.io/command": "true"},
+	}
+}
+
+func TestExecDriver_ExecExitCode(t *testing.T) {
+	t.Parallel()
+
+	docker := &localfakes.FakeDockerAPI{}
+	driver := local.NewExecDriver(docker, "", nil)
+
+	docker.ContainerInspectReturns(local.Container{State: "running"}, nil)
+
+	_, err := driver.ExecExitCode(context.Background(), "exc_123")
+	require.Error(t, err, "should fail until the container exits")
+
+	docker.ContainerInspectReturns(commandContainer(3), nil)
+
+	code, err := driver.ExecExitCode(context.Background(), "exc_123")
+	require.NoError(t, err)
+	require.Equal(t, 3, code)
+}
+
+func TestExecDriver_ExecConnectionInfo(t *testing.T) {
+	t.Parallel()
+
+	docker := &localfakes.FakeDockerAPI{}
+	docker.ContainerInspectReturns(local.Container{State: "running", Ports: map[int]int{22: 49153}}, nil)
+
+	info, err := local.NewExecDriver(docker, "", nil).ExecConnectionInfo(context.Background(), "exc_123")
+	require.NoError(t, err)
+	require.Equal(t, types.ConnectionInfo{Host: "localhost", Port: 49153, User: "root"}, info)
+}
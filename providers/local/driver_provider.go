@@ -0,0 +1,45 @@
+package local
+
+import (
+	"context"
+	"runtime"
+
+	"github.com/unweave/unweave-v1/api/types"
+)
+
+const localRegion = "local"
+
+// ProviderDriver lists the local machine as the only available node type.
+type ProviderDriver struct{}
+
+func NewProviderDriver() *ProviderDriver {
+	return &ProviderDriver{}
+}
+
+func (p *ProviderDriver) Provider() types.Provider {
+	return types.LocalProvider
+}
+
+func (p *ProviderDriver) ProviderListNodeTypes(_ context.Context, _ string, _ bool) ([]types.NodeType, error) {
+	name := "Local Docker daemon"
+	price := 0
+
+	return []types.NodeType{
+		{
+			Type:     "CPU",
+			ID:       "local",
+			Name:     &name,
+			Price:    &price,
+			Regions:  []string{localRegion},
+			Provider: types.LocalProvider,
+			Specs: types.HardwareSpec{
+				CPU: types.CPU{
+					HardwareRequestRange: types.HardwareRequestRange{
+						Min: runtime.NumCPU(),
+						Max: runtime.NumCPU(),
+					},
+				},
+			},
+		},
+	}, nil
+}
@@ -0,0 +1,52 @@
+package local
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/rs/zerolog/log"
+	"github.com/unweave/unweave-v1/api/types"
+	"github.com/unweave/unweave-v1/tools/random"
+)
+
+// VolumeDriver manages volumes as Docker named volumes. Named volumes on the default
+// local driver aren't size limited so sizes are only recorded, not enforced.
+type VolumeDriver struct {
+	docker DockerAPI
+}
+
+func NewVolumeDriver(docker DockerAPI) *VolumeDriver {
+	return &VolumeDriver{docker: docker}
+}
+
+func (v *VolumeDriver) VolumeCreate(ctx context.Context, projectID, name string, _ int) (string, error) {
+	id := "uwv_" + random.GenerateRandomLower(12)
+
+	labels := map[string]string{
+		"unweave.io/name":    name,
+		"unweave.io/project": projectID,
+	}
+
+	if err := v.docker.VolumeCreate(ctx, id, labels); err != nil {
+		return "", fmt.Errorf("failed to create volume: %w", err)
+	}
+
+	return id, nil
+}
+
+func (v *VolumeDriver) VolumeDelete(ctx context.Context, id string) error {
+	if err := v.docker.VolumeRemove(ctx, id); err != nil {
+		return fmt.Errorf("failed to delete volume: %w", err)
+	}
+
+	return nil
+}
+
+func (v *VolumeDriver) VolumeResize(ctx context.Context, id string, size int) error {
+	log.Ctx(ctx).Debug().Msgf("Ignoring resize of local volume %s to %dGB, local volumes are not size limited", id, size)
+
+	return nil
+}
+
+func (v *VolumeDriver) VolumeProvider() types.Provider        { return types.LocalProvider }
+func (v *VolumeDriver) VolumeDriver(_ context.Context) string { return "local" }
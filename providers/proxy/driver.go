@@ -0,0 +1,530 @@
+// Package proxy implements an endpoint driver that serves endpoints from an in-process HTTP
+// reverse proxy. It's meant for self-hosted deployments where the API can reach the nodes
+// of execs directly, e.g. the local provider or a private network.
+package proxy
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math/rand"
+	"net"
+	"net/http"
+	"net/http/httputil"
+	"net/url"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/rs/zerolog/log"
+	"github.com/unweave/unweave-v1/api/types"
+	"github.com/unweave/unweave-v1/db"
+)
+
+// ExecStore looks up where the exec of a version is listening.
+type ExecStore interface {
+	Get(id string) (types.Exec, error)
+}
+
+// RouteStore lists the endpoints, their versions and traffic policies to route on startup.
+type RouteStore interface {
+	EndpointList(ctx context.Context) ([]db.UnweaveEndpoint, error)
+	EndpointRoutes(ctx context.Context) ([]db.EndpointRoutesRow, error)
+	EndpointTrafficList(ctx context.Context) ([]db.UnweaveEndpointTraffic, error)
+}
+
+// maxMirrorBody is the largest request body that's buffered to be mirrored. Requests with
+// larger bodies are only proxied.
+const maxMirrorBody = 1 << 20
+
+// target is the exec a version routes to. A zero port is read from the exec.
+type target struct {
+	versionID string
+	execID    string
+	port      int32
+
+	// upstream caches the resolved address of the exec. It's reset when the target is
+	// promoted or its endpoint's traffic changes, and when proxying to it fails since the
+	// exec's host changes if it's restarted.
+	upstream atomic.Pointer[url.URL]
+}
+
+// route is served on a host. The traffic of an endpoint's route is replaced on every
+// promotion or change of policy, the traffic of a version's route never changes.
+type route struct {
+	traffic atomic.Pointer[traffic]
+}
+
+// traffic is a compiled types.EndpointTrafficPolicy.
+type traffic struct {
+	headers []headerRoute
+	// weights are cumulative, a request is routed to the first weight above a random
+	// number below 100.
+	weights       []weighted
+	mirror        *target
+	mirrorPercent int
+}
+
+type headerRoute struct {
+	header string
+	value  string
+	target *target
+}
+
+type weighted struct {
+	upTo   int
+	target *target
+}
+
+func single(t *target) *traffic {
+	return &traffic{weights: []weighted{{upTo: 100, target: t}}}
+}
+
+func (t *traffic) pick(r *http.Request) *target {
+	for _, h := range t.headers {
+		if r.Header.Get(h.header) == h.value {
+			return h.target
+		}
+	}
+
+	n := rand.Intn(100) //nolint:gosec
+	for _, w := range t.weights {
+		if n < w.upTo {
+			return w.target
+		}
+	}
+
+	return t.weights[len(t.weights)-1].target
+}
+
+// reset clears the cached upstreams of the targets of the traffic.
+func (t *traffic) reset() {
+	for _, h := range t.headers {
+		h.target.upstream.Store(nil)
+	}
+
+	for _, w := range t.weights {
+		w.target.upstream.Store(nil)
+	}
+
+	if t.mirror != nil {
+		t.mirror.upstream.Store(nil)
+	}
+}
+
+func (t *traffic) mirrored() *target {
+	if t.mirror == nil || rand.Intn(100) >= t.mirrorPercent { //nolint:gosec
+		return nil
+	}
+
+	return t.mirror
+}
+
+// EndpointDriver is an endpointsrv.Driver that routes the hosts of endpoints and their
+// versions to the execs serving them. Endpoints are served on <subdomain>.<domain> and
+// versions on <versionID>.<domain>. The driver is an http.Handler serving the proxy.
+type EndpointDriver struct {
+	domain string
+	execs  ExecStore
+	mirror *http.Client
+
+	mu        sync.RWMutex
+	hosts     map[string]*route
+	endpoints map[string]*route
+	versions  map[string]*target
+}
+
+func NewEndpointDriver(domain string, execs ExecStore) *EndpointDriver {
+	return &EndpointDriver{
+		domain:    strings.ToLower(strings.Trim(domain, ".")),
+		execs:     execs,
+		mirror:    &http.Client{Timeout: 30 * time.Second},
+		hosts:     make(map[string]*route),
+		endpoints: make(map[string]*route),
+		versions:  make(map[string]*target),
+	}
+}
+
+// Load rebuilds the routes of the endpoints and versions in the store, e.g. after a
+// restart. Endpoints without versions keep their host so it isn't taken by another.
+func (d *EndpointDriver) Load(ctx context.Context, store RouteStore) error {
+	endpoints, err := store.EndpointList(ctx)
+	if err != nil {
+		return fmt.Errorf("list endpoints: %w", err)
+	}
+
+	rows, err := store.EndpointRoutes(ctx)
+	if err != nil {
+		return fmt.Errorf("list endpoint routes: %w", err)
+	}
+
+	policies, err := store.EndpointTrafficList(ctx)
+	if err != nil {
+		return fmt.Errorf("list endpoint traffic: %w", err)
+	}
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	for _, row := range endpoints {
+		if _, ok := d.endpoints[row.ID]; ok {
+			continue
+		}
+
+		endpoint := &route{}
+		d.endpoints[row.ID] = endpoint
+		d.hosts[row.HttpAddress] = endpoint
+	}
+
+	for _, row := range rows {
+		endpoint, ok := d.endpoints[row.EndpointID]
+		if !ok {
+			continue
+		}
+
+		t := &target{versionID: row.ID, execID: row.ExecID}
+		d.addVersion(row.HttpAddress, t)
+
+		if row.PrimaryVersion {
+			endpoint.traffic.Store(single(t))
+		}
+	}
+
+	for _, row := range policies {
+		endpoint, ok := d.endpoints[row.EndpointID]
+		if !ok {
+			continue
+		}
+
+		var policy types.EndpointTrafficPolicy
+		if err := json.Unmarshal(row.Policy, &policy); err != nil {
+			return fmt.Errorf("unmarshal traffic policy of %s: %w", row.EndpointID, err)
+		}
+
+		tr, err := d.compile(policy)
+		if err != nil {
+			log.Warn().Err(err).Str(types.EndpointIDCtxKey, row.EndpointID).Msg("Skipping endpoint traffic policy")
+			continue
+		}
+
+		endpoint.traffic.Store(tr)
+	}
+
+	log.Info().Msgf("Loaded %d endpoint version routes and %d traffic policies", len(rows), len(policies))
+
+	return nil
+}
+
+func (d *EndpointDriver) EndpointDriverName() string {
+	return "proxy"
+}
+
+func (d *EndpointDriver) EndpointProvider() types.Provider {
+	return types.UnweaveProvider
+}
+
+func (d *EndpointDriver) EndpointCreate(_ context.Context, _, endpointID, subdomain string) (string, error) {
+	host := d.host(subdomain)
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	if _, ok := d.hosts[host]; ok {
+		return "", &types.Error{
+			Code:       http.StatusConflict,
+			Message:    fmt.Sprintf("Host %q is already routed", host),
+			Suggestion: "Use another endpoint name",
+			Provider:   types.UnweaveProvider,
+		}
+	}
+
+	endpoint := &route{}
+	d.endpoints[endpointID] = endpoint
+	d.hosts[host] = endpoint
+
+	return host, nil
+}
+
+func (d *EndpointDriver) EndpointVersionCreate(
+	_ context.Context,
+	_, _, versionID, execID string,
+	internalPort int32,
+) (string, error) {
+	host := d.host(versionID)
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	d.addVersion(host, &target{versionID: versionID, execID: execID, port: internalPort})
+
+	return host, nil
+}
+
+// EndpointVersionDelete stops routing the version's host. Versions that aren't routed are
+// ignored.
+func (d *EndpointDriver) EndpointVersionDelete(_ context.Context, _, versionID string) error {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	delete(d.versions, versionID)
+	delete(d.hosts, d.host(versionID))
+
+	return nil
+}
+
+// EndpointVersionPromote switches the endpoint's host to the version. Requests already
+// being proxied finish on the previous version.
+func (d *EndpointDriver) EndpointVersionPromote(_ context.Context, endpointID, versionID string, _ int32) error {
+	d.mu.RLock()
+	endpoint, ok := d.endpoints[endpointID]
+	t, vok := d.versions[versionID]
+	d.mu.RUnlock()
+
+	if !ok || !vok {
+		return &types.Error{
+			Code:     http.StatusNotFound,
+			Message:  fmt.Sprintf("Endpoint %q or version %q isn't routed", endpointID, versionID),
+			Provider: types.UnweaveProvider,
+		}
+	}
+
+	tr := single(t)
+	tr.reset()
+	endpoint.traffic.Store(tr)
+
+	return nil
+}
+
+// EndpointTrafficSet replaces the traffic of the endpoint's host with the policy.
+func (d *EndpointDriver) EndpointTrafficSet(_ context.Context, endpointID string, policy types.EndpointTrafficPolicy) error {
+	d.mu.RLock()
+	endpoint, ok := d.endpoints[endpointID]
+	tr, err := d.compile(policy)
+	d.mu.RUnlock()
+
+	if !ok {
+		return &types.Error{
+			Code:     http.StatusNotFound,
+			Message:  fmt.Sprintf("Endpoint %q isn't routed", endpointID),
+			Provider: types.UnweaveProvider,
+		}
+	}
+
+	if err != nil {
+		return err
+	}
+
+	tr.reset()
+	endpoint.traffic.Store(tr)
+
+	return nil
+}
+
+// ServeHTTP proxies the request to the exec routed on its host.
+func (d *EndpointDriver) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	host := strings.ToLower(r.Host)
+	if h, _, err := net.SplitHostPort(host); err == nil {
+		host = h
+	}
+
+	d.mu.RLock()
+	rt, ok := d.hosts[host]
+	d.mu.RUnlock()
+
+	if !ok {
+		http.Error(w, "endpoint not found", http.StatusNotFound)
+		return
+	}
+
+	tr := rt.traffic.Load()
+	if tr == nil {
+		http.Error(w, "endpoint has no primary version", http.StatusServiceUnavailable)
+		return
+	}
+
+	if m := tr.mirrored(); m != nil {
+		if err := d.mirrorRequest(r, m); err != nil {
+			http.Error(w, "failed to read request body", http.StatusBadRequest)
+			return
+		}
+	}
+
+	t := tr.pick(r)
+
+	upstream, err := d.upstream(t)
+	if err != nil {
+		log.Ctx(r.Context()).Warn().Err(err).Str(types.VersionIDCtxKey, t.versionID).Msg("Failed to resolve endpoint upstream")
+		http.Error(w, "endpoint version unavailable", http.StatusBadGateway)
+		return
+	}
+
+	proxy := httputil.NewSingleHostReverseProxy(upstream)
+	proxy.ErrorHandler = func(w http.ResponseWriter, r *http.Request, err error) {
+		t.upstream.CompareAndSwap(upstream, nil)
+
+		log.Ctx(r.Context()).Warn().Err(err).Str(types.VersionIDCtxKey, t.versionID).Msg("Failed to proxy to endpoint upstream")
+		w.WriteHeader(http.StatusBadGateway)
+	}
+	proxy.ServeHTTP(w, r)
+}
+
+// mirrorRequest sends a copy of the request to the target in the background and discards
+// the response. The request's body is buffered so it can be sent twice, requests with
+// bodies larger than maxMirrorBody aren't mirrored.
+func (d *EndpointDriver) mirrorRequest(r *http.Request, t *target) error {
+	if r.ContentLength > maxMirrorBody {
+		log.Ctx(r.Context()).Debug().Str(types.VersionIDCtxKey, t.versionID).Msg("Skipping mirror of large request")
+		return nil
+	}
+
+	body, err := io.ReadAll(io.LimitReader(r.Body, maxMirrorBody+1))
+	if err != nil {
+		return err
+	}
+
+	if len(body) > maxMirrorBody {
+		r.Body = readCloser{Reader: io.MultiReader(bytes.NewReader(body), r.Body), Closer: r.Body}
+		log.Ctx(r.Context()).Debug().Str(types.VersionIDCtxKey, t.versionID).Msg("Skipping mirror of large request")
+		return nil
+	}
+	r.Body = io.NopCloser(bytes.NewReader(body))
+
+	upstream, err := d.upstream(t)
+	if err != nil {
+		log.Ctx(r.Context()).Debug().Err(err).Str(types.VersionIDCtxKey, t.versionID).Msg("Skipping mirror")
+		return nil
+	}
+
+	//nolint:contextcheck
+	req := r.Clone(context.Background())
+	req.RequestURI = ""
+	req.URL.Scheme = upstream.Scheme
+	req.URL.Host = upstream.Host
+	req.Body = io.NopCloser(bytes.NewReader(body))
+
+	go func() {
+		res, err := d.mirror.Do(req)
+		if err != nil {
+			log.Debug().Err(err).Str(types.VersionIDCtxKey, t.versionID).Msg("Mirrored request failed")
+			return
+		}
+
+		_, _ = io.Copy(io.Discard, res.Body)
+		res.Body.Close()
+	}()
+
+	return nil
+}
+
+type readCloser struct {
+	io.Reader
+	io.Closer
+}
+
+// upstream resolves the address of the version's exec. The address is cached on the
+// target until it's reset.
+func (d *EndpointDriver) upstream(t *target) (*url.URL, error) {
+	if u := t.upstream.Load(); u != nil {
+		return u, nil
+	}
+
+	exec, err := d.execs.Get(t.execID)
+	if err != nil {
+		return nil, fmt.Errorf("get exec: %w", err)
+	}
+
+	if exec.Network.Host == "" {
+		return nil, fmt.Errorf("exec %s has no host yet", exec.ID)
+	}
+
+	port := t.port
+	if port == 0 && exec.Network.HTTPService != nil {
+		port = exec.Network.HTTPService.InternalPort
+	}
+
+	if port == 0 {
+		return nil, fmt.Errorf("exec %s doesn't expose an http service", exec.ID)
+	}
+
+	u := &url.URL{Scheme: "http", Host: net.JoinHostPort(exec.Network.Host, fmt.Sprint(port))}
+	t.upstream.Store(u)
+
+	return u, nil
+}
+
+// compile resolves the versions of the policy. It must be called with the lock held.
+func (d *EndpointDriver) compile(policy types.EndpointTrafficPolicy) (*traffic, error) {
+	lookup := func(versionID string) (*target, error) {
+		t, ok := d.versions[versionID]
+		if !ok {
+			return nil, &types.Error{
+				Code:     http.StatusNotFound,
+				Message:  fmt.Sprintf("Version %q isn't routed", versionID),
+				Provider: types.UnweaveProvider,
+			}
+		}
+
+		return t, nil
+	}
+
+	tr := &traffic{}
+
+	for _, h := range policy.Headers {
+		t, err := lookup(h.VersionID)
+		if err != nil {
+			return nil, err
+		}
+
+		tr.headers = append(tr.headers, headerRoute{header: h.Header, value: h.Value, target: t})
+	}
+
+	upTo := 0
+	for _, w := range policy.Weights {
+		t, err := lookup(w.VersionID)
+		if err != nil {
+			return nil, err
+		}
+
+		upTo += w.Weight
+		tr.weights = append(tr.weights, weighted{upTo: upTo, target: t})
+	}
+
+	if len(tr.weights) == 0 {
+		return nil, &types.Error{
+			Code:     http.StatusBadRequest,
+			Message:  "Traffic policy has no weights",
+			Provider: types.UnweaveProvider,
+		}
+	}
+
+	if policy.Mirror != nil {
+		t, err := lookup(policy.Mirror.VersionID)
+		if err != nil {
+			return nil, err
+		}
+
+		tr.mirror = t
+		tr.mirrorPercent = policy.Mirror.Percent
+	}
+
+	return tr, nil
+}
+
+// addVersion must be called with the lock held.
+func (d *EndpointDriver) addVersion(host string, t *target) {
+	version := &route{}
+	version.traffic.Store(single(t))
+
+	d.versions[t.versionID] = t
+	d.hosts[host] = version
+}
+
+// host returns the host of the label under the driver's domain. IDs contain underscores,
+// which aren't valid in hostnames.
+func (d *EndpointDriver) host(label string) string {
+	label = strings.ToLower(strings.ReplaceAll(label, "_", "-"))
+	return label + "." + d.domain
+}
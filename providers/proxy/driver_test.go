@@ -0,0 +1,398 @@
+package proxy_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"github.com/unweave/unweave-v1/api/types"
+	"github.com/unweave/unweave-v1/db"
+	"github.com/unweave/unweave-v1/providers/proxy"
+)
+
+type execStore map[string]types.Exec
+
+func (s execStore) Get(id string) (types.Exec, error) {
+	exec, ok := s[id]
+	if !ok {
+		return types.Exec{}, errors.New("not found")
+	}
+	return exec, nil
+}
+
+// countingStore counts the lookups of execs. Its execs can be replaced while serving.
+type countingStore struct {
+	mu    sync.Mutex
+	execs execStore
+	gets  int
+}
+
+func (s *countingStore) Get(id string) (types.Exec, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.gets++
+	return s.execs.Get(id)
+}
+
+func (s *countingStore) set(exec types.Exec) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.execs[exec.ID] = exec
+}
+
+func (s *countingStore) count() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.gets
+}
+
+type routeStore struct {
+	endpoints []db.UnweaveEndpoint
+	routes    []db.EndpointRoutesRow
+	traffic   []db.UnweaveEndpointTraffic
+}
+
+func (s routeStore) EndpointList(context.Context) ([]db.UnweaveEndpoint, error) {
+	return s.endpoints, nil
+}
+
+func (s routeStore) EndpointRoutes(context.Context) ([]db.EndpointRoutesRow, error) {
+	return s.routes, nil
+}
+
+func (s routeStore) EndpointTrafficList(context.Context) ([]db.UnweaveEndpointTraffic, error) {
+	return s.traffic, nil
+}
+
+// backend starts a server replying with its name and returns the exec serving it.
+func backend(t *testing.T, id, name string) types.Exec {
+	t.Helper()
+
+	return backendFunc(t, id, func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, name)
+	})
+}
+
+func backendFunc(t *testing.T, id string, handler http.HandlerFunc) types.Exec {
+	t.Helper()
+
+	srv := httptest.NewServer(handler)
+	t.Cleanup(srv.Close)
+
+	host, port, err := net.SplitHostPort(srv.Listener.Addr().String())
+	require.NoError(t, err)
+
+	p, err := strconv.Atoi(port)
+	require.NoError(t, err)
+
+	return types.Exec{
+		ID: id,
+		Network: types.ExecNetwork{
+			Host:        host,
+			HTTPService: &types.HTTPService{InternalPort: int32(p)},
+		},
+	}
+}
+
+func get(t *testing.T, h http.Handler, host string) (int, string) {
+	t.Helper()
+
+	return getWithHeader(t, h, host, "", "")
+}
+
+func getWithHeader(t *testing.T, h http.Handler, host, header, value string) (int, string) {
+	t.Helper()
+
+	req := httptest.NewRequest(http.MethodGet, "http://"+host+"/predict", nil)
+	if header != "" {
+		req.Header.Set(header, value)
+	}
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, req)
+
+	body, err := io.ReadAll(rec.Body)
+	require.NoError(t, err)
+
+	return rec.Code, string(body)
+}
+
+func TestEndpointDriver(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	v1, v2 := backend(t, "exc_1", "v1"), backend(t, "exc_2", "v2")
+	driver := proxy.NewEndpointDriver("endpoints.test", execStore{v1.ID: v1, v2.ID: v2})
+
+	host, err := driver.EndpointCreate(ctx, "prj_1", "end_1", "Predict")
+	require.NoError(t, err)
+	require.Equal(t, "predict.endpoints.test", host)
+
+	_, err = driver.EndpointCreate(ctx, "prj_1", "end_2", "predict")
+
+	var e *types.Error
+	require.True(t, errors.As(err, &e))
+	require.Equal(t, http.StatusConflict, e.Code)
+
+	code, _ := get(t, driver, host)
+	require.Equal(t, http.StatusServiceUnavailable, code, "should not route before a promotion")
+
+	v1Host, err := driver.EndpointVersionCreate(ctx, "prj_1", "end_1", "ver_1", v1.ID, v1.Network.HTTPService.InternalPort)
+	require.NoError(t, err)
+	require.Equal(t, "ver-1.endpoints.test", v1Host)
+
+	v2Host, err := driver.EndpointVersionCreate(ctx, "prj_1", "end_1", "ver_2", v2.ID, v2.Network.HTTPService.InternalPort)
+	require.NoError(t, err)
+
+	_, body := get(t, driver, v2Host)
+	require.Equal(t, "v2", body)
+
+	require.NoError(t, driver.EndpointVersionPromote(ctx, "end_1", "ver_1", 0))
+	_, body = get(t, driver, host+":8080")
+	require.Equal(t, "v1", body)
+
+	require.NoError(t, driver.EndpointVersionPromote(ctx, "end_1", "ver_2", 0))
+	_, body = get(t, driver, host)
+	require.Equal(t, "v2", body, "should switch to the promoted version")
+
+	err = driver.EndpointVersionPromote(ctx, "end_1", "ver_3", 0)
+	require.True(t, errors.As(err, &e))
+	require.Equal(t, http.StatusNotFound, e.Code)
+
+	v3 := backend(t, "exc_3", "v3")
+	v3Host, err := driver.EndpointVersionCreate(ctx, "prj_1", "end_1", "ver_3", v3.ID, 0)
+	require.NoError(t, err)
+	require.NoError(t, driver.EndpointVersionDelete(ctx, "end_1", "ver_3"))
+
+	code, _ = get(t, driver, v3Host)
+	require.Equal(t, http.StatusNotFound, code, "should not route deleted versions")
+
+	err = driver.EndpointVersionPromote(ctx, "end_1", "ver_3", 0)
+	require.True(t, errors.As(err, &e))
+	require.Equal(t, http.StatusNotFound, e.Code)
+
+	code, _ = get(t, driver, "unknown.endpoints.test")
+	require.Equal(t, http.StatusNotFound, code)
+}
+
+func TestEndpointDriver_Load(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	v1, v2 := backend(t, "exc_1", "v1"), backend(t, "exc_2", "v2")
+	driver := proxy.NewEndpointDriver("endpoints.test", execStore{v1.ID: v1, v2.ID: v2})
+
+	routes := routeStore{
+		endpoints: []db.UnweaveEndpoint{
+			{ID: "end_1", HttpAddress: "predict.endpoints.test"},
+			{ID: "end_2", HttpAddress: "empty.endpoints.test"},
+		},
+		routes: []db.EndpointRoutesRow{
+			{ID: "ver_1", EndpointID: "end_1", ExecID: v1.ID, HttpAddress: "ver-1.endpoints.test", EndpointHttpAddress: "predict.endpoints.test"},
+			{ID: "ver_2", EndpointID: "end_1", ExecID: v2.ID, HttpAddress: "ver-2.endpoints.test", PrimaryVersion: true, EndpointHttpAddress: "predict.endpoints.test"},
+		},
+	}
+	require.NoError(t, driver.Load(ctx, routes))
+
+	_, body := get(t, driver, "predict.endpoints.test")
+	require.Equal(t, "v2", body, "should route the endpoint to its primary version")
+
+	_, err := driver.EndpointCreate(ctx, "pr_1", "end_3", "empty")
+	require.Error(t, err, "endpoints without versions should keep their host")
+
+	routes.traffic = []db.UnweaveEndpointTraffic{
+		{EndpointID: "end_1", Policy: []byte(`{"weights":[{"versionID":"ver_1","weight":100}]}`)},
+	}
+	require.NoError(t, driver.Load(ctx, routes))
+
+	_, body = get(t, driver, "predict.endpoints.test")
+	require.Equal(t, "v1", body, "should apply the stored traffic policy")
+
+	require.NoError(t, driver.EndpointVersionPromote(ctx, "end_1", "ver_2", 0))
+	_, body = get(t, driver, "predict.endpoints.test")
+	require.Equal(t, "v2", body)
+
+	_, body = get(t, driver, "ver-1.endpoints.test")
+	require.Equal(t, "v1", body)
+
+	require.NoError(t, driver.EndpointVersionPromote(ctx, "end_1", "ver_1", 0))
+	_, body = get(t, driver, "predict.endpoints.test")
+	require.Equal(t, "v1", body)
+}
+
+func TestEndpointDriver_Traffic(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	mirrored := make(chan string, 10)
+
+	v1, v2 := backend(t, "exc_1", "v1"), backend(t, "exc_2", "v2")
+	v3 := backendFunc(t, "exc_3", func(w http.ResponseWriter, r *http.Request) {
+		mirrored <- r.URL.Path
+	})
+	driver := proxy.NewEndpointDriver("endpoints.test", execStore{v1.ID: v1, v2.ID: v2, v3.ID: v3})
+
+	host, err := driver.EndpointCreate(ctx, "prj_1", "end_1", "predict")
+	require.NoError(t, err)
+
+	for i, exec := range []types.Exec{v1, v2, v3} {
+		_, err := driver.EndpointVersionCreate(ctx, "prj_1", "end_1", fmt.Sprint("ver_", i+1), exec.ID, 0)
+		require.NoError(t, err)
+	}
+
+	err = driver.EndpointTrafficSet(ctx, "end_1", types.EndpointTrafficPolicy{
+		Weights: []types.EndpointTrafficWeight{{VersionID: "ver_1", Weight: 0}, {VersionID: "ver_2", Weight: 100}},
+		Headers: []types.EndpointHeaderRoute{{Header: "X-Version", Value: "1", VersionID: "ver_1"}},
+		Mirror:  &types.EndpointTrafficMirror{VersionID: "ver_3", Percent: 100},
+	})
+	require.NoError(t, err)
+
+	for i := 0; i < 5; i++ {
+		_, body := get(t, driver, host)
+		require.Equal(t, "v2", body, "should route by weight")
+		require.Equal(t, "/predict", <-mirrored, "should mirror the request")
+	}
+
+	_, body := getWithHeader(t, driver, host, "X-Version", "1")
+	require.Equal(t, "v1", body, "should route by header ahead of weights")
+
+	err = driver.EndpointTrafficSet(ctx, "end_1", types.EndpointTrafficPolicy{
+		Weights: []types.EndpointTrafficWeight{{VersionID: "ver_4", Weight: 100}},
+	})
+
+	var e *types.Error
+	require.True(t, errors.As(err, &e))
+	require.Equal(t, http.StatusNotFound, e.Code)
+}
+
+func TestEndpointDriver_UpstreamCache(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, "v1")
+	}))
+	t.Cleanup(srv.Close)
+
+	addr := srv.Listener.Addr().(*net.TCPAddr)
+	v1 := types.Exec{
+		ID: "exc_1",
+		Network: types.ExecNetwork{
+			Host:        addr.IP.String(),
+			HTTPService: &types.HTTPService{InternalPort: int32(addr.Port)},
+		},
+	}
+	v2 := backend(t, "exc_2", "v2")
+	store := &countingStore{execs: execStore{v1.ID: v1, v2.ID: v2}}
+	driver := proxy.NewEndpointDriver("endpoints.test", store)
+
+	host, err := driver.EndpointCreate(ctx, "prj_1", "end_1", "predict")
+	require.NoError(t, err)
+
+	for i, exec := range []types.Exec{v1, v2} {
+		_, err := driver.EndpointVersionCreate(ctx, "prj_1", "end_1", fmt.Sprint("ver_", i+1), exec.ID, 0)
+		require.NoError(t, err)
+	}
+
+	require.NoError(t, driver.EndpointVersionPromote(ctx, "end_1", "ver_1", 0))
+
+	for i := 0; i < 3; i++ {
+		_, body := get(t, driver, host)
+		require.Equal(t, "v1", body)
+	}
+	require.Equal(t, 1, store.count(), "should resolve the upstream once")
+
+	require.NoError(t, driver.EndpointTrafficSet(ctx, "end_1", types.EndpointTrafficPolicy{
+		Weights: []types.EndpointTrafficWeight{{VersionID: "ver_1", Weight: 100}},
+	}))
+	_, body := get(t, driver, host)
+	require.Equal(t, "v1", body)
+	require.Equal(t, 2, store.count(), "should resolve the upstream again after a traffic change")
+
+	require.NoError(t, driver.EndpointVersionPromote(ctx, "end_1", "ver_1", 0))
+	_, body = get(t, driver, host)
+	require.Equal(t, "v1", body)
+	require.Equal(t, 3, store.count(), "should resolve the upstream again after a promotion")
+
+	// Restart the exec on another port. The cached upstream fails once and is resolved again.
+	srv.Close()
+	store.set(backend(t, v1.ID, "v1 restarted"))
+
+	code, _ := get(t, driver, host)
+	require.Equal(t, http.StatusBadGateway, code)
+
+	_, body = get(t, driver, host)
+	require.Equal(t, "v1 restarted", body, "should resolve the upstream again after a failure")
+}
+
+func TestEndpointDriver_MirrorLimit(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	mirrored := make(chan int, 10)
+
+	v1 := backendFunc(t, "exc_1", func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		fmt.Fprint(w, len(body))
+	})
+	v2 := backendFunc(t, "exc_2", func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		mirrored <- len(body)
+	})
+	driver := proxy.NewEndpointDriver("endpoints.test", execStore{v1.ID: v1, v2.ID: v2})
+
+	host, err := driver.EndpointCreate(ctx, "prj_1", "end_1", "predict")
+	require.NoError(t, err)
+
+	for i, exec := range []types.Exec{v1, v2} {
+		_, err := driver.EndpointVersionCreate(ctx, "prj_1", "end_1", fmt.Sprint("ver_", i+1), exec.ID, 0)
+		require.NoError(t, err)
+	}
+
+	require.NoError(t, driver.EndpointTrafficSet(ctx, "end_1", types.EndpointTrafficPolicy{
+		Weights: []types.EndpointTrafficWeight{{VersionID: "ver_1", Weight: 100}},
+		Mirror:  &types.EndpointTrafficMirror{VersionID: "ver_2", Percent: 100},
+	}))
+
+	post := func(size int, chunked bool) string {
+		var body io.Reader = strings.NewReader(strings.Repeat("a", size))
+		if chunked {
+			body = io.MultiReader(body)
+		}
+
+		req := httptest.NewRequest(http.MethodPost, "http://"+host+"/predict", body)
+		if chunked {
+			req.ContentLength = -1
+		}
+		rec := httptest.NewRecorder()
+		driver.ServeHTTP(rec, req)
+
+		return rec.Body.String()
+	}
+
+	require.Equal(t, "10", post(10, false))
+	require.Equal(t, 10, <-mirrored, "should mirror small bodies")
+
+	for _, chunked := range []bool{false, true} {
+		size := 1<<20 + 1
+		require.Equal(t, fmt.Sprint(size), post(size, chunked), "should proxy the whole body")
+
+		select {
+		case n := <-mirrored:
+			t.Fatalf("should not mirror a body of %d bytes", n)
+		case <-time.After(100 * time.Millisecond):
+		}
+	}
+}
@@ -0,0 +1,169 @@
+package resilient
+
+import (
+	"fmt"
+	"net/http"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/unweave/unweave-v1/api/types"
+)
+
+// Breaker is a circuit breaker for the calls to a provider. It opens after
+// Policy.FailureThreshold consecutive failures and rejects calls until Policy.OpenTimeout
+// has passed. It then lets a single call through to probe the provider, closing again if
+// it succeeds and re-opening if it fails.
+type Breaker struct {
+	provider types.Provider
+	policy   Policy
+	now      func() time.Time
+
+	mu        sync.Mutex
+	state     types.BreakerState
+	failures  int
+	openedAt  time.Time
+	lastError string
+	probing   bool
+}
+
+func newBreaker(provider types.Provider, policy Policy) *Breaker {
+	return &Breaker{
+		provider: provider,
+		policy:   policy,
+		now:      time.Now,
+		state:    types.BreakerClosed,
+	}
+}
+
+// Allow returns a *types.Error if the breaker is open. Every allowed call must be followed
+// by one of Success, Failure or Release.
+func (b *Breaker) Allow() error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.state == types.BreakerOpen && b.now().Sub(b.openedAt) >= b.policy.OpenTimeout {
+		b.state = types.BreakerHalfOpen
+	}
+
+	switch {
+	case b.state == types.BreakerOpen, b.state == types.BreakerHalfOpen && b.probing:
+		return &types.Error{
+			Code:       http.StatusServiceUnavailable,
+			Message:    fmt.Sprintf("%s is unavailable after repeated failures", b.provider.DisplayName()),
+			Suggestion: "Try again in a minute or use another provider",
+			Provider:   b.provider,
+		}
+	case b.state == types.BreakerHalfOpen:
+		b.probing = true
+	}
+
+	return nil
+}
+
+// Success records that the provider handled a call and closes the breaker.
+func (b *Breaker) Success() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.state = types.BreakerClosed
+	b.failures = 0
+	b.probing = false
+}
+
+// Failure records a call the provider failed to handle and opens the breaker once there
+// were too many in a row.
+func (b *Breaker) Failure(err error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.failures++
+	b.lastError = err.Error()
+
+	if b.state == types.BreakerHalfOpen || b.failures >= b.policy.FailureThreshold {
+		b.state = types.BreakerOpen
+		b.openedAt = b.now()
+	}
+
+	b.probing = false
+}
+
+// Release lets another call probe the provider without recording an outcome.
+func (b *Breaker) Release() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.probing = false
+}
+
+func (b *Breaker) Health() types.ProviderHealth {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	health := types.ProviderHealth{
+		Provider:            b.provider,
+		State:               b.state,
+		ConsecutiveFailures: b.failures,
+		LastError:           b.lastError,
+	}
+
+	if b.state != types.BreakerClosed {
+		openedAt := b.openedAt
+		health.OpenedAt = &openedAt
+	}
+
+	return health
+}
+
+// Breakers holds the breaker of every provider. The exec, volume and endpoint drivers of
+// a provider share its breaker.
+type Breakers struct {
+	policy Policy
+
+	mu       sync.Mutex
+	breakers map[types.Provider]*Breaker
+}
+
+func NewBreakers(policy Policy) *Breakers {
+	return &Breakers{policy: policy, breakers: make(map[types.Provider]*Breaker)}
+}
+
+// Get returns the breaker of the provider, creating it if needed.
+func (b *Breakers) Get(provider types.Provider) *Breaker {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	breaker, ok := b.breakers[provider]
+	if !ok {
+		breaker = newBreaker(provider, b.policy)
+		b.breakers[provider] = breaker
+	}
+
+	return breaker
+}
+
+// Health reports the state of every breaker, sorted by provider.
+func (b *Breakers) Health() types.HealthResponse {
+	b.mu.Lock()
+	breakers := make([]*Breaker, 0, len(b.breakers))
+	for _, breaker := range b.breakers {
+		breakers = append(breakers, breaker)
+	}
+	b.mu.Unlock()
+
+	res := types.HealthResponse{Status: types.HealthOK, Providers: []types.ProviderHealth{}}
+
+	for _, breaker := range breakers {
+		health := breaker.Health()
+		if health.State != types.BreakerClosed {
+			res.Status = types.HealthDegraded
+		}
+		res.Providers = append(res.Providers, health)
+	}
+
+	sort.Slice(res.Providers, func(i, j int) bool {
+		return res.Providers[i].Provider < res.Providers[j].Provider
+	})
+
+	return res
+}
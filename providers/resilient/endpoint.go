@@ -0,0 +1,76 @@
+package resilient
+
+import (
+	"context"
+
+	"github.com/unweave/unweave-v1/api/types"
+	"github.com/unweave/unweave-v1/services/endpointsrv"
+)
+
+// EndpointDriver retries the calls to an endpointsrv.Driver and guards them with the
+// breaker of its provider.
+type EndpointDriver struct {
+	driver  endpointsrv.Driver
+	breaker *Breaker
+	policy  Policy
+}
+
+var _ endpointsrv.Driver = (*EndpointDriver)(nil)
+
+func NewEndpointDriver(driver endpointsrv.Driver, breakers *Breakers) *EndpointDriver {
+	return &EndpointDriver{
+		driver:  driver,
+		breaker: breakers.Get(driver.EndpointProvider()),
+		policy:  breakers.policy,
+	}
+}
+
+func (d *EndpointDriver) EndpointDriverName() string {
+	return d.driver.EndpointDriverName()
+}
+
+func (d *EndpointDriver) EndpointProvider() types.Provider {
+	return d.driver.EndpointProvider()
+}
+
+func (d *EndpointDriver) EndpointCreate(ctx context.Context, project, endpointID, subdomain string) (string, error) {
+	return call(ctx, d.breaker, d.policy, false, func() (string, error) {
+		return d.driver.EndpointCreate(ctx, project, endpointID, subdomain)
+	})
+}
+
+func (d *EndpointDriver) EndpointVersionCreate(
+	ctx context.Context,
+	project, endpointID, versionID, execID string,
+	internalPort int32,
+) (string, error) {
+	return call(ctx, d.breaker, d.policy, false, func() (string, error) {
+		return d.driver.EndpointVersionCreate(ctx, project, endpointID, versionID, execID, internalPort)
+	})
+}
+
+func (d *EndpointDriver) EndpointVersionDelete(ctx context.Context, endpointID, versionID string) error {
+	return do(ctx, d.breaker, d.policy, true, func() error {
+		return d.driver.EndpointVersionDelete(ctx, endpointID, versionID)
+	})
+}
+
+func (d *EndpointDriver) EndpointVersionPromote(
+	ctx context.Context,
+	endpointID, versionID string,
+	internalPort int32,
+) error {
+	return do(ctx, d.breaker, d.policy, true, func() error {
+		return d.driver.EndpointVersionPromote(ctx, endpointID, versionID, internalPort)
+	})
+}
+
+func (d *EndpointDriver) EndpointTrafficSet(
+	ctx context.Context,
+	endpointID string,
+	policy types.EndpointTrafficPolicy,
+) error {
+	return do(ctx, d.breaker, d.policy, true, func() error {
+		return d.driver.EndpointTrafficSet(ctx, endpointID, policy)
+	})
+}
@@ -0,0 +1,116 @@
+package resilient
+
+import (
+	"context"
+
+	"github.com/unweave/unweave-v1/api/types"
+	"github.com/unweave/unweave-v1/services/execsrv"
+)
+
+// ExecDriver retries the calls to an execsrv.Driver and guards them with the breaker of
+// its provider.
+type ExecDriver struct {
+	driver  execsrv.Driver
+	breaker *Breaker
+	policy  Policy
+}
+
+var _ execsrv.Driver = (*ExecDriver)(nil)
+
+// NewExecDriver wraps the driver. The returned driver implements execsrv.ExitCodeDriver
+// if the wrapped one does.
+func NewExecDriver(driver execsrv.Driver, breakers *Breakers) execsrv.Driver {
+	d := &ExecDriver{
+		driver:  driver,
+		breaker: breakers.Get(driver.ExecProvider()),
+		policy:  breakers.policy,
+	}
+
+	if exitCodes, ok := driver.(execsrv.ExitCodeDriver); ok {
+		return &exitCodeExecDriver{ExecDriver: d, exitCodes: exitCodes}
+	}
+
+	return d
+}
+
+func (d *ExecDriver) ExecCreate(
+	ctx context.Context,
+	project, image string,
+	command []string,
+	spec types.HardwareSpec,
+	network types.ExecNetwork,
+	volumes []types.ExecVolume,
+	pubKeys []string,
+	region *string,
+) (string, error) {
+	return call(ctx, d.breaker, d.policy, false, func() (string, error) {
+		return d.driver.ExecCreate(ctx, project, image, command, spec, network, volumes, pubKeys, region)
+	})
+}
+
+func (d *ExecDriver) ExecDriverName() string {
+	return d.driver.ExecDriverName()
+}
+
+func (d *ExecDriver) ExecGetStatus(ctx context.Context, execID string) (types.Status, error) {
+	return call(ctx, d.breaker, d.policy, true, func() (types.Status, error) {
+		return d.driver.ExecGetStatus(ctx, execID)
+	})
+}
+
+func (d *ExecDriver) ExecProvider() types.Provider {
+	return d.driver.ExecProvider()
+}
+
+func (d *ExecDriver) ExecTerminate(ctx context.Context, id string) error {
+	return do(ctx, d.breaker, d.policy, true, func() error {
+		return d.driver.ExecTerminate(ctx, id)
+	})
+}
+
+func (d *ExecDriver) ExecStop(ctx context.Context, id string) error {
+	return do(ctx, d.breaker, d.policy, true, func() error {
+		return d.driver.ExecStop(ctx, id)
+	})
+}
+
+func (d *ExecDriver) ExecStart(ctx context.Context, id string) error {
+	return do(ctx, d.breaker, d.policy, true, func() error {
+		return d.driver.ExecStart(ctx, id)
+	})
+}
+
+func (d *ExecDriver) ExecSpec(ctx context.Context, id string) (types.HardwareSpec, error) {
+	return call(ctx, d.breaker, d.policy, true, func() (types.HardwareSpec, error) {
+		return d.driver.ExecSpec(ctx, id)
+	})
+}
+
+func (d *ExecDriver) ExecStats(ctx context.Context, id string) (execsrv.Stats, error) {
+	return call(ctx, d.breaker, d.policy, true, func() (execsrv.Stats, error) {
+		return d.driver.ExecStats(ctx, id)
+	})
+}
+
+func (d *ExecDriver) ExecPing(ctx context.Context, accountID *string) error {
+	return do(ctx, d.breaker, d.policy, true, func() error {
+		return d.driver.ExecPing(ctx, accountID)
+	})
+}
+
+func (d *ExecDriver) ExecConnectionInfo(ctx context.Context, execID string) (types.ConnectionInfo, error) {
+	return call(ctx, d.breaker, d.policy, true, func() (types.ConnectionInfo, error) {
+		return d.driver.ExecConnectionInfo(ctx, execID)
+	})
+}
+
+type exitCodeExecDriver struct {
+	*ExecDriver
+	exitCodes execsrv.ExitCodeDriver
+}
+
+func (d *exitCodeExecDriver) ExecExitCode(ctx context.Context, execID string) (int, error) {
+	return call(ctx, d.breaker, d.policy, true, func() (int, error) {
+		return d.exitCodes.ExecExitCode(ctx, execID)
+	})
+}
@@ -0,0 +1,177 @@
+package resilient_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"github.com/unweave/unweave-v1/api/types"
+	"github.com/unweave/unweave-v1/providers/resilient"
+	"github.com/unweave/unweave-v1/services/execsrv"
+)
+
+var testPolicy = resilient.Policy{
+	MaxAttempts:      3,
+	BaseDelay:        time.Millisecond,
+	MaxDelay:         time.Millisecond,
+	FailureThreshold: 4,
+	OpenTimeout:      20 * time.Millisecond,
+}
+
+// execDriver fails every call with the queued errors before succeeding.
+type execDriver struct {
+	execsrv.Driver
+
+	errs  []error
+	calls int
+}
+
+func (d *execDriver) next() error {
+	d.calls++
+	if len(d.errs) == 0 {
+		return nil
+	}
+
+	err := d.errs[0]
+	d.errs = d.errs[1:]
+
+	return err
+}
+
+func (d *execDriver) ExecProvider() types.Provider { return types.LambdaLabsProvider }
+
+func (d *execDriver) ExecGetStatus(context.Context, string) (types.Status, error) {
+	if err := d.next(); err != nil {
+		return "", err
+	}
+	return types.StatusRunning, nil
+}
+
+func (d *execDriver) ExecCreate(
+	context.Context, string, string, []string, types.HardwareSpec, types.ExecNetwork, []types.ExecVolume, []string, *string,
+) (string, error) {
+	return "exc_1", d.next()
+}
+
+type exitCodeDriver struct {
+	*execDriver
+}
+
+func (d exitCodeDriver) ExecExitCode(context.Context, string) (int, error) {
+	return 7, d.next()
+}
+
+func errCode(code int) error {
+	return &types.Error{Code: code, Message: http.StatusText(code), Provider: types.LambdaLabsProvider}
+}
+
+func TestExecDriver_Retries(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+
+	inner := &execDriver{errs: []error{errCode(http.StatusServiceUnavailable), errCode(http.StatusTooManyRequests)}}
+	driver := resilient.NewExecDriver(inner, resilient.NewBreakers(testPolicy))
+
+	status, err := driver.ExecGetStatus(ctx, "exc_1")
+	require.NoError(t, err)
+	require.Equal(t, types.StatusRunning, status)
+	require.Equal(t, 3, inner.calls, "should retry rate limits and server errors")
+
+	inner = &execDriver{errs: []error{errCode(http.StatusNotFound)}}
+	driver = resilient.NewExecDriver(inner, resilient.NewBreakers(testPolicy))
+
+	_, err = driver.ExecGetStatus(ctx, "exc_1")
+	require.Error(t, err)
+	require.Equal(t, 1, inner.calls, "should not retry client errors")
+
+	timeout := &net.OpError{Op: "dial", Net: "tcp", Err: os.ErrDeadlineExceeded}
+	inner = &execDriver{errs: []error{
+		fmt.Errorf("get status: %w", timeout),
+		context.DeadlineExceeded,
+		errors.New("connection reset by peer"),
+	}}
+	driver = resilient.NewExecDriver(inner, resilient.NewBreakers(testPolicy))
+
+	_, err = driver.ExecGetStatus(ctx, "exc_1")
+	require.Error(t, err)
+	require.Equal(t, 3, inner.calls, "should retry transport errors")
+
+	cancelled, cancel := context.WithCancel(ctx)
+	cancel()
+
+	breakers := resilient.NewBreakers(testPolicy)
+	inner = &execDriver{errs: []error{context.Canceled}}
+	driver = resilient.NewExecDriver(inner, breakers)
+
+	_, err = driver.ExecGetStatus(cancelled, "exc_1")
+	require.Error(t, err)
+	require.Equal(t, 1, inner.calls, "should not retry calls the caller gave up on")
+	require.Zero(t, breakers.Health().Providers[0].ConsecutiveFailures)
+
+	inner = &execDriver{errs: []error{errCode(http.StatusServiceUnavailable)}}
+	driver = resilient.NewExecDriver(inner, resilient.NewBreakers(testPolicy))
+
+	_, err = driver.ExecCreate(ctx, "pr_1", "ubuntu", nil, types.HardwareSpec{}, types.ExecNetwork{}, nil, nil, nil)
+	require.Error(t, err)
+	require.Equal(t, 1, inner.calls, "should not retry creating execs")
+
+	_, ok := driver.(execsrv.ExitCodeDriver)
+	require.False(t, ok)
+
+	driver = resilient.NewExecDriver(exitCodeDriver{&execDriver{}}, resilient.NewBreakers(testPolicy))
+	exitCodes, ok := driver.(execsrv.ExitCodeDriver)
+	require.True(t, ok, "should keep reporting exit codes")
+
+	code, err := exitCodes.ExecExitCode(ctx, "exc_1")
+	require.NoError(t, err)
+	require.Equal(t, 7, code)
+}
+
+func TestBreaker(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	breakers := resilient.NewBreakers(testPolicy)
+
+	unavailable := errCode(http.StatusInternalServerError)
+	inner := &execDriver{errs: []error{unavailable, unavailable, unavailable, unavailable}}
+	driver := resilient.NewExecDriver(inner, breakers)
+
+	_, err := driver.ExecGetStatus(ctx, "exc_1")
+	require.Error(t, err)
+	require.Equal(t, types.HealthOK, breakers.Health().Status, "should stay closed below the threshold")
+
+	_, err = driver.ExecGetStatus(ctx, "exc_1")
+	require.Error(t, err)
+	require.Equal(t, 4, inner.calls, "should stop retrying once the breaker opens")
+
+	health := breakers.Health()
+	require.Equal(t, types.HealthDegraded, health.Status)
+	require.Len(t, health.Providers, 1)
+	require.Equal(t, types.BreakerOpen, health.Providers[0].State)
+	require.NotNil(t, health.Providers[0].OpenedAt)
+
+	_, err = driver.ExecGetStatus(ctx, "exc_1")
+
+	var e *types.Error
+	require.True(t, errors.As(err, &e))
+	require.Equal(t, http.StatusServiceUnavailable, e.Code)
+	require.Equal(t, 4, inner.calls, "should reject calls while open")
+
+	time.Sleep(testPolicy.OpenTimeout)
+
+	_, err = driver.ExecGetStatus(ctx, "exc_1")
+	require.NoError(t, err, "should let a probe through after the timeout")
+
+	health = breakers.Health()
+	require.Equal(t, types.HealthOK, health.Status)
+	require.Equal(t, types.BreakerClosed, health.Providers[0].State)
+	require.Zero(t, health.Providers[0].ConsecutiveFailures)
+}
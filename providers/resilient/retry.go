@@ -0,0 +1,138 @@
+// Package resilient decorates provider drivers with retries and a circuit breaker per
+// provider.
+package resilient
+
+import (
+	"context"
+	"errors"
+	"math/rand"
+	"net/http"
+	"time"
+
+	"github.com/unweave/unweave-v1/api/types"
+)
+
+// Policy configures the retries and breakers of the drivers.
+type Policy struct {
+	// MaxAttempts is the number of times a call is tried, including the first.
+	MaxAttempts int
+	// BaseDelay is the backoff before the first retry. It doubles with every retry up to
+	// MaxDelay and is jittered.
+	BaseDelay time.Duration
+	MaxDelay  time.Duration
+	// FailureThreshold is the number of consecutive failures that open a breaker.
+	FailureThreshold int
+	// OpenTimeout is how long a breaker stays open before probing the provider again.
+	OpenTimeout time.Duration
+}
+
+var DefaultPolicy = Policy{
+	MaxAttempts:      3,
+	BaseDelay:        200 * time.Millisecond,
+	MaxDelay:         2 * time.Second,
+	FailureThreshold: 5,
+	OpenTimeout:      30 * time.Second,
+}
+
+// call runs op through the breaker. Retryable errors count as failures of the provider
+// and are retried if idempotent is set. Calls that aren't safe to retry, like creating
+// resources, are still rejected while the breaker is open but don't count as failures. A
+// provider out of capacity isn't unhealthy. Calls abandoned by the caller say nothing
+// about the provider and aren't recorded either.
+func call[T any](
+	ctx context.Context,
+	b *Breaker,
+	p Policy,
+	idempotent bool,
+	op func() (T, error),
+) (T, error) {
+	var (
+		res T
+		err error
+	)
+
+	for attempt := 1; ; attempt++ {
+		if allowErr := b.Allow(); allowErr != nil {
+			// Report the provider's error if the breaker opened while retrying.
+			if err == nil {
+				err = allowErr
+			}
+			return res, err
+		}
+
+		res, err = op()
+
+		switch {
+		case err == nil:
+			b.Success()
+			return res, err
+		case ctx.Err() != nil:
+			b.Release()
+			return res, err
+		case !retryable(err):
+			// The provider handled the call even if it returned an error.
+			b.Success()
+			return res, err
+		case !idempotent:
+			b.Release()
+			return res, err
+		}
+
+		b.Failure(err)
+
+		if attempt >= p.MaxAttempts {
+			return res, err
+		}
+
+		select {
+		case <-ctx.Done():
+			return res, err
+		case <-time.After(backoff(p, attempt)):
+		}
+	}
+}
+
+func do(ctx context.Context, b *Breaker, p Policy, idempotent bool, op func() error) error {
+	_, err := call(ctx, b, p, idempotent, func() (struct{}, error) {
+		return struct{}{}, op()
+	})
+	return err
+}
+
+// retryable returns false for errors the provider handled the call with, i.e. client
+// errors and calls it doesn't implement. Rate limits, server errors and errors without a
+// status, like timeouts and failed connections, are retryable. Errors from the AWS SDK
+// are classified by their HTTP status.
+func retryable(err error) bool {
+	code := 0
+
+	var e *types.Error
+	var httpErr interface{ HTTPStatusCode() int }
+
+	switch {
+	case errors.As(err, &e):
+		code = e.Code
+	case errors.As(err, &httpErr):
+		code = httpErr.HTTPStatusCode()
+	}
+
+	if code == 0 {
+		return true
+	}
+
+	return code == http.StatusTooManyRequests || code >= 500 && code != http.StatusNotImplemented
+}
+
+// backoff returns a random delay up to the exponential backoff of the attempt.
+func backoff(p Policy, attempt int) time.Duration {
+	max := p.BaseDelay << (attempt - 1)
+	if max > p.MaxDelay || max <= 0 {
+		max = p.MaxDelay
+	}
+
+	if max <= 0 {
+		return 0
+	}
+
+	return time.Duration(rand.Int63n(int64(max)))
+}
@@ -0,0 +1,52 @@
+package resilient
+
+import (
+	"context"
+
+	"github.com/unweave/unweave-v1/api/types"
+	"github.com/unweave/unweave-v1/services/volumesrv"
+)
+
+// VolumeDriver retries the calls to a volumesrv.Driver and guards them with the breaker
+// of its provider.
+type VolumeDriver struct {
+	driver  volumesrv.Driver
+	breaker *Breaker
+	policy  Policy
+}
+
+var _ volumesrv.Driver = (*VolumeDriver)(nil)
+
+func NewVolumeDriver(driver volumesrv.Driver, breakers *Breakers) *VolumeDriver {
+	return &VolumeDriver{
+		driver:  driver,
+		breaker: breakers.Get(driver.VolumeProvider()),
+		policy:  breakers.policy,
+	}
+}
+
+func (d *VolumeDriver) VolumeCreate(ctx context.Context, projectID, name string, size int) (string, error) {
+	return call(ctx, d.breaker, d.policy, false, func() (string, error) {
+		return d.driver.VolumeCreate(ctx, projectID, name, size)
+	})
+}
+
+func (d *VolumeDriver) VolumeDelete(ctx context.Context, id string) error {
+	return do(ctx, d.breaker, d.policy, true, func() error {
+		return d.driver.VolumeDelete(ctx, id)
+	})
+}
+
+func (d *VolumeDriver) VolumeProvider() types.Provider {
+	return d.driver.VolumeProvider()
+}
+
+func (d *VolumeDriver) VolumeDriver(ctx context.Context) string {
+	return d.driver.VolumeDriver(ctx)
+}
+
+func (d *VolumeDriver) VolumeResize(ctx context.Context, id string, size int) error {
+	return do(ctx, d.breaker, d.policy, true, func() error {
+		return d.driver.VolumeResize(ctx, id, size)
+	})
+}
@@ -0,0 +1,43 @@
+package costsrv
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/unweave/unweave-v1/api/types"
+	"github.com/unweave/unweave-v1/services/providersrv"
+	"github.com/unweave/unweave-v1/services/schedulersrv"
+)
+
+// Pricer looks up the hourly price of specs in the node types of a provider.
+type Pricer struct {
+	provider *providersrv.ProviderService
+}
+
+func NewPricer(provider *providersrv.ProviderService) *Pricer {
+	return &Pricer{provider: provider}
+}
+
+// HourlyPrice returns the price in cents per hour of the cheapest priced node type
+// matching the spec or nil if none of the matching node types have a price.
+func (p *Pricer) HourlyPrice(ctx context.Context, userID string, spec types.HardwareSpec) (*int, error) {
+	nodeTypes, err := p.provider.ListNodeTypes(ctx, userID, false)
+	if err != nil {
+		return nil, fmt.Errorf("list node types: %w", err)
+	}
+
+	var price *int
+
+	for _, nt := range nodeTypes {
+		if nt.Price == nil || !schedulersrv.Matches(nt, spec) {
+			continue
+		}
+
+		if price == nil || *nt.Price < *price {
+			v := *nt.Price
+			price = &v
+		}
+	}
+
+	return price, nil
+}
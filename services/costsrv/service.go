@@ -0,0 +1,306 @@
+package costsrv
+
+import (
+	"context"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/unweave/unweave-v1/api/types"
+)
+
+// CPUGPUType is the GPU type usage of execs without a GPU is reported under.
+const CPUGPUType = "cpu"
+
+type ExecStore interface {
+	ListInWindow(projectID string, from, to time.Time) ([]types.Exec, error)
+	ListEventsByExecs(execIDs []string) (map[string][]types.ExecEvent, error)
+}
+
+type Service struct {
+	store ExecStore
+}
+
+func NewService(store ExecStore) *Service {
+	return &Service{store: store}
+}
+
+// Usage returns the usage of the project's execs between from and to. Execs accrue cost
+// from creation until they exit except while stopped, running execs accrue cost until now.
+func (s *Service) Usage(ctx context.Context, projectID string, from, to time.Time) (types.UsageReport, error) {
+	execs, err := s.store.ListInWindow(projectID, from, to)
+	if err != nil {
+		return types.UsageReport{}, fmt.Errorf("list execs: %w", err)
+	}
+
+	ids := make([]string, len(execs))
+	for i, exec := range execs {
+		ids[i] = exec.ID
+	}
+
+	events, err := s.store.ListEventsByExecs(ids)
+	if err != nil {
+		return types.UsageReport{}, fmt.Errorf("list exec events: %w", err)
+	}
+
+	now := time.Now()
+	report := types.UsageReport{From: from, To: to, Sessions: []types.UsageSession{}}
+
+	var (
+		byUser     = newBreakdowns()
+		byProvider = newBreakdowns()
+		byGPUType  = newBreakdowns()
+	)
+
+	for _, exec := range execs {
+		session, ok := Accrued(exec, events[exec.ID], from, to, now)
+		if !ok {
+			continue
+		}
+
+		report.Sessions = append(report.Sessions, session)
+		report.Cost += session.Cost
+		report.Hours += session.Hours
+
+		byUser.add(session.CreatedBy, session)
+		byProvider.add(session.Provider.String(), session)
+		byGPUType.add(session.GPUType, session)
+	}
+
+	sort.Slice(report.Sessions, func(i, j int) bool {
+		return report.Sessions[i].Start.Before(report.Sessions[j].Start)
+	})
+
+	report.ByUser = byUser.list()
+	report.ByProvider = byProvider.list()
+	report.ByGPUType = byGPUType.list()
+
+	return report, nil
+}
+
+// Accrued returns the usage of the exec within [from, to]. The exec accrues cost while
+// it's alive and not stopped, the stops and starts are taken from its events. It returns
+// false if the exec didn't accrue any usage in that window.
+func Accrued(exec types.Exec, events []types.ExecEvent, from, to, now time.Time) (types.UsageSession, bool) {
+	end := accrualEnd(exec, events, now)
+
+	var (
+		start, last time.Time
+		hours       float64
+	)
+
+	for _, iv := range runningIntervals(exec.CreatedAt, end, events) {
+		if iv.start.Before(from) {
+			iv.start = from
+		}
+
+		if iv.end.After(to) {
+			iv.end = to
+		}
+
+		if !iv.end.After(iv.start) {
+			continue
+		}
+
+		if start.IsZero() {
+			start = iv.start
+		}
+
+		last = iv.end
+		hours += iv.end.Sub(iv.start).Hours()
+	}
+
+	if hours == 0 {
+		return types.UsageSession{}, false
+	}
+
+	gpuType := exec.Spec.GPU.Type
+	if gpuType == "" {
+		gpuType = CPUGPUType
+	}
+
+	session := types.UsageSession{
+		ExecID:    exec.ID,
+		Name:      exec.Name,
+		CreatedBy: exec.CreatedBy,
+		Provider:  exec.Provider,
+		GPUType:   gpuType,
+		Start:     start,
+		End:       last,
+		Hours:     hours,
+		Running:   exec.ExitedAt == nil && !exec.Status.IsTerminal() && exec.Status != types.StatusStopped,
+	}
+
+	if exec.HourlyPrice != nil {
+		price := float64(*exec.HourlyPrice) / 100
+		session.HourlyPrice = &price
+		session.Cost = price * session.Hours
+	}
+
+	return session, true
+}
+
+// accrualEnd returns when the exec stopped accruing cost for good. Execs that ended
+// without an exit time, e.g. ones that errored before it was recorded, stop at their
+// last terminal event, or their last event if there's none.
+func accrualEnd(exec types.Exec, events []types.ExecEvent, now time.Time) time.Time {
+	if exec.ExitedAt != nil {
+		return *exec.ExitedAt
+	}
+
+	if !exec.Status.IsTerminal() {
+		return now
+	}
+
+	var last, lastTerminal time.Time
+
+	for _, ev := range events {
+		if ev.CreatedAt.After(last) {
+			last = ev.CreatedAt
+		}
+		if ev.Status.IsTerminal() && ev.CreatedAt.After(lastTerminal) {
+			lastTerminal = ev.CreatedAt
+		}
+	}
+
+	switch {
+	case !lastTerminal.IsZero():
+		return lastTerminal
+	case !last.IsZero():
+		return last
+	default:
+		return exec.CreatedAt
+	}
+}
+
+func active(status types.Status) bool {
+	switch status {
+	case types.StatusPending, types.StatusInitializing, types.StatusRunning:
+		return true
+	default:
+		return false
+	}
+}
+
+type interval struct {
+	start, end time.Time
+}
+
+// runningIntervals splits [created, end] into the intervals the exec wasn't stopped in.
+// A stopped event closes the current interval and the next event of an active status opens
+// a new one.
+func runningIntervals(created, end time.Time, events []types.ExecEvent) []interval {
+	sorted := make([]types.ExecEvent, len(events))
+	copy(sorted, events)
+
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return sorted[i].CreatedAt.Before(sorted[j].CreatedAt)
+	})
+
+	var (
+		out     []interval
+		current = &interval{start: created}
+	)
+
+	for _, ev := range sorted {
+		if !ev.CreatedAt.Before(end) {
+			break
+		}
+
+		switch {
+		case ev.Status == types.StatusStopped && current != nil:
+			current.end = ev.CreatedAt
+			out = append(out, *current)
+			current = nil
+		case active(ev.Status) && current == nil:
+			current = &interval{start: ev.CreatedAt}
+		}
+	}
+
+	if current != nil {
+		current.end = end
+		out = append(out, *current)
+	}
+
+	return out
+}
+
+// WriteCSV writes a row per session of the report.
+func WriteCSV(w io.Writer, report types.UsageReport) error {
+	cw := csv.NewWriter(w)
+
+	header := []string{
+		"session_id", "name", "created_by", "provider", "gpu_type",
+		"start", "end", "hours", "hourly_price_usd", "cost_usd", "running",
+	}
+	if err := cw.Write(header); err != nil {
+		return fmt.Errorf("write header: %w", err)
+	}
+
+	for _, s := range report.Sessions {
+		price := ""
+		if s.HourlyPrice != nil {
+			price = strconv.FormatFloat(*s.HourlyPrice, 'f', 2, 64)
+		}
+
+		row := []string{
+			s.ExecID,
+			s.Name,
+			s.CreatedBy,
+			s.Provider.String(),
+			s.GPUType,
+			s.Start.Format(time.RFC3339),
+			s.End.Format(time.RFC3339),
+			strconv.FormatFloat(s.Hours, 'f', 4, 64),
+			price,
+			strconv.FormatFloat(s.Cost, 'f', 4, 64),
+			strconv.FormatBool(s.Running),
+		}
+		if err := cw.Write(row); err != nil {
+			return fmt.Errorf("write row: %w", err)
+		}
+	}
+
+	cw.Flush()
+
+	return cw.Error()
+}
+
+type breakdowns map[string]*types.UsageBreakdown
+
+func newBreakdowns() breakdowns {
+	return make(breakdowns)
+}
+
+func (b breakdowns) add(key string, session types.UsageSession) {
+	bd, ok := b[key]
+	if !ok {
+		bd = &types.UsageBreakdown{Key: key}
+		b[key] = bd
+	}
+
+	bd.Sessions++
+	bd.Hours += session.Hours
+	bd.Cost += session.Cost
+}
+
+// list returns the breakdowns by decreasing cost.
+func (b breakdowns) list() []types.UsageBreakdown {
+	out := make([]types.UsageBreakdown, 0, len(b))
+	for _, bd := range b {
+		out = append(out, *bd)
+	}
+
+	sort.Slice(out, func(i, j int) bool {
+		if out[i].Cost != out[j].Cost {
+			return out[i].Cost > out[j].Cost
+		}
+
+		return out[i].Key < out[j].Key
+	})
+
+	return out
+}
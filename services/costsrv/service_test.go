@@ -0,0 +1,198 @@
+package costsrv_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"github.com/unweave/unweave-v1/api/types"
+	"github.com/unweave/unweave-v1/services/costsrv"
+	"github.com/unweave/unweave-v1/services/providersrv"
+)
+
+type execStore []types.Exec
+
+func (s execStore) ListInWindow(string, time.Time, time.Time) ([]types.Exec, error) {
+	return s, nil
+}
+
+func (s execStore) ListEventsByExecs([]string) (map[string][]types.ExecEvent, error) {
+	return nil, nil
+}
+
+type providerDriver []types.NodeType
+
+func (d providerDriver) Provider() types.Provider { return types.LambdaLabsProvider }
+
+func (d providerDriver) ProviderListNodeTypes(context.Context, string, bool) ([]types.NodeType, error) {
+	return d, nil
+}
+
+func TestAccrued(t *testing.T) {
+	t.Parallel()
+
+	from := time.Date(2023, 7, 1, 0, 0, 0, 0, time.UTC)
+	to := from.AddDate(0, 1, 0)
+	now := from.Add(36 * time.Hour)
+	price := 110
+
+	exec := types.Exec{ID: "exc_1", CreatedAt: from.Add(-2 * time.Hour), HourlyPrice: &price}
+
+	session, ok := costsrv.Accrued(exec, nil, from, to, now)
+	require.True(t, ok)
+	require.True(t, session.Running)
+	require.Equal(t, from, session.Start, "should only count usage within the window")
+	require.InDelta(t, 36, session.Hours, 0.001)
+	require.InDelta(t, 39.6, session.Cost, 0.001)
+	require.Equal(t, costsrv.CPUGPUType, session.GPUType)
+
+	exitedAt := from.Add(-time.Hour)
+	exec.ExitedAt = &exitedAt
+
+	_, ok = costsrv.Accrued(exec, nil, from, to, now)
+	require.False(t, ok, "should skip execs that exited before the window")
+}
+
+func TestAccrued_StopStart(t *testing.T) {
+	t.Parallel()
+
+	from := time.Date(2023, 7, 1, 0, 0, 0, 0, time.UTC)
+	to := from.AddDate(0, 1, 0)
+	now := from.Add(24 * time.Hour)
+	price := 100
+
+	exec := types.Exec{ID: "exc_1", CreatedAt: from, HourlyPrice: &price, Status: types.StatusRunning}
+	events := []types.ExecEvent{
+		{Status: types.StatusRunning, CreatedAt: from.Add(time.Minute)},
+		{Status: types.StatusStopped, CreatedAt: from.Add(2 * time.Hour)},
+		{Status: types.StatusInitializing, CreatedAt: from.Add(10 * time.Hour)},
+		{Status: types.StatusRunning, CreatedAt: from.Add(10*time.Hour + time.Minute)},
+	}
+
+	session, ok := costsrv.Accrued(exec, events, from, to, now)
+	require.True(t, ok)
+	require.True(t, session.Running)
+	require.InDelta(t, 2+14, session.Hours, 0.001, "should not accrue while stopped")
+	require.InDelta(t, 16, session.Cost, 0.001)
+	require.Equal(t, now, session.End)
+
+	exec.Status = types.StatusStopped
+	events = append(events, types.ExecEvent{Status: types.StatusStopped, CreatedAt: from.Add(12 * time.Hour)})
+
+	session, ok = costsrv.Accrued(exec, events, from, to, now)
+	require.True(t, ok)
+	require.False(t, session.Running)
+	require.InDelta(t, 2+2, session.Hours, 0.001)
+	require.Equal(t, from.Add(12*time.Hour), session.End)
+
+	_, ok = costsrv.Accrued(exec, events, from.Add(3*time.Hour), from.Add(9*time.Hour), now)
+	require.False(t, ok, "should skip windows the exec was stopped in")
+}
+
+func TestAccrued_Errored(t *testing.T) {
+	t.Parallel()
+
+	from := time.Date(2023, 7, 1, 0, 0, 0, 0, time.UTC)
+	to := from.AddDate(0, 1, 0)
+	now := from.Add(72 * time.Hour)
+	price := 100
+
+	exec := types.Exec{ID: "exc_1", CreatedAt: from, HourlyPrice: &price, Status: types.StatusError}
+	events := []types.ExecEvent{
+		{Status: types.StatusRunning, CreatedAt: from.Add(time.Minute)},
+		{Status: types.StatusError, CreatedAt: from.Add(3 * time.Hour)},
+		{Status: types.StatusUnknown, CreatedAt: from.Add(4 * time.Hour)},
+	}
+
+	session, ok := costsrv.Accrued(exec, events, from, to, now)
+	require.True(t, ok)
+	require.False(t, session.Running)
+	require.InDelta(t, 3, session.Hours, 0.001, "should stop accruing at the error")
+	require.Equal(t, from.Add(3*time.Hour), session.End)
+
+	session, ok = costsrv.Accrued(exec, events[:1], from, to, now)
+	require.True(t, ok)
+	require.InDelta(t, 1.0/60, session.Hours, 0.001, "should stop at the last event without a terminal one")
+
+	_, ok = costsrv.Accrued(exec, nil, from, to, now)
+	require.False(t, ok, "should not accrue errored execs without a history")
+}
+
+func TestService_Usage(t *testing.T) {
+	t.Parallel()
+
+	var (
+		from   = time.Now().Add(-48 * time.Hour)
+		to     = time.Now().Add(-24 * time.Hour)
+		a10    = 60
+		a100   = 110
+		exited = from.Add(10 * time.Hour)
+	)
+
+	srv := costsrv.NewService(execStore{
+		{
+			ID: "exc_1", CreatedBy: "usr_alice", Provider: types.LambdaLabsProvider,
+			CreatedAt: from, ExitedAt: &exited, HourlyPrice: &a10,
+			Spec: types.HardwareSpec{GPU: types.GPU{Type: "gpu_1x_a10"}},
+		},
+		{
+			ID: "exc_2", CreatedBy: "usr_bob", Provider: types.LambdaLabsProvider,
+			CreatedAt: from.Add(4 * time.Hour), HourlyPrice: &a100,
+			Spec: types.HardwareSpec{GPU: types.GPU{Type: "gpu_1x_a100"}},
+		},
+		{
+			ID: "exc_3", CreatedBy: "usr_alice", Provider: types.AWSProvider,
+			CreatedAt: from.Add(12 * time.Hour),
+		},
+	})
+
+	report, err := srv.Usage(context.Background(), "pr_1", from, to)
+	require.NoError(t, err)
+	require.Len(t, report.Sessions, 3)
+	require.InDelta(t, 10+20+12, report.Hours, 0.001)
+	require.InDelta(t, 0.6*10+1.1*20, report.Cost, 0.001)
+
+	require.Equal(t, "usr_bob", report.ByUser[0].Key)
+	require.InDelta(t, 22, report.ByUser[0].Cost, 0.001)
+	require.Equal(t, 2, report.ByUser[1].Sessions)
+
+	require.Equal(t, "lambdalabs", report.ByProvider[0].Key)
+	require.Equal(t, "aws", report.ByProvider[1].Key)
+	require.Zero(t, report.ByProvider[1].Cost, "unpriced sessions shouldn't add to the cost")
+
+	require.Len(t, report.ByGPUType, 3)
+	require.Equal(t, "gpu_1x_a100", report.ByGPUType[0].Key)
+
+	buf := &bytes.Buffer{}
+	require.NoError(t, costsrv.WriteCSV(buf, report))
+
+	rows, err := csv.NewReader(buf).ReadAll()
+	require.NoError(t, err)
+	require.Len(t, rows, 4)
+	require.Equal(t, "session_id", rows[0][0])
+	require.Equal(t, []string{"exc_1", "usr_alice", "gpu_1x_a10", "0.60", "6.0000"},
+		[]string{rows[1][0], rows[1][2], rows[1][4], rows[1][8], rows[1][9]})
+	require.Empty(t, rows[3][8], "unpriced sessions should have an empty price")
+}
+
+func TestPricer_HourlyPrice(t *testing.T) {
+	t.Parallel()
+
+	price := func(p int) *int { return &p }
+
+	pricer := costsrv.NewPricer(providersrv.NewProviderService(providerDriver{
+		{ID: "gpu_1x_a10", Price: price(60)},
+		{ID: "gpu_1x_a100", Price: price(110)},
+	}))
+
+	got, err := pricer.HourlyPrice(context.Background(), "usr_1", types.HardwareSpec{GPU: types.GPU{Type: "gpu_1x_a100"}})
+	require.NoError(t, err)
+	require.Equal(t, 110, *got)
+
+	got, err = pricer.HourlyPrice(context.Background(), "usr_1", types.HardwareSpec{GPU: types.GPU{Type: "gpu_8x_h100"}})
+	require.NoError(t, err)
+	require.Nil(t, got)
+}
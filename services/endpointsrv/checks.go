@@ -5,10 +5,12 @@ import (
 	"context"
 	"database/sql"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
 	"net/url"
+	"sync"
 	"time"
 
 	"github.com/rs/zerolog/log"
@@ -17,51 +19,215 @@ import (
 	"go.jetpack.io/typeid"
 )
 
+const (
+	// checkConcurrency is the number of steps of a check that run at the same time.
+	checkConcurrency = 4
+	// checkStepTimeout bounds a single attempt at calling the endpoint and asserting
+	// its response.
+	checkStepTimeout = 30 * time.Second
+	// checkStepRetries is how many times a failed attempt at a step is retried.
+	checkStepRetries  = 2
+	checkRetryBackoff = 500 * time.Millisecond
+)
+
+var errCheckCancelled = errors.New("check cancelled")
+
 type endpointChecker struct {
 	checkID string
 	loaded  bool
 	checks  []checkEndpointStep
+	store   Store
+	runs    *checkRuns
+
+	concurrency int
+	stepTimeout time.Duration
+	retries     int
+	backoff     time.Duration
 }
 
-func newEndpointChecker(checkID string) (*endpointChecker, error) {
+func newEndpointChecker(checkID string, runs *checkRuns) (*endpointChecker, error) {
 	if checkID == "" {
 		return nil, fmt.Errorf("check id must be provided")
 	}
 
 	checker := &endpointChecker{
-		checkID: checkID,
+		checkID:     checkID,
+		runs:        runs,
+		concurrency: checkConcurrency,
+		stepTimeout: checkStepTimeout,
+		retries:     checkStepRetries,
+		backoff:     checkRetryBackoff,
 	}
 
 	return checker, nil
 }
 
-func (c *endpointChecker) Run(ctx context.Context) error {
+// Run runs the check steps in the background and calls done once they have all completed
+// or the check is cancelled. Steps run concurrently, each bounded by a timeout and retried
+// on failure. Steps that still fail have their error saved.
+func (c *endpointChecker) Run(ctx context.Context, done func(ctx context.Context)) error {
 	if !c.loaded {
 		panic("check steps must be loaded before calling run")
 	}
 
+	if err := c.store.EndpointCheckStatusUpdate(ctx, db.EndpointCheckStatusUpdateParams{
+		ID:     c.checkID,
+		Status: string(types.CheckInProgress),
+	}); err != nil {
+		return fmt.Errorf("update check status: %w", err)
+	}
+
+	runCtx := c.runs.start(ctx, c.checkID)
+
 	go func() {
-		for _, check := range c.checks {
-			check.callEndpoint(ctx)
-			check.assertResponse(ctx)
+		defer c.runs.stop(c.checkID)
 
-			if check.err != nil {
-				log.Error().Err(check.err).Msg("check failed")
-			}
+		if done != nil {
+			defer done(ctx)
+		}
+
+		steps := make(chan *checkEndpointStep)
+		wg := sync.WaitGroup{}
+
+		for i := 0; i < c.concurrency; i++ {
+			wg.Add(1)
+
+			go func() {
+				defer wg.Done()
+
+				for step := range steps {
+					c.runStep(ctx, runCtx, step)
+				}
+			}()
+		}
+
+		for i := range c.checks {
+			steps <- &c.checks[i]
+		}
+
+		close(steps)
+		wg.Wait()
+
+		if runCtx.Err() != nil {
+			return
+		}
 
-			log.Debug().
-				Str("check_id", check.checkID).
-				Str("step_id", check.stepID).
-				Str("input", string(check.input)).
-				Str("response", string(check.endpointResponse)).
-				Str("assertion", check.assertion).
-				Send()
+		if err := c.store.EndpointCheckStatusUpdate(ctx, db.EndpointCheckStatusUpdateParams{
+			ID:     c.checkID,
+			Status: string(types.CheckCompleted),
+		}); err != nil {
+			log.Ctx(ctx).Error().Err(err).Str("check_id", c.checkID).Msg("Failed to complete check")
 		}
 	}()
 
 	return nil
 }
 
+// runStep runs the step until it succeeds, runs out of retries or the check is cancelled.
+// The store is written with ctx rather than runCtx so errors are saved on cancellation too.
+func (c *endpointChecker) runStep(ctx, runCtx context.Context, step *checkEndpointStep) {
+	var err error
+
+	for attempt := 0; attempt <= c.retries; attempt++ {
+		if attempt > 0 {
+			select {
+			case <-runCtx.Done():
+			case <-time.After(c.backoff * time.Duration(attempt)):
+			}
+		}
+
+		if runCtx.Err() != nil {
+			err = errCheckCancelled
+			break
+		}
+
+		if err = c.attemptStep(ctx, runCtx, step); err == nil {
+			break
+		}
+
+		log.Ctx(ctx).Warn().
+			Err(err).
+			Str("check_id", step.checkID).
+			Str("step_id", step.stepID).
+			Int("attempt", attempt+1).
+			Msg("Check step attempt failed")
+	}
+
+	if err != nil && runCtx.Err() != nil {
+		err = errCheckCancelled
+	}
+
+	if err != nil {
+		if serr := step.store.EndpointCheckStepUpdate(ctx, db.EndpointCheckStepUpdateParams{
+			ID:    sql.NullString{String: step.stepID, Valid: true},
+			Error: sql.NullString{String: err.Error(), Valid: true},
+		}); serr != nil {
+			log.Ctx(ctx).Error().Err(serr).Str("step_id", step.stepID).Msg("Failed to save check step error")
+		}
+	}
+
+	log.Debug().
+		Str("check_id", step.checkID).
+		Str("step_id", step.stepID).
+		Str("input", string(step.input)).
+		Str("response", string(step.endpointResponse)).
+		Str("assertion", step.assertion).
+		Send()
+}
+
+func (c *endpointChecker) attemptStep(ctx, runCtx context.Context, step *checkEndpointStep) error {
+	stepCtx, cancel := context.WithTimeout(runCtx, c.stepTimeout)
+	defer cancel()
+
+	if step.endpointResponse == nil {
+		if err := step.callEndpoint(ctx, stepCtx); err != nil {
+			return err
+		}
+	}
+
+	return step.assertResponse(ctx, stepCtx)
+}
+
+// checkRuns holds the cancel functions of the checks running in this process.
+type checkRuns struct {
+	mu      sync.Mutex
+	cancels map[string]context.CancelFunc
+}
+
+func newCheckRuns() *checkRuns {
+	return &checkRuns{cancels: map[string]context.CancelFunc{}}
+}
+
+func (r *checkRuns) start(ctx context.Context, checkID string) context.Context {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	ctx, cancel := context.WithCancel(ctx)
+	r.cancels[checkID] = cancel
+
+	return ctx
+}
+
+func (r *checkRuns) stop(checkID string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if cancel, ok := r.cancels[checkID]; ok {
+		cancel()
+		delete(r.cancels, checkID)
+	}
+}
+
+// cancel cancels the check if it is running in this process.
+func (r *checkRuns) cancel(checkID string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if cancel, ok := r.cancels[checkID]; ok {
+		cancel()
+	}
+}
+
 var (
 	ErrEndpointUnavailable = fmt.Errorf("endpoint unavailable")
 	ErrInvalidManifest     = fmt.Errorf("manifest is invalid")
@@ -199,14 +365,13 @@ func (c *endpointChecker) CreateCheckSteps(ctx context.Context, store Store, end
 	}
 
 	c.checks = checks
+	c.store = store
 	c.loaded = true
 
 	return nil
 }
 
 type checkEndpointStep struct {
-	err error
-
 	checkID          string
 	stepID           string
 	runPath          string
@@ -218,38 +383,48 @@ type checkEndpointStep struct {
 	store            Store
 }
 
-func (c *checkEndpointStep) callEndpoint(ctx context.Context) {
+// callEndpoint calls the endpoint with stepCtx and saves its response with ctx.
+func (c *checkEndpointStep) callEndpoint(ctx, stepCtx context.Context) error {
 	buf := bytes.NewBuffer(c.input)
 
-	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.runPath, buf)
+	req, err := http.NewRequestWithContext(stepCtx, http.MethodPost, c.runPath, buf)
 	if err != nil {
-		c.err = fmt.Errorf("build endpoint request: %w", err)
-
-		return
+		return fmt.Errorf("build endpoint request: %w", err)
 	}
 
 	req.Header.Set("X-Unweave-Target-Endpoint-URL", c.endpoint)
 
 	resp, err := http.DefaultClient.Do(req)
 	if err != nil {
-		c.err = fmt.Errorf("call endpoint: %w", err)
-
-		return
+		return fmt.Errorf("call endpoint: %w", err)
 	}
 
 	defer resp.Body.Close()
 
-	c.endpointResponse, c.err = io.ReadAll(resp.Body)
+	if resp.StatusCode >= 500 {
+		return fmt.Errorf("call endpoint: status %d %w", resp.StatusCode, ErrEndpointUnavailable)
+	}
+
+	response, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return fmt.Errorf("read endpoint response: %w", err)
+	}
 
 	if err := c.store.EndpointCheckStepUpdate(ctx, db.EndpointCheckStepUpdateParams{
 		ID:     sql.NullString{String: c.stepID, Valid: true},
-		Output: sql.NullString{String: string(c.endpointResponse), Valid: true},
+		Output: sql.NullString{String: string(response), Valid: true},
 	}); err != nil {
-		c.err = err
+		return fmt.Errorf("save endpoint response: %w", err)
 	}
+
+	c.endpointResponse = response
+
+	return nil
 }
 
-func (c *checkEndpointStep) assertResponse(ctx context.Context) {
+// assertResponse asserts the endpoint's response with stepCtx and saves the assertion
+// with ctx.
+func (c *checkEndpointStep) assertResponse(ctx, stepCtx context.Context) error {
 	buf := &bytes.Buffer{}
 
 	if err := json.
@@ -257,31 +432,23 @@ func (c *checkEndpointStep) assertResponse(ctx context.Context) {
 		Encode(datasetItemEndpointResponse{
 			EndpointResponse: c.endpointResponse,
 		}); err != nil {
-		c.err = fmt.Errorf("build assert body: %w", err)
-
-		return
+		return fmt.Errorf("build assert body: %w", err)
 	}
 
-	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.assertPath, buf)
+	req, err := http.NewRequestWithContext(stepCtx, http.MethodPost, c.assertPath, buf)
 	if err != nil {
-		c.err = fmt.Errorf("build assert request: %w", err)
-
-		return
+		return fmt.Errorf("build assert request: %w", err)
 	}
 
 	resp, err := http.DefaultClient.Do(req)
 	if err != nil {
-		c.err = fmt.Errorf("call assert: %w", err)
-
-		return
+		return fmt.Errorf("call assert: %w", err)
 	}
 
 	defer resp.Body.Close()
 
 	if resp.StatusCode != http.StatusOK {
-		c.err = fmt.Errorf("assert status code: %d", resp.StatusCode)
-
-		return
+		return fmt.Errorf("assert status code: %d", resp.StatusCode)
 	}
 
 	var response struct {
@@ -289,9 +456,7 @@ func (c *checkEndpointStep) assertResponse(ctx context.Context) {
 	}
 
 	if err := json.NewDecoder(resp.Body).Decode(&response); err != nil {
-		c.err = fmt.Errorf("decode assert response: %w", err)
-
-		return
+		return fmt.Errorf("decode assert response: %w", err)
 	}
 
 	c.assertion = response.Result
@@ -299,8 +464,10 @@ func (c *checkEndpointStep) assertResponse(ctx context.Context) {
 		ID:        sql.NullString{String: c.stepID, Valid: true},
 		Assertion: sql.NullString{String: c.assertion, Valid: true},
 	}); err != nil {
-		c.err = err
+		return fmt.Errorf("save assertion: %w", err)
 	}
+
+	return nil
 }
 
 type dataset struct {
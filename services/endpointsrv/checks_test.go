@@ -2,7 +2,14 @@
 package endpointsrv
 
 import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"sync"
+	"sync/atomic"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/require"
 	"github.com/unweave/unweave-v1/api/types"
@@ -113,3 +120,153 @@ func TestResolveManifestURLs(t *testing.T) {
 		})
 	}
 }
+
+// runChecks runs the endpoint's evals with short step timeouts and waits for them to
+// finish.
+func runChecks(t *testing.T, srv *EndpointService, started func(checkID string)) types.EndpointCheck {
+	t.Helper()
+
+	ctx := context.Background()
+
+	end, err := srv.EndpointGet(ctx, "prj_1", "end_1")
+	require.NoError(t, err)
+
+	checker, err := srv.createChecks(ctx, end)
+	require.NoError(t, err)
+
+	checker.concurrency = 2
+	checker.stepTimeout = 100 * time.Millisecond
+	checker.retries = 1
+	checker.backoff = time.Millisecond
+
+	done := make(chan struct{})
+	require.NoError(t, checker.Run(ctx, func(context.Context) { close(done) }))
+
+	if started != nil {
+		started(checker.checkID)
+	}
+
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("check did not finish")
+	}
+
+	check, err := srv.EndpointCheckStatus(ctx, "prj_1", checker.checkID)
+	require.NoError(t, err)
+
+	_, err = srv.EndpointCheckStatus(ctx, "prj_2", checker.checkID)
+	require.Error(t, err, "checks of other projects should not be found")
+
+	return check
+}
+
+func TestEndpointChecker_Run(t *testing.T) {
+	var (
+		mu       sync.Mutex
+		attempts = map[int]int{}
+		inFlight int32
+		maxIn    int32
+	)
+
+	dataset := `{"data":[{"input":{"n":1}},{"input":{"n":2}},{"input":{"n":3}},{"input":{"n":4}}]}`
+	eval := evalServerFunc(t, "success", dataset, func(w http.ResponseWriter, r *http.Request) {
+		n := atomic.AddInt32(&inFlight, 1)
+		defer atomic.AddInt32(&inFlight, -1)
+
+		for {
+			m := atomic.LoadInt32(&maxIn)
+			if n <= m || atomic.CompareAndSwapInt32(&maxIn, m, n) {
+				break
+			}
+		}
+
+		var input struct{ N int }
+		_ = json.NewDecoder(r.Body).Decode(&input)
+
+		mu.Lock()
+		attempts[input.N]++
+		attempt := attempts[input.N]
+		mu.Unlock()
+
+		switch {
+		case input.N == 1 && attempt == 1:
+			w.WriteHeader(http.StatusBadGateway)
+		case input.N == 2:
+			<-r.Context().Done()
+		default:
+			time.Sleep(20 * time.Millisecond)
+			_, _ = w.Write([]byte(`{"output":"ok"}`))
+		}
+	})
+
+	srv, store, _ := newCanaryTestService(eval)
+	check := runChecks(t, srv, nil)
+
+	require.Equal(t, types.CheckCompleted, check.Status)
+	require.Equal(t, types.CheckError, *check.Conclusion)
+	require.LessOrEqual(t, atomic.LoadInt32(&maxIn), int32(2), "should bound concurrency")
+	require.Equal(t, int32(2), atomic.LoadInt32(&maxIn), "should run steps concurrently")
+
+	mu.Lock()
+	require.Equal(t, 2, attempts[1], "should retry a failed step")
+	require.Equal(t, 2, attempts[2], "should retry a timed out step")
+	require.Equal(t, 1, attempts[3])
+	mu.Unlock()
+
+	for _, step := range check.Steps {
+		var input struct{ N int }
+		require.NoError(t, json.Unmarshal(step.Input, &input))
+
+		if input.N == 2 {
+			require.Equal(t, types.CheckError, *step.Conclusion)
+			require.Contains(t, step.Error, context.DeadlineExceeded.Error())
+
+			continue
+		}
+
+		require.Equal(t, types.CheckSuccess, *step.Conclusion)
+		require.Empty(t, step.Error)
+	}
+
+	dbCheck, err := store.EndpointCheck(context.Background(), check.CheckID)
+	require.NoError(t, err)
+	require.Equal(t, string(types.CheckCompleted), dbCheck.Status)
+}
+
+func TestEndpointCheckCancel(t *testing.T) {
+	ctx := context.Background()
+	eval := evalServerFunc(t, "success", `{"data":[{"input":{"n":1}}]}`, func(w http.ResponseWriter, r *http.Request) {
+		<-r.Context().Done()
+	})
+
+	srv, _, _ := newCanaryTestService(eval)
+
+	var e *types.Error
+
+	_, err := srv.EndpointCheckCancel(ctx, "prj_1", "check_unknown")
+	require.True(t, errors.As(err, &e))
+	require.Equal(t, http.StatusNotFound, e.Code)
+
+	var checkID string
+
+	check := runChecks(t, srv, func(id string) {
+		checkID = id
+
+		_, err := srv.EndpointCheckCancel(ctx, "prj_2", id)
+		require.True(t, errors.As(err, &e))
+		require.Equal(t, http.StatusNotFound, e.Code, "should not cancel checks of other projects")
+
+		cancelled, err := srv.EndpointCheckCancel(ctx, "prj_1", id)
+		require.NoError(t, err)
+		require.Equal(t, types.CheckCancelled, *cancelled.Conclusion)
+	})
+
+	require.Equal(t, types.CheckCompleted, check.Status)
+	require.Equal(t, types.CheckCancelled, *check.Conclusion)
+	require.Equal(t, errCheckCancelled.Error(), check.Steps[0].Error)
+
+	_, err = srv.EndpointCheckCancel(ctx, "prj_1", checkID)
+	require.True(t, errors.As(err, &e))
+	require.Equal(t, http.StatusConflict, e.Code)
+}
@@ -0,0 +1,156 @@
+package endpointsrv
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"net/http"
+
+	"github.com/unweave/unweave-v1/api/types"
+	"github.com/unweave/unweave-v1/db"
+	"go.jetpack.io/typeid"
+)
+
+// promotion describes why and by whom a version is promoted to primary.
+type promotion struct {
+	reason types.PromotionReason
+	userID string
+}
+
+func (p promotion) params(endpointID, versionID, previousVersionID string) db.EndpointPromotionCreateParams {
+	return db.EndpointPromotionCreateParams{
+		ID:                typeid.Must(typeid.New("promo")).String(),
+		EndpointID:        endpointID,
+		VersionID:         versionID,
+		PreviousVersionID: sql.NullString{String: previousVersionID, Valid: previousVersionID != ""},
+		PromotedBy:        sql.NullString{String: p.userID, Valid: p.userID != ""},
+		Reason:            string(p.reason),
+	}
+}
+
+// EndpointVersionPromote routes all of the endpoint's traffic to the version.
+func (e *EndpointService) EndpointVersionPromote(
+	ctx context.Context,
+	projectID,
+	endpointID,
+	versionID,
+	userID string,
+) (types.EndpointVersion, error) {
+	end, err := e.EndpointGet(ctx, projectID, endpointID)
+	if err != nil {
+		return types.EndpointVersion{}, fmt.Errorf("endpoint get: %w", err)
+	}
+
+	version, ok := findVersion(end, versionID)
+	if !ok {
+		return types.EndpointVersion{}, errVersionNotFound(e.driver.EndpointProvider(), end.ID, versionID)
+	}
+
+	if version.Primary {
+		return types.EndpointVersion{}, &types.Error{
+			Code:     http.StatusBadRequest,
+			Message:  "Version is already the primary version",
+			Provider: e.driver.EndpointProvider(),
+		}
+	}
+
+	return e.promoteVersion(ctx, end, version, promotion{reason: types.PromotionManual, userID: userID})
+}
+
+// EndpointRollback promotes the version that was primary before the latest promotion that
+// hasn't been rolled back yet. Rolling back repeatedly walks back through the promotions.
+func (e *EndpointService) EndpointRollback(ctx context.Context, projectID, endpointID, userID string) (types.EndpointVersion, error) {
+	end, err := e.EndpointGet(ctx, projectID, endpointID)
+	if err != nil {
+		return types.EndpointVersion{}, fmt.Errorf("endpoint get: %w", err)
+	}
+
+	previousID := rollbackVersionID(end.Promotions)
+	if previousID == "" {
+		return types.EndpointVersion{}, &types.Error{
+			Code:       http.StatusConflict,
+			Message:    "Endpoint has no previous version to roll back to",
+			Suggestion: "Promote a version with the promote endpoint",
+			Provider:   e.driver.EndpointProvider(),
+		}
+	}
+
+	version, ok := findVersion(end, previousID)
+	if !ok {
+		return types.EndpointVersion{}, errVersionNotFound(e.driver.EndpointProvider(), end.ID, previousID)
+	}
+
+	return e.promoteVersion(ctx, end, version, promotion{reason: types.PromotionRollback, userID: userID})
+}
+
+// rollbackVersionID returns the version to roll back to from the promotions, oldest first.
+// Every rollback undoes the latest promotion before it that isn't a rollback and hasn't
+// been undone yet.
+func rollbackVersionID(promotions []types.EndpointPromotion) string {
+	undone := 0
+
+	for i := len(promotions) - 1; i >= 0; i-- {
+		p := promotions[i]
+
+		switch {
+		case p.Reason == types.PromotionRollback:
+			undone++
+		case undone > 0:
+			undone--
+		default:
+			return p.PreviousVersionID
+		}
+	}
+
+	return ""
+}
+
+func (e *EndpointService) promoteVersion(
+	ctx context.Context,
+	end types.Endpoint,
+	version types.EndpointVersion,
+	by promotion,
+) (types.EndpointVersion, error) {
+	if canaryInProgress(end) {
+		return types.EndpointVersion{}, errCanaryInProgress(e.driver.EndpointProvider())
+	}
+
+	exec, err := e.execs.Get(ctx, version.ExecID)
+	if err != nil {
+		return types.EndpointVersion{}, fmt.Errorf("exec get: %w", err)
+	}
+
+	if exec.Network.HTTPService == nil {
+		return types.EndpointVersion{}, errNoHTTPService(e.driver.EndpointProvider(), version.ID)
+	}
+
+	if err := e.setPrimary(ctx, end, version, exec.Network.HTTPService.InternalPort, nil, by); err != nil {
+		return types.EndpointVersion{}, fmt.Errorf("promote: %w", err)
+	}
+
+	version.Primary = true
+
+	return version, nil
+}
+
+func (e *EndpointService) endpointPromotions(ctx context.Context, endpointID string) ([]types.EndpointPromotion, error) {
+	promotions, err := e.store.EndpointPromotionList(ctx, endpointID)
+	if err != nil {
+		return nil, fmt.Errorf("promotion list: %w", err)
+	}
+
+	out := make([]types.EndpointPromotion, len(promotions))
+
+	for idx, p := range promotions {
+		out[idx] = types.EndpointPromotion{
+			ID:                p.ID,
+			VersionID:         p.VersionID,
+			PreviousVersionID: p.PreviousVersionID.String,
+			Reason:            types.PromotionReason(p.Reason),
+			PromotedBy:        p.PromotedBy.String,
+			CreatedAt:         p.CreatedAt,
+		}
+	}
+
+	return out, nil
+}
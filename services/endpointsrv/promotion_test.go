@@ -0,0 +1,88 @@
+//nolint:paralleltest,testpackage
+package endpointsrv
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/unweave/unweave-v1/api/types"
+	"github.com/unweave/unweave-v1/db"
+)
+
+func TestEndpointVersionPromote_Rollback(t *testing.T) {
+	ctx := context.Background()
+	srv, _, driver := newCanaryTestService()
+
+	var e *types.Error
+
+	_, err := srv.EndpointRollback(ctx, "prj_1", "end_1", "usr_1")
+	require.True(t, errors.As(err, &e))
+	require.Equal(t, http.StatusConflict, e.Code, "should not roll back without a promotion history")
+
+	_, err = srv.EndpointVersionPromote(ctx, "prj_1", "end_1", "ver_1", "usr_1")
+	require.True(t, errors.As(err, &e))
+	require.Equal(t, http.StatusBadRequest, e.Code, "should not promote the primary version")
+
+	_, err = srv.EndpointVersionPromote(ctx, "prj_1", "end_1", "ver_3", "usr_1")
+	require.True(t, errors.As(err, &e))
+	require.Equal(t, http.StatusNotFound, e.Code)
+
+	version, err := srv.EndpointVersionPromote(ctx, "prj_1", "end_1", "ver_2", "usr_1")
+	require.NoError(t, err)
+	require.Equal(t, "ver_2", version.ID)
+	require.True(t, version.Primary)
+
+	version, err = srv.EndpointRollback(ctx, "prj_1", "end_1", "usr_2")
+	require.NoError(t, err)
+	require.Equal(t, "ver_1", version.ID)
+	require.Equal(t, []string{"ver_2", "ver_1"}, driver.promoted)
+
+	end, err := srv.EndpointGet(ctx, "prj_1", "end_1")
+	require.NoError(t, err)
+
+	primary, ok := primaryVersion(end)
+	require.True(t, ok)
+	require.Equal(t, "ver_1", primary.ID)
+	require.Equal(t, primaryTraffic("ver_1"), *end.Traffic)
+
+	require.Len(t, end.Promotions, 2)
+	require.Equal(t, "ver_2", end.Promotions[0].VersionID)
+	require.Equal(t, "ver_1", end.Promotions[0].PreviousVersionID)
+	require.Equal(t, types.PromotionManual, end.Promotions[0].Reason)
+	require.Equal(t, "usr_1", end.Promotions[0].PromotedBy)
+	require.Equal(t, "ver_1", end.Promotions[1].VersionID)
+	require.Equal(t, "ver_2", end.Promotions[1].PreviousVersionID)
+	require.Equal(t, types.PromotionRollback, end.Promotions[1].Reason)
+	require.Equal(t, "usr_2", end.Promotions[1].PromotedBy)
+}
+
+func TestEndpointRollback_Repeated(t *testing.T) {
+	ctx := context.Background()
+	srv, store, driver := newCanaryTestService()
+	store.versions = append(store.versions,
+		db.UnweaveEndpointVersion{ID: "ver_3", EndpointID: "end_1", ExecID: "exc_3", HttpAddress: "ver-3.endpoints.test"},
+	)
+
+	for _, id := range []string{"ver_2", "ver_3"} {
+		_, err := srv.EndpointVersionPromote(ctx, "prj_1", "end_1", id, "usr_1")
+		require.NoError(t, err)
+	}
+
+	version, err := srv.EndpointRollback(ctx, "prj_1", "end_1", "usr_1")
+	require.NoError(t, err)
+	require.Equal(t, "ver_2", version.ID)
+
+	version, err = srv.EndpointRollback(ctx, "prj_1", "end_1", "usr_1")
+	require.NoError(t, err)
+	require.Equal(t, "ver_1", version.ID, "should skip the previous rollback")
+	require.Equal(t, []string{"ver_2", "ver_3", "ver_2", "ver_1"}, driver.promoted)
+
+	_, err = srv.EndpointRollback(ctx, "prj_1", "end_1", "usr_1")
+
+	var e *types.Error
+	require.True(t, errors.As(err, &e))
+	require.Equal(t, http.StatusConflict, e.Code, "should not roll back past the first promotion")
+}
@@ -2,8 +2,10 @@ package endpointsrv
 
 import (
 	"context"
+	"database/sql"
 	"errors"
 	"fmt"
+	"net/http"
 	"time"
 
 	"github.com/rs/zerolog/log"
@@ -33,11 +35,25 @@ type Driver interface {
 		execID string,
 		internalPort int32) (string, error)
 
+	// EndpointVersionDelete removes the version's route. It undoes EndpointVersionCreate
+	// when the version can't be stored.
+	EndpointVersionDelete(
+		ctx context.Context,
+		endpointID,
+		versionID string) error
+
+	// EndpointVersionPromote routes all of the endpoint's traffic to the version,
+	// replacing its traffic policy.
 	EndpointVersionPromote(
 		ctx context.Context,
 		endpointID,
 		versionID string,
 		internalPort int32) error
+
+	EndpointTrafficSet(
+		ctx context.Context,
+		endpointID string,
+		policy types.EndpointTrafficPolicy) error
 }
 
 type Service interface {
@@ -47,16 +63,36 @@ type Service interface {
 
 	RunEndpointEvals(ctx context.Context, projectID, endpointID string) (string, error)
 	EndpointAttachEval(ctx context.Context, endpointID, evalID string) error
-	EndpointCheckStatus(ctx context.Context, checkID string) (types.EndpointCheck, error)
+	EndpointCheckStatus(ctx context.Context, projectID, checkID string) (types.EndpointCheck, error)
+	EndpointCheckCancel(ctx context.Context, projectID, checkID string) (types.EndpointCheck, error)
 
-	EndpointVersionCreate(ctx context.Context, projectID, parentEndpointID, execID string, promote bool) (types.EndpointVersion, error)
+	EndpointVersionCreate(ctx context.Context, projectID, parentEndpointID, userID string, params types.EndpointVersionCreateParams) (types.EndpointVersion, error)
+	EndpointVersionPromote(ctx context.Context, projectID, endpointID, versionID, userID string) (types.EndpointVersion, error)
+	EndpointRollback(ctx context.Context, projectID, endpointID, userID string) (types.EndpointVersion, error)
+
+	EndpointTrafficSet(ctx context.Context, projectID, endpointID string, policy types.EndpointTrafficPolicy) (types.EndpointTrafficPolicy, error)
+	EndpointCanaryStart(ctx context.Context, projectID, endpointID, userID string, params types.EndpointCanaryCreateParams) (types.EndpointCanary, error)
+	EndpointCanaryAdvance(ctx context.Context, projectID, endpointID, userID string) (types.EndpointCanary, error)
+	EndpointCanaryRollback(ctx context.Context, projectID, endpointID string) (types.EndpointCanary, error)
 }
 
 type EndpointService struct {
-	store  Store
-	evals  evalsrv.Service
-	execs  execsrv.Service
-	driver Driver
+	store    Store
+	evals    evalsrv.Service
+	execs    execsrv.Service
+	driver   Driver
+	notifier Notifier
+	runs     *checkRuns
+}
+
+// Notifier is notified of endpoint lifecycle events, e.g. to deliver them to webhooks.
+type Notifier interface {
+	Notify(ctx context.Context, projectID string, event types.WebhookEvent, data any)
+}
+
+func WithNotifier(e *EndpointService, n Notifier) *EndpointService {
+	e.notifier = n
+	return e
 }
 
 var _ Service = (*EndpointService)(nil)
@@ -72,12 +108,20 @@ type Store interface {
 	EndpointCheckStepUpdate(ctx context.Context, arg db.EndpointCheckStepUpdateParams) error
 	EndpointCheckSteps(ctx context.Context, checkID string) ([]db.UnweaveEndpointCheckStep, error)
 	EndpointCheck(ctx context.Context, checkID string) (db.UnweaveEndpointCheck, error)
+	EndpointCheckStatusUpdate(ctx context.Context, arg db.EndpointCheckStatusUpdateParams) error
+	EndpointPromotionList(ctx context.Context, endpointID string) ([]db.UnweaveEndpointPromotion, error)
+
+	EndpointTraffic(ctx context.Context, endpointID string) (db.UnweaveEndpointTraffic, error)
+	EndpointTrafficSet(ctx context.Context, arg db.EndpointTrafficSetParams) error
 
 	EndpointVersion(ctx context.Context, versionID string) (db.UnweaveEndpointVersion, error)
 	EndpointVersionCreate(ctx context.Context, arg db.EndpointVersionCreateParams) error
 	EndpointVersionList(ctx context.Context, endpointID string) ([]db.UnweaveEndpointVersion, error)
 	EndpointVersionDemote(ctx context.Context, endpointID string) error
 	EndpointVersionPromote(ctx context.Context, id string) error
+	EndpointVersionStatusUpdate(ctx context.Context, arg db.EndpointVersionStatusUpdateParams) error
+
+	ExecGet(ctx context.Context, idOrName string) (db.UnweaveExec, error)
 
 	Tx(txFunc func(db.Querier) error) error
 }
@@ -93,6 +137,7 @@ func NewEndpointService(
 		evals:  evals,
 		execs:  execs,
 		driver: driver,
+		runs:   newCheckRuns(),
 	}
 }
 
@@ -208,6 +253,16 @@ func (e *EndpointService) EndpointGet(ctx context.Context, projectID, endpointID
 		return types.Endpoint{}, fmt.Errorf("versions: %w", err)
 	}
 
+	traffic, canary, err := e.endpointTraffic(ctx, endpointID)
+	if err != nil {
+		return types.Endpoint{}, fmt.Errorf("traffic: %w", err)
+	}
+
+	promotions, err := e.endpointPromotions(ctx, endpointID)
+	if err != nil {
+		return types.Endpoint{}, fmt.Errorf("promotions: %w", err)
+	}
+
 	endpoint := types.Endpoint{
 		ID:          end.ID,
 		Name:        end.Name,
@@ -218,6 +273,9 @@ func (e *EndpointService) EndpointGet(ctx context.Context, projectID, endpointID
 		Status:      "",
 		Versions:    versions,
 		CreatedAt:   end.CreatedAt,
+		Traffic:     traffic,
+		Canary:      canary,
+		Promotions:  promotions,
 	}
 
 	return endpoint, nil
@@ -236,9 +294,10 @@ func (e *EndpointService) endpointVersions(ctx context.Context, endpointID strin
 			ID:          ver.ID,
 			ExecID:      ver.ExecID,
 			HTTPAddress: ver.HttpAddress,
-			Status:      "",
+			Status:      types.EndpointStatus(ver.Status),
 			Primary:     ver.PrimaryVersion,
 			CreatedAt:   ver.CreatedAt,
+			CheckID:     ver.CheckID.String,
 		}
 	}
 
@@ -257,20 +316,34 @@ func (e *EndpointService) EndpointAttachEval(ctx context.Context, endpointID, ev
 }
 
 func (e *EndpointService) RunEndpointEvals(ctx context.Context, projectID, endpointID string) (string, error) {
-	checkID := typeid.Must(typeid.New("check")).String()
-
 	endpoint, err := e.EndpointGet(ctx, projectID, endpointID)
 	if err != nil {
 		return "", fmt.Errorf("get endpoint: %w", err)
 	}
 
+	checker, err := e.createChecks(ctx, endpoint)
+	if err != nil {
+		return "", err
+	}
+
+	//nolint:contextcheck
+	return checker.checkID, checker.Run(context.Background(), func(ctx context.Context) {
+		e.notifyCheckCompleted(ctx, endpoint, checker.checkID)
+	})
+}
+
+// createChecks creates a check of the endpoint's evals against the endpoint's HTTP address.
+// The check must be run once created.
+func (e *EndpointService) createChecks(ctx context.Context, endpoint types.Endpoint) (*endpointChecker, error) {
+	checkID := typeid.Must(typeid.New("check")).String()
+
 	evals, err := e.evals.Evals(ctx, endpoint.EvalIDs)
 	if err != nil {
-		return "", fmt.Errorf("get evals: %w", err)
+		return nil, fmt.Errorf("get evals: %w", err)
 	}
 
 	if err := verifyCanRunChecks(endpoint, evals); err != nil {
-		return "", fmt.Errorf("verify checks: %w", err)
+		return nil, fmt.Errorf("verify checks: %w", err)
 	}
 
 	if err := e.store.EndpointCheckCreate(ctx, db.EndpointCheckCreateParams{
@@ -278,21 +351,50 @@ func (e *EndpointService) RunEndpointEvals(ctx context.Context, projectID, endpo
 		EndpointID: endpoint.ID,
 		ProjectID:  endpoint.ProjectID,
 	}); err != nil {
-		return "", fmt.Errorf("create eval check: %w", err)
+		return nil, fmt.Errorf("create eval check: %w", err)
 	}
 
-	checker, err := newEndpointChecker(checkID)
+	checker, err := newEndpointChecker(checkID, e.runs)
 	if err != nil {
-		return "", fmt.Errorf("new endpoint checker: %w", err)
+		e.abandonCheck(ctx, checkID)
+		return nil, fmt.Errorf("new endpoint checker: %w", err)
 	}
 
 	err = checker.CreateCheckSteps(ctx, e.store, endpoint, evals)
 	if err != nil {
-		return "", fmt.Errorf("create endpoint checks: %w", err)
+		e.abandonCheck(ctx, checkID)
+		return nil, fmt.Errorf("create endpoint checks: %w", err)
 	}
 
-	//nolint:contextcheck
-	return checkID, checker.Run(context.Background())
+	return checker, nil
+}
+
+// abandonCheck cancels a check that won't be run because it or its version couldn't be
+// created.
+func (e *EndpointService) abandonCheck(ctx context.Context, checkID string) {
+	if err := e.store.EndpointCheckStatusUpdate(ctx, db.EndpointCheckStatusUpdateParams{
+		ID:     checkID,
+		Status: string(types.CheckCancelled),
+	}); err != nil {
+		log.Ctx(ctx).Error().Err(err).Str("check_id", checkID).Msg("Failed to cancel abandoned check")
+	}
+}
+
+func (e *EndpointService) notifyCheckCompleted(ctx context.Context, endpoint types.Endpoint, checkID string) {
+	if e.notifier == nil {
+		return
+	}
+
+	check, err := e.EndpointCheckStatus(ctx, endpoint.ProjectID, checkID)
+	if err != nil {
+		log.Ctx(ctx).Error().Err(err).Str("check_id", checkID).Msg("Failed to get check status for notification")
+		return
+	}
+
+	e.notifier.Notify(ctx, endpoint.ProjectID, types.WebhookEventEndpointCheckCompleted, types.WebhookEndpointCheckData{
+		EndpointID: endpoint.ID,
+		Check:      check,
+	})
 }
 
 func verifyCanRunChecks(endpoint types.Endpoint, evals []types.Eval) error {
@@ -321,7 +423,22 @@ func allHaveHTTPServiceHostname(evals ...types.Eval) bool {
 	return true
 }
 
-func (e *EndpointService) EndpointCheckStatus(ctx context.Context, checkID string) (types.EndpointCheck, error) {
+// EndpointCheckStatus reports the progress of the check. Checks of other projects are
+// reported as missing.
+func (e *EndpointService) EndpointCheckStatus(ctx context.Context, projectID, checkID string) (types.EndpointCheck, error) {
+	dbCheck, err := e.store.EndpointCheck(ctx, checkID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return types.EndpointCheck{}, errCheckNotFound(e.driver.EndpointProvider(), checkID)
+		}
+
+		return types.EndpointCheck{}, fmt.Errorf("get check: %w", err)
+	}
+
+	if dbCheck.ProjectID != projectID {
+		return types.EndpointCheck{}, errCheckNotFound(e.driver.EndpointProvider(), checkID)
+	}
+
 	steps, err := e.store.EndpointCheckSteps(ctx, checkID)
 	if err != nil {
 		return types.EndpointCheck{}, fmt.Errorf("get steps: %w", err)
@@ -339,10 +456,14 @@ func (e *EndpointService) EndpointCheckStatus(ctx context.Context, checkID strin
 			Assertion:  step.Assertion.String,
 			Status:     status,
 			Conclusion: conclusion,
+			Error:      step.Error.String,
 		}
 	}
 
 	status, conclusion := checkStatusAndConclusion(out)
+	if dbCheck.Status == string(types.CheckCancelled) {
+		status, conclusion = types.CheckCompleted, &types.CheckCancelled
+	}
 	check := types.EndpointCheck{
 		CheckID:    checkID,
 		Steps:      out,
@@ -353,19 +474,104 @@ func (e *EndpointService) EndpointCheckStatus(ctx context.Context, checkID strin
 	return check, nil
 }
 
+// EndpointCheckCancel stops the check's remaining steps. The check concludes as cancelled,
+// failing any canary or promotion waiting on it.
+func (e *EndpointService) EndpointCheckCancel(ctx context.Context, projectID, checkID string) (types.EndpointCheck, error) {
+	check, err := e.store.EndpointCheck(ctx, checkID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return types.EndpointCheck{}, errCheckNotFound(e.driver.EndpointProvider(), checkID)
+		}
+
+		return types.EndpointCheck{}, fmt.Errorf("get check: %w", err)
+	}
+
+	if check.ProjectID != projectID {
+		return types.EndpointCheck{}, errCheckNotFound(e.driver.EndpointProvider(), checkID)
+	}
+
+	if check.Status == string(types.CheckCompleted) || check.Status == string(types.CheckCancelled) {
+		return types.EndpointCheck{}, &types.Error{
+			Code:     http.StatusConflict,
+			Message:  fmt.Sprintf("Check %q has already %s", checkID, check.Status),
+			Provider: e.driver.EndpointProvider(),
+		}
+	}
+
+	if err := e.store.EndpointCheckStatusUpdate(ctx, db.EndpointCheckStatusUpdateParams{
+		ID:     checkID,
+		Status: string(types.CheckCancelled),
+	}); err != nil {
+		return types.EndpointCheck{}, fmt.Errorf("update check status: %w", err)
+	}
+
+	e.runs.cancel(checkID)
+
+	return e.EndpointCheckStatus(ctx, projectID, checkID)
+}
+
+func errCheckNotFound(provider types.Provider, checkID string) error {
+	return &types.Error{
+		Code:     http.StatusNotFound,
+		Message:  fmt.Sprintf("Check %q not found", checkID),
+		Provider: provider,
+	}
+}
+
+// EndpointVersionCreate creates a version of the endpoint serving the exec. The version is
+// promoted right away if promote is set, or once the endpoint's evals pass against it if
+// promoteOnPass is set.
 func (e *EndpointService) EndpointVersionCreate(
 	ctx context.Context,
 	projectID,
 	endpointID,
-	execID string,
-	promote bool,
+	userID string,
+	params types.EndpointVersionCreateParams,
 ) (types.EndpointVersion, error) {
+	promote, promoteOnPass := params.Promote, params.PromoteOnPass
+
 	end, err := e.EndpointGet(ctx, projectID, endpointID)
 	if err != nil {
 		return types.EndpointVersion{}, fmt.Errorf("endpoint get: %w", err)
 	}
 
-	exec, err := e.execs.Get(ctx, execID)
+	if promote && promoteOnPass {
+		return types.EndpointVersion{}, &types.Error{
+			Code:       http.StatusBadRequest,
+			Message:    "Cannot set both promote and promoteOnPass",
+			Suggestion: "Promote the version right away or once its evals pass",
+			Provider:   e.driver.EndpointProvider(),
+		}
+	}
+
+	if promoteOnPass && len(end.EvalIDs) == 0 {
+		return types.EndpointVersion{}, &types.Error{
+			Code:       http.StatusBadRequest,
+			Message:    "Endpoint has no evals to gate the promotion on",
+			Suggestion: "Attach an eval to the endpoint or promote the version directly",
+			Provider:   e.driver.EndpointProvider(),
+		}
+	}
+
+	if (promote || promoteOnPass) && canaryInProgress(end) {
+		return types.EndpointVersion{}, errCanaryInProgress(e.driver.EndpointProvider())
+	}
+
+	// Sessions of other projects are reported as missing to not leak their existence.
+	row, err := e.store.ExecGet(ctx, params.ExecID)
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		return types.EndpointVersion{}, fmt.Errorf("exec get: %w", err)
+	}
+	if err != nil || row.ProjectID != projectID {
+		return types.EndpointVersion{}, &types.Error{
+			Code:       http.StatusNotFound,
+			Message:    "Session not found",
+			Suggestion: "Make sure the session id is valid",
+			Provider:   e.driver.EndpointProvider(),
+		}
+	}
+
+	exec, err := e.execs.Get(ctx, row.ID)
 	if err != nil {
 		return types.EndpointVersion{}, fmt.Errorf("exec get: %w", err)
 	}
@@ -379,15 +585,17 @@ func (e *EndpointService) EndpointVersionCreate(
 		}
 	}
 
-	return e.createAttachEndpointVersion(ctx, end, exec.ID, exec.Network.HTTPService.InternalPort, promote)
+	return e.createAttachEndpointVersion(ctx, end, exec.ID, userID, exec.Network.HTTPService.InternalPort, promote, promoteOnPass)
 }
 
 func (e *EndpointService) createAttachEndpointVersion(
 	ctx context.Context,
 	end types.Endpoint,
-	execID string,
+	execID,
+	userID string,
 	internalPort int32,
-	promote bool,
+	promote,
+	promoteOnPass bool,
 ) (types.EndpointVersion, error) {
 	versionID := typeid.Must(typeid.New("version")).String()
 
@@ -397,6 +605,7 @@ func (e *EndpointService) createAttachEndpointVersion(
 		Str(types.ExecIDCtxKey, execID).
 		Str(types.VersionIDCtxKey, versionID).
 		Bool("promote", promote).
+		Bool("promote_on_pass", promoteOnPass).
 		Msg("creating endpoint version")
 
 	httpAddr, err := e.driver.EndpointVersionCreate(
@@ -411,6 +620,24 @@ func (e *EndpointService) createAttachEndpointVersion(
 		return types.EndpointVersion{}, fmt.Errorf("version create: %w", err)
 	}
 
+	status := types.EndpointStatusDeployed
+
+	var checker *endpointChecker
+
+	if promoteOnPass {
+		// Check the version on its own address, the endpoint's still routes to the primary.
+		target := end
+		target.HTTPAddress = httpAddr
+
+		checker, err = e.createChecks(ctx, target)
+		if err != nil {
+			e.deleteVersionRoute(ctx, end.ID, versionID)
+			return types.EndpointVersion{}, fmt.Errorf("version checks: %w", err)
+		}
+
+		status = types.EndpointStatusPending
+	}
+
 	args := db.EndpointVersionCreateParams{
 		ID:          versionID,
 		EndpointID:  end.ID,
@@ -418,9 +645,20 @@ func (e *EndpointService) createAttachEndpointVersion(
 		ProjectID:   end.ProjectID,
 		HttpAddress: httpAddr,
 		CreatedAt:   time.Now(),
+		Status:      string(status),
+	}
+
+	if checker != nil {
+		args.CheckID = sql.NullString{String: checker.checkID, Valid: true}
 	}
 
 	if err := e.store.EndpointVersionCreate(ctx, args); err != nil {
+		e.deleteVersionRoute(ctx, end.ID, versionID)
+
+		if checker != nil {
+			e.abandonCheck(ctx, checker.checkID)
+		}
+
 		return types.EndpointVersion{}, fmt.Errorf("version store: %w", err)
 	}
 
@@ -428,27 +666,127 @@ func (e *EndpointService) createAttachEndpointVersion(
 		ID:          versionID,
 		ExecID:      execID,
 		HTTPAddress: httpAddr,
+		Status:      status,
 		Primary:     promote,
 		CreatedAt:   args.CreatedAt,
+		CheckID:     args.CheckID.String,
 	}
 
 	if version.Primary {
-		if err := e.setPrimary(ctx, end, version, internalPort); err != nil {
+		by := promotion{reason: types.PromotionCreate, userID: userID}
+		if err := e.setPrimary(ctx, end, version, internalPort, nil, by); err != nil {
 			return types.EndpointVersion{}, fmt.Errorf("promote: %w", err)
 		}
 	}
 
+	if checker != nil {
+		//nolint:contextcheck
+		if err := checker.Run(context.Background(), func(ctx context.Context) {
+			e.notifyCheckCompleted(ctx, end, checker.checkID)
+			by := promotion{reason: types.PromotionChecksPassed, userID: userID}
+			e.versionCheckCompleted(ctx, end.ProjectID, end.ID, version, internalPort, by)
+		}); err != nil {
+			return types.EndpointVersion{}, fmt.Errorf("run version checks: %w", err)
+		}
+	}
+
 	return version, nil
 }
 
+// deleteVersionRoute removes the driver's route of a version that failed to be created.
+func (e *EndpointService) deleteVersionRoute(ctx context.Context, endpointID, versionID string) {
+	if err := e.driver.EndpointVersionDelete(ctx, endpointID, versionID); err != nil {
+		log.Ctx(ctx).Error().
+			Err(err).
+			Str(types.EndpointIDCtxKey, endpointID).
+			Str(types.VersionIDCtxKey, versionID).
+			Msg("Failed to delete route of failed version")
+	}
+}
+
+// versionCheckCompleted promotes the version if the check gating it succeeded and marks
+// it failed otherwise.
+func (e *EndpointService) versionCheckCompleted(
+	ctx context.Context,
+	projectID,
+	endpointID string,
+	version types.EndpointVersion,
+	internalPort int32,
+	by promotion,
+) {
+	logger := log.Ctx(ctx).With().
+		Str(types.EndpointIDCtxKey, endpointID).
+		Str(types.VersionIDCtxKey, version.ID).
+		Str("check_id", version.CheckID).
+		Logger()
+
+	status := types.EndpointStatusFailed
+
+	check, err := e.EndpointCheckStatus(ctx, projectID, version.CheckID)
+
+	switch {
+	case err != nil:
+		logger.Error().Err(err).Msg("Failed to get version check status")
+	case check.Conclusion == nil || *check.Conclusion != types.CheckSuccess:
+		logger.Info().Msg("Version failed its checks, not promoting")
+	default:
+		if err := e.promoteCheckedVersion(ctx, projectID, endpointID, version, internalPort, by); err != nil {
+			logger.Error().Err(err).Msg("Failed to promote version after its checks passed")
+			break
+		}
+
+		status = types.EndpointStatusDeployed
+		logger.Info().Msg("Promoted version after its checks passed")
+	}
+
+	if err := e.store.EndpointVersionStatusUpdate(ctx, db.EndpointVersionStatusUpdateParams{
+		ID:     version.ID,
+		Status: string(status),
+	}); err != nil {
+		logger.Error().Err(err).Msg("Failed to update version status")
+	}
+}
+
+func (e *EndpointService) promoteCheckedVersion(
+	ctx context.Context,
+	projectID,
+	endpointID string,
+	version types.EndpointVersion,
+	internalPort int32,
+	by promotion,
+) error {
+	end, err := e.EndpointGet(ctx, projectID, endpointID)
+	if err != nil {
+		return fmt.Errorf("endpoint get: %w", err)
+	}
+
+	if canaryInProgress(end) {
+		return errCanaryInProgress(e.driver.EndpointProvider())
+	}
+
+	return e.setPrimary(ctx, end, version, internalPort, nil, by)
+}
+
+// setPrimary routes all of the endpoint's traffic to the version and records the
+// promotion. The canary, if any, is saved with the new traffic policy.
 func (e *EndpointService) setPrimary(
 	ctx context.Context,
 	end types.Endpoint,
 	version types.EndpointVersion,
 	internalPort int32,
+	canary *types.EndpointCanary,
+	by promotion,
 ) error {
+	previous, _ := primaryVersion(end)
+	record := by.params(end.ID, version.ID, previous.ID)
+
 	demoteVersions(&end)
 
+	traffic, err := trafficParams(end.ID, primaryTraffic(version.ID), canary)
+	if err != nil {
+		return err
+	}
+
 	if err := e.driver.EndpointVersionPromote(
 		ctx,
 		end.ID,
@@ -467,6 +805,14 @@ func (e *EndpointService) setPrimary(
 			return fmt.Errorf("promote: %w", err)
 		}
 
+		if err := q.EndpointTrafficSet(ctx, traffic); err != nil {
+			return fmt.Errorf("traffic: %w", err)
+		}
+
+		if err := q.EndpointPromotionCreate(ctx, record); err != nil {
+			return fmt.Errorf("record promotion: %w", err)
+		}
+
 		return nil
 	}
 
@@ -485,6 +831,10 @@ func demoteVersions(end *types.Endpoint) {
 
 // stepStatusAndConclusion infers the status of a step based on the contents of the database.
 func stepStatusAndConclusion(step db.UnweaveEndpointCheckStep) (types.CheckStatus, *types.CheckConclusion) {
+	if step.Error.Valid {
+		return types.CheckCompleted, &types.CheckError
+	}
+
 	hasModelOutput := step.Output.Valid
 	hasAssertionOutput := step.Assertion.Valid
 
@@ -2,9 +2,12 @@
 package endpointsrv
 
 import (
+	"context"
 	"database/sql"
 	"errors"
+	"net/http"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/require"
 	"github.com/unweave/unweave-v1/api/types"
@@ -73,6 +76,16 @@ func TestStepStatusAndConclusion(t *testing.T) {
 				},
 				expectedConclusion: types.CheckError,
 			},
+			{
+				name: "step error",
+				step: func() db.UnweaveEndpointCheckStep {
+					step := builder.mustBuild()
+					step.Error = sql.NullString{String: "call endpoint: timeout", Valid: true}
+
+					return step
+				},
+				expectedConclusion: types.CheckError,
+			},
 		}
 
 		for _, test := range testCases {
@@ -217,3 +230,121 @@ func (b dbEndpointCheckStepBuilder) mustBuild() db.UnweaveEndpointCheckStep {
 
 	return step
 }
+
+func TestEndpointVersionCreate_PromoteOnPass(t *testing.T) {
+	ctx := context.Background()
+	params := types.EndpointVersionCreateParams{ExecID: "exc_3", PromoteOnPass: true}
+
+	versionStatus := func(srv *EndpointService, versionID string) types.EndpointStatus {
+		end, err := srv.EndpointGet(ctx, "prj_1", "end_1")
+		require.NoError(t, err)
+
+		version, ok := findVersion(end, versionID)
+		require.True(t, ok)
+
+		return version.Status
+	}
+
+	t.Run("passing checks promote", func(t *testing.T) {
+		eval, targets := evalServer(t, "success")
+		srv, _, driver := newCanaryTestService(eval)
+
+		version, err := srv.EndpointVersionCreate(ctx, "prj_1", "end_1", "usr_1", params)
+		require.NoError(t, err)
+		require.Equal(t, types.EndpointStatusPending, version.Status)
+		require.NotEmpty(t, version.CheckID)
+		require.False(t, version.Primary)
+
+		require.Eventually(t, func() bool {
+			return versionStatus(srv, version.ID) == types.EndpointStatusDeployed
+		}, 5*time.Second, 10*time.Millisecond)
+
+		require.Equal(t, []string{version.ID}, driver.promoted)
+		require.Equal(t, []string{"https://" + version.HTTPAddress + "/"}, targets(), "should check the new version")
+
+		end, err := srv.EndpointGet(ctx, "prj_1", "end_1")
+		require.NoError(t, err)
+
+		primary, ok := primaryVersion(end)
+		require.True(t, ok)
+		require.Equal(t, version.ID, primary.ID)
+	})
+
+	t.Run("failing checks do not promote", func(t *testing.T) {
+		eval, _ := evalServer(t, "failure")
+		srv, _, driver := newCanaryTestService(eval)
+
+		version, err := srv.EndpointVersionCreate(ctx, "prj_1", "end_1", "usr_1", params)
+		require.NoError(t, err)
+
+		require.Eventually(t, func() bool {
+			return versionStatus(srv, version.ID) == types.EndpointStatusFailed
+		}, 5*time.Second, 10*time.Millisecond)
+
+		require.Empty(t, driver.promoted)
+
+		end, err := srv.EndpointGet(ctx, "prj_1", "end_1")
+		require.NoError(t, err)
+
+		primary, ok := primaryVersion(end)
+		require.True(t, ok)
+		require.Equal(t, "ver_1", primary.ID)
+	})
+
+	t.Run("failing to create checks deletes the route", func(t *testing.T) {
+		eval := evalServerFunc(t, "success", "not a dataset", nil)
+		srv, store, driver := newCanaryTestService(eval)
+
+		_, err := srv.EndpointVersionCreate(ctx, "prj_1", "end_1", "usr_1", params)
+		require.ErrorIs(t, err, ErrInvalidDataset)
+
+		require.Len(t, driver.deleted, 1)
+		require.Len(t, store.versions, 2, "should not store the version")
+		require.Len(t, store.checks, 1)
+
+		for _, check := range store.checks {
+			require.Equal(t, string(types.CheckCancelled), check.Status)
+		}
+	})
+
+	t.Run("failing to store the version deletes the route and cancels its check", func(t *testing.T) {
+		eval, targets := evalServer(t, "success")
+		srv, store, driver := newCanaryTestService(eval)
+		store.versionCreateErr = errors.New("store unavailable")
+
+		_, err := srv.EndpointVersionCreate(ctx, "prj_1", "end_1", "usr_1", params)
+		require.ErrorIs(t, err, store.versionCreateErr)
+
+		require.Len(t, driver.deleted, 1)
+		require.Len(t, store.checks, 1)
+
+		for _, check := range store.checks {
+			require.Equal(t, string(types.CheckCancelled), check.Status)
+		}
+
+		require.Empty(t, targets(), "should not run the check")
+	})
+
+	t.Run("rejects execs of other projects", func(t *testing.T) {
+		srv, store, driver := newCanaryTestService()
+		store.execProjects = map[string]string{"exc_other": "prj_2"}
+
+		_, err := srv.EndpointVersionCreate(ctx, "prj_1", "end_1", "usr_1", types.EndpointVersionCreateParams{ExecID: "exc_other"})
+
+		var e *types.Error
+		require.True(t, errors.As(err, &e))
+		require.Equal(t, http.StatusNotFound, e.Code)
+		require.Len(t, store.versions, 2, "should not store the version")
+		require.Empty(t, driver.deleted)
+	})
+
+	t.Run("requires evals", func(t *testing.T) {
+		srv, _, _ := newCanaryTestService()
+
+		_, err := srv.EndpointVersionCreate(ctx, "prj_1", "end_1", "usr_1", params)
+
+		var e *types.Error
+		require.True(t, errors.As(err, &e))
+		require.Equal(t, http.StatusBadRequest, e.Code)
+	})
+}
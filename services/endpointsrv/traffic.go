@@ -0,0 +1,409 @@
+package endpointsrv
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/rs/zerolog/log"
+	"github.com/unweave/unweave-v1/api/types"
+	"github.com/unweave/unweave-v1/db"
+)
+
+// EndpointTrafficSet routes the endpoint's traffic by the policy. The policy can't be
+// changed while a canary is in progress.
+func (e *EndpointService) EndpointTrafficSet(
+	ctx context.Context,
+	projectID,
+	endpointID string,
+	policy types.EndpointTrafficPolicy,
+) (types.EndpointTrafficPolicy, error) {
+	end, err := e.EndpointGet(ctx, projectID, endpointID)
+	if err != nil {
+		return types.EndpointTrafficPolicy{}, fmt.Errorf("endpoint get: %w", err)
+	}
+
+	if canaryInProgress(end) {
+		return types.EndpointTrafficPolicy{}, errCanaryInProgress(e.driver.EndpointProvider())
+	}
+
+	for _, id := range policy.VersionIDs() {
+		if _, ok := findVersion(end, id); !ok {
+			return types.EndpointTrafficPolicy{}, errVersionNotFound(e.driver.EndpointProvider(), end.ID, id)
+		}
+	}
+
+	if err := e.setTraffic(ctx, end.ID, policy, nil); err != nil {
+		return types.EndpointTrafficPolicy{}, err
+	}
+
+	return policy, nil
+}
+
+// EndpointCanaryStart shifts the first step of the endpoint's traffic from its primary
+// version to the canary version and runs the endpoint's evals against the canary.
+func (e *EndpointService) EndpointCanaryStart(
+	ctx context.Context,
+	projectID,
+	endpointID,
+	userID string,
+	params types.EndpointCanaryCreateParams,
+) (types.EndpointCanary, error) {
+	end, err := e.EndpointGet(ctx, projectID, endpointID)
+	if err != nil {
+		return types.EndpointCanary{}, fmt.Errorf("endpoint get: %w", err)
+	}
+
+	if canaryInProgress(end) {
+		return types.EndpointCanary{}, errCanaryInProgress(e.driver.EndpointProvider())
+	}
+
+	stable, ok := primaryVersion(end)
+	if !ok {
+		return types.EndpointCanary{}, &types.Error{
+			Code:       http.StatusConflict,
+			Message:    "Endpoint has no primary version",
+			Suggestion: "Promote a version before starting a canary",
+			Provider:   e.driver.EndpointProvider(),
+		}
+	}
+
+	if _, ok := findVersion(end, params.VersionID); !ok {
+		return types.EndpointCanary{}, errVersionNotFound(e.driver.EndpointProvider(), end.ID, params.VersionID)
+	}
+
+	if params.VersionID == stable.ID {
+		return types.EndpointCanary{}, &types.Error{
+			Code:       http.StatusBadRequest,
+			Message:    "Version is already the primary version",
+			Suggestion: "Start the canary with a new version",
+			Provider:   e.driver.EndpointProvider(),
+		}
+	}
+
+	steps := params.Steps
+	if len(steps) == 0 {
+		steps = types.DefaultCanarySteps
+	}
+
+	canary := types.EndpointCanary{
+		VersionID:       params.VersionID,
+		StableVersionID: stable.ID,
+		Steps:           steps,
+		Step:            0,
+		Status:          types.CanaryProgressing,
+	}
+
+	return e.rollCanary(ctx, end, canary, userID)
+}
+
+// EndpointCanaryAdvance shifts the next step of the endpoint's traffic to the canary once
+// the checks of the current step have passed. The canary is promoted at 100%.
+func (e *EndpointService) EndpointCanaryAdvance(ctx context.Context, projectID, endpointID, userID string) (types.EndpointCanary, error) {
+	end, err := e.EndpointGet(ctx, projectID, endpointID)
+	if err != nil {
+		return types.EndpointCanary{}, fmt.Errorf("endpoint get: %w", err)
+	}
+
+	if !canaryInProgress(end) {
+		return types.EndpointCanary{}, errNoCanary(e.driver.EndpointProvider())
+	}
+
+	canary := *end.Canary
+
+	if canary.CheckID != "" {
+		check, err := e.EndpointCheckStatus(ctx, projectID, canary.CheckID)
+		if err != nil {
+			return types.EndpointCanary{}, fmt.Errorf("check status: %w", err)
+		}
+
+		if check.Status != types.CheckCompleted {
+			return types.EndpointCanary{}, &types.Error{
+				Code:       http.StatusConflict,
+				Message:    "Canary checks are still running",
+				Suggestion: fmt.Sprintf("Wait for check %s to complete", canary.CheckID),
+				Provider:   e.driver.EndpointProvider(),
+			}
+		}
+
+		if *check.Conclusion != types.CheckSuccess {
+			return e.rollbackCanary(ctx, end, fmt.Sprintf("Check %s concluded with %s", check.CheckID, *check.Conclusion))
+		}
+	}
+
+	canary.Step++
+	canary.CheckID = ""
+
+	return e.rollCanary(ctx, end, canary, userID)
+}
+
+// EndpointCanaryRollback routes all of the endpoint's traffic back to its stable version.
+func (e *EndpointService) EndpointCanaryRollback(ctx context.Context, projectID, endpointID string) (types.EndpointCanary, error) {
+	end, err := e.EndpointGet(ctx, projectID, endpointID)
+	if err != nil {
+		return types.EndpointCanary{}, fmt.Errorf("endpoint get: %w", err)
+	}
+
+	if !canaryInProgress(end) {
+		return types.EndpointCanary{}, errNoCanary(e.driver.EndpointProvider())
+	}
+
+	return e.rollbackCanary(ctx, end, "Rolled back on request")
+}
+
+// rollCanary routes the traffic of the canary's current step. The canary is promoted if
+// the step is 100%, otherwise the endpoint's evals are run against it.
+func (e *EndpointService) rollCanary(
+	ctx context.Context,
+	end types.Endpoint,
+	canary types.EndpointCanary,
+	userID string,
+) (types.EndpointCanary, error) {
+	version, _ := findVersion(end, canary.VersionID)
+
+	if canary.Weight() == 100 {
+		exec, err := e.execs.Get(ctx, version.ExecID)
+		if err != nil {
+			return types.EndpointCanary{}, fmt.Errorf("exec get: %w", err)
+		}
+
+		if exec.Network.HTTPService == nil {
+			return types.EndpointCanary{}, errNoHTTPService(e.driver.EndpointProvider(), version.ID)
+		}
+
+		canary.Status = types.CanaryPromoted
+
+		by := promotion{reason: types.PromotionCanary, userID: userID}
+		if err := e.setPrimary(ctx, end, version, exec.Network.HTTPService.InternalPort, &canary, by); err != nil {
+			return types.EndpointCanary{}, fmt.Errorf("promote canary: %w", err)
+		}
+
+		return canary, nil
+	}
+
+	var checker *endpointChecker
+
+	if len(end.EvalIDs) > 0 {
+		// Check the canary on its own address rather than the endpoint's, which only
+		// routes part of the traffic to it.
+		target := end
+		target.HTTPAddress = version.HTTPAddress
+
+		var err error
+
+		checker, err = e.createChecks(ctx, target)
+		if err != nil {
+			return types.EndpointCanary{}, fmt.Errorf("canary checks: %w", err)
+		}
+
+		canary.CheckID = checker.checkID
+	}
+
+	policy := types.EndpointTrafficPolicy{
+		Weights: []types.EndpointTrafficWeight{
+			{VersionID: canary.StableVersionID, Weight: 100 - canary.Weight()},
+			{VersionID: canary.VersionID, Weight: canary.Weight()},
+		},
+	}
+
+	if err := e.setTraffic(ctx, end.ID, policy, &canary); err != nil {
+		return types.EndpointCanary{}, err
+	}
+
+	if checker != nil {
+		//nolint:contextcheck
+		if err := checker.Run(context.Background(), func(ctx context.Context) {
+			e.notifyCheckCompleted(ctx, end, checker.checkID)
+			e.canaryCheckCompleted(ctx, end.ProjectID, end.ID, checker.checkID)
+		}); err != nil {
+			return types.EndpointCanary{}, fmt.Errorf("run canary checks: %w", err)
+		}
+	}
+
+	return canary, nil
+}
+
+// canaryCheckCompleted rolls the canary back if the check of its current step didn't
+// succeed.
+func (e *EndpointService) canaryCheckCompleted(ctx context.Context, projectID, endpointID, checkID string) {
+	logger := log.Ctx(ctx).With().Str(types.EndpointIDCtxKey, endpointID).Str("check_id", checkID).Logger()
+
+	end, err := e.EndpointGet(ctx, projectID, endpointID)
+	if err != nil {
+		logger.Error().Err(err).Msg("Failed to get endpoint of canary check")
+		return
+	}
+
+	if !canaryInProgress(end) || end.Canary.CheckID != checkID {
+		return
+	}
+
+	check, err := e.EndpointCheckStatus(ctx, projectID, checkID)
+	if err != nil {
+		logger.Error().Err(err).Msg("Failed to get canary check status")
+		return
+	}
+
+	if check.Conclusion != nil && *check.Conclusion == types.CheckSuccess {
+		return
+	}
+
+	conclusion := types.CheckError
+	if check.Conclusion != nil {
+		conclusion = *check.Conclusion
+	}
+
+	if _, err := e.rollbackCanary(ctx, end, fmt.Sprintf("Check %s concluded with %s", checkID, conclusion)); err != nil {
+		logger.Error().Err(err).Msg("Failed to roll back canary")
+		return
+	}
+
+	logger.Info().Msg("Rolled back canary after failed checks")
+}
+
+func (e *EndpointService) rollbackCanary(ctx context.Context, end types.Endpoint, reason string) (types.EndpointCanary, error) {
+	canary := *end.Canary
+	canary.Status = types.CanaryRolledBack
+	canary.Reason = reason
+
+	if err := e.setTraffic(ctx, end.ID, primaryTraffic(canary.StableVersionID), &canary); err != nil {
+		return types.EndpointCanary{}, err
+	}
+
+	return canary, nil
+}
+
+func (e *EndpointService) setTraffic(
+	ctx context.Context,
+	endpointID string,
+	policy types.EndpointTrafficPolicy,
+	canary *types.EndpointCanary,
+) error {
+	params, err := trafficParams(endpointID, policy, canary)
+	if err != nil {
+		return err
+	}
+
+	if err := e.driver.EndpointTrafficSet(ctx, endpointID, policy); err != nil {
+		return fmt.Errorf("driver set traffic: %w", err)
+	}
+
+	if err := e.store.EndpointTrafficSet(ctx, params); err != nil {
+		return fmt.Errorf("save traffic: %w", err)
+	}
+
+	return nil
+}
+
+// endpointTraffic returns the endpoint's traffic policy and canary, nil if it has none.
+func (e *EndpointService) endpointTraffic(
+	ctx context.Context,
+	endpointID string,
+) (*types.EndpointTrafficPolicy, *types.EndpointCanary, error) {
+	row, err := e.store.EndpointTraffic(ctx, endpointID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil, nil
+	}
+
+	if err != nil {
+		return nil, nil, fmt.Errorf("get traffic: %w", err)
+	}
+
+	var policy types.EndpointTrafficPolicy
+	if err := json.Unmarshal(row.Policy, &policy); err != nil {
+		return nil, nil, fmt.Errorf("unmarshal policy: %w", err)
+	}
+
+	var canary *types.EndpointCanary
+	if err := json.Unmarshal(row.Canary, &canary); err != nil {
+		return nil, nil, fmt.Errorf("unmarshal canary: %w", err)
+	}
+
+	return &policy, canary, nil
+}
+
+func trafficParams(
+	endpointID string,
+	policy types.EndpointTrafficPolicy,
+	canary *types.EndpointCanary,
+) (db.EndpointTrafficSetParams, error) {
+	p, err := json.Marshal(policy)
+	if err != nil {
+		return db.EndpointTrafficSetParams{}, fmt.Errorf("marshal policy: %w", err)
+	}
+
+	c, err := json.Marshal(canary)
+	if err != nil {
+		return db.EndpointTrafficSetParams{}, fmt.Errorf("marshal canary: %w", err)
+	}
+
+	return db.EndpointTrafficSetParams{EndpointID: endpointID, Policy: p, Canary: c}, nil
+}
+
+func primaryTraffic(versionID string) types.EndpointTrafficPolicy {
+	return types.EndpointTrafficPolicy{
+		Weights: []types.EndpointTrafficWeight{{VersionID: versionID, Weight: 100}},
+	}
+}
+
+func canaryInProgress(end types.Endpoint) bool {
+	return end.Canary != nil && end.Canary.Status == types.CanaryProgressing
+}
+
+func primaryVersion(end types.Endpoint) (types.EndpointVersion, bool) {
+	for _, v := range end.Versions {
+		if v.Primary {
+			return v, true
+		}
+	}
+
+	return types.EndpointVersion{}, false
+}
+
+func findVersion(end types.Endpoint, versionID string) (types.EndpointVersion, bool) {
+	for _, v := range end.Versions {
+		if v.ID == versionID {
+			return v, true
+		}
+	}
+
+	return types.EndpointVersion{}, false
+}
+
+func errCanaryInProgress(provider types.Provider) error {
+	return &types.Error{
+		Code:       http.StatusConflict,
+		Message:    "Endpoint has a canary in progress",
+		Suggestion: "Advance or roll back the canary first",
+		Provider:   provider,
+	}
+}
+
+func errNoCanary(provider types.Provider) error {
+	return &types.Error{
+		Code:       http.StatusConflict,
+		Message:    "Endpoint has no canary in progress",
+		Suggestion: "Start a canary first",
+		Provider:   provider,
+	}
+}
+
+func errNoHTTPService(provider types.Provider, versionID string) error {
+	return &types.Error{
+		Code:       http.StatusConflict,
+		Message:    fmt.Sprintf("Version %q doesn't expose an HTTP service", versionID),
+		Suggestion: "Promote a version created on an exec with a port",
+		Provider:   provider,
+	}
+}
+
+func errVersionNotFound(provider types.Provider, endpointID, versionID string) error {
+	return &types.Error{
+		Code:     http.StatusNotFound,
+		Message:  fmt.Sprintf("Version %q not found on endpoint %q", versionID, endpointID),
+		Provider: provider,
+	}
+}
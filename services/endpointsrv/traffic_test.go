@@ -0,0 +1,487 @@
+//nolint:paralleltest,testpackage
+package endpointsrv
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"github.com/unweave/unweave-v1/api/types"
+	"github.com/unweave/unweave-v1/db"
+	"github.com/unweave/unweave-v1/services/evalsrv"
+	"github.com/unweave/unweave-v1/services/execsrv"
+)
+
+// memoryStore keeps the endpoint tables used by canaries in memory.
+type memoryStore struct {
+	db.Querier
+
+	mu       sync.Mutex
+	endpoint db.UnweaveEndpoint
+	evalIDs  []string
+	versions []db.UnweaveEndpointVersion
+	traffic  *db.UnweaveEndpointTraffic
+	checks   map[string]db.UnweaveEndpointCheck
+	steps    []db.UnweaveEndpointCheckStep
+
+	promotions []db.UnweaveEndpointPromotion
+
+	// execProjects are the projects of execs outside prj_1.
+	execProjects map[string]string
+
+	versionCreateErr error
+}
+
+func (m *memoryStore) Tx(txFunc func(db.Querier) error) error {
+	return txFunc(m)
+}
+
+func (m *memoryStore) ExecGet(_ context.Context, id string) (db.UnweaveExec, error) {
+	projectID, ok := m.execProjects[id]
+	if !ok {
+		projectID = "prj_1"
+	}
+
+	return db.UnweaveExec{ID: id, ProjectID: projectID}, nil
+}
+
+func (m *memoryStore) EndpointGet(context.Context, db.EndpointGetParams) (db.UnweaveEndpoint, error) {
+	return m.endpoint, nil
+}
+
+func (m *memoryStore) EndpointEval(_ context.Context, endpointID string) ([]db.UnweaveEndpointEval, error) {
+	out := make([]db.UnweaveEndpointEval, len(m.evalIDs))
+	for i, id := range m.evalIDs {
+		out[i] = db.UnweaveEndpointEval{EndpointID: endpointID, EvalID: id}
+	}
+
+	return out, nil
+}
+
+func (m *memoryStore) EndpointVersionList(context.Context, string) ([]db.UnweaveEndpointVersion, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	return append([]db.UnweaveEndpointVersion{}, m.versions...), nil
+}
+
+func (m *memoryStore) EndpointVersionCreate(_ context.Context, arg db.EndpointVersionCreateParams) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if m.versionCreateErr != nil {
+		return m.versionCreateErr
+	}
+
+	m.versions = append(m.versions, db.UnweaveEndpointVersion{
+		ID:          arg.ID,
+		EndpointID:  arg.EndpointID,
+		ExecID:      arg.ExecID,
+		ProjectID:   arg.ProjectID,
+		HttpAddress: arg.HttpAddress,
+		CreatedAt:   arg.CreatedAt,
+		Status:      arg.Status,
+		CheckID:     arg.CheckID,
+	})
+
+	return nil
+}
+
+func (m *memoryStore) EndpointVersionStatusUpdate(_ context.Context, arg db.EndpointVersionStatusUpdateParams) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	for i := range m.versions {
+		if m.versions[i].ID == arg.ID {
+			m.versions[i].Status = arg.Status
+		}
+	}
+
+	return nil
+}
+
+func (m *memoryStore) EndpointVersionDemote(context.Context, string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	for i := range m.versions {
+		m.versions[i].PrimaryVersion = false
+	}
+
+	return nil
+}
+
+func (m *memoryStore) EndpointVersionPromote(_ context.Context, id string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	for i := range m.versions {
+		if m.versions[i].ID == id {
+			m.versions[i].PrimaryVersion = true
+		}
+	}
+
+	return nil
+}
+
+func (m *memoryStore) EndpointTraffic(context.Context, string) (db.UnweaveEndpointTraffic, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if m.traffic == nil {
+		return db.UnweaveEndpointTraffic{}, sql.ErrNoRows
+	}
+
+	return *m.traffic, nil
+}
+
+func (m *memoryStore) EndpointTrafficSet(_ context.Context, arg db.EndpointTrafficSetParams) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.traffic = &db.UnweaveEndpointTraffic{EndpointID: arg.EndpointID, Policy: arg.Policy, Canary: arg.Canary}
+
+	return nil
+}
+
+func (m *memoryStore) EndpointPromotionCreate(_ context.Context, arg db.EndpointPromotionCreateParams) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.promotions = append(m.promotions, db.UnweaveEndpointPromotion{
+		ID:                arg.ID,
+		EndpointID:        arg.EndpointID,
+		VersionID:         arg.VersionID,
+		PreviousVersionID: arg.PreviousVersionID,
+		PromotedBy:        arg.PromotedBy,
+		Reason:            arg.Reason,
+		CreatedAt:         time.Now(),
+	})
+
+	return nil
+}
+
+func (m *memoryStore) EndpointPromotionList(context.Context, string) ([]db.UnweaveEndpointPromotion, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	return append([]db.UnweaveEndpointPromotion{}, m.promotions...), nil
+}
+
+func (m *memoryStore) EndpointCheckCreate(_ context.Context, arg db.EndpointCheckCreateParams) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if m.checks == nil {
+		m.checks = map[string]db.UnweaveEndpointCheck{}
+	}
+
+	m.checks[arg.ID] = db.UnweaveEndpointCheck{
+		ID:         arg.ID,
+		EndpointID: arg.EndpointID,
+		ProjectID:  arg.ProjectID,
+		Status:     string(types.CheckPending),
+	}
+
+	return nil
+}
+
+func (m *memoryStore) EndpointCheck(_ context.Context, id string) (db.UnweaveEndpointCheck, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	check, ok := m.checks[id]
+	if !ok {
+		return db.UnweaveEndpointCheck{}, sql.ErrNoRows
+	}
+
+	return check, nil
+}
+
+func (m *memoryStore) EndpointCheckStatusUpdate(_ context.Context, arg db.EndpointCheckStatusUpdateParams) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	check, ok := m.checks[arg.ID]
+	if ok && check.Status != string(types.CheckCancelled) {
+		check.Status = arg.Status
+		m.checks[arg.ID] = check
+	}
+
+	return nil
+}
+
+func (m *memoryStore) EndpointCheckStepCreate(_ context.Context, arg db.EndpointCheckStepCreateParams) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.steps = append(m.steps, db.UnweaveEndpointCheckStep{ID: arg.ID, CheckID: arg.CheckID, EvalID: arg.EvalID, Input: arg.Input})
+
+	return nil
+}
+
+func (m *memoryStore) EndpointCheckStepUpdate(_ context.Context, arg db.EndpointCheckStepUpdateParams) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	for i := range m.steps {
+		if m.steps[i].ID != arg.ID.String {
+			continue
+		}
+
+		if arg.Output.Valid {
+			m.steps[i].Output = arg.Output
+		}
+
+		if arg.Assertion.Valid {
+			m.steps[i].Assertion = arg.Assertion
+		}
+
+		if arg.Error.Valid {
+			m.steps[i].Error = arg.Error
+		}
+	}
+
+	return nil
+}
+
+func (m *memoryStore) EndpointCheckSteps(_ context.Context, checkID string) ([]db.UnweaveEndpointCheckStep, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	var out []db.UnweaveEndpointCheckStep
+
+	for _, step := range m.steps {
+		if step.CheckID == checkID {
+			out = append(out, step)
+		}
+	}
+
+	return out, nil
+}
+
+// trafficDriver records the traffic routed by the service.
+type trafficDriver struct {
+	Driver
+
+	mu       sync.Mutex
+	policies []types.EndpointTrafficPolicy
+	promoted []string
+	deleted  []string
+}
+
+func (d *trafficDriver) EndpointProvider() types.Provider {
+	return types.UnweaveProvider
+}
+
+func (d *trafficDriver) EndpointVersionCreate(_ context.Context, _, _, versionID, _ string, _ int32) (string, error) {
+	return strings.ReplaceAll(versionID, "_", "-") + ".endpoints.test", nil
+}
+
+func (d *trafficDriver) EndpointVersionDelete(_ context.Context, _, versionID string) error {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	d.deleted = append(d.deleted, versionID)
+
+	return nil
+}
+
+func (d *trafficDriver) EndpointVersionPromote(_ context.Context, _, versionID string, _ int32) error {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	d.promoted = append(d.promoted, versionID)
+
+	return nil
+}
+
+func (d *trafficDriver) EndpointTrafficSet(_ context.Context, _ string, policy types.EndpointTrafficPolicy) error {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	d.policies = append(d.policies, policy)
+
+	return nil
+}
+
+func (d *trafficDriver) last() types.EndpointTrafficPolicy {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	return d.policies[len(d.policies)-1]
+}
+
+type evalService struct {
+	evalsrv.Service
+
+	evals []types.Eval
+}
+
+func (s evalService) Evals(context.Context, []string) ([]types.Eval, error) {
+	return s.evals, nil
+}
+
+type execService struct {
+	execsrv.Service
+}
+
+func (execService) Get(_ context.Context, id string) (types.Exec, error) {
+	return types.Exec{ID: id, Network: types.ExecNetwork{HTTPService: &types.HTTPService{InternalPort: 8080}}}, nil
+}
+
+func newCanaryTestService(evals ...types.Eval) (*EndpointService, *memoryStore, *trafficDriver) {
+	store := &memoryStore{
+		endpoint: db.UnweaveEndpoint{ID: "end_1", ProjectID: "prj_1", HttpAddress: "predict.endpoints.test"},
+		versions: []db.UnweaveEndpointVersion{
+			{ID: "ver_1", EndpointID: "end_1", ExecID: "exc_1", HttpAddress: "ver-1.endpoints.test", PrimaryVersion: true},
+			{ID: "ver_2", EndpointID: "end_1", ExecID: "exc_2", HttpAddress: "ver-2.endpoints.test"},
+		},
+	}
+
+	for _, eval := range evals {
+		store.evalIDs = append(store.evalIDs, eval.ID)
+	}
+
+	driver := &trafficDriver{}
+	srv := NewEndpointService(store, evalService{evals: evals}, execService{}, driver)
+
+	return srv, store, driver
+}
+
+func canaryWeights(stable, canary int) types.EndpointTrafficPolicy {
+	return types.EndpointTrafficPolicy{Weights: []types.EndpointTrafficWeight{
+		{VersionID: "ver_1", Weight: stable},
+		{VersionID: "ver_2", Weight: canary},
+	}}
+}
+
+func TestEndpointCanary(t *testing.T) {
+	ctx := context.Background()
+	srv, store, driver := newCanaryTestService()
+
+	canary, err := srv.EndpointCanaryStart(ctx, "prj_1", "end_1", "usr_1", types.EndpointCanaryCreateParams{VersionID: "ver_2"})
+	require.NoError(t, err)
+	require.Equal(t, types.CanaryProgressing, canary.Status)
+	require.Equal(t, "ver_1", canary.StableVersionID)
+	require.Equal(t, canaryWeights(90, 10), driver.last())
+
+	_, err = srv.EndpointTrafficSet(ctx, "prj_1", "end_1", canaryWeights(50, 50))
+
+	var e *types.Error
+	require.True(t, errors.As(err, &e))
+	require.Equal(t, http.StatusConflict, e.Code, "should not change traffic during a canary")
+
+	canary, err = srv.EndpointCanaryAdvance(ctx, "prj_1", "end_1", "usr_1")
+	require.NoError(t, err)
+	require.Equal(t, 1, canary.Step)
+	require.Equal(t, canaryWeights(50, 50), driver.last())
+
+	canary, err = srv.EndpointCanaryAdvance(ctx, "prj_1", "end_1", "usr_1")
+	require.NoError(t, err)
+	require.Equal(t, types.CanaryPromoted, canary.Status)
+	require.Equal(t, []string{"ver_2"}, driver.promoted)
+
+	end, err := srv.EndpointGet(ctx, "prj_1", "end_1")
+	require.NoError(t, err)
+	require.True(t, end.Versions[1].Primary)
+	require.False(t, end.Versions[0].Primary)
+	require.Equal(t, primaryTraffic("ver_2"), *end.Traffic)
+	require.Equal(t, types.CanaryPromoted, end.Canary.Status)
+
+	_, err = srv.EndpointCanaryAdvance(ctx, "prj_1", "end_1", "usr_1")
+	require.True(t, errors.As(err, &e))
+	require.Equal(t, http.StatusConflict, e.Code, "should not advance a finished canary")
+
+	var stored types.EndpointCanary
+	require.NoError(t, json.Unmarshal(store.traffic.Canary, &stored))
+	require.Equal(t, canary, stored)
+}
+
+// evalServer serves an eval asserting result for every input. It returns the eval and
+// the endpoints the eval ran against. The default HTTP client trusts the server until the
+// test ends.
+func evalServer(t *testing.T, result string) (types.Eval, func() []string) {
+	t.Helper()
+
+	var (
+		mu      sync.Mutex
+		targets []string
+	)
+
+	eval := evalServerFunc(t, result, `{"data":[{"input":{"prompt":"hi"}}]}`, func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		targets = append(targets, r.Header.Get("X-Unweave-Target-Endpoint-URL"))
+		mu.Unlock()
+
+		_, _ = w.Write([]byte(`{"output":"bye"}`))
+	})
+
+	return eval, func() []string {
+		mu.Lock()
+		defer mu.Unlock()
+
+		return append([]string{}, targets...)
+	}
+}
+
+// evalServerFunc serves an eval asserting result on the endpoint responses of the dataset,
+// which are served by run.
+func evalServerFunc(t *testing.T, result, dataset string, run http.HandlerFunc) types.Eval {
+	t.Helper()
+
+	srv := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/":
+			_, _ = w.Write([]byte(`{"runURL":"/run"}`))
+		case "/dataset":
+			_, _ = w.Write([]byte(dataset))
+		case "/assert":
+			_, _ = w.Write([]byte(`{"result":"` + result + `"}`))
+		case "/run":
+			run(w, r)
+		}
+	}))
+	t.Cleanup(srv.Close)
+
+	defaultClient := http.DefaultClient
+	http.DefaultClient = srv.Client()
+
+	t.Cleanup(func() { http.DefaultClient = defaultClient })
+
+	return types.Eval{ID: "eval_1", HTTPEndpoint: strings.TrimPrefix(srv.URL, "https://")}
+}
+
+func TestEndpointCanary_RollbackOnFailedChecks(t *testing.T) {
+	ctx := context.Background()
+	eval, targets := evalServer(t, "failure")
+	srv, _, driver := newCanaryTestService(eval)
+
+	canary, err := srv.EndpointCanaryStart(ctx, "prj_1", "end_1", "usr_1", types.EndpointCanaryCreateParams{VersionID: "ver_2", Steps: []int{25, 100}})
+	require.NoError(t, err)
+	require.NotEmpty(t, canary.CheckID)
+	require.Equal(t, canaryWeights(75, 25), driver.last())
+
+	require.Eventually(t, func() bool {
+		end, err := srv.EndpointGet(ctx, "prj_1", "end_1")
+		require.NoError(t, err)
+
+		return end.Canary.Status == types.CanaryRolledBack
+	}, 5*time.Second, 10*time.Millisecond, "should roll back once the check fails")
+
+	require.Equal(t, primaryTraffic("ver_1"), driver.last())
+	require.Empty(t, driver.promoted)
+	require.Equal(t, []string{"https://ver-2.endpoints.test/"}, targets(), "should check the canary version")
+
+	end, err := srv.EndpointGet(ctx, "prj_1", "end_1")
+	require.NoError(t, err)
+	require.Contains(t, end.Canary.Reason, string(types.CheckFailure))
+}
@@ -24,6 +24,8 @@ type StateInformer interface {
 // registered per exec.
 type StateInformerManger interface {
 	Add(exec types.Exec) StateInformer
+	// Get returns the informer watching the exec, if there is one.
+	Get(execID string) (StateInformer, bool)
 	Remove(execID string)
 }
 
@@ -81,6 +83,8 @@ type StatsInformerManger interface {
 	Remove(execID string)
 }
 
+//counterfeiter:generate -o internal/execsrvfakes . StatsObserver
+
 // StatsObserver listens for exec stats and updates the exec based on the implementing
 // policy
 type StatsObserver interface {
@@ -103,6 +107,8 @@ type Heartbeat struct {
 	ExecID string
 	Time   time.Time
 	Status types.Status
+	// Error is set on the last heartbeat sent before the informer gives up on the exec.
+	Error error
 }
 
 // HeartbeatInformer informs observers of heartbeats in registered execs.
@@ -137,3 +143,18 @@ func (fn HeartbeatObserverFactoryFunc) New(exec types.Exec) HeartbeatObserver {
 type HeartbeatObserverFactory interface {
 	New(exec types.Exec) HeartbeatObserver
 }
+
+// An ExpiryWarning is sent shortly before an exec reaches its maximum lifetime and is
+// terminated.
+type ExpiryWarning struct {
+	ExecID    string
+	ExpiresAt time.Time
+}
+
+//counterfeiter:generate -o internal/execsrvfakes . ExpiryObserver
+
+// ExpiryObserver listens for exec expiry warnings.
+type ExpiryObserver interface {
+	ID() string
+	Update(warning ExpiryWarning)
+}
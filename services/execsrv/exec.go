@@ -20,20 +20,48 @@ type Store interface {
 	Get(id string) (types.Exec, error)
 	GetDriver(id string) (string, error)
 	List(filterProject *string, filterProvider *types.Provider, filterActive bool) ([]types.Exec, error)
+	// ListUnfinished returns the execs that are active or stopped, i.e. the ones that
+	// can still change state.
+	ListUnfinished(filterProvider *types.Provider) ([]types.Exec, error)
+	// ListInWindow returns the execs of the project that were alive at some point in
+	// [from, to], i.e. created by to and not exited before from. Their keys and volumes
+	// aren't loaded.
+	ListInWindow(projectID string, from, to time.Time) ([]types.Exec, error)
 	Delete(id string) error
-	Update(id string, exec types.Exec) error
+	// Finish soft deletes an exec whose command exited, same as Delete, but with the final
+	// status and exit code of the command. The exit code is nil if it's unknown.
+	Finish(id string, status types.Status, exitCode *int) error
 	UpdateStatus(id string, status types.Status, setReadyAt, setExitedAt time.Time) error
 	UpdateConnectionInfo(execID string, info types.ConnectionInfo) error
+	UpdateTerminationReason(execID string, reason string) error
+	AddEvent(event types.ExecEvent) error
+	// ListEvents returns the history of the exec, oldest first.
+	ListEvents(execID string) ([]types.ExecEvent, error)
+	// ListEventsByExecs returns the histories of the execs by exec id, oldest first.
+	ListEventsByExecs(execIDs []string) (map[string][]types.ExecEvent, error)
+}
+
+// ExitCodeDriver is implemented by drivers that can report the exit code of an exec's
+// command once it exits. Only these drivers can run jobs.
+type ExitCodeDriver interface {
+	ExecExitCode(ctx context.Context, execID string) (int, error)
 }
 
 //counterfeiter:generate -o internal/execsrvfakes . Driver
 
 type Driver interface {
-	ExecCreate(ctx context.Context, project, image string, spec types.HardwareSpec, network types.ExecNetwork, volumes []types.ExecVolume, pubKeys []string, region *string) (string, error)
+	// ExecCreate starts an exec running the image with command, or the image's default
+	// command if it's empty. Drivers that run their own entrypoint in the image ignore it.
+	ExecCreate(ctx context.Context, project, image string, command []string, spec types.HardwareSpec, network types.ExecNetwork, volumes []types.ExecVolume, pubKeys []string, region *string) (string, error)
 	ExecDriverName() string
 	ExecGetStatus(ctx context.Context, execID string) (types.Status, error)
 	ExecProvider() types.Provider
 	ExecTerminate(ctx context.Context, id string) error
+	// ExecStop stops the exec without losing its disk. Drivers that can't do this should
+	// return a *types.Error.
+	ExecStop(ctx context.Context, id string) error
+	// ExecStart starts an exec previously stopped with ExecStop.
+	ExecStart(ctx context.Context, id string) error
 	ExecSpec(ctx context.Context, id string) (types.HardwareSpec, error)
 	ExecStats(ctx context.Context, id string) (Stats, error)
 	// ExecPing pings the driver availability on behalf of a user. This can be used to
@@ -0,0 +1,356 @@
+// Package execsrvtest contains a conformance suite that every execsrv.Store
+// implementation should pass.
+package execsrvtest
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"github.com/unweave/unweave-v1/api/types"
+	"github.com/unweave/unweave-v1/services/execsrv"
+	"github.com/unweave/unweave-v1/tools/random"
+)
+
+// StoreFixtures are the records that must exist before an exec can be stored. The memory
+// store accepts any values, the postgres store needs them to satisfy foreign keys.
+type StoreFixtures struct {
+	ProjectID      string
+	OtherProjectID string
+	CreatedBy      string
+	// VolumeID is a volume in ProjectID that can be attached to execs.
+	VolumeID string
+}
+
+// TestStore runs the store conformance suite against the store returned by newStore.
+func TestStore(t *testing.T, newStore func(t *testing.T) execsrv.Store, fx StoreFixtures) {
+	t.Helper()
+
+	newExec := func() types.Exec {
+		pub := "ssh-ed25519 AAAA" + random.GenerateRandomLower(24)
+
+		return types.Exec{
+			ID:        "exc_" + random.GenerateRandomLower(11),
+			Name:      random.GenerateRandomPhrase(4, "-") + "-" + random.GenerateRandomLower(4),
+			CreatedBy: fx.CreatedBy,
+			Image:     "ubuntu:latest",
+			Status:    types.StatusPending,
+			Keys:      []types.SSHKey{{Name: "uw:" + random.GenerateRandomLower(12), PublicKey: &pub}},
+			Volumes:   []types.ExecVolume{{VolumeID: fx.VolumeID, MountPath: "/data"}},
+			Network:   types.ExecNetwork{HTTPService: &types.HTTPService{InternalPort: 8080}},
+			Spec:      types.HardwareSpec{CPU: types.CPU{HardwareRequestRange: types.HardwareRequestRange{Min: 4, Max: 4}}},
+			Provider:  types.LocalProvider,
+		}
+	}
+
+	t.Run("create and get", func(t *testing.T) {
+		store := newStore(t)
+		exec := newExec()
+		expiresAt := time.Now().Add(time.Hour)
+		exec.ExpiresAt = &expiresAt
+
+		require.NoError(t, store.Create(fx.ProjectID, exec))
+
+		for _, ref := range []string{exec.ID, exec.Name} {
+			got, err := store.Get(ref)
+			require.NoError(t, err)
+			require.Equal(t, exec.ID, got.ID)
+			require.Equal(t, exec.Name, got.Name)
+			require.Equal(t, exec.Image, got.Image)
+			require.Equal(t, exec.CreatedBy, got.CreatedBy)
+			require.Equal(t, types.StatusPending, got.Status)
+			require.Equal(t, exec.Provider, got.Provider)
+			require.Equal(t, exec.Spec, got.Spec)
+			require.Equal(t, exec.Volumes, got.Volumes)
+			require.Equal(t, exec.Network.HTTPService, got.Network.HTTPService)
+			require.NotNil(t, got.ExpiresAt)
+			require.WithinDuration(t, *exec.ExpiresAt, *got.ExpiresAt, time.Millisecond)
+			require.Len(t, got.Keys, 1)
+			require.Equal(t, *exec.Keys[0].PublicKey, *got.Keys[0].PublicKey)
+		}
+
+		driver, err := store.GetDriver(exec.ID)
+		require.NoError(t, err)
+		require.Equal(t, exec.Provider.String(), driver)
+	})
+
+	t.Run("create validates exec", func(t *testing.T) {
+		store := newStore(t)
+
+		require.Error(t, store.Create("", newExec()))
+
+		noKeys := newExec()
+		noKeys.Keys = nil
+		require.Error(t, store.Create(fx.ProjectID, noKeys))
+
+		noName := newExec()
+		noName.Name = ""
+		require.Error(t, store.Create(fx.ProjectID, noName))
+	})
+
+	t.Run("get missing exec", func(t *testing.T) {
+		store := newStore(t)
+
+		_, err := store.Get("exc_doesnotexist")
+		require.True(t, errors.Is(err, execsrv.ErrNotFound))
+	})
+
+	t.Run("list filters", func(t *testing.T) {
+		store := newStore(t)
+
+		inProject := newExec()
+		otherProject := newExec()
+		otherProject.Volumes = nil
+		otherProvider := newExec()
+		otherProvider.Provider = types.AWSProvider
+		terminated := newExec()
+
+		require.NoError(t, store.Create(fx.ProjectID, inProject))
+		require.NoError(t, store.Create(fx.OtherProjectID, otherProject))
+		require.NoError(t, store.Create(fx.ProjectID, otherProvider))
+		require.NoError(t, store.Create(fx.ProjectID, terminated))
+		require.NoError(t, store.UpdateStatus(terminated.ID, types.StatusTerminated, time.Time{}, time.Now()))
+
+		provider := types.LocalProvider
+
+		execs, err := store.List(&fx.ProjectID, nil, false)
+		require.NoError(t, err)
+		requireIDs(t, execs, []string{inProject.ID, otherProvider.ID, terminated.ID}, []string{otherProject.ID})
+
+		execs, err = store.List(&fx.ProjectID, &provider, false)
+		require.NoError(t, err)
+		requireIDs(t, execs, []string{inProject.ID, terminated.ID}, []string{otherProject.ID, otherProvider.ID})
+
+		execs, err = store.List(&fx.ProjectID, &provider, true)
+		require.NoError(t, err)
+		requireIDs(t, execs, []string{inProject.ID}, []string{otherProject.ID, otherProvider.ID, terminated.ID})
+
+		execs, err = store.List(nil, nil, false)
+		require.NoError(t, err)
+		requireIDs(t, execs, []string{inProject.ID, otherProject.ID, otherProvider.ID, terminated.ID}, nil)
+	})
+
+	t.Run("list unfinished", func(t *testing.T) {
+		store := newStore(t)
+
+		running := newExec()
+		stopped := newExec()
+		otherProvider := newExec()
+		otherProvider.Provider = types.AWSProvider
+		terminated := newExec()
+
+		for _, exec := range []types.Exec{running, stopped, otherProvider, terminated} {
+			require.NoError(t, store.Create(fx.ProjectID, exec))
+		}
+		require.NoError(t, store.UpdateStatus(running.ID, types.StatusRunning, time.Now(), time.Time{}))
+		require.NoError(t, store.UpdateStatus(stopped.ID, types.StatusStopped, time.Time{}, time.Time{}))
+		require.NoError(t, store.UpdateStatus(terminated.ID, types.StatusTerminated, time.Time{}, time.Now()))
+
+		provider := types.LocalProvider
+
+		execs, err := store.ListUnfinished(&provider)
+		require.NoError(t, err)
+		requireIDs(t, execs, []string{running.ID, stopped.ID}, []string{otherProvider.ID, terminated.ID})
+
+		execs, err = store.ListUnfinished(nil)
+		require.NoError(t, err)
+		requireIDs(t, execs, []string{running.ID, stopped.ID, otherProvider.ID}, []string{terminated.ID})
+	})
+
+	t.Run("list in window", func(t *testing.T) {
+		store := newStore(t)
+		now := time.Now()
+
+		live := newExec()
+		exited := newExec()
+		otherProject := newExec()
+
+		require.NoError(t, store.Create(fx.ProjectID, live))
+		require.NoError(t, store.Create(fx.ProjectID, exited))
+		require.NoError(t, store.Create(fx.OtherProjectID, otherProject))
+		require.NoError(t, store.UpdateStatus(exited.ID, types.StatusTerminated, time.Time{}, now.Add(10*time.Minute)))
+
+		execs, err := store.ListInWindow(fx.ProjectID, now.Add(-time.Minute), now.Add(time.Minute))
+		require.NoError(t, err)
+		requireIDs(t, execs, []string{live.ID, exited.ID}, []string{otherProject.ID})
+
+		execs, err = store.ListInWindow(fx.ProjectID, now.Add(20*time.Minute), now.Add(time.Hour))
+		require.NoError(t, err)
+		requireIDs(t, execs, []string{live.ID}, []string{exited.ID, otherProject.ID})
+
+		execs, err = store.ListInWindow(fx.ProjectID, now.Add(-time.Hour), now.Add(-30*time.Minute))
+		require.NoError(t, err)
+		requireIDs(t, execs, nil, []string{live.ID, exited.ID, otherProject.ID})
+	})
+
+	t.Run("update status", func(t *testing.T) {
+		store := newStore(t)
+		exec := newExec()
+
+		require.NoError(t, store.Create(fx.ProjectID, exec))
+		require.NoError(t, store.UpdateStatus(exec.ID, types.StatusRunning, time.Now(), time.Time{}))
+
+		got, err := store.Get(exec.ID)
+		require.NoError(t, err)
+		require.Equal(t, types.StatusRunning, got.Status)
+		require.Nil(t, got.ExitedAt)
+
+		exitedAt := time.Now()
+		require.NoError(t, store.UpdateStatus(exec.ID, types.StatusError, time.Time{}, exitedAt))
+
+		got, err = store.Get(exec.ID)
+		require.NoError(t, err)
+		require.Equal(t, types.StatusError, got.Status)
+		require.NotNil(t, got.ExitedAt)
+		require.WithinDuration(t, exitedAt, *got.ExitedAt, time.Millisecond)
+	})
+
+	t.Run("update connection info", func(t *testing.T) {
+		store := newStore(t)
+		exec := newExec()
+
+		require.NoError(t, store.Create(fx.ProjectID, exec))
+
+		info := types.ConnectionInfo{Host: "10.0.0.1", Port: 2222, User: "unweave"}
+		require.NoError(t, store.UpdateConnectionInfo(exec.ID, info))
+
+		got, err := store.Get(exec.ID)
+		require.NoError(t, err)
+		require.Equal(t, info.Host, got.Network.Host)
+		require.Equal(t, info.Port, got.Network.Port)
+		require.Equal(t, info.User, got.Network.User)
+		require.Equal(t, exec.Network.HTTPService, got.Network.HTTPService)
+	})
+
+	t.Run("update termination reason", func(t *testing.T) {
+		store := newStore(t)
+		exec := newExec()
+		exec.IdlePolicy = &types.IdlePolicy{Timeout: 3600, Threshold: 5}
+
+		require.NoError(t, store.Create(fx.ProjectID, exec))
+		require.NoError(t, store.UpdateTerminationReason(exec.ID, "idle for 1h0m0s"))
+
+		got, err := store.Get(exec.ID)
+		require.NoError(t, err)
+		require.Equal(t, "idle for 1h0m0s", got.TerminationReason)
+		require.Equal(t, exec.IdlePolicy, got.IdlePolicy)
+	})
+
+	t.Run("add and list events", func(t *testing.T) {
+		store := newStore(t)
+		exec := newExec()
+
+		require.NoError(t, store.Create(fx.ProjectID, exec))
+
+		events, err := store.ListEvents(exec.ID)
+		require.NoError(t, err)
+		require.Empty(t, events)
+
+		require.NoError(t, store.AddEvent(types.ExecEvent{
+			ExecID: exec.ID,
+			Status: types.StatusRunning,
+			Source: "state-informer",
+		}))
+		require.NoError(t, store.AddEvent(types.ExecEvent{
+			ExecID: exec.ID,
+			Status: types.StatusUnknown,
+			Source: "heartbeat-informer",
+			Error:  "no heartbeat",
+		}))
+
+		events, err = store.ListEvents(exec.ID)
+		require.NoError(t, err)
+		require.Len(t, events, 2)
+		require.Equal(t, types.StatusRunning, events[0].Status)
+		require.Equal(t, "state-informer", events[0].Source)
+		require.Empty(t, events[0].Error)
+		require.Equal(t, "no heartbeat", events[1].Error)
+		require.False(t, events[1].CreatedAt.IsZero())
+	})
+
+	t.Run("list events by execs", func(t *testing.T) {
+		store := newStore(t)
+		first := newExec()
+		second := newExec()
+		quiet := newExec()
+
+		for _, exec := range []types.Exec{first, second, quiet} {
+			require.NoError(t, store.Create(fx.ProjectID, exec))
+		}
+
+		for _, status := range []types.Status{types.StatusRunning, types.StatusStopped} {
+			require.NoError(t, store.AddEvent(types.ExecEvent{ExecID: first.ID, Status: status, Source: "test"}))
+		}
+		require.NoError(t, store.AddEvent(types.ExecEvent{ExecID: second.ID, Status: types.StatusRunning, Source: "test"}))
+
+		events, err := store.ListEventsByExecs([]string{first.ID, second.ID, quiet.ID})
+		require.NoError(t, err)
+		require.Len(t, events[first.ID], 2)
+		require.Equal(t, types.StatusRunning, events[first.ID][0].Status)
+		require.Equal(t, types.StatusStopped, events[first.ID][1].Status)
+		require.Len(t, events[second.ID], 1)
+		require.Empty(t, events[quiet.ID])
+
+		events, err = store.ListEventsByExecs(nil)
+		require.NoError(t, err)
+		require.Empty(t, events)
+	})
+
+	t.Run("finish", func(t *testing.T) {
+		store := newStore(t)
+
+		// Both final statuses must be valid values of the postgres exec_status enum.
+		for status, exitCode := range map[types.Status]int{types.StatusSuccess: 0, types.StatusFailed: 1} {
+			exec := newExec()
+			exec.Job = true
+			exec.Command = []string{"python", "train.py"}
+
+			require.NoError(t, store.Create(fx.ProjectID, exec))
+
+			exitCode := exitCode
+			require.NoError(t, store.Finish(exec.ID, status, &exitCode))
+
+			got, err := store.Get(exec.ID)
+			require.NoError(t, err)
+			require.True(t, got.Job)
+			require.Equal(t, status, got.Status)
+			require.NotNil(t, got.ExitCode)
+			require.Equal(t, exitCode, *got.ExitCode)
+			require.NotNil(t, got.ExitedAt)
+			require.Empty(t, got.Volumes)
+		}
+	})
+
+	t.Run("delete", func(t *testing.T) {
+		store := newStore(t)
+		exec := newExec()
+
+		require.NoError(t, store.Create(fx.ProjectID, exec))
+		require.NoError(t, store.Delete(exec.ID))
+
+		// Execs are soft deleted.
+		got, err := store.Get(exec.ID)
+		require.NoError(t, err)
+		require.Equal(t, types.StatusTerminated, got.Status)
+		require.NotNil(t, got.ExitedAt)
+		require.Empty(t, got.Volumes)
+	})
+}
+
+func requireIDs(t *testing.T, execs []types.Exec, included, excluded []string) {
+	t.Helper()
+
+	ids := make(map[string]bool, len(execs))
+	for _, e := range execs {
+		ids[e.ID] = true
+	}
+
+	for _, id := range included {
+		require.True(t, ids[id], "expected exec %s in list", id)
+	}
+
+	for _, id := range excluded {
+		require.False(t, ids[id], "unexpected exec %s in list", id)
+	}
+}
@@ -2,6 +2,7 @@ package execsrv
 
 import (
 	"context"
+	"fmt"
 	"sync"
 	"time"
 
@@ -17,6 +18,7 @@ type heartbeatInformer struct {
 	maxFail   int
 	failCount int
 	manager   *HeartbeatPollingInformerManager // for removing itself from the manager
+	watchOnce sync.Once
 
 	// Default 10 seconds
 	pollInterval time.Duration
@@ -25,7 +27,8 @@ type heartbeatInformer struct {
 type HeartbeatPollingInformerManager struct {
 	driver    Driver
 	maxFail   int
-	informers map[string]HeartbeatInformer
+	mu        sync.Mutex
+	informers map[string]*heartbeatInformer
 
 	PollInterval time.Duration
 }
@@ -38,11 +41,14 @@ func NewPollingHeartbeatInformerManager(driver Driver, maxFail int) *HeartbeatPo
 	return &HeartbeatPollingInformerManager{
 		driver:    driver,
 		maxFail:   maxFail,
-		informers: make(map[string]HeartbeatInformer),
+		informers: make(map[string]*heartbeatInformer),
 	}
 }
 
 func (h *HeartbeatPollingInformerManager) Add(exec types.Exec) HeartbeatInformer {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
 	if _, ok := h.informers[exec.ID]; ok {
 
 		log.Warn().
@@ -78,6 +84,9 @@ func (h *HeartbeatPollingInformerManager) Add(exec types.Exec) HeartbeatInformer
 }
 
 func (h *HeartbeatPollingInformerManager) Remove(execID string) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
 	if _, ok := h.informers[execID]; !ok {
 
 		log.Warn().
@@ -90,6 +99,17 @@ func (h *HeartbeatPollingInformerManager) Remove(execID string) {
 	delete(h.informers, execID)
 }
 
+// remove removes the informer only if it's still the one registered for its exec. An
+// exiting informer must not remove a newer one that was added for the same exec.
+func (h *HeartbeatPollingInformerManager) remove(inf *heartbeatInformer) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if h.informers[inf.execID] == inf {
+		delete(h.informers, inf.execID)
+	}
+}
+
 func (b *heartbeatInformer) inform(heartbeat Heartbeat) {
 	b.mu.Lock()
 	defer b.mu.Unlock()
@@ -116,7 +136,13 @@ func (b *heartbeatInformer) Unregister(o HeartbeatObserver) {
 	delete(b.observers, o.ID())
 }
 
+// Watch starts polling the exec's heartbeat. Only the first call starts a poller, so an
+// informer that is still running can be watched again.
 func (b *heartbeatInformer) Watch() {
+	b.watchOnce.Do(b.watch)
+}
+
+func (b *heartbeatInformer) watch() {
 	log.Info().
 		Str(types.ExecIDCtxKey, b.execID).
 		Msgf("Starting watch for heartbeat informer for exec %s", b.execID)
@@ -127,7 +153,7 @@ func (b *heartbeatInformer) Watch() {
 				Str(types.ExecIDCtxKey, b.execID).
 				Msgf("Heartbeat informer stopped for exec %s", b.execID)
 
-			b.manager.Remove(b.execID)
+			b.manager.remove(b)
 		}()
 
 		for {
@@ -138,11 +164,18 @@ func (b *heartbeatInformer) Watch() {
 					b.failCount++
 
 					if b.failCount > b.maxFail {
-						// No need to notify. Heartbeat just stops.
 						log.Info().
 							Str(types.ExecIDCtxKey, b.execID).
 							Msgf("Heartbeat not detected for exec %q", b.execID)
 
+						// Let observers know why the heartbeat stops.
+						b.inform(Heartbeat{
+							ExecID: b.execID,
+							Time:   time.Now(),
+							Status: types.StatusUnknown,
+							Error:  fmt.Errorf("no heartbeat after %d attempts: %w", b.failCount, err),
+						})
+
 						return
 					}
 
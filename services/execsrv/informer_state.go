@@ -23,6 +23,7 @@ type pollingStateInformer struct {
 type PollingStateInformerManager struct {
 	store     Store
 	driver    Driver
+	mu        sync.Mutex
 	informers map[string]*pollingStateInformer
 
 	PollInterval time.Duration
@@ -40,6 +41,9 @@ func NewPollingStateInformerManager(store Store, driver Driver) *PollingStateInf
 }
 
 func (m *PollingStateInformerManager) Add(exec types.Exec) StateInformer {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
 	if _, ok := m.informers[exec.ID]; ok {
 
 		log.Warn().
@@ -67,7 +71,22 @@ func (m *PollingStateInformerManager) Add(exec types.Exec) StateInformer {
 	return inf
 }
 
+func (m *PollingStateInformerManager) Get(execID string) (StateInformer, bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	inf, ok := m.informers[execID]
+	if !ok {
+		return nil, false
+	}
+
+	return inf, true
+}
+
 func (m *PollingStateInformerManager) Remove(execID string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
 	if _, ok := m.informers[execID]; !ok {
 
 		log.Warn().
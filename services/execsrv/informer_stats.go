@@ -2,22 +2,47 @@ package execsrv
 
 import (
 	"context"
+	"errors"
+	"sync"
+	"time"
 
 	"github.com/rs/zerolog/log"
 	"github.com/unweave/unweave-v1/api/types"
 )
 
 type statsInformer struct {
-	store  Store
-	driver Driver
+	execID    string
+	observers map[string]StatsObserver
+	mu        sync.Mutex
+	store     Store
+	driver    Driver
+	manager   *StatsPollingInformerManager // for removing itself from the manager
+	done      chan struct{}
+	stopOnce  sync.Once
+	watchOnce sync.Once
+
+	pollInterval time.Duration
+	maxBackoff   time.Duration
 }
 
 type StatsPollingInformerManager struct {
 	store     Store
 	driver    Driver
+	mu        sync.Mutex
 	informers map[string]*statsInformer
+
+	// PollInterval is how often the driver is polled for stats. Default 30 seconds.
+	PollInterval time.Duration
+	// MaxBackoff caps the wait between polls while the driver keeps failing. Default 5
+	// minutes.
+	MaxBackoff time.Duration
 }
 
+// NewPollingStatsInformerManager returns a new StatsPollingInformerManager that allows for
+// adding and removing StatsInformers for execs. The informer polls the driver for the
+// exec's resource usage and passes it on to all subscribed observers. Driver errors
+// double the poll interval up to MaxBackoff. The informer exits once the exec is no
+// longer active in the store or it is removed from the manager.
 func NewPollingStatsInformerManager(store Store, driver Driver) *StatsPollingInformerManager {
 	return &StatsPollingInformerManager{
 		store:     store,
@@ -27,6 +52,9 @@ func NewPollingStatsInformerManager(store Store, driver Driver) *StatsPollingInf
 }
 
 func (m *StatsPollingInformerManager) Add(exec types.Exec) StatsInformer {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
 	if _, ok := m.informers[exec.ID]; ok {
 		log.Warn().
 			Str(types.ExecIDCtxKey, exec.ID).
@@ -35,9 +63,25 @@ func (m *StatsPollingInformerManager) Add(exec types.Exec) StatsInformer {
 		return m.informers[exec.ID]
 	}
 
+	interval := m.PollInterval
+	if interval == 0 {
+		interval = 30 * time.Second
+	}
+
+	maxBackoff := m.MaxBackoff
+	if maxBackoff == 0 {
+		maxBackoff = 5 * time.Minute
+	}
+
 	inf := &statsInformer{
-		store:  m.store,
-		driver: m.driver,
+		execID:       exec.ID,
+		observers:    make(map[string]StatsObserver),
+		store:        m.store,
+		driver:       m.driver,
+		manager:      m,
+		done:         make(chan struct{}),
+		pollInterval: interval,
+		maxBackoff:   maxBackoff,
 	}
 
 	m.informers[exec.ID] = inf
@@ -50,24 +94,133 @@ func (m *StatsPollingInformerManager) Add(exec types.Exec) StatsInformer {
 }
 
 func (m *StatsPollingInformerManager) Remove(execID string) {
-	if _, ok := m.informers[execID]; !ok {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	inf, ok := m.informers[execID]
+	if !ok {
 		log.Warn().
 			Str(types.ExecIDCtxKey, execID).
 			Msgf("Stats informer does not exist for exec %s", execID)
 		return
 	}
 
+	inf.stop()
 	delete(m.informers, execID)
 }
 
+// remove removes the informer only if it's still the one registered for its exec. An
+// exiting informer must not remove a newer one that was added for the same exec.
+func (m *StatsPollingInformerManager) remove(inf *statsInformer) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if m.informers[inf.execID] == inf {
+		delete(m.informers, inf.execID)
+	}
+}
+
+func (i *statsInformer) inform(stats Stats) {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+
+	for _, o := range i.observers {
+		o := o
+		go o.Update(stats)
+	}
+}
+
 func (i *statsInformer) Register(o StatsObserver) {
-	log.Ctx(context.Background()).Info().Msg("no-op stats register method called")
+	i.mu.Lock()
+	defer i.mu.Unlock()
+
+	if _, ok := i.observers[o.ID()]; ok {
+		return
+	}
+	i.observers[o.ID()] = o
 }
 
 func (i *statsInformer) Unregister(o StatsObserver) {
-	log.Ctx(context.Background()).Info().Msg("no-op stats unregister method called")
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	delete(i.observers, o.ID())
+}
+
+func (i *statsInformer) stop() {
+	i.stopOnce.Do(func() { close(i.done) })
+}
+
+// active reports whether the exec is still worth polling. Store errors other than the
+// exec not existing keep the informer running, they're usually transient.
+func (i *statsInformer) active() bool {
+	exec, err := i.store.Get(i.execID)
+	if err != nil {
+		if errors.Is(err, ErrNotFound) {
+			return false
+		}
+
+		log.Warn().
+			Err(err).
+			Str(types.ExecIDCtxKey, i.execID).
+			Msg("Failed to get exec from store in stats informer")
+
+		return true
+	}
+
+	return exec.Status == types.StatusPending ||
+		exec.Status == types.StatusInitializing ||
+		exec.Status == types.StatusRunning
 }
 
 func (i *statsInformer) Watch() {
-	log.Ctx(context.Background()).Info().Msg("no-op stats watch method called")
+	i.watchOnce.Do(func() {
+		log.Info().
+			Str(types.ExecIDCtxKey, i.execID).
+			Msgf("Starting watch for stats informer for exec %s", i.execID)
+
+		go i.watch()
+	})
+}
+
+func (i *statsInformer) watch() {
+	defer func() {
+		log.Info().
+			Str(types.ExecIDCtxKey, i.execID).
+			Msgf("Stats informer stopped for exec %s", i.execID)
+
+		i.manager.remove(i)
+	}()
+
+	wait := i.pollInterval
+
+	for {
+		select {
+		case <-i.done:
+			return
+		case <-time.After(wait):
+		}
+
+		if !i.active() {
+			return
+		}
+
+		stats, err := i.driver.ExecStats(context.Background(), i.execID)
+		if err != nil {
+			wait *= 2
+			if wait > i.maxBackoff {
+				wait = i.maxBackoff
+			}
+
+			log.Warn().
+				Err(err).
+				Str(types.ExecIDCtxKey, i.execID).
+				Msgf("Failed to get exec stats, retrying in %s", wait)
+
+			continue
+		}
+
+		wait = i.pollInterval
+
+		i.inform(stats)
+	}
 }
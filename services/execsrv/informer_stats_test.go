@@ -0,0 +1,92 @@
+package execsrv_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"github.com/unweave/unweave-v1/api/types"
+	"github.com/unweave/unweave-v1/services/execsrv"
+	"github.com/unweave/unweave-v1/services/execsrv/internal/execsrvfakes"
+)
+
+func newStatsTestExec(t *testing.T, store execsrv.Store) types.Exec {
+	t.Helper()
+
+	pub := "ssh-ed25519 AAAA"
+	exec := types.Exec{
+		ID:     "exc_statstest",
+		Name:   "stats-test",
+		Status: types.StatusRunning,
+		Keys:   []types.SSHKey{{Name: "key", PublicKey: &pub}},
+	}
+
+	require.NoError(t, store.Create("pr_statstest", exec))
+
+	return exec
+}
+
+func TestPollingStatsInformerManager(t *testing.T) {
+	t.Parallel()
+
+	store := execsrv.NewMemoryStore()
+	exec := newStatsTestExec(t, store)
+
+	driver := new(execsrvfakes.FakeDriver)
+	driver.ExecStatsReturnsOnCall(0, execsrv.Stats{}, errors.New("metrics not ready"))
+	driver.ExecStatsReturns(execsrv.Stats{CPU: 42}, nil)
+
+	received := make(chan execsrv.Stats, 1)
+	observer := new(execsrvfakes.FakeStatsObserver)
+	observer.IDReturns("observer")
+	observer.UpdateCalls(func(s execsrv.Stats) {
+		select {
+		case received <- s:
+		default:
+		}
+	})
+
+	manager := execsrv.NewPollingStatsInformerManager(store, driver)
+	manager.PollInterval = 5 * time.Millisecond
+	manager.MaxBackoff = 20 * time.Millisecond
+
+	informer := manager.Add(exec)
+	informer.Register(observer)
+	informer.Watch()
+
+	select {
+	case s := <-received:
+		require.Equal(t, 42.0, s.CPU)
+	case <-time.After(time.Second):
+		t.Fatal("should have passed stats to observer after the driver recovered")
+	}
+
+	require.GreaterOrEqual(t, driver.ExecStatsCallCount(), 2)
+	_, id := driver.ExecStatsArgsForCall(0)
+	require.Equal(t, exec.ID, id)
+}
+
+func TestPollingStatsInformerManager_StopsForInactiveExec(t *testing.T) {
+	t.Parallel()
+
+	store := execsrv.NewMemoryStore()
+	exec := newStatsTestExec(t, store)
+	require.NoError(t, store.UpdateStatus(exec.ID, types.StatusTerminated, time.Time{}, time.Now()))
+
+	driver := new(execsrvfakes.FakeDriver)
+
+	manager := execsrv.NewPollingStatsInformerManager(store, driver)
+	manager.PollInterval = time.Millisecond
+
+	first := manager.Add(exec)
+	first.Watch()
+
+	// The informer removes itself once it sees the exec is terminated so a new one can be
+	// added in its place.
+	require.Eventually(t, func() bool {
+		return manager.Add(exec) != first
+	}, time.Second, 5*time.Millisecond)
+
+	require.Zero(t, driver.ExecStatsCallCount())
+}
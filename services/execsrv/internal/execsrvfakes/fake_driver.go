@@ -24,17 +24,18 @@ type FakeDriver struct {
 		result1 types.ConnectionInfo
 		result2 error
 	}
-	ExecCreateStub        func(context.Context, string, string, types.HardwareSpec, types.ExecNetwork, []types.ExecVolume, []string, *string) (string, error)
+	ExecCreateStub        func(context.Context, string, string, []string, types.HardwareSpec, types.ExecNetwork, []types.ExecVolume, []string, *string) (string, error)
 	execCreateMutex       sync.RWMutex
 	execCreateArgsForCall []struct {
 		arg1 context.Context
 		arg2 string
 		arg3 string
-		arg4 types.HardwareSpec
-		arg5 types.ExecNetwork
-		arg6 []types.ExecVolume
-		arg7 []string
-		arg8 *string
+		arg4 []string
+		arg5 types.HardwareSpec
+		arg6 types.ExecNetwork
+		arg7 []types.ExecVolume
+		arg8 []string
+		arg9 *string
 	}
 	execCreateReturns struct {
 		result1 string
@@ -104,6 +105,18 @@ type FakeDriver struct {
 		result1 types.HardwareSpec
 		result2 error
 	}
+	ExecStartStub        func(context.Context, string) error
+	execStartMutex       sync.RWMutex
+	execStartArgsForCall []struct {
+		arg1 context.Context
+		arg2 string
+	}
+	execStartReturns struct {
+		result1 error
+	}
+	execStartReturnsOnCall map[int]struct {
+		result1 error
+	}
 	ExecStatsStub        func(context.Context, string) (execsrv.Stats, error)
 	execStatsMutex       sync.RWMutex
 	execStatsArgsForCall []struct {
@@ -118,6 +131,18 @@ type FakeDriver struct {
 		result1 execsrv.Stats
 		result2 error
 	}
+	ExecStopStub        func(context.Context, string) error
+	execStopMutex       sync.RWMutex
+	execStopArgsForCall []struct {
+		arg1 context.Context
+		arg2 string
+	}
+	execStopReturns struct {
+		result1 error
+	}
+	execStopReturnsOnCall map[int]struct {
+		result1 error
+	}
 	ExecTerminateStub        func(context.Context, string) error
 	execTerminateMutex       sync.RWMutex
 	execTerminateArgsForCall []struct {
@@ -199,35 +224,41 @@ func (fake *FakeDriver) ExecConnectionInfoReturnsOnCall(i int, result1 types.Con
 	}{result1, result2}
 }
 
-func (fake *FakeDriver) ExecCreate(arg1 context.Context, arg2 string, arg3 string, arg4 types.HardwareSpec, arg5 types.ExecNetwork, arg6 []types.ExecVolume, arg7 []string, arg8 *string) (string, error) {
-	var arg6Copy []types.ExecVolume
-	if arg6 != nil {
-		arg6Copy = make([]types.ExecVolume, len(arg6))
-		copy(arg6Copy, arg6)
+func (fake *FakeDriver) ExecCreate(arg1 context.Context, arg2 string, arg3 string, arg4 []string, arg5 types.HardwareSpec, arg6 types.ExecNetwork, arg7 []types.ExecVolume, arg8 []string, arg9 *string) (string, error) {
+	var arg4Copy []string
+	if arg4 != nil {
+		arg4Copy = make([]string, len(arg4))
+		copy(arg4Copy, arg4)
 	}
-	var arg7Copy []string
+	var arg7Copy []types.ExecVolume
 	if arg7 != nil {
-		arg7Copy = make([]string, len(arg7))
+		arg7Copy = make([]types.ExecVolume, len(arg7))
 		copy(arg7Copy, arg7)
 	}
+	var arg8Copy []string
+	if arg8 != nil {
+		arg8Copy = make([]string, len(arg8))
+		copy(arg8Copy, arg8)
+	}
 	fake.execCreateMutex.Lock()
 	ret, specificReturn := fake.execCreateReturnsOnCall[len(fake.execCreateArgsForCall)]
 	fake.execCreateArgsForCall = append(fake.execCreateArgsForCall, struct {
 		arg1 context.Context
 		arg2 string
 		arg3 string
-		arg4 types.HardwareSpec
-		arg5 types.ExecNetwork
-		arg6 []types.ExecVolume
-		arg7 []string
-		arg8 *string
-	}{arg1, arg2, arg3, arg4, arg5, arg6Copy, arg7Copy, arg8})
+		arg4 []string
+		arg5 types.HardwareSpec
+		arg6 types.ExecNetwork
+		arg7 []types.ExecVolume
+		arg8 []string
+		arg9 *string
+	}{arg1, arg2, arg3, arg4Copy, arg5, arg6, arg7Copy, arg8Copy, arg9})
 	stub := fake.ExecCreateStub
 	fakeReturns := fake.execCreateReturns
-	fake.recordInvocation("ExecCreate", []interface{}{arg1, arg2, arg3, arg4, arg5, arg6Copy, arg7Copy, arg8})
+	fake.recordInvocation("ExecCreate", []interface{}{arg1, arg2, arg3, arg4Copy, arg5, arg6, arg7Copy, arg8Copy, arg9})
 	fake.execCreateMutex.Unlock()
 	if stub != nil {
-		return stub(arg1, arg2, arg3, arg4, arg5, arg6, arg7, arg8)
+		return stub(arg1, arg2, arg3, arg4, arg5, arg6, arg7, arg8, arg9)
 	}
 	if specificReturn {
 		return ret.result1, ret.result2
@@ -241,17 +272,17 @@ func (fake *FakeDriver) ExecCreateCallCount() int {
 	return len(fake.execCreateArgsForCall)
 }
 
-func (fake *FakeDriver) ExecCreateCalls(stub func(context.Context, string, string, types.HardwareSpec, types.ExecNetwork, []types.ExecVolume, []string, *string) (string, error)) {
+func (fake *FakeDriver) ExecCreateCalls(stub func(context.Context, string, string, []string, types.HardwareSpec, types.ExecNetwork, []types.ExecVolume, []string, *string) (string, error)) {
 	fake.execCreateMutex.Lock()
 	defer fake.execCreateMutex.Unlock()
 	fake.ExecCreateStub = stub
 }
 
-func (fake *FakeDriver) ExecCreateArgsForCall(i int) (context.Context, string, string, types.HardwareSpec, types.ExecNetwork, []types.ExecVolume, []string, *string) {
+func (fake *FakeDriver) ExecCreateArgsForCall(i int) (context.Context, string, string, []string, types.HardwareSpec, types.ExecNetwork, []types.ExecVolume, []string, *string) {
 	fake.execCreateMutex.RLock()
 	defer fake.execCreateMutex.RUnlock()
 	argsForCall := fake.execCreateArgsForCall[i]
-	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3, argsForCall.arg4, argsForCall.arg5, argsForCall.arg6, argsForCall.arg7, argsForCall.arg8
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3, argsForCall.arg4, argsForCall.arg5, argsForCall.arg6, argsForCall.arg7, argsForCall.arg8, argsForCall.arg9
 }
 
 func (fake *FakeDriver) ExecCreateReturns(result1 string, result2 error) {
@@ -578,6 +609,68 @@ func (fake *FakeDriver) ExecSpecReturnsOnCall(i int, result1 types.HardwareSpec,
 	}{result1, result2}
 }
 
+func (fake *FakeDriver) ExecStart(arg1 context.Context, arg2 string) error {
+	fake.execStartMutex.Lock()
+	ret, specificReturn := fake.execStartReturnsOnCall[len(fake.execStartArgsForCall)]
+	fake.execStartArgsForCall = append(fake.execStartArgsForCall, struct {
+		arg1 context.Context
+		arg2 string
+	}{arg1, arg2})
+	stub := fake.ExecStartStub
+	fakeReturns := fake.execStartReturns
+	fake.recordInvocation("ExecStart", []interface{}{arg1, arg2})
+	fake.execStartMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeDriver) ExecStartCallCount() int {
+	fake.execStartMutex.RLock()
+	defer fake.execStartMutex.RUnlock()
+	return len(fake.execStartArgsForCall)
+}
+
+func (fake *FakeDriver) ExecStartCalls(stub func(context.Context, string) error) {
+	fake.execStartMutex.Lock()
+	defer fake.execStartMutex.Unlock()
+	fake.ExecStartStub = stub
+}
+
+func (fake *FakeDriver) ExecStartArgsForCall(i int) (context.Context, string) {
+	fake.execStartMutex.RLock()
+	defer fake.execStartMutex.RUnlock()
+	argsForCall := fake.execStartArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeDriver) ExecStartReturns(result1 error) {
+	fake.execStartMutex.Lock()
+	defer fake.execStartMutex.Unlock()
+	fake.ExecStartStub = nil
+	fake.execStartReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeDriver) ExecStartReturnsOnCall(i int, result1 error) {
+	fake.execStartMutex.Lock()
+	defer fake.execStartMutex.Unlock()
+	fake.ExecStartStub = nil
+	if fake.execStartReturnsOnCall == nil {
+		fake.execStartReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.execStartReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
 func (fake *FakeDriver) ExecStats(arg1 context.Context, arg2 string) (execsrv.Stats, error) {
 	fake.execStatsMutex.Lock()
 	ret, specificReturn := fake.execStatsReturnsOnCall[len(fake.execStatsArgsForCall)]
@@ -643,6 +736,68 @@ func (fake *FakeDriver) ExecStatsReturnsOnCall(i int, result1 execsrv.Stats, res
 	}{result1, result2}
 }
 
+func (fake *FakeDriver) ExecStop(arg1 context.Context, arg2 string) error {
+	fake.execStopMutex.Lock()
+	ret, specificReturn := fake.execStopReturnsOnCall[len(fake.execStopArgsForCall)]
+	fake.execStopArgsForCall = append(fake.execStopArgsForCall, struct {
+		arg1 context.Context
+		arg2 string
+	}{arg1, arg2})
+	stub := fake.ExecStopStub
+	fakeReturns := fake.execStopReturns
+	fake.recordInvocation("ExecStop", []interface{}{arg1, arg2})
+	fake.execStopMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeDriver) ExecStopCallCount() int {
+	fake.execStopMutex.RLock()
+	defer fake.execStopMutex.RUnlock()
+	return len(fake.execStopArgsForCall)
+}
+
+func (fake *FakeDriver) ExecStopCalls(stub func(context.Context, string) error) {
+	fake.execStopMutex.Lock()
+	defer fake.execStopMutex.Unlock()
+	fake.ExecStopStub = stub
+}
+
+func (fake *FakeDriver) ExecStopArgsForCall(i int) (context.Context, string) {
+	fake.execStopMutex.RLock()
+	defer fake.execStopMutex.RUnlock()
+	argsForCall := fake.execStopArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeDriver) ExecStopReturns(result1 error) {
+	fake.execStopMutex.Lock()
+	defer fake.execStopMutex.Unlock()
+	fake.ExecStopStub = nil
+	fake.execStopReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeDriver) ExecStopReturnsOnCall(i int, result1 error) {
+	fake.execStopMutex.Lock()
+	defer fake.execStopMutex.Unlock()
+	fake.ExecStopStub = nil
+	if fake.execStopReturnsOnCall == nil {
+		fake.execStopReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.execStopReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
 func (fake *FakeDriver) ExecTerminate(arg1 context.Context, arg2 string) error {
 	fake.execTerminateMutex.Lock()
 	ret, specificReturn := fake.execTerminateReturnsOnCall[len(fake.execTerminateArgsForCall)]
@@ -722,8 +877,12 @@ func (fake *FakeDriver) Invocations() map[string][][]interface{} {
 	defer fake.execProviderMutex.RUnlock()
 	fake.execSpecMutex.RLock()
 	defer fake.execSpecMutex.RUnlock()
+	fake.execStartMutex.RLock()
+	defer fake.execStartMutex.RUnlock()
 	fake.execStatsMutex.RLock()
 	defer fake.execStatsMutex.RUnlock()
+	fake.execStopMutex.RLock()
+	defer fake.execStopMutex.RUnlock()
 	fake.execTerminateMutex.RLock()
 	defer fake.execTerminateMutex.RUnlock()
 	copiedInvocations := map[string][][]interface{}{}
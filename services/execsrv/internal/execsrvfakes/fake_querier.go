@@ -3,12 +3,91 @@ package execsrvfakes
 
 import (
 	"context"
+	"database/sql"
 	"sync"
 
 	"github.com/unweave/unweave-v1/db"
 )
 
 type FakeQuerier struct {
+	AccessTokenCreateStub        func(context.Context, db.AccessTokenCreateParams) error
+	accessTokenCreateMutex       sync.RWMutex
+	accessTokenCreateArgsForCall []struct {
+		arg1 context.Context
+		arg2 db.AccessTokenCreateParams
+	}
+	accessTokenCreateReturns struct {
+		result1 error
+	}
+	accessTokenCreateReturnsOnCall map[int]struct {
+		result1 error
+	}
+	AccessTokenGetByHashStub        func(context.Context, string) (db.UnweaveAccessToken, error)
+	accessTokenGetByHashMutex       sync.RWMutex
+	accessTokenGetByHashArgsForCall []struct {
+		arg1 context.Context
+		arg2 string
+	}
+	accessTokenGetByHashReturns struct {
+		result1 db.UnweaveAccessToken
+		result2 error
+	}
+	accessTokenGetByHashReturnsOnCall map[int]struct {
+		result1 db.UnweaveAccessToken
+		result2 error
+	}
+	AccessTokenListStub        func(context.Context, string) ([]db.UnweaveAccessToken, error)
+	accessTokenListMutex       sync.RWMutex
+	accessTokenListArgsForCall []struct {
+		arg1 context.Context
+		arg2 string
+	}
+	accessTokenListReturns struct {
+		result1 []db.UnweaveAccessToken
+		result2 error
+	}
+	accessTokenListReturnsOnCall map[int]struct {
+		result1 []db.UnweaveAccessToken
+		result2 error
+	}
+	AccessTokenRevokeStub        func(context.Context, db.AccessTokenRevokeParams) (int64, error)
+	accessTokenRevokeMutex       sync.RWMutex
+	accessTokenRevokeArgsForCall []struct {
+		arg1 context.Context
+		arg2 db.AccessTokenRevokeParams
+	}
+	accessTokenRevokeReturns struct {
+		result1 int64
+		result2 error
+	}
+	accessTokenRevokeReturnsOnCall map[int]struct {
+		result1 int64
+		result2 error
+	}
+	AccessTokenTouchStub        func(context.Context, string) error
+	accessTokenTouchMutex       sync.RWMutex
+	accessTokenTouchArgsForCall []struct {
+		arg1 context.Context
+		arg2 string
+	}
+	accessTokenTouchReturns struct {
+		result1 error
+	}
+	accessTokenTouchReturnsOnCall map[int]struct {
+		result1 error
+	}
+	AccountCreateStub        func(context.Context, string) error
+	accountCreateMutex       sync.RWMutex
+	accountCreateArgsForCall []struct {
+		arg1 context.Context
+		arg2 string
+	}
+	accountCreateReturns struct {
+		result1 error
+	}
+	accountCreateReturnsOnCall map[int]struct {
+		result1 error
+	}
 	BuildCreateStub        func(context.Context, db.BuildCreateParams) (string, error)
 	buildCreateMutex       sync.RWMutex
 	buildCreateArgsForCall []struct {
@@ -89,6 +168,18 @@ type FakeQuerier struct {
 	endpointCheckCreateReturnsOnCall map[int]struct {
 		result1 error
 	}
+	EndpointCheckStatusUpdateStub        func(context.Context, db.EndpointCheckStatusUpdateParams) error
+	endpointCheckStatusUpdateMutex       sync.RWMutex
+	endpointCheckStatusUpdateArgsForCall []struct {
+		arg1 context.Context
+		arg2 db.EndpointCheckStatusUpdateParams
+	}
+	endpointCheckStatusUpdateReturns struct {
+		result1 error
+	}
+	endpointCheckStatusUpdateReturnsOnCall map[int]struct {
+		result1 error
+	}
 	EndpointCheckStepCreateStub        func(context.Context, db.EndpointCheckStepCreateParams) error
 	endpointCheckStepCreateMutex       sync.RWMutex
 	endpointCheckStepCreateArgsForCall []struct {
@@ -191,6 +282,109 @@ type FakeQuerier struct {
 		result1 db.UnweaveEndpoint
 		result2 error
 	}
+	EndpointListStub        func(context.Context) ([]db.UnweaveEndpoint, error)
+	endpointListMutex       sync.RWMutex
+	endpointListArgsForCall []struct {
+		arg1 context.Context
+	}
+	endpointListReturns struct {
+		result1 []db.UnweaveEndpoint
+		result2 error
+	}
+	endpointListReturnsOnCall map[int]struct {
+		result1 []db.UnweaveEndpoint
+		result2 error
+	}
+	EndpointPromotionCreateStub        func(context.Context, db.EndpointPromotionCreateParams) error
+	endpointPromotionCreateMutex       sync.RWMutex
+	endpointPromotionCreateArgsForCall []struct {
+		arg1 context.Context
+		arg2 db.EndpointPromotionCreateParams
+	}
+	endpointPromotionCreateReturns struct {
+		result1 error
+	}
+	endpointPromotionCreateReturnsOnCall map[int]struct {
+		result1 error
+	}
+	EndpointPromotionListStub        func(context.Context, string) ([]db.UnweaveEndpointPromotion, error)
+	endpointPromotionListMutex       sync.RWMutex
+	endpointPromotionListArgsForCall []struct {
+		arg1 context.Context
+		arg2 string
+	}
+	endpointPromotionListReturns struct {
+		result1 []db.UnweaveEndpointPromotion
+		result2 error
+	}
+	endpointPromotionListReturnsOnCall map[int]struct {
+		result1 []db.UnweaveEndpointPromotion
+		result2 error
+	}
+	EndpointRoutesStub        func(context.Context) ([]db.EndpointRoutesRow, error)
+	endpointRoutesMutex       sync.RWMutex
+	endpointRoutesArgsForCall []struct {
+		arg1 context.Context
+	}
+	endpointRoutesReturns struct {
+		result1 []db.EndpointRoutesRow
+		result2 error
+	}
+	endpointRoutesReturnsOnCall map[int]struct {
+		result1 []db.EndpointRoutesRow
+		result2 error
+	}
+	EndpointTrafficStub        func(context.Context, string) (db.UnweaveEndpointTraffic, error)
+	endpointTrafficMutex       sync.RWMutex
+	endpointTrafficArgsForCall []struct {
+		arg1 context.Context
+		arg2 string
+	}
+	endpointTrafficReturns struct {
+		result1 db.UnweaveEndpointTraffic
+		result2 error
+	}
+	endpointTrafficReturnsOnCall map[int]struct {
+		result1 db.UnweaveEndpointTraffic
+		result2 error
+	}
+	EndpointTrafficDeleteStub        func(context.Context, string) error
+	endpointTrafficDeleteMutex       sync.RWMutex
+	endpointTrafficDeleteArgsForCall []struct {
+		arg1 context.Context
+		arg2 string
+	}
+	endpointTrafficDeleteReturns struct {
+		result1 error
+	}
+	endpointTrafficDeleteReturnsOnCall map[int]struct {
+		result1 error
+	}
+	EndpointTrafficListStub        func(context.Context) ([]db.UnweaveEndpointTraffic, error)
+	endpointTrafficListMutex       sync.RWMutex
+	endpointTrafficListArgsForCall []struct {
+		arg1 context.Context
+	}
+	endpointTrafficListReturns struct {
+		result1 []db.UnweaveEndpointTraffic
+		result2 error
+	}
+	endpointTrafficListReturnsOnCall map[int]struct {
+		result1 []db.UnweaveEndpointTraffic
+		result2 error
+	}
+	EndpointTrafficSetStub        func(context.Context, db.EndpointTrafficSetParams) error
+	endpointTrafficSetMutex       sync.RWMutex
+	endpointTrafficSetArgsForCall []struct {
+		arg1 context.Context
+		arg2 db.EndpointTrafficSetParams
+	}
+	endpointTrafficSetReturns struct {
+		result1 error
+	}
+	endpointTrafficSetReturnsOnCall map[int]struct {
+		result1 error
+	}
 	EndpointVersionStub        func(context.Context, string) (db.UnweaveEndpointVersion, error)
 	endpointVersionMutex       sync.RWMutex
 	endpointVersionArgsForCall []struct {
@@ -255,6 +449,18 @@ type FakeQuerier struct {
 	endpointVersionPromoteReturnsOnCall map[int]struct {
 		result1 error
 	}
+	EndpointVersionStatusUpdateStub        func(context.Context, db.EndpointVersionStatusUpdateParams) error
+	endpointVersionStatusUpdateMutex       sync.RWMutex
+	endpointVersionStatusUpdateArgsForCall []struct {
+		arg1 context.Context
+		arg2 db.EndpointVersionStatusUpdateParams
+	}
+	endpointVersionStatusUpdateReturns struct {
+		result1 error
+	}
+	endpointVersionStatusUpdateReturnsOnCall map[int]struct {
+		result1 error
+	}
 	EndpointsForProjectStub        func(context.Context, string) ([]db.UnweaveEndpoint, error)
 	endpointsForProjectMutex       sync.RWMutex
 	endpointsForProjectArgsForCall []struct {
@@ -347,6 +553,46 @@ type FakeQuerier struct {
 	execCreateReturnsOnCall map[int]struct {
 		result1 error
 	}
+	ExecEventCreateStub        func(context.Context, db.ExecEventCreateParams) error
+	execEventCreateMutex       sync.RWMutex
+	execEventCreateArgsForCall []struct {
+		arg1 context.Context
+		arg2 db.ExecEventCreateParams
+	}
+	execEventCreateReturns struct {
+		result1 error
+	}
+	execEventCreateReturnsOnCall map[int]struct {
+		result1 error
+	}
+	ExecEventListStub        func(context.Context, string) ([]db.UnweaveExecEvent, error)
+	execEventListMutex       sync.RWMutex
+	execEventListArgsForCall []struct {
+		arg1 context.Context
+		arg2 string
+	}
+	execEventListReturns struct {
+		result1 []db.UnweaveExecEvent
+		result2 error
+	}
+	execEventListReturnsOnCall map[int]struct {
+		result1 []db.UnweaveExecEvent
+		result2 error
+	}
+	ExecEventListByExecsStub        func(context.Context, []string) ([]db.UnweaveExecEvent, error)
+	execEventListByExecsMutex       sync.RWMutex
+	execEventListByExecsArgsForCall []struct {
+		arg1 context.Context
+		arg2 []string
+	}
+	execEventListByExecsReturns struct {
+		result1 []db.UnweaveExecEvent
+		result2 error
+	}
+	execEventListByExecsReturnsOnCall map[int]struct {
+		result1 []db.UnweaveExecEvent
+		result2 error
+	}
 	ExecGetStub        func(context.Context, string) (db.UnweaveExec, error)
 	execGetMutex       sync.RWMutex
 	execGetArgsForCall []struct {
@@ -416,6 +662,34 @@ type FakeQuerier struct {
 		result1 []db.UnweaveExec
 		result2 error
 	}
+	ExecListInWindowStub        func(context.Context, db.ExecListInWindowParams) ([]db.UnweaveExec, error)
+	execListInWindowMutex       sync.RWMutex
+	execListInWindowArgsForCall []struct {
+		arg1 context.Context
+		arg2 db.ExecListInWindowParams
+	}
+	execListInWindowReturns struct {
+		result1 []db.UnweaveExec
+		result2 error
+	}
+	execListInWindowReturnsOnCall map[int]struct {
+		result1 []db.UnweaveExec
+		result2 error
+	}
+	ExecListUnfinishedStub        func(context.Context, sql.NullString) ([]db.UnweaveExec, error)
+	execListUnfinishedMutex       sync.RWMutex
+	execListUnfinishedArgsForCall []struct {
+		arg1 context.Context
+		arg2 sql.NullString
+	}
+	execListUnfinishedReturns struct {
+		result1 []db.UnweaveExec
+		result2 error
+	}
+	execListUnfinishedReturnsOnCall map[int]struct {
+		result1 []db.UnweaveExec
+		result2 error
+	}
 	ExecSSHKeyDeleteStub        func(context.Context, db.ExecSSHKeyDeleteParams) error
 	execSSHKeyDeleteMutex       sync.RWMutex
 	execSSHKeyDeleteArgsForCall []struct {
@@ -516,6 +790,18 @@ type FakeQuerier struct {
 	execUpdateConnectionInfoReturnsOnCall map[int]struct {
 		result1 error
 	}
+	ExecUpdateExitCodeStub        func(context.Context, db.ExecUpdateExitCodeParams) error
+	execUpdateExitCodeMutex       sync.RWMutex
+	execUpdateExitCodeArgsForCall []struct {
+		arg1 context.Context
+		arg2 db.ExecUpdateExitCodeParams
+	}
+	execUpdateExitCodeReturns struct {
+		result1 error
+	}
+	execUpdateExitCodeReturnsOnCall map[int]struct {
+		result1 error
+	}
 	ExecUpdateNetworkStub        func(context.Context, db.ExecUpdateNetworkParams) error
 	execUpdateNetworkMutex       sync.RWMutex
 	execUpdateNetworkArgsForCall []struct {
@@ -528,6 +814,18 @@ type FakeQuerier struct {
 	execUpdateNetworkReturnsOnCall map[int]struct {
 		result1 error
 	}
+	ExecUpdateTerminationReasonStub        func(context.Context, db.ExecUpdateTerminationReasonParams) error
+	execUpdateTerminationReasonMutex       sync.RWMutex
+	execUpdateTerminationReasonArgsForCall []struct {
+		arg1 context.Context
+		arg2 db.ExecUpdateTerminationReasonParams
+	}
+	execUpdateTerminationReasonReturns struct {
+		result1 error
+	}
+	execUpdateTerminationReasonReturnsOnCall map[int]struct {
+		result1 error
+	}
 	ExecVolumeCreateStub        func(context.Context, db.ExecVolumeCreateParams) error
 	execVolumeCreateMutex       sync.RWMutex
 	execVolumeCreateArgsForCall []struct {
@@ -618,6 +916,46 @@ type FakeQuerier struct {
 	nodeStatusUpdateReturnsOnCall map[int]struct {
 		result1 error
 	}
+	ProjectAccountAddStub        func(context.Context, db.ProjectAccountAddParams) error
+	projectAccountAddMutex       sync.RWMutex
+	projectAccountAddArgsForCall []struct {
+		arg1 context.Context
+		arg2 db.ProjectAccountAddParams
+	}
+	projectAccountAddReturns struct {
+		result1 error
+	}
+	projectAccountAddReturnsOnCall map[int]struct {
+		result1 error
+	}
+	ProjectAccountExistsStub        func(context.Context, db.ProjectAccountExistsParams) (bool, error)
+	projectAccountExistsMutex       sync.RWMutex
+	projectAccountExistsArgsForCall []struct {
+		arg1 context.Context
+		arg2 db.ProjectAccountExistsParams
+	}
+	projectAccountExistsReturns struct {
+		result1 bool
+		result2 error
+	}
+	projectAccountExistsReturnsOnCall map[int]struct {
+		result1 bool
+		result2 error
+	}
+	ProjectAccountRemoveStub        func(context.Context, db.ProjectAccountRemoveParams) (int64, error)
+	projectAccountRemoveMutex       sync.RWMutex
+	projectAccountRemoveArgsForCall []struct {
+		arg1 context.Context
+		arg2 db.ProjectAccountRemoveParams
+	}
+	projectAccountRemoveReturns struct {
+		result1 int64
+		result2 error
+	}
+	projectAccountRemoveReturnsOnCall map[int]struct {
+		result1 int64
+		result2 error
+	}
 	ProjectGetStub        func(context.Context, string) (db.UnweaveProject, error)
 	projectGetMutex       sync.RWMutex
 	projectGetArgsForCall []struct {
@@ -632,6 +970,20 @@ type FakeQuerier struct {
 		result1 db.UnweaveProject
 		result2 error
 	}
+	ProjectQuotasUpdateStub        func(context.Context, db.ProjectQuotasUpdateParams) (int64, error)
+	projectQuotasUpdateMutex       sync.RWMutex
+	projectQuotasUpdateArgsForCall []struct {
+		arg1 context.Context
+		arg2 db.ProjectQuotasUpdateParams
+	}
+	projectQuotasUpdateReturns struct {
+		result1 int64
+		result2 error
+	}
+	projectQuotasUpdateReturnsOnCall map[int]struct {
+		result1 int64
+		result2 error
+	}
 	SSHKeyAddStub        func(context.Context, db.SSHKeyAddParams) error
 	sSHKeyAddMutex       sync.RWMutex
 	sSHKeyAddArgsForCall []struct {
@@ -766,53 +1118,542 @@ type FakeQuerier struct {
 	volumeUpdateReturnsOnCall map[int]struct {
 		result1 error
 	}
+	WebhookCreateStub        func(context.Context, db.WebhookCreateParams) error
+	webhookCreateMutex       sync.RWMutex
+	webhookCreateArgsForCall []struct {
+		arg1 context.Context
+		arg2 db.WebhookCreateParams
+	}
+	webhookCreateReturns struct {
+		result1 error
+	}
+	webhookCreateReturnsOnCall map[int]struct {
+		result1 error
+	}
+	WebhookDeleteStub        func(context.Context, db.WebhookDeleteParams) (int64, error)
+	webhookDeleteMutex       sync.RWMutex
+	webhookDeleteArgsForCall []struct {
+		arg1 context.Context
+		arg2 db.WebhookDeleteParams
+	}
+	webhookDeleteReturns struct {
+		result1 int64
+		result2 error
+	}
+	webhookDeleteReturnsOnCall map[int]struct {
+		result1 int64
+		result2 error
+	}
+	WebhookDeliveryCreateStub        func(context.Context, db.WebhookDeliveryCreateParams) error
+	webhookDeliveryCreateMutex       sync.RWMutex
+	webhookDeliveryCreateArgsForCall []struct {
+		arg1 context.Context
+		arg2 db.WebhookDeliveryCreateParams
+	}
+	webhookDeliveryCreateReturns struct {
+		result1 error
+	}
+	webhookDeliveryCreateReturnsOnCall map[int]struct {
+		result1 error
+	}
+	WebhookDeliveryListStub        func(context.Context, string) ([]db.UnweaveWebhookDelivery, error)
+	webhookDeliveryListMutex       sync.RWMutex
+	webhookDeliveryListArgsForCall []struct {
+		arg1 context.Context
+		arg2 string
+	}
+	webhookDeliveryListReturns struct {
+		result1 []db.UnweaveWebhookDelivery
+		result2 error
+	}
+	webhookDeliveryListReturnsOnCall map[int]struct {
+		result1 []db.UnweaveWebhookDelivery
+		result2 error
+	}
+	WebhookGetStub        func(context.Context, db.WebhookGetParams) (db.UnweaveWebhook, error)
+	webhookGetMutex       sync.RWMutex
+	webhookGetArgsForCall []struct {
+		arg1 context.Context
+		arg2 db.WebhookGetParams
+	}
+	webhookGetReturns struct {
+		result1 db.UnweaveWebhook
+		result2 error
+	}
+	webhookGetReturnsOnCall map[int]struct {
+		result1 db.UnweaveWebhook
+		result2 error
+	}
+	WebhookListStub        func(context.Context, string) ([]db.UnweaveWebhook, error)
+	webhookListMutex       sync.RWMutex
+	webhookListArgsForCall []struct {
+		arg1 context.Context
+		arg2 string
+	}
+	webhookListReturns struct {
+		result1 []db.UnweaveWebhook
+		result2 error
+	}
+	webhookListReturnsOnCall map[int]struct {
+		result1 []db.UnweaveWebhook
+		result2 error
+	}
+	WebhookListForEventStub        func(context.Context, db.WebhookListForEventParams) ([]db.UnweaveWebhook, error)
+	webhookListForEventMutex       sync.RWMutex
+	webhookListForEventArgsForCall []struct {
+		arg1 context.Context
+		arg2 db.WebhookListForEventParams
+	}
+	webhookListForEventReturns struct {
+		result1 []db.UnweaveWebhook
+		result2 error
+	}
+	webhookListForEventReturnsOnCall map[int]struct {
+		result1 []db.UnweaveWebhook
+		result2 error
+	}
+	WebhookUpdateStub        func(context.Context, db.WebhookUpdateParams) (int64, error)
+	webhookUpdateMutex       sync.RWMutex
+	webhookUpdateArgsForCall []struct {
+		arg1 context.Context
+		arg2 db.WebhookUpdateParams
+	}
+	webhookUpdateReturns struct {
+		result1 int64
+		result2 error
+	}
+	webhookUpdateReturnsOnCall map[int]struct {
+		result1 int64
+		result2 error
+	}
 	invocations      map[string][][]interface{}
 	invocationsMutex sync.RWMutex
 }
 
-func (fake *FakeQuerier) BuildCreate(arg1 context.Context, arg2 db.BuildCreateParams) (string, error) {
-	fake.buildCreateMutex.Lock()
-	ret, specificReturn := fake.buildCreateReturnsOnCall[len(fake.buildCreateArgsForCall)]
-	fake.buildCreateArgsForCall = append(fake.buildCreateArgsForCall, struct {
+func (fake *FakeQuerier) AccessTokenCreate(arg1 context.Context, arg2 db.AccessTokenCreateParams) error {
+	fake.accessTokenCreateMutex.Lock()
+	ret, specificReturn := fake.accessTokenCreateReturnsOnCall[len(fake.accessTokenCreateArgsForCall)]
+	fake.accessTokenCreateArgsForCall = append(fake.accessTokenCreateArgsForCall, struct {
 		arg1 context.Context
-		arg2 db.BuildCreateParams
+		arg2 db.AccessTokenCreateParams
 	}{arg1, arg2})
-	stub := fake.BuildCreateStub
-	fakeReturns := fake.buildCreateReturns
-	fake.recordInvocation("BuildCreate", []interface{}{arg1, arg2})
-	fake.buildCreateMutex.Unlock()
+	stub := fake.AccessTokenCreateStub
+	fakeReturns := fake.accessTokenCreateReturns
+	fake.recordInvocation("AccessTokenCreate", []interface{}{arg1, arg2})
+	fake.accessTokenCreateMutex.Unlock()
 	if stub != nil {
 		return stub(arg1, arg2)
 	}
 	if specificReturn {
-		return ret.result1, ret.result2
+		return ret.result1
 	}
-	return fakeReturns.result1, fakeReturns.result2
+	return fakeReturns.result1
 }
 
-func (fake *FakeQuerier) BuildCreateCallCount() int {
-	fake.buildCreateMutex.RLock()
-	defer fake.buildCreateMutex.RUnlock()
-	return len(fake.buildCreateArgsForCall)
+func (fake *FakeQuerier) AccessTokenCreateCallCount() int {
+	fake.accessTokenCreateMutex.RLock()
+	defer fake.accessTokenCreateMutex.RUnlock()
+	return len(fake.accessTokenCreateArgsForCall)
 }
 
-func (fake *FakeQuerier) BuildCreateCalls(stub func(context.Context, db.BuildCreateParams) (string, error)) {
-	fake.buildCreateMutex.Lock()
-	defer fake.buildCreateMutex.Unlock()
-	fake.BuildCreateStub = stub
+func (fake *FakeQuerier) AccessTokenCreateCalls(stub func(context.Context, db.AccessTokenCreateParams) error) {
+	fake.accessTokenCreateMutex.Lock()
+	defer fake.accessTokenCreateMutex.Unlock()
+	fake.AccessTokenCreateStub = stub
 }
 
-func (fake *FakeQuerier) BuildCreateArgsForCall(i int) (context.Context, db.BuildCreateParams) {
-	fake.buildCreateMutex.RLock()
-	defer fake.buildCreateMutex.RUnlock()
-	argsForCall := fake.buildCreateArgsForCall[i]
+func (fake *FakeQuerier) AccessTokenCreateArgsForCall(i int) (context.Context, db.AccessTokenCreateParams) {
+	fake.accessTokenCreateMutex.RLock()
+	defer fake.accessTokenCreateMutex.RUnlock()
+	argsForCall := fake.accessTokenCreateArgsForCall[i]
 	return argsForCall.arg1, argsForCall.arg2
 }
 
-func (fake *FakeQuerier) BuildCreateReturns(result1 string, result2 error) {
-	fake.buildCreateMutex.Lock()
-	defer fake.buildCreateMutex.Unlock()
-	fake.BuildCreateStub = nil
+func (fake *FakeQuerier) AccessTokenCreateReturns(result1 error) {
+	fake.accessTokenCreateMutex.Lock()
+	defer fake.accessTokenCreateMutex.Unlock()
+	fake.AccessTokenCreateStub = nil
+	fake.accessTokenCreateReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeQuerier) AccessTokenCreateReturnsOnCall(i int, result1 error) {
+	fake.accessTokenCreateMutex.Lock()
+	defer fake.accessTokenCreateMutex.Unlock()
+	fake.AccessTokenCreateStub = nil
+	if fake.accessTokenCreateReturnsOnCall == nil {
+		fake.accessTokenCreateReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.accessTokenCreateReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeQuerier) AccessTokenGetByHash(arg1 context.Context, arg2 string) (db.UnweaveAccessToken, error) {
+	fake.accessTokenGetByHashMutex.Lock()
+	ret, specificReturn := fake.accessTokenGetByHashReturnsOnCall[len(fake.accessTokenGetByHashArgsForCall)]
+	fake.accessTokenGetByHashArgsForCall = append(fake.accessTokenGetByHashArgsForCall, struct {
+		arg1 context.Context
+		arg2 string
+	}{arg1, arg2})
+	stub := fake.AccessTokenGetByHashStub
+	fakeReturns := fake.accessTokenGetByHashReturns
+	fake.recordInvocation("AccessTokenGetByHash", []interface{}{arg1, arg2})
+	fake.accessTokenGetByHashMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeQuerier) AccessTokenGetByHashCallCount() int {
+	fake.accessTokenGetByHashMutex.RLock()
+	defer fake.accessTokenGetByHashMutex.RUnlock()
+	return len(fake.accessTokenGetByHashArgsForCall)
+}
+
+func (fake *FakeQuerier) AccessTokenGetByHashCalls(stub func(context.Context, string) (db.UnweaveAccessToken, error)) {
+	fake.accessTokenGetByHashMutex.Lock()
+	defer fake.accessTokenGetByHashMutex.Unlock()
+	fake.AccessTokenGetByHashStub = stub
+}
+
+func (fake *FakeQuerier) AccessTokenGetByHashArgsForCall(i int) (context.Context, string) {
+	fake.accessTokenGetByHashMutex.RLock()
+	defer fake.accessTokenGetByHashMutex.RUnlock()
+	argsForCall := fake.accessTokenGetByHashArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeQuerier) AccessTokenGetByHashReturns(result1 db.UnweaveAccessToken, result2 error) {
+	fake.accessTokenGetByHashMutex.Lock()
+	defer fake.accessTokenGetByHashMutex.Unlock()
+	fake.AccessTokenGetByHashStub = nil
+	fake.accessTokenGetByHashReturns = struct {
+		result1 db.UnweaveAccessToken
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeQuerier) AccessTokenGetByHashReturnsOnCall(i int, result1 db.UnweaveAccessToken, result2 error) {
+	fake.accessTokenGetByHashMutex.Lock()
+	defer fake.accessTokenGetByHashMutex.Unlock()
+	fake.AccessTokenGetByHashStub = nil
+	if fake.accessTokenGetByHashReturnsOnCall == nil {
+		fake.accessTokenGetByHashReturnsOnCall = make(map[int]struct {
+			result1 db.UnweaveAccessToken
+			result2 error
+		})
+	}
+	fake.accessTokenGetByHashReturnsOnCall[i] = struct {
+		result1 db.UnweaveAccessToken
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeQuerier) AccessTokenList(arg1 context.Context, arg2 string) ([]db.UnweaveAccessToken, error) {
+	fake.accessTokenListMutex.Lock()
+	ret, specificReturn := fake.accessTokenListReturnsOnCall[len(fake.accessTokenListArgsForCall)]
+	fake.accessTokenListArgsForCall = append(fake.accessTokenListArgsForCall, struct {
+		arg1 context.Context
+		arg2 string
+	}{arg1, arg2})
+	stub := fake.AccessTokenListStub
+	fakeReturns := fake.accessTokenListReturns
+	fake.recordInvocation("AccessTokenList", []interface{}{arg1, arg2})
+	fake.accessTokenListMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeQuerier) AccessTokenListCallCount() int {
+	fake.accessTokenListMutex.RLock()
+	defer fake.accessTokenListMutex.RUnlock()
+	return len(fake.accessTokenListArgsForCall)
+}
+
+func (fake *FakeQuerier) AccessTokenListCalls(stub func(context.Context, string) ([]db.UnweaveAccessToken, error)) {
+	fake.accessTokenListMutex.Lock()
+	defer fake.accessTokenListMutex.Unlock()
+	fake.AccessTokenListStub = stub
+}
+
+func (fake *FakeQuerier) AccessTokenListArgsForCall(i int) (context.Context, string) {
+	fake.accessTokenListMutex.RLock()
+	defer fake.accessTokenListMutex.RUnlock()
+	argsForCall := fake.accessTokenListArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeQuerier) AccessTokenListReturns(result1 []db.UnweaveAccessToken, result2 error) {
+	fake.accessTokenListMutex.Lock()
+	defer fake.accessTokenListMutex.Unlock()
+	fake.AccessTokenListStub = nil
+	fake.accessTokenListReturns = struct {
+		result1 []db.UnweaveAccessToken
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeQuerier) AccessTokenListReturnsOnCall(i int, result1 []db.UnweaveAccessToken, result2 error) {
+	fake.accessTokenListMutex.Lock()
+	defer fake.accessTokenListMutex.Unlock()
+	fake.AccessTokenListStub = nil
+	if fake.accessTokenListReturnsOnCall == nil {
+		fake.accessTokenListReturnsOnCall = make(map[int]struct {
+			result1 []db.UnweaveAccessToken
+			result2 error
+		})
+	}
+	fake.accessTokenListReturnsOnCall[i] = struct {
+		result1 []db.UnweaveAccessToken
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeQuerier) AccessTokenRevoke(arg1 context.Context, arg2 db.AccessTokenRevokeParams) (int64, error) {
+	fake.accessTokenRevokeMutex.Lock()
+	ret, specificReturn := fake.accessTokenRevokeReturnsOnCall[len(fake.accessTokenRevokeArgsForCall)]
+	fake.accessTokenRevokeArgsForCall = append(fake.accessTokenRevokeArgsForCall, struct {
+		arg1 context.Context
+		arg2 db.AccessTokenRevokeParams
+	}{arg1, arg2})
+	stub := fake.AccessTokenRevokeStub
+	fakeReturns := fake.accessTokenRevokeReturns
+	fake.recordInvocation("AccessTokenRevoke", []interface{}{arg1, arg2})
+	fake.accessTokenRevokeMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeQuerier) AccessTokenRevokeCallCount() int {
+	fake.accessTokenRevokeMutex.RLock()
+	defer fake.accessTokenRevokeMutex.RUnlock()
+	return len(fake.accessTokenRevokeArgsForCall)
+}
+
+func (fake *FakeQuerier) AccessTokenRevokeCalls(stub func(context.Context, db.AccessTokenRevokeParams) (int64, error)) {
+	fake.accessTokenRevokeMutex.Lock()
+	defer fake.accessTokenRevokeMutex.Unlock()
+	fake.AccessTokenRevokeStub = stub
+}
+
+func (fake *FakeQuerier) AccessTokenRevokeArgsForCall(i int) (context.Context, db.AccessTokenRevokeParams) {
+	fake.accessTokenRevokeMutex.RLock()
+	defer fake.accessTokenRevokeMutex.RUnlock()
+	argsForCall := fake.accessTokenRevokeArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeQuerier) AccessTokenRevokeReturns(result1 int64, result2 error) {
+	fake.accessTokenRevokeMutex.Lock()
+	defer fake.accessTokenRevokeMutex.Unlock()
+	fake.AccessTokenRevokeStub = nil
+	fake.accessTokenRevokeReturns = struct {
+		result1 int64
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeQuerier) AccessTokenRevokeReturnsOnCall(i int, result1 int64, result2 error) {
+	fake.accessTokenRevokeMutex.Lock()
+	defer fake.accessTokenRevokeMutex.Unlock()
+	fake.AccessTokenRevokeStub = nil
+	if fake.accessTokenRevokeReturnsOnCall == nil {
+		fake.accessTokenRevokeReturnsOnCall = make(map[int]struct {
+			result1 int64
+			result2 error
+		})
+	}
+	fake.accessTokenRevokeReturnsOnCall[i] = struct {
+		result1 int64
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeQuerier) AccessTokenTouch(arg1 context.Context, arg2 string) error {
+	fake.accessTokenTouchMutex.Lock()
+	ret, specificReturn := fake.accessTokenTouchReturnsOnCall[len(fake.accessTokenTouchArgsForCall)]
+	fake.accessTokenTouchArgsForCall = append(fake.accessTokenTouchArgsForCall, struct {
+		arg1 context.Context
+		arg2 string
+	}{arg1, arg2})
+	stub := fake.AccessTokenTouchStub
+	fakeReturns := fake.accessTokenTouchReturns
+	fake.recordInvocation("AccessTokenTouch", []interface{}{arg1, arg2})
+	fake.accessTokenTouchMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeQuerier) AccessTokenTouchCallCount() int {
+	fake.accessTokenTouchMutex.RLock()
+	defer fake.accessTokenTouchMutex.RUnlock()
+	return len(fake.accessTokenTouchArgsForCall)
+}
+
+func (fake *FakeQuerier) AccessTokenTouchCalls(stub func(context.Context, string) error) {
+	fake.accessTokenTouchMutex.Lock()
+	defer fake.accessTokenTouchMutex.Unlock()
+	fake.AccessTokenTouchStub = stub
+}
+
+func (fake *FakeQuerier) AccessTokenTouchArgsForCall(i int) (context.Context, string) {
+	fake.accessTokenTouchMutex.RLock()
+	defer fake.accessTokenTouchMutex.RUnlock()
+	argsForCall := fake.accessTokenTouchArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeQuerier) AccessTokenTouchReturns(result1 error) {
+	fake.accessTokenTouchMutex.Lock()
+	defer fake.accessTokenTouchMutex.Unlock()
+	fake.AccessTokenTouchStub = nil
+	fake.accessTokenTouchReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeQuerier) AccessTokenTouchReturnsOnCall(i int, result1 error) {
+	fake.accessTokenTouchMutex.Lock()
+	defer fake.accessTokenTouchMutex.Unlock()
+	fake.AccessTokenTouchStub = nil
+	if fake.accessTokenTouchReturnsOnCall == nil {
+		fake.accessTokenTouchReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.accessTokenTouchReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeQuerier) AccountCreate(arg1 context.Context, arg2 string) error {
+	fake.accountCreateMutex.Lock()
+	ret, specificReturn := fake.accountCreateReturnsOnCall[len(fake.accountCreateArgsForCall)]
+	fake.accountCreateArgsForCall = append(fake.accountCreateArgsForCall, struct {
+		arg1 context.Context
+		arg2 string
+	}{arg1, arg2})
+	stub := fake.AccountCreateStub
+	fakeReturns := fake.accountCreateReturns
+	fake.recordInvocation("AccountCreate", []interface{}{arg1, arg2})
+	fake.accountCreateMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeQuerier) AccountCreateCallCount() int {
+	fake.accountCreateMutex.RLock()
+	defer fake.accountCreateMutex.RUnlock()
+	return len(fake.accountCreateArgsForCall)
+}
+
+func (fake *FakeQuerier) AccountCreateCalls(stub func(context.Context, string) error) {
+	fake.accountCreateMutex.Lock()
+	defer fake.accountCreateMutex.Unlock()
+	fake.AccountCreateStub = stub
+}
+
+func (fake *FakeQuerier) AccountCreateArgsForCall(i int) (context.Context, string) {
+	fake.accountCreateMutex.RLock()
+	defer fake.accountCreateMutex.RUnlock()
+	argsForCall := fake.accountCreateArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeQuerier) AccountCreateReturns(result1 error) {
+	fake.accountCreateMutex.Lock()
+	defer fake.accountCreateMutex.Unlock()
+	fake.AccountCreateStub = nil
+	fake.accountCreateReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeQuerier) AccountCreateReturnsOnCall(i int, result1 error) {
+	fake.accountCreateMutex.Lock()
+	defer fake.accountCreateMutex.Unlock()
+	fake.AccountCreateStub = nil
+	if fake.accountCreateReturnsOnCall == nil {
+		fake.accountCreateReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.accountCreateReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeQuerier) BuildCreate(arg1 context.Context, arg2 db.BuildCreateParams) (string, error) {
+	fake.buildCreateMutex.Lock()
+	ret, specificReturn := fake.buildCreateReturnsOnCall[len(fake.buildCreateArgsForCall)]
+	fake.buildCreateArgsForCall = append(fake.buildCreateArgsForCall, struct {
+		arg1 context.Context
+		arg2 db.BuildCreateParams
+	}{arg1, arg2})
+	stub := fake.BuildCreateStub
+	fakeReturns := fake.buildCreateReturns
+	fake.recordInvocation("BuildCreate", []interface{}{arg1, arg2})
+	fake.buildCreateMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeQuerier) BuildCreateCallCount() int {
+	fake.buildCreateMutex.RLock()
+	defer fake.buildCreateMutex.RUnlock()
+	return len(fake.buildCreateArgsForCall)
+}
+
+func (fake *FakeQuerier) BuildCreateCalls(stub func(context.Context, db.BuildCreateParams) (string, error)) {
+	fake.buildCreateMutex.Lock()
+	defer fake.buildCreateMutex.Unlock()
+	fake.BuildCreateStub = stub
+}
+
+func (fake *FakeQuerier) BuildCreateArgsForCall(i int) (context.Context, db.BuildCreateParams) {
+	fake.buildCreateMutex.RLock()
+	defer fake.buildCreateMutex.RUnlock()
+	argsForCall := fake.buildCreateArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeQuerier) BuildCreateReturns(result1 string, result2 error) {
+	fake.buildCreateMutex.Lock()
+	defer fake.buildCreateMutex.Unlock()
+	fake.BuildCreateStub = nil
 	fake.buildCreateReturns = struct {
 		result1 string
 		result2 error
@@ -1154,6 +1995,68 @@ func (fake *FakeQuerier) EndpointCheckCreateReturnsOnCall(i int, result1 error)
 	}{result1}
 }
 
+func (fake *FakeQuerier) EndpointCheckStatusUpdate(arg1 context.Context, arg2 db.EndpointCheckStatusUpdateParams) error {
+	fake.endpointCheckStatusUpdateMutex.Lock()
+	ret, specificReturn := fake.endpointCheckStatusUpdateReturnsOnCall[len(fake.endpointCheckStatusUpdateArgsForCall)]
+	fake.endpointCheckStatusUpdateArgsForCall = append(fake.endpointCheckStatusUpdateArgsForCall, struct {
+		arg1 context.Context
+		arg2 db.EndpointCheckStatusUpdateParams
+	}{arg1, arg2})
+	stub := fake.EndpointCheckStatusUpdateStub
+	fakeReturns := fake.endpointCheckStatusUpdateReturns
+	fake.recordInvocation("EndpointCheckStatusUpdate", []interface{}{arg1, arg2})
+	fake.endpointCheckStatusUpdateMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeQuerier) EndpointCheckStatusUpdateCallCount() int {
+	fake.endpointCheckStatusUpdateMutex.RLock()
+	defer fake.endpointCheckStatusUpdateMutex.RUnlock()
+	return len(fake.endpointCheckStatusUpdateArgsForCall)
+}
+
+func (fake *FakeQuerier) EndpointCheckStatusUpdateCalls(stub func(context.Context, db.EndpointCheckStatusUpdateParams) error) {
+	fake.endpointCheckStatusUpdateMutex.Lock()
+	defer fake.endpointCheckStatusUpdateMutex.Unlock()
+	fake.EndpointCheckStatusUpdateStub = stub
+}
+
+func (fake *FakeQuerier) EndpointCheckStatusUpdateArgsForCall(i int) (context.Context, db.EndpointCheckStatusUpdateParams) {
+	fake.endpointCheckStatusUpdateMutex.RLock()
+	defer fake.endpointCheckStatusUpdateMutex.RUnlock()
+	argsForCall := fake.endpointCheckStatusUpdateArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeQuerier) EndpointCheckStatusUpdateReturns(result1 error) {
+	fake.endpointCheckStatusUpdateMutex.Lock()
+	defer fake.endpointCheckStatusUpdateMutex.Unlock()
+	fake.EndpointCheckStatusUpdateStub = nil
+	fake.endpointCheckStatusUpdateReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeQuerier) EndpointCheckStatusUpdateReturnsOnCall(i int, result1 error) {
+	fake.endpointCheckStatusUpdateMutex.Lock()
+	defer fake.endpointCheckStatusUpdateMutex.Unlock()
+	fake.EndpointCheckStatusUpdateStub = nil
+	if fake.endpointCheckStatusUpdateReturnsOnCall == nil {
+		fake.endpointCheckStatusUpdateReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.endpointCheckStatusUpdateReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
 func (fake *FakeQuerier) EndpointCheckStepCreate(arg1 context.Context, arg2 db.EndpointCheckStepCreateParams) error {
 	fake.endpointCheckStepCreateMutex.Lock()
 	ret, specificReturn := fake.endpointCheckStepCreateReturnsOnCall[len(fake.endpointCheckStepCreateArgsForCall)]
@@ -1659,19 +2562,18 @@ func (fake *FakeQuerier) EndpointGetReturnsOnCall(i int, result1 db.UnweaveEndpo
 	}{result1, result2}
 }
 
-func (fake *FakeQuerier) EndpointVersion(arg1 context.Context, arg2 string) (db.UnweaveEndpointVersion, error) {
-	fake.endpointVersionMutex.Lock()
-	ret, specificReturn := fake.endpointVersionReturnsOnCall[len(fake.endpointVersionArgsForCall)]
-	fake.endpointVersionArgsForCall = append(fake.endpointVersionArgsForCall, struct {
+func (fake *FakeQuerier) EndpointList(arg1 context.Context) ([]db.UnweaveEndpoint, error) {
+	fake.endpointListMutex.Lock()
+	ret, specificReturn := fake.endpointListReturnsOnCall[len(fake.endpointListArgsForCall)]
+	fake.endpointListArgsForCall = append(fake.endpointListArgsForCall, struct {
 		arg1 context.Context
-		arg2 string
-	}{arg1, arg2})
-	stub := fake.EndpointVersionStub
-	fakeReturns := fake.endpointVersionReturns
-	fake.recordInvocation("EndpointVersion", []interface{}{arg1, arg2})
-	fake.endpointVersionMutex.Unlock()
+	}{arg1})
+	stub := fake.EndpointListStub
+	fakeReturns := fake.endpointListReturns
+	fake.recordInvocation("EndpointList", []interface{}{arg1})
+	fake.endpointListMutex.Unlock()
 	if stub != nil {
-		return stub(arg1, arg2)
+		return stub(arg1)
 	}
 	if specificReturn {
 		return ret.result1, ret.result2
@@ -1679,21 +2581,530 @@ func (fake *FakeQuerier) EndpointVersion(arg1 context.Context, arg2 string) (db.
 	return fakeReturns.result1, fakeReturns.result2
 }
 
-func (fake *FakeQuerier) EndpointVersionCallCount() int {
-	fake.endpointVersionMutex.RLock()
-	defer fake.endpointVersionMutex.RUnlock()
-	return len(fake.endpointVersionArgsForCall)
+func (fake *FakeQuerier) EndpointListCallCount() int {
+	fake.endpointListMutex.RLock()
+	defer fake.endpointListMutex.RUnlock()
+	return len(fake.endpointListArgsForCall)
 }
 
-func (fake *FakeQuerier) EndpointVersionCalls(stub func(context.Context, string) (db.UnweaveEndpointVersion, error)) {
-	fake.endpointVersionMutex.Lock()
-	defer fake.endpointVersionMutex.Unlock()
-	fake.EndpointVersionStub = stub
+func (fake *FakeQuerier) EndpointListCalls(stub func(context.Context) ([]db.UnweaveEndpoint, error)) {
+	fake.endpointListMutex.Lock()
+	defer fake.endpointListMutex.Unlock()
+	fake.EndpointListStub = stub
 }
 
-func (fake *FakeQuerier) EndpointVersionArgsForCall(i int) (context.Context, string) {
-	fake.endpointVersionMutex.RLock()
-	defer fake.endpointVersionMutex.RUnlock()
+func (fake *FakeQuerier) EndpointListArgsForCall(i int) context.Context {
+	fake.endpointListMutex.RLock()
+	defer fake.endpointListMutex.RUnlock()
+	argsForCall := fake.endpointListArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeQuerier) EndpointListReturns(result1 []db.UnweaveEndpoint, result2 error) {
+	fake.endpointListMutex.Lock()
+	defer fake.endpointListMutex.Unlock()
+	fake.EndpointListStub = nil
+	fake.endpointListReturns = struct {
+		result1 []db.UnweaveEndpoint
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeQuerier) EndpointListReturnsOnCall(i int, result1 []db.UnweaveEndpoint, result2 error) {
+	fake.endpointListMutex.Lock()
+	defer fake.endpointListMutex.Unlock()
+	fake.EndpointListStub = nil
+	if fake.endpointListReturnsOnCall == nil {
+		fake.endpointListReturnsOnCall = make(map[int]struct {
+			result1 []db.UnweaveEndpoint
+			result2 error
+		})
+	}
+	fake.endpointListReturnsOnCall[i] = struct {
+		result1 []db.UnweaveEndpoint
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeQuerier) EndpointPromotionCreate(arg1 context.Context, arg2 db.EndpointPromotionCreateParams) error {
+	fake.endpointPromotionCreateMutex.Lock()
+	ret, specificReturn := fake.endpointPromotionCreateReturnsOnCall[len(fake.endpointPromotionCreateArgsForCall)]
+	fake.endpointPromotionCreateArgsForCall = append(fake.endpointPromotionCreateArgsForCall, struct {
+		arg1 context.Context
+		arg2 db.EndpointPromotionCreateParams
+	}{arg1, arg2})
+	stub := fake.EndpointPromotionCreateStub
+	fakeReturns := fake.endpointPromotionCreateReturns
+	fake.recordInvocation("EndpointPromotionCreate", []interface{}{arg1, arg2})
+	fake.endpointPromotionCreateMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeQuerier) EndpointPromotionCreateCallCount() int {
+	fake.endpointPromotionCreateMutex.RLock()
+	defer fake.endpointPromotionCreateMutex.RUnlock()
+	return len(fake.endpointPromotionCreateArgsForCall)
+}
+
+func (fake *FakeQuerier) EndpointPromotionCreateCalls(stub func(context.Context, db.EndpointPromotionCreateParams) error) {
+	fake.endpointPromotionCreateMutex.Lock()
+	defer fake.endpointPromotionCreateMutex.Unlock()
+	fake.EndpointPromotionCreateStub = stub
+}
+
+func (fake *FakeQuerier) EndpointPromotionCreateArgsForCall(i int) (context.Context, db.EndpointPromotionCreateParams) {
+	fake.endpointPromotionCreateMutex.RLock()
+	defer fake.endpointPromotionCreateMutex.RUnlock()
+	argsForCall := fake.endpointPromotionCreateArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeQuerier) EndpointPromotionCreateReturns(result1 error) {
+	fake.endpointPromotionCreateMutex.Lock()
+	defer fake.endpointPromotionCreateMutex.Unlock()
+	fake.EndpointPromotionCreateStub = nil
+	fake.endpointPromotionCreateReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeQuerier) EndpointPromotionCreateReturnsOnCall(i int, result1 error) {
+	fake.endpointPromotionCreateMutex.Lock()
+	defer fake.endpointPromotionCreateMutex.Unlock()
+	fake.EndpointPromotionCreateStub = nil
+	if fake.endpointPromotionCreateReturnsOnCall == nil {
+		fake.endpointPromotionCreateReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.endpointPromotionCreateReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeQuerier) EndpointPromotionList(arg1 context.Context, arg2 string) ([]db.UnweaveEndpointPromotion, error) {
+	fake.endpointPromotionListMutex.Lock()
+	ret, specificReturn := fake.endpointPromotionListReturnsOnCall[len(fake.endpointPromotionListArgsForCall)]
+	fake.endpointPromotionListArgsForCall = append(fake.endpointPromotionListArgsForCall, struct {
+		arg1 context.Context
+		arg2 string
+	}{arg1, arg2})
+	stub := fake.EndpointPromotionListStub
+	fakeReturns := fake.endpointPromotionListReturns
+	fake.recordInvocation("EndpointPromotionList", []interface{}{arg1, arg2})
+	fake.endpointPromotionListMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeQuerier) EndpointPromotionListCallCount() int {
+	fake.endpointPromotionListMutex.RLock()
+	defer fake.endpointPromotionListMutex.RUnlock()
+	return len(fake.endpointPromotionListArgsForCall)
+}
+
+func (fake *FakeQuerier) EndpointPromotionListCalls(stub func(context.Context, string) ([]db.UnweaveEndpointPromotion, error)) {
+	fake.endpointPromotionListMutex.Lock()
+	defer fake.endpointPromotionListMutex.Unlock()
+	fake.EndpointPromotionListStub = stub
+}
+
+func (fake *FakeQuerier) EndpointPromotionListArgsForCall(i int) (context.Context, string) {
+	fake.endpointPromotionListMutex.RLock()
+	defer fake.endpointPromotionListMutex.RUnlock()
+	argsForCall := fake.endpointPromotionListArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeQuerier) EndpointPromotionListReturns(result1 []db.UnweaveEndpointPromotion, result2 error) {
+	fake.endpointPromotionListMutex.Lock()
+	defer fake.endpointPromotionListMutex.Unlock()
+	fake.EndpointPromotionListStub = nil
+	fake.endpointPromotionListReturns = struct {
+		result1 []db.UnweaveEndpointPromotion
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeQuerier) EndpointPromotionListReturnsOnCall(i int, result1 []db.UnweaveEndpointPromotion, result2 error) {
+	fake.endpointPromotionListMutex.Lock()
+	defer fake.endpointPromotionListMutex.Unlock()
+	fake.EndpointPromotionListStub = nil
+	if fake.endpointPromotionListReturnsOnCall == nil {
+		fake.endpointPromotionListReturnsOnCall = make(map[int]struct {
+			result1 []db.UnweaveEndpointPromotion
+			result2 error
+		})
+	}
+	fake.endpointPromotionListReturnsOnCall[i] = struct {
+		result1 []db.UnweaveEndpointPromotion
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeQuerier) EndpointRoutes(arg1 context.Context) ([]db.EndpointRoutesRow, error) {
+	fake.endpointRoutesMutex.Lock()
+	ret, specificReturn := fake.endpointRoutesReturnsOnCall[len(fake.endpointRoutesArgsForCall)]
+	fake.endpointRoutesArgsForCall = append(fake.endpointRoutesArgsForCall, struct {
+		arg1 context.Context
+	}{arg1})
+	stub := fake.EndpointRoutesStub
+	fakeReturns := fake.endpointRoutesReturns
+	fake.recordInvocation("EndpointRoutes", []interface{}{arg1})
+	fake.endpointRoutesMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeQuerier) EndpointRoutesCallCount() int {
+	fake.endpointRoutesMutex.RLock()
+	defer fake.endpointRoutesMutex.RUnlock()
+	return len(fake.endpointRoutesArgsForCall)
+}
+
+func (fake *FakeQuerier) EndpointRoutesCalls(stub func(context.Context) ([]db.EndpointRoutesRow, error)) {
+	fake.endpointRoutesMutex.Lock()
+	defer fake.endpointRoutesMutex.Unlock()
+	fake.EndpointRoutesStub = stub
+}
+
+func (fake *FakeQuerier) EndpointRoutesArgsForCall(i int) context.Context {
+	fake.endpointRoutesMutex.RLock()
+	defer fake.endpointRoutesMutex.RUnlock()
+	argsForCall := fake.endpointRoutesArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeQuerier) EndpointRoutesReturns(result1 []db.EndpointRoutesRow, result2 error) {
+	fake.endpointRoutesMutex.Lock()
+	defer fake.endpointRoutesMutex.Unlock()
+	fake.EndpointRoutesStub = nil
+	fake.endpointRoutesReturns = struct {
+		result1 []db.EndpointRoutesRow
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeQuerier) EndpointRoutesReturnsOnCall(i int, result1 []db.EndpointRoutesRow, result2 error) {
+	fake.endpointRoutesMutex.Lock()
+	defer fake.endpointRoutesMutex.Unlock()
+	fake.EndpointRoutesStub = nil
+	if fake.endpointRoutesReturnsOnCall == nil {
+		fake.endpointRoutesReturnsOnCall = make(map[int]struct {
+			result1 []db.EndpointRoutesRow
+			result2 error
+		})
+	}
+	fake.endpointRoutesReturnsOnCall[i] = struct {
+		result1 []db.EndpointRoutesRow
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeQuerier) EndpointTraffic(arg1 context.Context, arg2 string) (db.UnweaveEndpointTraffic, error) {
+	fake.endpointTrafficMutex.Lock()
+	ret, specificReturn := fake.endpointTrafficReturnsOnCall[len(fake.endpointTrafficArgsForCall)]
+	fake.endpointTrafficArgsForCall = append(fake.endpointTrafficArgsForCall, struct {
+		arg1 context.Context
+		arg2 string
+	}{arg1, arg2})
+	stub := fake.EndpointTrafficStub
+	fakeReturns := fake.endpointTrafficReturns
+	fake.recordInvocation("EndpointTraffic", []interface{}{arg1, arg2})
+	fake.endpointTrafficMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeQuerier) EndpointTrafficCallCount() int {
+	fake.endpointTrafficMutex.RLock()
+	defer fake.endpointTrafficMutex.RUnlock()
+	return len(fake.endpointTrafficArgsForCall)
+}
+
+func (fake *FakeQuerier) EndpointTrafficCalls(stub func(context.Context, string) (db.UnweaveEndpointTraffic, error)) {
+	fake.endpointTrafficMutex.Lock()
+	defer fake.endpointTrafficMutex.Unlock()
+	fake.EndpointTrafficStub = stub
+}
+
+func (fake *FakeQuerier) EndpointTrafficArgsForCall(i int) (context.Context, string) {
+	fake.endpointTrafficMutex.RLock()
+	defer fake.endpointTrafficMutex.RUnlock()
+	argsForCall := fake.endpointTrafficArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeQuerier) EndpointTrafficReturns(result1 db.UnweaveEndpointTraffic, result2 error) {
+	fake.endpointTrafficMutex.Lock()
+	defer fake.endpointTrafficMutex.Unlock()
+	fake.EndpointTrafficStub = nil
+	fake.endpointTrafficReturns = struct {
+		result1 db.UnweaveEndpointTraffic
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeQuerier) EndpointTrafficReturnsOnCall(i int, result1 db.UnweaveEndpointTraffic, result2 error) {
+	fake.endpointTrafficMutex.Lock()
+	defer fake.endpointTrafficMutex.Unlock()
+	fake.EndpointTrafficStub = nil
+	if fake.endpointTrafficReturnsOnCall == nil {
+		fake.endpointTrafficReturnsOnCall = make(map[int]struct {
+			result1 db.UnweaveEndpointTraffic
+			result2 error
+		})
+	}
+	fake.endpointTrafficReturnsOnCall[i] = struct {
+		result1 db.UnweaveEndpointTraffic
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeQuerier) EndpointTrafficDelete(arg1 context.Context, arg2 string) error {
+	fake.endpointTrafficDeleteMutex.Lock()
+	ret, specificReturn := fake.endpointTrafficDeleteReturnsOnCall[len(fake.endpointTrafficDeleteArgsForCall)]
+	fake.endpointTrafficDeleteArgsForCall = append(fake.endpointTrafficDeleteArgsForCall, struct {
+		arg1 context.Context
+		arg2 string
+	}{arg1, arg2})
+	stub := fake.EndpointTrafficDeleteStub
+	fakeReturns := fake.endpointTrafficDeleteReturns
+	fake.recordInvocation("EndpointTrafficDelete", []interface{}{arg1, arg2})
+	fake.endpointTrafficDeleteMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeQuerier) EndpointTrafficDeleteCallCount() int {
+	fake.endpointTrafficDeleteMutex.RLock()
+	defer fake.endpointTrafficDeleteMutex.RUnlock()
+	return len(fake.endpointTrafficDeleteArgsForCall)
+}
+
+func (fake *FakeQuerier) EndpointTrafficDeleteCalls(stub func(context.Context, string) error) {
+	fake.endpointTrafficDeleteMutex.Lock()
+	defer fake.endpointTrafficDeleteMutex.Unlock()
+	fake.EndpointTrafficDeleteStub = stub
+}
+
+func (fake *FakeQuerier) EndpointTrafficDeleteArgsForCall(i int) (context.Context, string) {
+	fake.endpointTrafficDeleteMutex.RLock()
+	defer fake.endpointTrafficDeleteMutex.RUnlock()
+	argsForCall := fake.endpointTrafficDeleteArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeQuerier) EndpointTrafficDeleteReturns(result1 error) {
+	fake.endpointTrafficDeleteMutex.Lock()
+	defer fake.endpointTrafficDeleteMutex.Unlock()
+	fake.EndpointTrafficDeleteStub = nil
+	fake.endpointTrafficDeleteReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeQuerier) EndpointTrafficDeleteReturnsOnCall(i int, result1 error) {
+	fake.endpointTrafficDeleteMutex.Lock()
+	defer fake.endpointTrafficDeleteMutex.Unlock()
+	fake.EndpointTrafficDeleteStub = nil
+	if fake.endpointTrafficDeleteReturnsOnCall == nil {
+		fake.endpointTrafficDeleteReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.endpointTrafficDeleteReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeQuerier) EndpointTrafficList(arg1 context.Context) ([]db.UnweaveEndpointTraffic, error) {
+	fake.endpointTrafficListMutex.Lock()
+	ret, specificReturn := fake.endpointTrafficListReturnsOnCall[len(fake.endpointTrafficListArgsForCall)]
+	fake.endpointTrafficListArgsForCall = append(fake.endpointTrafficListArgsForCall, struct {
+		arg1 context.Context
+	}{arg1})
+	stub := fake.EndpointTrafficListStub
+	fakeReturns := fake.endpointTrafficListReturns
+	fake.recordInvocation("EndpointTrafficList", []interface{}{arg1})
+	fake.endpointTrafficListMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeQuerier) EndpointTrafficListCallCount() int {
+	fake.endpointTrafficListMutex.RLock()
+	defer fake.endpointTrafficListMutex.RUnlock()
+	return len(fake.endpointTrafficListArgsForCall)
+}
+
+func (fake *FakeQuerier) EndpointTrafficListCalls(stub func(context.Context) ([]db.UnweaveEndpointTraffic, error)) {
+	fake.endpointTrafficListMutex.Lock()
+	defer fake.endpointTrafficListMutex.Unlock()
+	fake.EndpointTrafficListStub = stub
+}
+
+func (fake *FakeQuerier) EndpointTrafficListArgsForCall(i int) context.Context {
+	fake.endpointTrafficListMutex.RLock()
+	defer fake.endpointTrafficListMutex.RUnlock()
+	argsForCall := fake.endpointTrafficListArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeQuerier) EndpointTrafficListReturns(result1 []db.UnweaveEndpointTraffic, result2 error) {
+	fake.endpointTrafficListMutex.Lock()
+	defer fake.endpointTrafficListMutex.Unlock()
+	fake.EndpointTrafficListStub = nil
+	fake.endpointTrafficListReturns = struct {
+		result1 []db.UnweaveEndpointTraffic
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeQuerier) EndpointTrafficListReturnsOnCall(i int, result1 []db.UnweaveEndpointTraffic, result2 error) {
+	fake.endpointTrafficListMutex.Lock()
+	defer fake.endpointTrafficListMutex.Unlock()
+	fake.EndpointTrafficListStub = nil
+	if fake.endpointTrafficListReturnsOnCall == nil {
+		fake.endpointTrafficListReturnsOnCall = make(map[int]struct {
+			result1 []db.UnweaveEndpointTraffic
+			result2 error
+		})
+	}
+	fake.endpointTrafficListReturnsOnCall[i] = struct {
+		result1 []db.UnweaveEndpointTraffic
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeQuerier) EndpointTrafficSet(arg1 context.Context, arg2 db.EndpointTrafficSetParams) error {
+	fake.endpointTrafficSetMutex.Lock()
+	ret, specificReturn := fake.endpointTrafficSetReturnsOnCall[len(fake.endpointTrafficSetArgsForCall)]
+	fake.endpointTrafficSetArgsForCall = append(fake.endpointTrafficSetArgsForCall, struct {
+		arg1 context.Context
+		arg2 db.EndpointTrafficSetParams
+	}{arg1, arg2})
+	stub := fake.EndpointTrafficSetStub
+	fakeReturns := fake.endpointTrafficSetReturns
+	fake.recordInvocation("EndpointTrafficSet", []interface{}{arg1, arg2})
+	fake.endpointTrafficSetMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeQuerier) EndpointTrafficSetCallCount() int {
+	fake.endpointTrafficSetMutex.RLock()
+	defer fake.endpointTrafficSetMutex.RUnlock()
+	return len(fake.endpointTrafficSetArgsForCall)
+}
+
+func (fake *FakeQuerier) EndpointTrafficSetCalls(stub func(context.Context, db.EndpointTrafficSetParams) error) {
+	fake.endpointTrafficSetMutex.Lock()
+	defer fake.endpointTrafficSetMutex.Unlock()
+	fake.EndpointTrafficSetStub = stub
+}
+
+func (fake *FakeQuerier) EndpointTrafficSetArgsForCall(i int) (context.Context, db.EndpointTrafficSetParams) {
+	fake.endpointTrafficSetMutex.RLock()
+	defer fake.endpointTrafficSetMutex.RUnlock()
+	argsForCall := fake.endpointTrafficSetArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeQuerier) EndpointTrafficSetReturns(result1 error) {
+	fake.endpointTrafficSetMutex.Lock()
+	defer fake.endpointTrafficSetMutex.Unlock()
+	fake.EndpointTrafficSetStub = nil
+	fake.endpointTrafficSetReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeQuerier) EndpointTrafficSetReturnsOnCall(i int, result1 error) {
+	fake.endpointTrafficSetMutex.Lock()
+	defer fake.endpointTrafficSetMutex.Unlock()
+	fake.EndpointTrafficSetStub = nil
+	if fake.endpointTrafficSetReturnsOnCall == nil {
+		fake.endpointTrafficSetReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.endpointTrafficSetReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeQuerier) EndpointVersion(arg1 context.Context, arg2 string) (db.UnweaveEndpointVersion, error) {
+	fake.endpointVersionMutex.Lock()
+	ret, specificReturn := fake.endpointVersionReturnsOnCall[len(fake.endpointVersionArgsForCall)]
+	fake.endpointVersionArgsForCall = append(fake.endpointVersionArgsForCall, struct {
+		arg1 context.Context
+		arg2 string
+	}{arg1, arg2})
+	stub := fake.EndpointVersionStub
+	fakeReturns := fake.endpointVersionReturns
+	fake.recordInvocation("EndpointVersion", []interface{}{arg1, arg2})
+	fake.endpointVersionMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeQuerier) EndpointVersionCallCount() int {
+	fake.endpointVersionMutex.RLock()
+	defer fake.endpointVersionMutex.RUnlock()
+	return len(fake.endpointVersionArgsForCall)
+}
+
+func (fake *FakeQuerier) EndpointVersionCalls(stub func(context.Context, string) (db.UnweaveEndpointVersion, error)) {
+	fake.endpointVersionMutex.Lock()
+	defer fake.endpointVersionMutex.Unlock()
+	fake.EndpointVersionStub = stub
+}
+
+func (fake *FakeQuerier) EndpointVersionArgsForCall(i int) (context.Context, string) {
+	fake.endpointVersionMutex.RLock()
+	defer fake.endpointVersionMutex.RUnlock()
 	argsForCall := fake.endpointVersionArgsForCall[i]
 	return argsForCall.arg1, argsForCall.arg2
 }
@@ -1975,6 +3386,68 @@ func (fake *FakeQuerier) EndpointVersionPromoteReturnsOnCall(i int, result1 erro
 	}{result1}
 }
 
+func (fake *FakeQuerier) EndpointVersionStatusUpdate(arg1 context.Context, arg2 db.EndpointVersionStatusUpdateParams) error {
+	fake.endpointVersionStatusUpdateMutex.Lock()
+	ret, specificReturn := fake.endpointVersionStatusUpdateReturnsOnCall[len(fake.endpointVersionStatusUpdateArgsForCall)]
+	fake.endpointVersionStatusUpdateArgsForCall = append(fake.endpointVersionStatusUpdateArgsForCall, struct {
+		arg1 context.Context
+		arg2 db.EndpointVersionStatusUpdateParams
+	}{arg1, arg2})
+	stub := fake.EndpointVersionStatusUpdateStub
+	fakeReturns := fake.endpointVersionStatusUpdateReturns
+	fake.recordInvocation("EndpointVersionStatusUpdate", []interface{}{arg1, arg2})
+	fake.endpointVersionStatusUpdateMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeQuerier) EndpointVersionStatusUpdateCallCount() int {
+	fake.endpointVersionStatusUpdateMutex.RLock()
+	defer fake.endpointVersionStatusUpdateMutex.RUnlock()
+	return len(fake.endpointVersionStatusUpdateArgsForCall)
+}
+
+func (fake *FakeQuerier) EndpointVersionStatusUpdateCalls(stub func(context.Context, db.EndpointVersionStatusUpdateParams) error) {
+	fake.endpointVersionStatusUpdateMutex.Lock()
+	defer fake.endpointVersionStatusUpdateMutex.Unlock()
+	fake.EndpointVersionStatusUpdateStub = stub
+}
+
+func (fake *FakeQuerier) EndpointVersionStatusUpdateArgsForCall(i int) (context.Context, db.EndpointVersionStatusUpdateParams) {
+	fake.endpointVersionStatusUpdateMutex.RLock()
+	defer fake.endpointVersionStatusUpdateMutex.RUnlock()
+	argsForCall := fake.endpointVersionStatusUpdateArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeQuerier) EndpointVersionStatusUpdateReturns(result1 error) {
+	fake.endpointVersionStatusUpdateMutex.Lock()
+	defer fake.endpointVersionStatusUpdateMutex.Unlock()
+	fake.EndpointVersionStatusUpdateStub = nil
+	fake.endpointVersionStatusUpdateReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeQuerier) EndpointVersionStatusUpdateReturnsOnCall(i int, result1 error) {
+	fake.endpointVersionStatusUpdateMutex.Lock()
+	defer fake.endpointVersionStatusUpdateMutex.Unlock()
+	fake.EndpointVersionStatusUpdateStub = nil
+	if fake.endpointVersionStatusUpdateReturnsOnCall == nil {
+		fake.endpointVersionStatusUpdateReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.endpointVersionStatusUpdateReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
 func (fake *FakeQuerier) EndpointsForProject(arg1 context.Context, arg2 string) ([]db.UnweaveEndpoint, error) {
 	fake.endpointsForProjectMutex.Lock()
 	ret, specificReturn := fake.endpointsForProjectReturnsOnCall[len(fake.endpointsForProjectArgsForCall)]
@@ -2426,6 +3899,203 @@ func (fake *FakeQuerier) ExecCreateReturnsOnCall(i int, result1 error) {
 	}{result1}
 }
 
+func (fake *FakeQuerier) ExecEventCreate(arg1 context.Context, arg2 db.ExecEventCreateParams) error {
+	fake.execEventCreateMutex.Lock()
+	ret, specificReturn := fake.execEventCreateReturnsOnCall[len(fake.execEventCreateArgsForCall)]
+	fake.execEventCreateArgsForCall = append(fake.execEventCreateArgsForCall, struct {
+		arg1 context.Context
+		arg2 db.ExecEventCreateParams
+	}{arg1, arg2})
+	stub := fake.ExecEventCreateStub
+	fakeReturns := fake.execEventCreateReturns
+	fake.recordInvocation("ExecEventCreate", []interface{}{arg1, arg2})
+	fake.execEventCreateMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeQuerier) ExecEventCreateCallCount() int {
+	fake.execEventCreateMutex.RLock()
+	defer fake.execEventCreateMutex.RUnlock()
+	return len(fake.execEventCreateArgsForCall)
+}
+
+func (fake *FakeQuerier) ExecEventCreateCalls(stub func(context.Context, db.ExecEventCreateParams) error) {
+	fake.execEventCreateMutex.Lock()
+	defer fake.execEventCreateMutex.Unlock()
+	fake.ExecEventCreateStub = stub
+}
+
+func (fake *FakeQuerier) ExecEventCreateArgsForCall(i int) (context.Context, db.ExecEventCreateParams) {
+	fake.execEventCreateMutex.RLock()
+	defer fake.execEventCreateMutex.RUnlock()
+	argsForCall := fake.execEventCreateArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeQuerier) ExecEventCreateReturns(result1 error) {
+	fake.execEventCreateMutex.Lock()
+	defer fake.execEventCreateMutex.Unlock()
+	fake.ExecEventCreateStub = nil
+	fake.execEventCreateReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeQuerier) ExecEventCreateReturnsOnCall(i int, result1 error) {
+	fake.execEventCreateMutex.Lock()
+	defer fake.execEventCreateMutex.Unlock()
+	fake.ExecEventCreateStub = nil
+	if fake.execEventCreateReturnsOnCall == nil {
+		fake.execEventCreateReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.execEventCreateReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeQuerier) ExecEventList(arg1 context.Context, arg2 string) ([]db.UnweaveExecEvent, error) {
+	fake.execEventListMutex.Lock()
+	ret, specificReturn := fake.execEventListReturnsOnCall[len(fake.execEventListArgsForCall)]
+	fake.execEventListArgsForCall = append(fake.execEventListArgsForCall, struct {
+		arg1 context.Context
+		arg2 string
+	}{arg1, arg2})
+	stub := fake.ExecEventListStub
+	fakeReturns := fake.execEventListReturns
+	fake.recordInvocation("ExecEventList", []interface{}{arg1, arg2})
+	fake.execEventListMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeQuerier) ExecEventListCallCount() int {
+	fake.execEventListMutex.RLock()
+	defer fake.execEventListMutex.RUnlock()
+	return len(fake.execEventListArgsForCall)
+}
+
+func (fake *FakeQuerier) ExecEventListCalls(stub func(context.Context, string) ([]db.UnweaveExecEvent, error)) {
+	fake.execEventListMutex.Lock()
+	defer fake.execEventListMutex.Unlock()
+	fake.ExecEventListStub = stub
+}
+
+func (fake *FakeQuerier) ExecEventListArgsForCall(i int) (context.Context, string) {
+	fake.execEventListMutex.RLock()
+	defer fake.execEventListMutex.RUnlock()
+	argsForCall := fake.execEventListArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeQuerier) ExecEventListReturns(result1 []db.UnweaveExecEvent, result2 error) {
+	fake.execEventListMutex.Lock()
+	defer fake.execEventListMutex.Unlock()
+	fake.ExecEventListStub = nil
+	fake.execEventListReturns = struct {
+		result1 []db.UnweaveExecEvent
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeQuerier) ExecEventListReturnsOnCall(i int, result1 []db.UnweaveExecEvent, result2 error) {
+	fake.execEventListMutex.Lock()
+	defer fake.execEventListMutex.Unlock()
+	fake.ExecEventListStub = nil
+	if fake.execEventListReturnsOnCall == nil {
+		fake.execEventListReturnsOnCall = make(map[int]struct {
+			result1 []db.UnweaveExecEvent
+			result2 error
+		})
+	}
+	fake.execEventListReturnsOnCall[i] = struct {
+		result1 []db.UnweaveExecEvent
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeQuerier) ExecEventListByExecs(arg1 context.Context, arg2 []string) ([]db.UnweaveExecEvent, error) {
+	var arg2Copy []string
+	if arg2 != nil {
+		arg2Copy = make([]string, len(arg2))
+		copy(arg2Copy, arg2)
+	}
+	fake.execEventListByExecsMutex.Lock()
+	ret, specificReturn := fake.execEventListByExecsReturnsOnCall[len(fake.execEventListByExecsArgsForCall)]
+	fake.execEventListByExecsArgsForCall = append(fake.execEventListByExecsArgsForCall, struct {
+		arg1 context.Context
+		arg2 []string
+	}{arg1, arg2Copy})
+	stub := fake.ExecEventListByExecsStub
+	fakeReturns := fake.execEventListByExecsReturns
+	fake.recordInvocation("ExecEventListByExecs", []interface{}{arg1, arg2Copy})
+	fake.execEventListByExecsMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeQuerier) ExecEventListByExecsCallCount() int {
+	fake.execEventListByExecsMutex.RLock()
+	defer fake.execEventListByExecsMutex.RUnlock()
+	return len(fake.execEventListByExecsArgsForCall)
+}
+
+func (fake *FakeQuerier) ExecEventListByExecsCalls(stub func(context.Context, []string) ([]db.UnweaveExecEvent, error)) {
+	fake.execEventListByExecsMutex.Lock()
+	defer fake.execEventListByExecsMutex.Unlock()
+	fake.ExecEventListByExecsStub = stub
+}
+
+func (fake *FakeQuerier) ExecEventListByExecsArgsForCall(i int) (context.Context, []string) {
+	fake.execEventListByExecsMutex.RLock()
+	defer fake.execEventListByExecsMutex.RUnlock()
+	argsForCall := fake.execEventListByExecsArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeQuerier) ExecEventListByExecsReturns(result1 []db.UnweaveExecEvent, result2 error) {
+	fake.execEventListByExecsMutex.Lock()
+	defer fake.execEventListByExecsMutex.Unlock()
+	fake.ExecEventListByExecsStub = nil
+	fake.execEventListByExecsReturns = struct {
+		result1 []db.UnweaveExecEvent
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeQuerier) ExecEventListByExecsReturnsOnCall(i int, result1 []db.UnweaveExecEvent, result2 error) {
+	fake.execEventListByExecsMutex.Lock()
+	defer fake.execEventListByExecsMutex.Unlock()
+	fake.ExecEventListByExecsStub = nil
+	if fake.execEventListByExecsReturnsOnCall == nil {
+		fake.execEventListByExecsReturnsOnCall = make(map[int]struct {
+			result1 []db.UnweaveExecEvent
+			result2 error
+		})
+	}
+	fake.execEventListByExecsReturnsOnCall[i] = struct {
+		result1 []db.UnweaveExecEvent
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakeQuerier) ExecGet(arg1 context.Context, arg2 string) (db.UnweaveExec, error) {
 	fake.execGetMutex.Lock()
 	ret, specificReturn := fake.execGetReturnsOnCall[len(fake.execGetArgsForCall)]
@@ -2750,6 +4420,136 @@ func (fake *FakeQuerier) ExecListByProviderReturnsOnCall(i int, result1 []db.Unw
 	}{result1, result2}
 }
 
+func (fake *FakeQuerier) ExecListInWindow(arg1 context.Context, arg2 db.ExecListInWindowParams) ([]db.UnweaveExec, error) {
+	fake.execListInWindowMutex.Lock()
+	ret, specificReturn := fake.execListInWindowReturnsOnCall[len(fake.execListInWindowArgsForCall)]
+	fake.execListInWindowArgsForCall = append(fake.execListInWindowArgsForCall, struct {
+		arg1 context.Context
+		arg2 db.ExecListInWindowParams
+	}{arg1, arg2})
+	stub := fake.ExecListInWindowStub
+	fakeReturns := fake.execListInWindowReturns
+	fake.recordInvocation("ExecListInWindow", []interface{}{arg1, arg2})
+	fake.execListInWindowMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeQuerier) ExecListInWindowCallCount() int {
+	fake.execListInWindowMutex.RLock()
+	defer fake.execListInWindowMutex.RUnlock()
+	return len(fake.execListInWindowArgsForCall)
+}
+
+func (fake *FakeQuerier) ExecListInWindowCalls(stub func(context.Context, db.ExecListInWindowParams) ([]db.UnweaveExec, error)) {
+	fake.execListInWindowMutex.Lock()
+	defer fake.execListInWindowMutex.Unlock()
+	fake.ExecListInWindowStub = stub
+}
+
+func (fake *FakeQuerier) ExecListInWindowArgsForCall(i int) (context.Context, db.ExecListInWindowParams) {
+	fake.execListInWindowMutex.RLock()
+	defer fake.execListInWindowMutex.RUnlock()
+	argsForCall := fake.execListInWindowArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeQuerier) ExecListInWindowReturns(result1 []db.UnweaveExec, result2 error) {
+	fake.execListInWindowMutex.Lock()
+	defer fake.execListInWindowMutex.Unlock()
+	fake.ExecListInWindowStub = nil
+	fake.execListInWindowReturns = struct {
+		result1 []db.UnweaveExec
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeQuerier) ExecListInWindowReturnsOnCall(i int, result1 []db.UnweaveExec, result2 error) {
+	fake.execListInWindowMutex.Lock()
+	defer fake.execListInWindowMutex.Unlock()
+	fake.ExecListInWindowStub = nil
+	if fake.execListInWindowReturnsOnCall == nil {
+		fake.execListInWindowReturnsOnCall = make(map[int]struct {
+			result1 []db.UnweaveExec
+			result2 error
+		})
+	}
+	fake.execListInWindowReturnsOnCall[i] = struct {
+		result1 []db.UnweaveExec
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeQuerier) ExecListUnfinished(arg1 context.Context, arg2 sql.NullString) ([]db.UnweaveExec, error) {
+	fake.execListUnfinishedMutex.Lock()
+	ret, specificReturn := fake.execListUnfinishedReturnsOnCall[len(fake.execListUnfinishedArgsForCall)]
+	fake.execListUnfinishedArgsForCall = append(fake.execListUnfinishedArgsForCall, struct {
+		arg1 context.Context
+		arg2 sql.NullString
+	}{arg1, arg2})
+	stub := fake.ExecListUnfinishedStub
+	fakeReturns := fake.execListUnfinishedReturns
+	fake.recordInvocation("ExecListUnfinished", []interface{}{arg1, arg2})
+	fake.execListUnfinishedMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeQuerier) ExecListUnfinishedCallCount() int {
+	fake.execListUnfinishedMutex.RLock()
+	defer fake.execListUnfinishedMutex.RUnlock()
+	return len(fake.execListUnfinishedArgsForCall)
+}
+
+func (fake *FakeQuerier) ExecListUnfinishedCalls(stub func(context.Context, sql.NullString) ([]db.UnweaveExec, error)) {
+	fake.execListUnfinishedMutex.Lock()
+	defer fake.execListUnfinishedMutex.Unlock()
+	fake.ExecListUnfinishedStub = stub
+}
+
+func (fake *FakeQuerier) ExecListUnfinishedArgsForCall(i int) (context.Context, sql.NullString) {
+	fake.execListUnfinishedMutex.RLock()
+	defer fake.execListUnfinishedMutex.RUnlock()
+	argsForCall := fake.execListUnfinishedArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeQuerier) ExecListUnfinishedReturns(result1 []db.UnweaveExec, result2 error) {
+	fake.execListUnfinishedMutex.Lock()
+	defer fake.execListUnfinishedMutex.Unlock()
+	fake.ExecListUnfinishedStub = nil
+	fake.execListUnfinishedReturns = struct {
+		result1 []db.UnweaveExec
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeQuerier) ExecListUnfinishedReturnsOnCall(i int, result1 []db.UnweaveExec, result2 error) {
+	fake.execListUnfinishedMutex.Lock()
+	defer fake.execListUnfinishedMutex.Unlock()
+	fake.ExecListUnfinishedStub = nil
+	if fake.execListUnfinishedReturnsOnCall == nil {
+		fake.execListUnfinishedReturnsOnCall = make(map[int]struct {
+			result1 []db.UnweaveExec
+			result2 error
+		})
+	}
+	fake.execListUnfinishedReturnsOnCall[i] = struct {
+		result1 []db.UnweaveExec
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakeQuerier) ExecSSHKeyDelete(arg1 context.Context, arg2 db.ExecSSHKeyDeleteParams) error {
 	fake.execSSHKeyDeleteMutex.Lock()
 	ret, specificReturn := fake.execSSHKeyDeleteReturnsOnCall[len(fake.execSSHKeyDeleteArgsForCall)]
@@ -3252,6 +5052,68 @@ func (fake *FakeQuerier) ExecUpdateConnectionInfoReturnsOnCall(i int, result1 er
 	}{result1}
 }
 
+func (fake *FakeQuerier) ExecUpdateExitCode(arg1 context.Context, arg2 db.ExecUpdateExitCodeParams) error {
+	fake.execUpdateExitCodeMutex.Lock()
+	ret, specificReturn := fake.execUpdateExitCodeReturnsOnCall[len(fake.execUpdateExitCodeArgsForCall)]
+	fake.execUpdateExitCodeArgsForCall = append(fake.execUpdateExitCodeArgsForCall, struct {
+		arg1 context.Context
+		arg2 db.ExecUpdateExitCodeParams
+	}{arg1, arg2})
+	stub := fake.ExecUpdateExitCodeStub
+	fakeReturns := fake.execUpdateExitCodeReturns
+	fake.recordInvocation("ExecUpdateExitCode", []interface{}{arg1, arg2})
+	fake.execUpdateExitCodeMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeQuerier) ExecUpdateExitCodeCallCount() int {
+	fake.execUpdateExitCodeMutex.RLock()
+	defer fake.execUpdateExitCodeMutex.RUnlock()
+	return len(fake.execUpdateExitCodeArgsForCall)
+}
+
+func (fake *FakeQuerier) ExecUpdateExitCodeCalls(stub func(context.Context, db.ExecUpdateExitCodeParams) error) {
+	fake.execUpdateExitCodeMutex.Lock()
+	defer fake.execUpdateExitCodeMutex.Unlock()
+	fake.ExecUpdateExitCodeStub = stub
+}
+
+func (fake *FakeQuerier) ExecUpdateExitCodeArgsForCall(i int) (context.Context, db.ExecUpdateExitCodeParams) {
+	fake.execUpdateExitCodeMutex.RLock()
+	defer fake.execUpdateExitCodeMutex.RUnlock()
+	argsForCall := fake.execUpdateExitCodeArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeQuerier) ExecUpdateExitCodeReturns(result1 error) {
+	fake.execUpdateExitCodeMutex.Lock()
+	defer fake.execUpdateExitCodeMutex.Unlock()
+	fake.ExecUpdateExitCodeStub = nil
+	fake.execUpdateExitCodeReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeQuerier) ExecUpdateExitCodeReturnsOnCall(i int, result1 error) {
+	fake.execUpdateExitCodeMutex.Lock()
+	defer fake.execUpdateExitCodeMutex.Unlock()
+	fake.ExecUpdateExitCodeStub = nil
+	if fake.execUpdateExitCodeReturnsOnCall == nil {
+		fake.execUpdateExitCodeReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.execUpdateExitCodeReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
 func (fake *FakeQuerier) ExecUpdateNetwork(arg1 context.Context, arg2 db.ExecUpdateNetworkParams) error {
 	fake.execUpdateNetworkMutex.Lock()
 	ret, specificReturn := fake.execUpdateNetworkReturnsOnCall[len(fake.execUpdateNetworkArgsForCall)]
@@ -3314,6 +5176,68 @@ func (fake *FakeQuerier) ExecUpdateNetworkReturnsOnCall(i int, result1 error) {
 	}{result1}
 }
 
+func (fake *FakeQuerier) ExecUpdateTerminationReason(arg1 context.Context, arg2 db.ExecUpdateTerminationReasonParams) error {
+	fake.execUpdateTerminationReasonMutex.Lock()
+	ret, specificReturn := fake.execUpdateTerminationReasonReturnsOnCall[len(fake.execUpdateTerminationReasonArgsForCall)]
+	fake.execUpdateTerminationReasonArgsForCall = append(fake.execUpdateTerminationReasonArgsForCall, struct {
+		arg1 context.Context
+		arg2 db.ExecUpdateTerminationReasonParams
+	}{arg1, arg2})
+	stub := fake.ExecUpdateTerminationReasonStub
+	fakeReturns := fake.execUpdateTerminationReasonReturns
+	fake.recordInvocation("ExecUpdateTerminationReason", []interface{}{arg1, arg2})
+	fake.execUpdateTerminationReasonMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeQuerier) ExecUpdateTerminationReasonCallCount() int {
+	fake.execUpdateTerminationReasonMutex.RLock()
+	defer fake.execUpdateTerminationReasonMutex.RUnlock()
+	return len(fake.execUpdateTerminationReasonArgsForCall)
+}
+
+func (fake *FakeQuerier) ExecUpdateTerminationReasonCalls(stub func(context.Context, db.ExecUpdateTerminationReasonParams) error) {
+	fake.execUpdateTerminationReasonMutex.Lock()
+	defer fake.execUpdateTerminationReasonMutex.Unlock()
+	fake.ExecUpdateTerminationReasonStub = stub
+}
+
+func (fake *FakeQuerier) ExecUpdateTerminationReasonArgsForCall(i int) (context.Context, db.ExecUpdateTerminationReasonParams) {
+	fake.execUpdateTerminationReasonMutex.RLock()
+	defer fake.execUpdateTerminationReasonMutex.RUnlock()
+	argsForCall := fake.execUpdateTerminationReasonArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeQuerier) ExecUpdateTerminationReasonReturns(result1 error) {
+	fake.execUpdateTerminationReasonMutex.Lock()
+	defer fake.execUpdateTerminationReasonMutex.Unlock()
+	fake.ExecUpdateTerminationReasonStub = nil
+	fake.execUpdateTerminationReasonReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeQuerier) ExecUpdateTerminationReasonReturnsOnCall(i int, result1 error) {
+	fake.execUpdateTerminationReasonMutex.Lock()
+	defer fake.execUpdateTerminationReasonMutex.Unlock()
+	fake.ExecUpdateTerminationReasonStub = nil
+	if fake.execUpdateTerminationReasonReturnsOnCall == nil {
+		fake.execUpdateTerminationReasonReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.execUpdateTerminationReasonReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
 func (fake *FakeQuerier) ExecVolumeCreate(arg1 context.Context, arg2 db.ExecVolumeCreateParams) error {
 	fake.execVolumeCreateMutex.Lock()
 	ret, specificReturn := fake.execVolumeCreateReturnsOnCall[len(fake.execVolumeCreateArgsForCall)]
@@ -3757,6 +5681,198 @@ func (fake *FakeQuerier) NodeStatusUpdateReturnsOnCall(i int, result1 error) {
 	}{result1}
 }
 
+func (fake *FakeQuerier) ProjectAccountAdd(arg1 context.Context, arg2 db.ProjectAccountAddParams) error {
+	fake.projectAccountAddMutex.Lock()
+	ret, specificReturn := fake.projectAccountAddReturnsOnCall[len(fake.projectAccountAddArgsForCall)]
+	fake.projectAccountAddArgsForCall = append(fake.projectAccountAddArgsForCall, struct {
+		arg1 context.Context
+		arg2 db.ProjectAccountAddParams
+	}{arg1, arg2})
+	stub := fake.ProjectAccountAddStub
+	fakeReturns := fake.projectAccountAddReturns
+	fake.recordInvocation("ProjectAccountAdd", []interface{}{arg1, arg2})
+	fake.projectAccountAddMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeQuerier) ProjectAccountAddCallCount() int {
+	fake.projectAccountAddMutex.RLock()
+	defer fake.projectAccountAddMutex.RUnlock()
+	return len(fake.projectAccountAddArgsForCall)
+}
+
+func (fake *FakeQuerier) ProjectAccountAddCalls(stub func(context.Context, db.ProjectAccountAddParams) error) {
+	fake.projectAccountAddMutex.Lock()
+	defer fake.projectAccountAddMutex.Unlock()
+	fake.ProjectAccountAddStub = stub
+}
+
+func (fake *FakeQuerier) ProjectAccountAddArgsForCall(i int) (context.Context, db.ProjectAccountAddParams) {
+	fake.projectAccountAddMutex.RLock()
+	defer fake.projectAccountAddMutex.RUnlock()
+	argsForCall := fake.projectAccountAddArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeQuerier) ProjectAccountAddReturns(result1 error) {
+	fake.projectAccountAddMutex.Lock()
+	defer fake.projectAccountAddMutex.Unlock()
+	fake.ProjectAccountAddStub = nil
+	fake.projectAccountAddReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeQuerier) ProjectAccountAddReturnsOnCall(i int, result1 error) {
+	fake.projectAccountAddMutex.Lock()
+	defer fake.projectAccountAddMutex.Unlock()
+	fake.ProjectAccountAddStub = nil
+	if fake.projectAccountAddReturnsOnCall == nil {
+		fake.projectAccountAddReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.projectAccountAddReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeQuerier) ProjectAccountExists(arg1 context.Context, arg2 db.ProjectAccountExistsParams) (bool, error) {
+	fake.projectAccountExistsMutex.Lock()
+	ret, specificReturn := fake.projectAccountExistsReturnsOnCall[len(fake.projectAccountExistsArgsForCall)]
+	fake.projectAccountExistsArgsForCall = append(fake.projectAccountExistsArgsForCall, struct {
+		arg1 context.Context
+		arg2 db.ProjectAccountExistsParams
+	}{arg1, arg2})
+	stub := fake.ProjectAccountExistsStub
+	fakeReturns := fake.projectAccountExistsReturns
+	fake.recordInvocation("ProjectAccountExists", []interface{}{arg1, arg2})
+	fake.projectAccountExistsMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeQuerier) ProjectAccountExistsCallCount() int {
+	fake.projectAccountExistsMutex.RLock()
+	defer fake.projectAccountExistsMutex.RUnlock()
+	return len(fake.projectAccountExistsArgsForCall)
+}
+
+func (fake *FakeQuerier) ProjectAccountExistsCalls(stub func(context.Context, db.ProjectAccountExistsParams) (bool, error)) {
+	fake.projectAccountExistsMutex.Lock()
+	defer fake.projectAccountExistsMutex.Unlock()
+	fake.ProjectAccountExistsStub = stub
+}
+
+func (fake *FakeQuerier) ProjectAccountExistsArgsForCall(i int) (context.Context, db.ProjectAccountExistsParams) {
+	fake.projectAccountExistsMutex.RLock()
+	defer fake.projectAccountExistsMutex.RUnlock()
+	argsForCall := fake.projectAccountExistsArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeQuerier) ProjectAccountExistsReturns(result1 bool, result2 error) {
+	fake.projectAccountExistsMutex.Lock()
+	defer fake.projectAccountExistsMutex.Unlock()
+	fake.ProjectAccountExistsStub = nil
+	fake.projectAccountExistsReturns = struct {
+		result1 bool
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeQuerier) ProjectAccountExistsReturnsOnCall(i int, result1 bool, result2 error) {
+	fake.projectAccountExistsMutex.Lock()
+	defer fake.projectAccountExistsMutex.Unlock()
+	fake.ProjectAccountExistsStub = nil
+	if fake.projectAccountExistsReturnsOnCall == nil {
+		fake.projectAccountExistsReturnsOnCall = make(map[int]struct {
+			result1 bool
+			result2 error
+		})
+	}
+	fake.projectAccountExistsReturnsOnCall[i] = struct {
+		result1 bool
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeQuerier) ProjectAccountRemove(arg1 context.Context, arg2 db.ProjectAccountRemoveParams) (int64, error) {
+	fake.projectAccountRemoveMutex.Lock()
+	ret, specificReturn := fake.projectAccountRemoveReturnsOnCall[len(fake.projectAccountRemoveArgsForCall)]
+	fake.projectAccountRemoveArgsForCall = append(fake.projectAccountRemoveArgsForCall, struct {
+		arg1 context.Context
+		arg2 db.ProjectAccountRemoveParams
+	}{arg1, arg2})
+	stub := fake.ProjectAccountRemoveStub
+	fakeReturns := fake.projectAccountRemoveReturns
+	fake.recordInvocation("ProjectAccountRemove", []interface{}{arg1, arg2})
+	fake.projectAccountRemoveMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeQuerier) ProjectAccountRemoveCallCount() int {
+	fake.projectAccountRemoveMutex.RLock()
+	defer fake.projectAccountRemoveMutex.RUnlock()
+	return len(fake.projectAccountRemoveArgsForCall)
+}
+
+func (fake *FakeQuerier) ProjectAccountRemoveCalls(stub func(context.Context, db.ProjectAccountRemoveParams) (int64, error)) {
+	fake.projectAccountRemoveMutex.Lock()
+	defer fake.projectAccountRemoveMutex.Unlock()
+	fake.ProjectAccountRemoveStub = stub
+}
+
+func (fake *FakeQuerier) ProjectAccountRemoveArgsForCall(i int) (context.Context, db.ProjectAccountRemoveParams) {
+	fake.projectAccountRemoveMutex.RLock()
+	defer fake.projectAccountRemoveMutex.RUnlock()
+	argsForCall := fake.projectAccountRemoveArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeQuerier) ProjectAccountRemoveReturns(result1 int64, result2 error) {
+	fake.projectAccountRemoveMutex.Lock()
+	defer fake.projectAccountRemoveMutex.Unlock()
+	fake.ProjectAccountRemoveStub = nil
+	fake.projectAccountRemoveReturns = struct {
+		result1 int64
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeQuerier) ProjectAccountRemoveReturnsOnCall(i int, result1 int64, result2 error) {
+	fake.projectAccountRemoveMutex.Lock()
+	defer fake.projectAccountRemoveMutex.Unlock()
+	fake.ProjectAccountRemoveStub = nil
+	if fake.projectAccountRemoveReturnsOnCall == nil {
+		fake.projectAccountRemoveReturnsOnCall = make(map[int]struct {
+			result1 int64
+			result2 error
+		})
+	}
+	fake.projectAccountRemoveReturnsOnCall[i] = struct {
+		result1 int64
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakeQuerier) ProjectGet(arg1 context.Context, arg2 string) (db.UnweaveProject, error) {
 	fake.projectGetMutex.Lock()
 	ret, specificReturn := fake.projectGetReturnsOnCall[len(fake.projectGetArgsForCall)]
@@ -3789,35 +5905,100 @@ func (fake *FakeQuerier) ProjectGetCalls(stub func(context.Context, string) (db.
 	fake.ProjectGetStub = stub
 }
 
-func (fake *FakeQuerier) ProjectGetArgsForCall(i int) (context.Context, string) {
-	fake.projectGetMutex.RLock()
-	defer fake.projectGetMutex.RUnlock()
-	argsForCall := fake.projectGetArgsForCall[i]
+func (fake *FakeQuerier) ProjectGetArgsForCall(i int) (context.Context, string) {
+	fake.projectGetMutex.RLock()
+	defer fake.projectGetMutex.RUnlock()
+	argsForCall := fake.projectGetArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeQuerier) ProjectGetReturns(result1 db.UnweaveProject, result2 error) {
+	fake.projectGetMutex.Lock()
+	defer fake.projectGetMutex.Unlock()
+	fake.ProjectGetStub = nil
+	fake.projectGetReturns = struct {
+		result1 db.UnweaveProject
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeQuerier) ProjectGetReturnsOnCall(i int, result1 db.UnweaveProject, result2 error) {
+	fake.projectGetMutex.Lock()
+	defer fake.projectGetMutex.Unlock()
+	fake.ProjectGetStub = nil
+	if fake.projectGetReturnsOnCall == nil {
+		fake.projectGetReturnsOnCall = make(map[int]struct {
+			result1 db.UnweaveProject
+			result2 error
+		})
+	}
+	fake.projectGetReturnsOnCall[i] = struct {
+		result1 db.UnweaveProject
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeQuerier) ProjectQuotasUpdate(arg1 context.Context, arg2 db.ProjectQuotasUpdateParams) (int64, error) {
+	fake.projectQuotasUpdateMutex.Lock()
+	ret, specificReturn := fake.projectQuotasUpdateReturnsOnCall[len(fake.projectQuotasUpdateArgsForCall)]
+	fake.projectQuotasUpdateArgsForCall = append(fake.projectQuotasUpdateArgsForCall, struct {
+		arg1 context.Context
+		arg2 db.ProjectQuotasUpdateParams
+	}{arg1, arg2})
+	stub := fake.ProjectQuotasUpdateStub
+	fakeReturns := fake.projectQuotasUpdateReturns
+	fake.recordInvocation("ProjectQuotasUpdate", []interface{}{arg1, arg2})
+	fake.projectQuotasUpdateMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeQuerier) ProjectQuotasUpdateCallCount() int {
+	fake.projectQuotasUpdateMutex.RLock()
+	defer fake.projectQuotasUpdateMutex.RUnlock()
+	return len(fake.projectQuotasUpdateArgsForCall)
+}
+
+func (fake *FakeQuerier) ProjectQuotasUpdateCalls(stub func(context.Context, db.ProjectQuotasUpdateParams) (int64, error)) {
+	fake.projectQuotasUpdateMutex.Lock()
+	defer fake.projectQuotasUpdateMutex.Unlock()
+	fake.ProjectQuotasUpdateStub = stub
+}
+
+func (fake *FakeQuerier) ProjectQuotasUpdateArgsForCall(i int) (context.Context, db.ProjectQuotasUpdateParams) {
+	fake.projectQuotasUpdateMutex.RLock()
+	defer fake.projectQuotasUpdateMutex.RUnlock()
+	argsForCall := fake.projectQuotasUpdateArgsForCall[i]
 	return argsForCall.arg1, argsForCall.arg2
 }
 
-func (fake *FakeQuerier) ProjectGetReturns(result1 db.UnweaveProject, result2 error) {
-	fake.projectGetMutex.Lock()
-	defer fake.projectGetMutex.Unlock()
-	fake.ProjectGetStub = nil
-	fake.projectGetReturns = struct {
-		result1 db.UnweaveProject
+func (fake *FakeQuerier) ProjectQuotasUpdateReturns(result1 int64, result2 error) {
+	fake.projectQuotasUpdateMutex.Lock()
+	defer fake.projectQuotasUpdateMutex.Unlock()
+	fake.ProjectQuotasUpdateStub = nil
+	fake.projectQuotasUpdateReturns = struct {
+		result1 int64
 		result2 error
 	}{result1, result2}
 }
 
-func (fake *FakeQuerier) ProjectGetReturnsOnCall(i int, result1 db.UnweaveProject, result2 error) {
-	fake.projectGetMutex.Lock()
-	defer fake.projectGetMutex.Unlock()
-	fake.ProjectGetStub = nil
-	if fake.projectGetReturnsOnCall == nil {
-		fake.projectGetReturnsOnCall = make(map[int]struct {
-			result1 db.UnweaveProject
+func (fake *FakeQuerier) ProjectQuotasUpdateReturnsOnCall(i int, result1 int64, result2 error) {
+	fake.projectQuotasUpdateMutex.Lock()
+	defer fake.projectQuotasUpdateMutex.Unlock()
+	fake.ProjectQuotasUpdateStub = nil
+	if fake.projectQuotasUpdateReturnsOnCall == nil {
+		fake.projectQuotasUpdateReturnsOnCall = make(map[int]struct {
+			result1 int64
 			result2 error
 		})
 	}
-	fake.projectGetReturnsOnCall[i] = struct {
-		result1 db.UnweaveProject
+	fake.projectQuotasUpdateReturnsOnCall[i] = struct {
+		result1 int64
 		result2 error
 	}{result1, result2}
 }
@@ -4468,9 +6649,535 @@ func (fake *FakeQuerier) VolumeUpdateReturnsOnCall(i int, result1 error) {
 	}{result1}
 }
 
+func (fake *FakeQuerier) WebhookCreate(arg1 context.Context, arg2 db.WebhookCreateParams) error {
+	fake.webhookCreateMutex.Lock()
+	ret, specificReturn := fake.webhookCreateReturnsOnCall[len(fake.webhookCreateArgsForCall)]
+	fake.webhookCreateArgsForCall = append(fake.webhookCreateArgsForCall, struct {
+		arg1 context.Context
+		arg2 db.WebhookCreateParams
+	}{arg1, arg2})
+	stub := fake.WebhookCreateStub
+	fakeReturns := fake.webhookCreateReturns
+	fake.recordInvocation("WebhookCreate", []interface{}{arg1, arg2})
+	fake.webhookCreateMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeQuerier) WebhookCreateCallCount() int {
+	fake.webhookCreateMutex.RLock()
+	defer fake.webhookCreateMutex.RUnlock()
+	return len(fake.webhookCreateArgsForCall)
+}
+
+func (fake *FakeQuerier) WebhookCreateCalls(stub func(context.Context, db.WebhookCreateParams) error) {
+	fake.webhookCreateMutex.Lock()
+	defer fake.webhookCreateMutex.Unlock()
+	fake.WebhookCreateStub = stub
+}
+
+func (fake *FakeQuerier) WebhookCreateArgsForCall(i int) (context.Context, db.WebhookCreateParams) {
+	fake.webhookCreateMutex.RLock()
+	defer fake.webhookCreateMutex.RUnlock()
+	argsForCall := fake.webhookCreateArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeQuerier) WebhookCreateReturns(result1 error) {
+	fake.webhookCreateMutex.Lock()
+	defer fake.webhookCreateMutex.Unlock()
+	fake.WebhookCreateStub = nil
+	fake.webhookCreateReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeQuerier) WebhookCreateReturnsOnCall(i int, result1 error) {
+	fake.webhookCreateMutex.Lock()
+	defer fake.webhookCreateMutex.Unlock()
+	fake.WebhookCreateStub = nil
+	if fake.webhookCreateReturnsOnCall == nil {
+		fake.webhookCreateReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.webhookCreateReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeQuerier) WebhookDelete(arg1 context.Context, arg2 db.WebhookDeleteParams) (int64, error) {
+	fake.webhookDeleteMutex.Lock()
+	ret, specificReturn := fake.webhookDeleteReturnsOnCall[len(fake.webhookDeleteArgsForCall)]
+	fake.webhookDeleteArgsForCall = append(fake.webhookDeleteArgsForCall, struct {
+		arg1 context.Context
+		arg2 db.WebhookDeleteParams
+	}{arg1, arg2})
+	stub := fake.WebhookDeleteStub
+	fakeReturns := fake.webhookDeleteReturns
+	fake.recordInvocation("WebhookDelete", []interface{}{arg1, arg2})
+	fake.webhookDeleteMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeQuerier) WebhookDeleteCallCount() int {
+	fake.webhookDeleteMutex.RLock()
+	defer fake.webhookDeleteMutex.RUnlock()
+	return len(fake.webhookDeleteArgsForCall)
+}
+
+func (fake *FakeQuerier) WebhookDeleteCalls(stub func(context.Context, db.WebhookDeleteParams) (int64, error)) {
+	fake.webhookDeleteMutex.Lock()
+	defer fake.webhookDeleteMutex.Unlock()
+	fake.WebhookDeleteStub = stub
+}
+
+func (fake *FakeQuerier) WebhookDeleteArgsForCall(i int) (context.Context, db.WebhookDeleteParams) {
+	fake.webhookDeleteMutex.RLock()
+	defer fake.webhookDeleteMutex.RUnlock()
+	argsForCall := fake.webhookDeleteArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeQuerier) WebhookDeleteReturns(result1 int64, result2 error) {
+	fake.webhookDeleteMutex.Lock()
+	defer fake.webhookDeleteMutex.Unlock()
+	fake.WebhookDeleteStub = nil
+	fake.webhookDeleteReturns = struct {
+		result1 int64
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeQuerier) WebhookDeleteReturnsOnCall(i int, result1 int64, result2 error) {
+	fake.webhookDeleteMutex.Lock()
+	defer fake.webhookDeleteMutex.Unlock()
+	fake.WebhookDeleteStub = nil
+	if fake.webhookDeleteReturnsOnCall == nil {
+		fake.webhookDeleteReturnsOnCall = make(map[int]struct {
+			result1 int64
+			result2 error
+		})
+	}
+	fake.webhookDeleteReturnsOnCall[i] = struct {
+		result1 int64
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeQuerier) WebhookDeliveryCreate(arg1 context.Context, arg2 db.WebhookDeliveryCreateParams) error {
+	fake.webhookDeliveryCreateMutex.Lock()
+	ret, specificReturn := fake.webhookDeliveryCreateReturnsOnCall[len(fake.webhookDeliveryCreateArgsForCall)]
+	fake.webhookDeliveryCreateArgsForCall = append(fake.webhookDeliveryCreateArgsForCall, struct {
+		arg1 context.Context
+		arg2 db.WebhookDeliveryCreateParams
+	}{arg1, arg2})
+	stub := fake.WebhookDeliveryCreateStub
+	fakeReturns := fake.webhookDeliveryCreateReturns
+	fake.recordInvocation("WebhookDeliveryCreate", []interface{}{arg1, arg2})
+	fake.webhookDeliveryCreateMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeQuerier) WebhookDeliveryCreateCallCount() int {
+	fake.webhookDeliveryCreateMutex.RLock()
+	defer fake.webhookDeliveryCreateMutex.RUnlock()
+	return len(fake.webhookDeliveryCreateArgsForCall)
+}
+
+func (fake *FakeQuerier) WebhookDeliveryCreateCalls(stub func(context.Context, db.WebhookDeliveryCreateParams) error) {
+	fake.webhookDeliveryCreateMutex.Lock()
+	defer fake.webhookDeliveryCreateMutex.Unlock()
+	fake.WebhookDeliveryCreateStub = stub
+}
+
+func (fake *FakeQuerier) WebhookDeliveryCreateArgsForCall(i int) (context.Context, db.WebhookDeliveryCreateParams) {
+	fake.webhookDeliveryCreateMutex.RLock()
+	defer fake.webhookDeliveryCreateMutex.RUnlock()
+	argsForCall := fake.webhookDeliveryCreateArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeQuerier) WebhookDeliveryCreateReturns(result1 error) {
+	fake.webhookDeliveryCreateMutex.Lock()
+	defer fake.webhookDeliveryCreateMutex.Unlock()
+	fake.WebhookDeliveryCreateStub = nil
+	fake.webhookDeliveryCreateReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeQuerier) WebhookDeliveryCreateReturnsOnCall(i int, result1 error) {
+	fake.webhookDeliveryCreateMutex.Lock()
+	defer fake.webhookDeliveryCreateMutex.Unlock()
+	fake.WebhookDeliveryCreateStub = nil
+	if fake.webhookDeliveryCreateReturnsOnCall == nil {
+		fake.webhookDeliveryCreateReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.webhookDeliveryCreateReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeQuerier) WebhookDeliveryList(arg1 context.Context, arg2 string) ([]db.UnweaveWebhookDelivery, error) {
+	fake.webhookDeliveryListMutex.Lock()
+	ret, specificReturn := fake.webhookDeliveryListReturnsOnCall[len(fake.webhookDeliveryListArgsForCall)]
+	fake.webhookDeliveryListArgsForCall = append(fake.webhookDeliveryListArgsForCall, struct {
+		arg1 context.Context
+		arg2 string
+	}{arg1, arg2})
+	stub := fake.WebhookDeliveryListStub
+	fakeReturns := fake.webhookDeliveryListReturns
+	fake.recordInvocation("WebhookDeliveryList", []interface{}{arg1, arg2})
+	fake.webhookDeliveryListMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeQuerier) WebhookDeliveryListCallCount() int {
+	fake.webhookDeliveryListMutex.RLock()
+	defer fake.webhookDeliveryListMutex.RUnlock()
+	return len(fake.webhookDeliveryListArgsForCall)
+}
+
+func (fake *FakeQuerier) WebhookDeliveryListCalls(stub func(context.Context, string) ([]db.UnweaveWebhookDelivery, error)) {
+	fake.webhookDeliveryListMutex.Lock()
+	defer fake.webhookDeliveryListMutex.Unlock()
+	fake.WebhookDeliveryListStub = stub
+}
+
+func (fake *FakeQuerier) WebhookDeliveryListArgsForCall(i int) (context.Context, string) {
+	fake.webhookDeliveryListMutex.RLock()
+	defer fake.webhookDeliveryListMutex.RUnlock()
+	argsForCall := fake.webhookDeliveryListArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeQuerier) WebhookDeliveryListReturns(result1 []db.UnweaveWebhookDelivery, result2 error) {
+	fake.webhookDeliveryListMutex.Lock()
+	defer fake.webhookDeliveryListMutex.Unlock()
+	fake.WebhookDeliveryListStub = nil
+	fake.webhookDeliveryListReturns = struct {
+		result1 []db.UnweaveWebhookDelivery
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeQuerier) WebhookDeliveryListReturnsOnCall(i int, result1 []db.UnweaveWebhookDelivery, result2 error) {
+	fake.webhookDeliveryListMutex.Lock()
+	defer fake.webhookDeliveryListMutex.Unlock()
+	fake.WebhookDeliveryListStub = nil
+	if fake.webhookDeliveryListReturnsOnCall == nil {
+		fake.webhookDeliveryListReturnsOnCall = make(map[int]struct {
+			result1 []db.UnweaveWebhookDelivery
+			result2 error
+		})
+	}
+	fake.webhookDeliveryListReturnsOnCall[i] = struct {
+		result1 []db.UnweaveWebhookDelivery
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeQuerier) WebhookGet(arg1 context.Context, arg2 db.WebhookGetParams) (db.UnweaveWebhook, error) {
+	fake.webhookGetMutex.Lock()
+	ret, specificReturn := fake.webhookGetReturnsOnCall[len(fake.webhookGetArgsForCall)]
+	fake.webhookGetArgsForCall = append(fake.webhookGetArgsForCall, struct {
+		arg1 context.Context
+		arg2 db.WebhookGetParams
+	}{arg1, arg2})
+	stub := fake.WebhookGetStub
+	fakeReturns := fake.webhookGetReturns
+	fake.recordInvocation("WebhookGet", []interface{}{arg1, arg2})
+	fake.webhookGetMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeQuerier) WebhookGetCallCount() int {
+	fake.webhookGetMutex.RLock()
+	defer fake.webhookGetMutex.RUnlock()
+	return len(fake.webhookGetArgsForCall)
+}
+
+func (fake *FakeQuerier) WebhookGetCalls(stub func(context.Context, db.WebhookGetParams) (db.UnweaveWebhook, error)) {
+	fake.webhookGetMutex.Lock()
+	defer fake.webhookGetMutex.Unlock()
+	fake.WebhookGetStub = stub
+}
+
+func (fake *FakeQuerier) WebhookGetArgsForCall(i int) (context.Context, db.WebhookGetParams) {
+	fake.webhookGetMutex.RLock()
+	defer fake.webhookGetMutex.RUnlock()
+	argsForCall := fake.webhookGetArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeQuerier) WebhookGetReturns(result1 db.UnweaveWebhook, result2 error) {
+	fake.webhookGetMutex.Lock()
+	defer fake.webhookGetMutex.Unlock()
+	fake.WebhookGetStub = nil
+	fake.webhookGetReturns = struct {
+		result1 db.UnweaveWebhook
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeQuerier) WebhookGetReturnsOnCall(i int, result1 db.UnweaveWebhook, result2 error) {
+	fake.webhookGetMutex.Lock()
+	defer fake.webhookGetMutex.Unlock()
+	fake.WebhookGetStub = nil
+	if fake.webhookGetReturnsOnCall == nil {
+		fake.webhookGetReturnsOnCall = make(map[int]struct {
+			result1 db.UnweaveWebhook
+			result2 error
+		})
+	}
+	fake.webhookGetReturnsOnCall[i] = struct {
+		result1 db.UnweaveWebhook
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeQuerier) WebhookList(arg1 context.Context, arg2 string) ([]db.UnweaveWebhook, error) {
+	fake.webhookListMutex.Lock()
+	ret, specificReturn := fake.webhookListReturnsOnCall[len(fake.webhookListArgsForCall)]
+	fake.webhookListArgsForCall = append(fake.webhookListArgsForCall, struct {
+		arg1 context.Context
+		arg2 string
+	}{arg1, arg2})
+	stub := fake.WebhookListStub
+	fakeReturns := fake.webhookListReturns
+	fake.recordInvocation("WebhookList", []interface{}{arg1, arg2})
+	fake.webhookListMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeQuerier) WebhookListCallCount() int {
+	fake.webhookListMutex.RLock()
+	defer fake.webhookListMutex.RUnlock()
+	return len(fake.webhookListArgsForCall)
+}
+
+func (fake *FakeQuerier) WebhookListCalls(stub func(context.Context, string) ([]db.UnweaveWebhook, error)) {
+	fake.webhookListMutex.Lock()
+	defer fake.webhookListMutex.Unlock()
+	fake.WebhookListStub = stub
+}
+
+func (fake *FakeQuerier) WebhookListArgsForCall(i int) (context.Context, string) {
+	fake.webhookListMutex.RLock()
+	defer fake.webhookListMutex.RUnlock()
+	argsForCall := fake.webhookListArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeQuerier) WebhookListReturns(result1 []db.UnweaveWebhook, result2 error) {
+	fake.webhookListMutex.Lock()
+	defer fake.webhookListMutex.Unlock()
+	fake.WebhookListStub = nil
+	fake.webhookListReturns = struct {
+		result1 []db.UnweaveWebhook
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeQuerier) WebhookListReturnsOnCall(i int, result1 []db.UnweaveWebhook, result2 error) {
+	fake.webhookListMutex.Lock()
+	defer fake.webhookListMutex.Unlock()
+	fake.WebhookListStub = nil
+	if fake.webhookListReturnsOnCall == nil {
+		fake.webhookListReturnsOnCall = make(map[int]struct {
+			result1 []db.UnweaveWebhook
+			result2 error
+		})
+	}
+	fake.webhookListReturnsOnCall[i] = struct {
+		result1 []db.UnweaveWebhook
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeQuerier) WebhookListForEvent(arg1 context.Context, arg2 db.WebhookListForEventParams) ([]db.UnweaveWebhook, error) {
+	fake.webhookListForEventMutex.Lock()
+	ret, specificReturn := fake.webhookListForEventReturnsOnCall[len(fake.webhookListForEventArgsForCall)]
+	fake.webhookListForEventArgsForCall = append(fake.webhookListForEventArgsForCall, struct {
+		arg1 context.Context
+		arg2 db.WebhookListForEventParams
+	}{arg1, arg2})
+	stub := fake.WebhookListForEventStub
+	fakeReturns := fake.webhookListForEventReturns
+	fake.recordInvocation("WebhookListForEvent", []interface{}{arg1, arg2})
+	fake.webhookListForEventMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeQuerier) WebhookListForEventCallCount() int {
+	fake.webhookListForEventMutex.RLock()
+	defer fake.webhookListForEventMutex.RUnlock()
+	return len(fake.webhookListForEventArgsForCall)
+}
+
+func (fake *FakeQuerier) WebhookListForEventCalls(stub func(context.Context, db.WebhookListForEventParams) ([]db.UnweaveWebhook, error)) {
+	fake.webhookListForEventMutex.Lock()
+	defer fake.webhookListForEventMutex.Unlock()
+	fake.WebhookListForEventStub = stub
+}
+
+func (fake *FakeQuerier) WebhookListForEventArgsForCall(i int) (context.Context, db.WebhookListForEventParams) {
+	fake.webhookListForEventMutex.RLock()
+	defer fake.webhookListForEventMutex.RUnlock()
+	argsForCall := fake.webhookListForEventArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeQuerier) WebhookListForEventReturns(result1 []db.UnweaveWebhook, result2 error) {
+	fake.webhookListForEventMutex.Lock()
+	defer fake.webhookListForEventMutex.Unlock()
+	fake.WebhookListForEventStub = nil
+	fake.webhookListForEventReturns = struct {
+		result1 []db.UnweaveWebhook
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeQuerier) WebhookListForEventReturnsOnCall(i int, result1 []db.UnweaveWebhook, result2 error) {
+	fake.webhookListForEventMutex.Lock()
+	defer fake.webhookListForEventMutex.Unlock()
+	fake.WebhookListForEventStub = nil
+	if fake.webhookListForEventReturnsOnCall == nil {
+		fake.webhookListForEventReturnsOnCall = make(map[int]struct {
+			result1 []db.UnweaveWebhook
+			result2 error
+		})
+	}
+	fake.webhookListForEventReturnsOnCall[i] = struct {
+		result1 []db.UnweaveWebhook
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeQuerier) WebhookUpdate(arg1 context.Context, arg2 db.WebhookUpdateParams) (int64, error) {
+	fake.webhookUpdateMutex.Lock()
+	ret, specificReturn := fake.webhookUpdateReturnsOnCall[len(fake.webhookUpdateArgsForCall)]
+	fake.webhookUpdateArgsForCall = append(fake.webhookUpdateArgsForCall, struct {
+		arg1 context.Context
+		arg2 db.WebhookUpdateParams
+	}{arg1, arg2})
+	stub := fake.WebhookUpdateStub
+	fakeReturns := fake.webhookUpdateReturns
+	fake.recordInvocation("WebhookUpdate", []interface{}{arg1, arg2})
+	fake.webhookUpdateMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeQuerier) WebhookUpdateCallCount() int {
+	fake.webhookUpdateMutex.RLock()
+	defer fake.webhookUpdateMutex.RUnlock()
+	return len(fake.webhookUpdateArgsForCall)
+}
+
+func (fake *FakeQuerier) WebhookUpdateCalls(stub func(context.Context, db.WebhookUpdateParams) (int64, error)) {
+	fake.webhookUpdateMutex.Lock()
+	defer fake.webhookUpdateMutex.Unlock()
+	fake.WebhookUpdateStub = stub
+}
+
+func (fake *FakeQuerier) WebhookUpdateArgsForCall(i int) (context.Context, db.WebhookUpdateParams) {
+	fake.webhookUpdateMutex.RLock()
+	defer fake.webhookUpdateMutex.RUnlock()
+	argsForCall := fake.webhookUpdateArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeQuerier) WebhookUpdateReturns(result1 int64, result2 error) {
+	fake.webhookUpdateMutex.Lock()
+	defer fake.webhookUpdateMutex.Unlock()
+	fake.WebhookUpdateStub = nil
+	fake.webhookUpdateReturns = struct {
+		result1 int64
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeQuerier) WebhookUpdateReturnsOnCall(i int, result1 int64, result2 error) {
+	fake.webhookUpdateMutex.Lock()
+	defer fake.webhookUpdateMutex.Unlock()
+	fake.WebhookUpdateStub = nil
+	if fake.webhookUpdateReturnsOnCall == nil {
+		fake.webhookUpdateReturnsOnCall = make(map[int]struct {
+			result1 int64
+			result2 error
+		})
+	}
+	fake.webhookUpdateReturnsOnCall[i] = struct {
+		result1 int64
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakeQuerier) Invocations() map[string][][]interface{} {
 	fake.invocationsMutex.RLock()
 	defer fake.invocationsMutex.RUnlock()
+	fake.accessTokenCreateMutex.RLock()
+	defer fake.accessTokenCreateMutex.RUnlock()
+	fake.accessTokenGetByHashMutex.RLock()
+	defer fake.accessTokenGetByHashMutex.RUnlock()
+	fake.accessTokenListMutex.RLock()
+	defer fake.accessTokenListMutex.RUnlock()
+	fake.accessTokenRevokeMutex.RLock()
+	defer fake.accessTokenRevokeMutex.RUnlock()
+	fake.accessTokenTouchMutex.RLock()
+	defer fake.accessTokenTouchMutex.RUnlock()
+	fake.accountCreateMutex.RLock()
+	defer fake.accountCreateMutex.RUnlock()
 	fake.buildCreateMutex.RLock()
 	defer fake.buildCreateMutex.RUnlock()
 	fake.buildGetMutex.RLock()
@@ -4483,6 +7190,8 @@ func (fake *FakeQuerier) Invocations() map[string][][]interface{} {
 	defer fake.endpointCheckMutex.RUnlock()
 	fake.endpointCheckCreateMutex.RLock()
 	defer fake.endpointCheckCreateMutex.RUnlock()
+	fake.endpointCheckStatusUpdateMutex.RLock()
+	defer fake.endpointCheckStatusUpdateMutex.RUnlock()
 	fake.endpointCheckStepCreateMutex.RLock()
 	defer fake.endpointCheckStepCreateMutex.RUnlock()
 	fake.endpointCheckStepUpdateMutex.RLock()
@@ -4499,6 +7208,22 @@ func (fake *FakeQuerier) Invocations() map[string][][]interface{} {
 	defer fake.endpointEvalAttachMutex.RUnlock()
 	fake.endpointGetMutex.RLock()
 	defer fake.endpointGetMutex.RUnlock()
+	fake.endpointListMutex.RLock()
+	defer fake.endpointListMutex.RUnlock()
+	fake.endpointPromotionCreateMutex.RLock()
+	defer fake.endpointPromotionCreateMutex.RUnlock()
+	fake.endpointPromotionListMutex.RLock()
+	defer fake.endpointPromotionListMutex.RUnlock()
+	fake.endpointRoutesMutex.RLock()
+	defer fake.endpointRoutesMutex.RUnlock()
+	fake.endpointTrafficMutex.RLock()
+	defer fake.endpointTrafficMutex.RUnlock()
+	fake.endpointTrafficDeleteMutex.RLock()
+	defer fake.endpointTrafficDeleteMutex.RUnlock()
+	fake.endpointTrafficListMutex.RLock()
+	defer fake.endpointTrafficListMutex.RUnlock()
+	fake.endpointTrafficSetMutex.RLock()
+	defer fake.endpointTrafficSetMutex.RUnlock()
 	fake.endpointVersionMutex.RLock()
 	defer fake.endpointVersionMutex.RUnlock()
 	fake.endpointVersionCreateMutex.RLock()
@@ -4509,6 +7234,8 @@ func (fake *FakeQuerier) Invocations() map[string][][]interface{} {
 	defer fake.endpointVersionListMutex.RUnlock()
 	fake.endpointVersionPromoteMutex.RLock()
 	defer fake.endpointVersionPromoteMutex.RUnlock()
+	fake.endpointVersionStatusUpdateMutex.RLock()
+	defer fake.endpointVersionStatusUpdateMutex.RUnlock()
 	fake.endpointsForProjectMutex.RLock()
 	defer fake.endpointsForProjectMutex.RUnlock()
 	fake.evalCreateMutex.RLock()
@@ -4523,6 +7250,12 @@ func (fake *FakeQuerier) Invocations() map[string][][]interface{} {
 	defer fake.evalListForProjectMutex.RUnlock()
 	fake.execCreateMutex.RLock()
 	defer fake.execCreateMutex.RUnlock()
+	fake.execEventCreateMutex.RLock()
+	defer fake.execEventCreateMutex.RUnlock()
+	fake.execEventListMutex.RLock()
+	defer fake.execEventListMutex.RUnlock()
+	fake.execEventListByExecsMutex.RLock()
+	defer fake.execEventListByExecsMutex.RUnlock()
 	fake.execGetMutex.RLock()
 	defer fake.execGetMutex.RUnlock()
 	fake.execGetAllActiveMutex.RLock()
@@ -4533,6 +7266,10 @@ func (fake *FakeQuerier) Invocations() map[string][][]interface{} {
 	defer fake.execListActiveByProviderMutex.RUnlock()
 	fake.execListByProviderMutex.RLock()
 	defer fake.execListByProviderMutex.RUnlock()
+	fake.execListInWindowMutex.RLock()
+	defer fake.execListInWindowMutex.RUnlock()
+	fake.execListUnfinishedMutex.RLock()
+	defer fake.execListUnfinishedMutex.RUnlock()
 	fake.execSSHKeyDeleteMutex.RLock()
 	defer fake.execSSHKeyDeleteMutex.RUnlock()
 	fake.execSSHKeyGetMutex.RLock()
@@ -4549,8 +7286,12 @@ func (fake *FakeQuerier) Invocations() map[string][][]interface{} {
 	defer fake.execStatusUpdateMutex.RUnlock()
 	fake.execUpdateConnectionInfoMutex.RLock()
 	defer fake.execUpdateConnectionInfoMutex.RUnlock()
+	fake.execUpdateExitCodeMutex.RLock()
+	defer fake.execUpdateExitCodeMutex.RUnlock()
 	fake.execUpdateNetworkMutex.RLock()
 	defer fake.execUpdateNetworkMutex.RUnlock()
+	fake.execUpdateTerminationReasonMutex.RLock()
+	defer fake.execUpdateTerminationReasonMutex.RUnlock()
 	fake.execVolumeCreateMutex.RLock()
 	defer fake.execVolumeCreateMutex.RUnlock()
 	fake.execVolumeDeleteMutex.RLock()
@@ -4565,8 +7306,16 @@ func (fake *FakeQuerier) Invocations() map[string][][]interface{} {
 	defer fake.nodeCreateMutex.RUnlock()
 	fake.nodeStatusUpdateMutex.RLock()
 	defer fake.nodeStatusUpdateMutex.RUnlock()
+	fake.projectAccountAddMutex.RLock()
+	defer fake.projectAccountAddMutex.RUnlock()
+	fake.projectAccountExistsMutex.RLock()
+	defer fake.projectAccountExistsMutex.RUnlock()
+	fake.projectAccountRemoveMutex.RLock()
+	defer fake.projectAccountRemoveMutex.RUnlock()
 	fake.projectGetMutex.RLock()
 	defer fake.projectGetMutex.RUnlock()
+	fake.projectQuotasUpdateMutex.RLock()
+	defer fake.projectQuotasUpdateMutex.RUnlock()
 	fake.sSHKeyAddMutex.RLock()
 	defer fake.sSHKeyAddMutex.RUnlock()
 	fake.sSHKeyGetByNameMutex.RLock()
@@ -4587,6 +7336,22 @@ func (fake *FakeQuerier) Invocations() map[string][][]interface{} {
 	defer fake.volumeListMutex.RUnlock()
 	fake.volumeUpdateMutex.RLock()
 	defer fake.volumeUpdateMutex.RUnlock()
+	fake.webhookCreateMutex.RLock()
+	defer fake.webhookCreateMutex.RUnlock()
+	fake.webhookDeleteMutex.RLock()
+	defer fake.webhookDeleteMutex.RUnlock()
+	fake.webhookDeliveryCreateMutex.RLock()
+	defer fake.webhookDeliveryCreateMutex.RUnlock()
+	fake.webhookDeliveryListMutex.RLock()
+	defer fake.webhookDeliveryListMutex.RUnlock()
+	fake.webhookGetMutex.RLock()
+	defer fake.webhookGetMutex.RUnlock()
+	fake.webhookListMutex.RLock()
+	defer fake.webhookListMutex.RUnlock()
+	fake.webhookListForEventMutex.RLock()
+	defer fake.webhookListForEventMutex.RUnlock()
+	fake.webhookUpdateMutex.RLock()
+	defer fake.webhookUpdateMutex.RUnlock()
 	copiedInvocations := map[string][][]interface{}{}
 	for key, value := range fake.invocations {
 		copiedInvocations[key] = value
@@ -0,0 +1,898 @@
+// Code generated by counterfeiter. DO NOT EDIT.
+package execsrvfakes
+
+import (
+	"context"
+	"sync"
+
+	"github.com/unweave/unweave-v1/api/types"
+	"github.com/unweave/unweave-v1/services/execsrv"
+)
+
+type FakeService struct {
+	CreateStub        func(context.Context, string, string, types.ExecCreateParams) (types.Exec, error)
+	createMutex       sync.RWMutex
+	createArgsForCall []struct {
+		arg1 context.Context
+		arg2 string
+		arg3 string
+		arg4 types.ExecCreateParams
+	}
+	createReturns struct {
+		result1 types.Exec
+		result2 error
+	}
+	createReturnsOnCall map[int]struct {
+		result1 types.Exec
+		result2 error
+	}
+	GetStub        func(context.Context, string) (types.Exec, error)
+	getMutex       sync.RWMutex
+	getArgsForCall []struct {
+		arg1 context.Context
+		arg2 string
+	}
+	getReturns struct {
+		result1 types.Exec
+		result2 error
+	}
+	getReturnsOnCall map[int]struct {
+		result1 types.Exec
+		result2 error
+	}
+	HistoryStub        func(context.Context, string) ([]types.ExecEvent, error)
+	historyMutex       sync.RWMutex
+	historyArgsForCall []struct {
+		arg1 context.Context
+		arg2 string
+	}
+	historyReturns struct {
+		result1 []types.ExecEvent
+		result2 error
+	}
+	historyReturnsOnCall map[int]struct {
+		result1 []types.ExecEvent
+		result2 error
+	}
+	ListStub        func(context.Context, string) ([]types.Exec, error)
+	listMutex       sync.RWMutex
+	listArgsForCall []struct {
+		arg1 context.Context
+		arg2 string
+	}
+	listReturns struct {
+		result1 []types.Exec
+		result2 error
+	}
+	listReturnsOnCall map[int]struct {
+		result1 []types.Exec
+		result2 error
+	}
+	MonitorStub        func(context.Context, string) error
+	monitorMutex       sync.RWMutex
+	monitorArgsForCall []struct {
+		arg1 context.Context
+		arg2 string
+	}
+	monitorReturns struct {
+		result1 error
+	}
+	monitorReturnsOnCall map[int]struct {
+		result1 error
+	}
+	ProviderStub        func() types.Provider
+	providerMutex       sync.RWMutex
+	providerArgsForCall []struct {
+	}
+	providerReturns struct {
+		result1 types.Provider
+	}
+	providerReturnsOnCall map[int]struct {
+		result1 types.Provider
+	}
+	RefreshConnectionInfoStub        func(context.Context, string) (types.Exec, error)
+	refreshConnectionInfoMutex       sync.RWMutex
+	refreshConnectionInfoArgsForCall []struct {
+		arg1 context.Context
+		arg2 string
+	}
+	refreshConnectionInfoReturns struct {
+		result1 types.Exec
+		result2 error
+	}
+	refreshConnectionInfoReturnsOnCall map[int]struct {
+		result1 types.Exec
+		result2 error
+	}
+	StartStub        func(context.Context, string) error
+	startMutex       sync.RWMutex
+	startArgsForCall []struct {
+		arg1 context.Context
+		arg2 string
+	}
+	startReturns struct {
+		result1 error
+	}
+	startReturnsOnCall map[int]struct {
+		result1 error
+	}
+	StateInformerStub        func(context.Context, string) (execsrv.StateInformer, error)
+	stateInformerMutex       sync.RWMutex
+	stateInformerArgsForCall []struct {
+		arg1 context.Context
+		arg2 string
+	}
+	stateInformerReturns struct {
+		result1 execsrv.StateInformer
+		result2 error
+	}
+	stateInformerReturnsOnCall map[int]struct {
+		result1 execsrv.StateInformer
+		result2 error
+	}
+	StopStub        func(context.Context, string) error
+	stopMutex       sync.RWMutex
+	stopArgsForCall []struct {
+		arg1 context.Context
+		arg2 string
+	}
+	stopReturns struct {
+		result1 error
+	}
+	stopReturnsOnCall map[int]struct {
+		result1 error
+	}
+	TerminateStub        func(context.Context, string) error
+	terminateMutex       sync.RWMutex
+	terminateArgsForCall []struct {
+		arg1 context.Context
+		arg2 string
+	}
+	terminateReturns struct {
+		result1 error
+	}
+	terminateReturnsOnCall map[int]struct {
+		result1 error
+	}
+	invocations      map[string][][]interface{}
+	invocationsMutex sync.RWMutex
+}
+
+func (fake *FakeService) Create(arg1 context.Context, arg2 string, arg3 string, arg4 types.ExecCreateParams) (types.Exec, error) {
+	fake.createMutex.Lock()
+	ret, specificReturn := fake.createReturnsOnCall[len(fake.createArgsForCall)]
+	fake.createArgsForCall = append(fake.createArgsForCall, struct {
+		arg1 context.Context
+		arg2 string
+		arg3 string
+		arg4 types.ExecCreateParams
+	}{arg1, arg2, arg3, arg4})
+	stub := fake.CreateStub
+	fakeReturns := fake.createReturns
+	fake.recordInvocation("Create", []interface{}{arg1, arg2, arg3, arg4})
+	fake.createMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3, arg4)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeService) CreateCallCount() int {
+	fake.createMutex.RLock()
+	defer fake.createMutex.RUnlock()
+	return len(fake.createArgsForCall)
+}
+
+func (fake *FakeService) CreateCalls(stub func(context.Context, string, string, types.ExecCreateParams) (types.Exec, error)) {
+	fake.createMutex.Lock()
+	defer fake.createMutex.Unlock()
+	fake.CreateStub = stub
+}
+
+func (fake *FakeService) CreateArgsForCall(i int) (context.Context, string, string, types.ExecCreateParams) {
+	fake.createMutex.RLock()
+	defer fake.createMutex.RUnlock()
+	argsForCall := fake.createArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3, argsForCall.arg4
+}
+
+func (fake *FakeService) CreateReturns(result1 types.Exec, result2 error) {
+	fake.createMutex.Lock()
+	defer fake.createMutex.Unlock()
+	fake.CreateStub = nil
+	fake.createReturns = struct {
+		result1 types.Exec
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeService) CreateReturnsOnCall(i int, result1 types.Exec, result2 error) {
+	fake.createMutex.Lock()
+	defer fake.createMutex.Unlock()
+	fake.CreateStub = nil
+	if fake.createReturnsOnCall == nil {
+		fake.createReturnsOnCall = make(map[int]struct {
+			result1 types.Exec
+			result2 error
+		})
+	}
+	fake.createReturnsOnCall[i] = struct {
+		result1 types.Exec
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeService) Get(arg1 context.Context, arg2 string) (types.Exec, error) {
+	fake.getMutex.Lock()
+	ret, specificReturn := fake.getReturnsOnCall[len(fake.getArgsForCall)]
+	fake.getArgsForCall = append(fake.getArgsForCall, struct {
+		arg1 context.Context
+		arg2 string
+	}{arg1, arg2})
+	stub := fake.GetStub
+	fakeReturns := fake.getReturns
+	fake.recordInvocation("Get", []interface{}{arg1, arg2})
+	fake.getMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeService) GetCallCount() int {
+	fake.getMutex.RLock()
+	defer fake.getMutex.RUnlock()
+	return len(fake.getArgsForCall)
+}
+
+func (fake *FakeService) GetCalls(stub func(context.Context, string) (types.Exec, error)) {
+	fake.getMutex.Lock()
+	defer fake.getMutex.Unlock()
+	fake.GetStub = stub
+}
+
+func (fake *FakeService) GetArgsForCall(i int) (context.Context, string) {
+	fake.getMutex.RLock()
+	defer fake.getMutex.RUnlock()
+	argsForCall := fake.getArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeService) GetReturns(result1 types.Exec, result2 error) {
+	fake.getMutex.Lock()
+	defer fake.getMutex.Unlock()
+	fake.GetStub = nil
+	fake.getReturns = struct {
+		result1 types.Exec
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeService) GetReturnsOnCall(i int, result1 types.Exec, result2 error) {
+	fake.getMutex.Lock()
+	defer fake.getMutex.Unlock()
+	fake.GetStub = nil
+	if fake.getReturnsOnCall == nil {
+		fake.getReturnsOnCall = make(map[int]struct {
+			result1 types.Exec
+			result2 error
+		})
+	}
+	fake.getReturnsOnCall[i] = struct {
+		result1 types.Exec
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeService) History(arg1 context.Context, arg2 string) ([]types.ExecEvent, error) {
+	fake.historyMutex.Lock()
+	ret, specificReturn := fake.historyReturnsOnCall[len(fake.historyArgsForCall)]
+	fake.historyArgsForCall = append(fake.historyArgsForCall, struct {
+		arg1 context.Context
+		arg2 string
+	}{arg1, arg2})
+	stub := fake.HistoryStub
+	fakeReturns := fake.historyReturns
+	fake.recordInvocation("History", []interface{}{arg1, arg2})
+	fake.historyMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeService) HistoryCallCount() int {
+	fake.historyMutex.RLock()
+	defer fake.historyMutex.RUnlock()
+	return len(fake.historyArgsForCall)
+}
+
+func (fake *FakeService) HistoryCalls(stub func(context.Context, string) ([]types.ExecEvent, error)) {
+	fake.historyMutex.Lock()
+	defer fake.historyMutex.Unlock()
+	fake.HistoryStub = stub
+}
+
+func (fake *FakeService) HistoryArgsForCall(i int) (context.Context, string) {
+	fake.historyMutex.RLock()
+	defer fake.historyMutex.RUnlock()
+	argsForCall := fake.historyArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeService) HistoryReturns(result1 []types.ExecEvent, result2 error) {
+	fake.historyMutex.Lock()
+	defer fake.historyMutex.Unlock()
+	fake.HistoryStub = nil
+	fake.historyReturns = struct {
+		result1 []types.ExecEvent
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeService) HistoryReturnsOnCall(i int, result1 []types.ExecEvent, result2 error) {
+	fake.historyMutex.Lock()
+	defer fake.historyMutex.Unlock()
+	fake.HistoryStub = nil
+	if fake.historyReturnsOnCall == nil {
+		fake.historyReturnsOnCall = make(map[int]struct {
+			result1 []types.ExecEvent
+			result2 error
+		})
+	}
+	fake.historyReturnsOnCall[i] = struct {
+		result1 []types.ExecEvent
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeService) List(arg1 context.Context, arg2 string) ([]types.Exec, error) {
+	fake.listMutex.Lock()
+	ret, specificReturn := fake.listReturnsOnCall[len(fake.listArgsForCall)]
+	fake.listArgsForCall = append(fake.listArgsForCall, struct {
+		arg1 context.Context
+		arg2 string
+	}{arg1, arg2})
+	stub := fake.ListStub
+	fakeReturns := fake.listReturns
+	fake.recordInvocation("List", []interface{}{arg1, arg2})
+	fake.listMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeService) ListCallCount() int {
+	fake.listMutex.RLock()
+	defer fake.listMutex.RUnlock()
+	return len(fake.listArgsForCall)
+}
+
+func (fake *FakeService) ListCalls(stub func(context.Context, string) ([]types.Exec, error)) {
+	fake.listMutex.Lock()
+	defer fake.listMutex.Unlock()
+	fake.ListStub = stub
+}
+
+func (fake *FakeService) ListArgsForCall(i int) (context.Context, string) {
+	fake.listMutex.RLock()
+	defer fake.listMutex.RUnlock()
+	argsForCall := fake.listArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeService) ListReturns(result1 []types.Exec, result2 error) {
+	fake.listMutex.Lock()
+	defer fake.listMutex.Unlock()
+	fake.ListStub = nil
+	fake.listReturns = struct {
+		result1 []types.Exec
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeService) ListReturnsOnCall(i int, result1 []types.Exec, result2 error) {
+	fake.listMutex.Lock()
+	defer fake.listMutex.Unlock()
+	fake.ListStub = nil
+	if fake.listReturnsOnCall == nil {
+		fake.listReturnsOnCall = make(map[int]struct {
+			result1 []types.Exec
+			result2 error
+		})
+	}
+	fake.listReturnsOnCall[i] = struct {
+		result1 []types.Exec
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeService) Monitor(arg1 context.Context, arg2 string) error {
+	fake.monitorMutex.Lock()
+	ret, specificReturn := fake.monitorReturnsOnCall[len(fake.monitorArgsForCall)]
+	fake.monitorArgsForCall = append(fake.monitorArgsForCall, struct {
+		arg1 context.Context
+		arg2 string
+	}{arg1, arg2})
+	stub := fake.MonitorStub
+	fakeReturns := fake.monitorReturns
+	fake.recordInvocation("Monitor", []interface{}{arg1, arg2})
+	fake.monitorMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeService) MonitorCallCount() int {
+	fake.monitorMutex.RLock()
+	defer fake.monitorMutex.RUnlock()
+	return len(fake.monitorArgsForCall)
+}
+
+func (fake *FakeService) MonitorCalls(stub func(context.Context, string) error) {
+	fake.monitorMutex.Lock()
+	defer fake.monitorMutex.Unlock()
+	fake.MonitorStub = stub
+}
+
+func (fake *FakeService) MonitorArgsForCall(i int) (context.Context, string) {
+	fake.monitorMutex.RLock()
+	defer fake.monitorMutex.RUnlock()
+	argsForCall := fake.monitorArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeService) MonitorReturns(result1 error) {
+	fake.monitorMutex.Lock()
+	defer fake.monitorMutex.Unlock()
+	fake.MonitorStub = nil
+	fake.monitorReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeService) MonitorReturnsOnCall(i int, result1 error) {
+	fake.monitorMutex.Lock()
+	defer fake.monitorMutex.Unlock()
+	fake.MonitorStub = nil
+	if fake.monitorReturnsOnCall == nil {
+		fake.monitorReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.monitorReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeService) Provider() types.Provider {
+	fake.providerMutex.Lock()
+	ret, specificReturn := fake.providerReturnsOnCall[len(fake.providerArgsForCall)]
+	fake.providerArgsForCall = append(fake.providerArgsForCall, struct {
+	}{})
+	stub := fake.ProviderStub
+	fakeReturns := fake.providerReturns
+	fake.recordInvocation("Provider", []interface{}{})
+	fake.providerMutex.Unlock()
+	if stub != nil {
+		return stub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeService) ProviderCallCount() int {
+	fake.providerMutex.RLock()
+	defer fake.providerMutex.RUnlock()
+	return len(fake.providerArgsForCall)
+}
+
+func (fake *FakeService) ProviderCalls(stub func() types.Provider) {
+	fake.providerMutex.Lock()
+	defer fake.providerMutex.Unlock()
+	fake.ProviderStub = stub
+}
+
+func (fake *FakeService) ProviderReturns(result1 types.Provider) {
+	fake.providerMutex.Lock()
+	defer fake.providerMutex.Unlock()
+	fake.ProviderStub = nil
+	fake.providerReturns = struct {
+		result1 types.Provider
+	}{result1}
+}
+
+func (fake *FakeService) ProviderReturnsOnCall(i int, result1 types.Provider) {
+	fake.providerMutex.Lock()
+	defer fake.providerMutex.Unlock()
+	fake.ProviderStub = nil
+	if fake.providerReturnsOnCall == nil {
+		fake.providerReturnsOnCall = make(map[int]struct {
+			result1 types.Provider
+		})
+	}
+	fake.providerReturnsOnCall[i] = struct {
+		result1 types.Provider
+	}{result1}
+}
+
+func (fake *FakeService) RefreshConnectionInfo(arg1 context.Context, arg2 string) (types.Exec, error) {
+	fake.refreshConnectionInfoMutex.Lock()
+	ret, specificReturn := fake.refreshConnectionInfoReturnsOnCall[len(fake.refreshConnectionInfoArgsForCall)]
+	fake.refreshConnectionInfoArgsForCall = append(fake.refreshConnectionInfoArgsForCall, struct {
+		arg1 context.Context
+		arg2 string
+	}{arg1, arg2})
+	stub := fake.RefreshConnectionInfoStub
+	fakeReturns := fake.refreshConnectionInfoReturns
+	fake.recordInvocation("RefreshConnectionInfo", []interface{}{arg1, arg2})
+	fake.refreshConnectionInfoMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeService) RefreshConnectionInfoCallCount() int {
+	fake.refreshConnectionInfoMutex.RLock()
+	defer fake.refreshConnectionInfoMutex.RUnlock()
+	return len(fake.refreshConnectionInfoArgsForCall)
+}
+
+func (fake *FakeService) RefreshConnectionInfoCalls(stub func(context.Context, string) (types.Exec, error)) {
+	fake.refreshConnectionInfoMutex.Lock()
+	defer fake.refreshConnectionInfoMutex.Unlock()
+	fake.RefreshConnectionInfoStub = stub
+}
+
+func (fake *FakeService) RefreshConnectionInfoArgsForCall(i int) (context.Context, string) {
+	fake.refreshConnectionInfoMutex.RLock()
+	defer fake.refreshConnectionInfoMutex.RUnlock()
+	argsForCall := fake.refreshConnectionInfoArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeService) RefreshConnectionInfoReturns(result1 types.Exec, result2 error) {
+	fake.refreshConnectionInfoMutex.Lock()
+	defer fake.refreshConnectionInfoMutex.Unlock()
+	fake.RefreshConnectionInfoStub = nil
+	fake.refreshConnectionInfoReturns = struct {
+		result1 types.Exec
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeService) RefreshConnectionInfoReturnsOnCall(i int, result1 types.Exec, result2 error) {
+	fake.refreshConnectionInfoMutex.Lock()
+	defer fake.refreshConnectionInfoMutex.Unlock()
+	fake.RefreshConnectionInfoStub = nil
+	if fake.refreshConnectionInfoReturnsOnCall == nil {
+		fake.refreshConnectionInfoReturnsOnCall = make(map[int]struct {
+			result1 types.Exec
+			result2 error
+		})
+	}
+	fake.refreshConnectionInfoReturnsOnCall[i] = struct {
+		result1 types.Exec
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeService) Start(arg1 context.Context, arg2 string) error {
+	fake.startMutex.Lock()
+	ret, specificReturn := fake.startReturnsOnCall[len(fake.startArgsForCall)]
+	fake.startArgsForCall = append(fake.startArgsForCall, struct {
+		arg1 context.Context
+		arg2 string
+	}{arg1, arg2})
+	stub := fake.StartStub
+	fakeReturns := fake.startReturns
+	fake.recordInvocation("Start", []interface{}{arg1, arg2})
+	fake.startMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeService) StartCallCount() int {
+	fake.startMutex.RLock()
+	defer fake.startMutex.RUnlock()
+	return len(fake.startArgsForCall)
+}
+
+func (fake *FakeService) StartCalls(stub func(context.Context, string) error) {
+	fake.startMutex.Lock()
+	defer fake.startMutex.Unlock()
+	fake.StartStub = stub
+}
+
+func (fake *FakeService) StartArgsForCall(i int) (context.Context, string) {
+	fake.startMutex.RLock()
+	defer fake.startMutex.RUnlock()
+	argsForCall := fake.startArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeService) StartReturns(result1 error) {
+	fake.startMutex.Lock()
+	defer fake.startMutex.Unlock()
+	fake.StartStub = nil
+	fake.startReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeService) StartReturnsOnCall(i int, result1 error) {
+	fake.startMutex.Lock()
+	defer fake.startMutex.Unlock()
+	fake.StartStub = nil
+	if fake.startReturnsOnCall == nil {
+		fake.startReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.startReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeService) StateInformer(arg1 context.Context, arg2 string) (execsrv.StateInformer, error) {
+	fake.stateInformerMutex.Lock()
+	ret, specificReturn := fake.stateInformerReturnsOnCall[len(fake.stateInformerArgsForCall)]
+	fake.stateInformerArgsForCall = append(fake.stateInformerArgsForCall, struct {
+		arg1 context.Context
+		arg2 string
+	}{arg1, arg2})
+	stub := fake.StateInformerStub
+	fakeReturns := fake.stateInformerReturns
+	fake.recordInvocation("StateInformer", []interface{}{arg1, arg2})
+	fake.stateInformerMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeService) StateInformerCallCount() int {
+	fake.stateInformerMutex.RLock()
+	defer fake.stateInformerMutex.RUnlock()
+	return len(fake.stateInformerArgsForCall)
+}
+
+func (fake *FakeService) StateInformerCalls(stub func(context.Context, string) (execsrv.StateInformer, error)) {
+	fake.stateInformerMutex.Lock()
+	defer fake.stateInformerMutex.Unlock()
+	fake.StateInformerStub = stub
+}
+
+func (fake *FakeService) StateInformerArgsForCall(i int) (context.Context, string) {
+	fake.stateInformerMutex.RLock()
+	defer fake.stateInformerMutex.RUnlock()
+	argsForCall := fake.stateInformerArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeService) StateInformerReturns(result1 execsrv.StateInformer, result2 error) {
+	fake.stateInformerMutex.Lock()
+	defer fake.stateInformerMutex.Unlock()
+	fake.StateInformerStub = nil
+	fake.stateInformerReturns = struct {
+		result1 execsrv.StateInformer
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeService) StateInformerReturnsOnCall(i int, result1 execsrv.StateInformer, result2 error) {
+	fake.stateInformerMutex.Lock()
+	defer fake.stateInformerMutex.Unlock()
+	fake.StateInformerStub = nil
+	if fake.stateInformerReturnsOnCall == nil {
+		fake.stateInformerReturnsOnCall = make(map[int]struct {
+			result1 execsrv.StateInformer
+			result2 error
+		})
+	}
+	fake.stateInformerReturnsOnCall[i] = struct {
+		result1 execsrv.StateInformer
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeService) Stop(arg1 context.Context, arg2 string) error {
+	fake.stopMutex.Lock()
+	ret, specificReturn := fake.stopReturnsOnCall[len(fake.stopArgsForCall)]
+	fake.stopArgsForCall = append(fake.stopArgsForCall, struct {
+		arg1 context.Context
+		arg2 string
+	}{arg1, arg2})
+	stub := fake.StopStub
+	fakeReturns := fake.stopReturns
+	fake.recordInvocation("Stop", []interface{}{arg1, arg2})
+	fake.stopMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeService) StopCallCount() int {
+	fake.stopMutex.RLock()
+	defer fake.stopMutex.RUnlock()
+	return len(fake.stopArgsForCall)
+}
+
+func (fake *FakeService) StopCalls(stub func(context.Context, string) error) {
+	fake.stopMutex.Lock()
+	defer fake.stopMutex.Unlock()
+	fake.StopStub = stub
+}
+
+func (fake *FakeService) StopArgsForCall(i int) (context.Context, string) {
+	fake.stopMutex.RLock()
+	defer fake.stopMutex.RUnlock()
+	argsForCall := fake.stopArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeService) StopReturns(result1 error) {
+	fake.stopMutex.Lock()
+	defer fake.stopMutex.Unlock()
+	fake.StopStub = nil
+	fake.stopReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeService) StopReturnsOnCall(i int, result1 error) {
+	fake.stopMutex.Lock()
+	defer fake.stopMutex.Unlock()
+	fake.StopStub = nil
+	if fake.stopReturnsOnCall == nil {
+		fake.stopReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.stopReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeService) Terminate(arg1 context.Context, arg2 string) error {
+	fake.terminateMutex.Lock()
+	ret, specificReturn := fake.terminateReturnsOnCall[len(fake.terminateArgsForCall)]
+	fake.terminateArgsForCall = append(fake.terminateArgsForCall, struct {
+		arg1 context.Context
+		arg2 string
+	}{arg1, arg2})
+	stub := fake.TerminateStub
+	fakeReturns := fake.terminateReturns
+	fake.recordInvocation("Terminate", []interface{}{arg1, arg2})
+	fake.terminateMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeService) TerminateCallCount() int {
+	fake.terminateMutex.RLock()
+	defer fake.terminateMutex.RUnlock()
+	return len(fake.terminateArgsForCall)
+}
+
+func (fake *FakeService) TerminateCalls(stub func(context.Context, string) error) {
+	fake.terminateMutex.Lock()
+	defer fake.terminateMutex.Unlock()
+	fake.TerminateStub = stub
+}
+
+func (fake *FakeService) TerminateArgsForCall(i int) (context.Context, string) {
+	fake.terminateMutex.RLock()
+	defer fake.terminateMutex.RUnlock()
+	argsForCall := fake.terminateArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeService) TerminateReturns(result1 error) {
+	fake.terminateMutex.Lock()
+	defer fake.terminateMutex.Unlock()
+	fake.TerminateStub = nil
+	fake.terminateReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeService) TerminateReturnsOnCall(i int, result1 error) {
+	fake.terminateMutex.Lock()
+	defer fake.terminateMutex.Unlock()
+	fake.TerminateStub = nil
+	if fake.terminateReturnsOnCall == nil {
+		fake.terminateReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.terminateReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeService) Invocations() map[string][][]interface{} {
+	fake.invocationsMutex.RLock()
+	defer fake.invocationsMutex.RUnlock()
+	fake.createMutex.RLock()
+	defer fake.createMutex.RUnlock()
+	fake.getMutex.RLock()
+	defer fake.getMutex.RUnlock()
+	fake.historyMutex.RLock()
+	defer fake.historyMutex.RUnlock()
+	fake.listMutex.RLock()
+	defer fake.listMutex.RUnlock()
+	fake.monitorMutex.RLock()
+	defer fake.monitorMutex.RUnlock()
+	fake.providerMutex.RLock()
+	defer fake.providerMutex.RUnlock()
+	fake.refreshConnectionInfoMutex.RLock()
+	defer fake.refreshConnectionInfoMutex.RUnlock()
+	fake.startMutex.RLock()
+	defer fake.startMutex.RUnlock()
+	fake.stateInformerMutex.RLock()
+	defer fake.stateInformerMutex.RUnlock()
+	fake.stopMutex.RLock()
+	defer fake.stopMutex.RUnlock()
+	fake.terminateMutex.RLock()
+	defer fake.terminateMutex.RUnlock()
+	copiedInvocations := map[string][][]interface{}{}
+	for key, value := range fake.invocations {
+		copiedInvocations[key] = value
+	}
+	return copiedInvocations
+}
+
+func (fake *FakeService) recordInvocation(key string, args []interface{}) {
+	fake.invocationsMutex.Lock()
+	defer fake.invocationsMutex.Unlock()
+	if fake.invocations == nil {
+		fake.invocations = map[string][][]interface{}{}
+	}
+	if fake.invocations[key] == nil {
+		fake.invocations[key] = [][]interface{}{}
+	}
+	fake.invocations[key] = append(fake.invocations[key], args)
+}
+
+var _ execsrv.Service = new(FakeService)
@@ -10,6 +10,17 @@ import (
 )
 
 type FakeStore struct {
+	AddEventStub        func(types.ExecEvent) error
+	addEventMutex       sync.RWMutex
+	addEventArgsForCall []struct {
+		arg1 types.ExecEvent
+	}
+	addEventReturns struct {
+		result1 error
+	}
+	addEventReturnsOnCall map[int]struct {
+		result1 error
+	}
 	CreateStub        func(string, types.Exec) error
 	createMutex       sync.RWMutex
 	createArgsForCall []struct {
@@ -33,6 +44,19 @@ type FakeStore struct {
 	deleteReturnsOnCall map[int]struct {
 		result1 error
 	}
+	FinishStub        func(string, types.Status, *int) error
+	finishMutex       sync.RWMutex
+	finishArgsForCall []struct {
+		arg1 string
+		arg2 types.Status
+		arg3 *int
+	}
+	finishReturns struct {
+		result1 error
+	}
+	finishReturnsOnCall map[int]struct {
+		result1 error
+	}
 	GetStub        func(string) (types.Exec, error)
 	getMutex       sync.RWMutex
 	getArgsForCall []struct {
@@ -74,17 +98,59 @@ type FakeStore struct {
 		result1 []types.Exec
 		result2 error
 	}
-	UpdateStub        func(string, types.Exec) error
-	updateMutex       sync.RWMutex
-	updateArgsForCall []struct {
+	ListEventsStub        func(string) ([]types.ExecEvent, error)
+	listEventsMutex       sync.RWMutex
+	listEventsArgsForCall []struct {
 		arg1 string
-		arg2 types.Exec
 	}
-	updateReturns struct {
-		result1 error
+	listEventsReturns struct {
+		result1 []types.ExecEvent
+		result2 error
 	}
-	updateReturnsOnCall map[int]struct {
-		result1 error
+	listEventsReturnsOnCall map[int]struct {
+		result1 []types.ExecEvent
+		result2 error
+	}
+	ListEventsByExecsStub        func([]string) (map[string][]types.ExecEvent, error)
+	listEventsByExecsMutex       sync.RWMutex
+	listEventsByExecsArgsForCall []struct {
+		arg1 []string
+	}
+	listEventsByExecsReturns struct {
+		result1 map[string][]types.ExecEvent
+		result2 error
+	}
+	listEventsByExecsReturnsOnCall map[int]struct {
+		result1 map[string][]types.ExecEvent
+		result2 error
+	}
+	ListInWindowStub        func(string, time.Time, time.Time) ([]types.Exec, error)
+	listInWindowMutex       sync.RWMutex
+	listInWindowArgsForCall []struct {
+		arg1 string
+		arg2 time.Time
+		arg3 time.Time
+	}
+	listInWindowReturns struct {
+		result1 []types.Exec
+		result2 error
+	}
+	listInWindowReturnsOnCall map[int]struct {
+		result1 []types.Exec
+		result2 error
+	}
+	ListUnfinishedStub        func(*types.Provider) ([]types.Exec, error)
+	listUnfinishedMutex       sync.RWMutex
+	listUnfinishedArgsForCall []struct {
+		arg1 *types.Provider
+	}
+	listUnfinishedReturns struct {
+		result1 []types.Exec
+		result2 error
+	}
+	listUnfinishedReturnsOnCall map[int]struct {
+		result1 []types.Exec
+		result2 error
 	}
 	UpdateConnectionInfoStub        func(string, types.ConnectionInfo) error
 	updateConnectionInfoMutex       sync.RWMutex
@@ -112,10 +178,83 @@ type FakeStore struct {
 	updateStatusReturnsOnCall map[int]struct {
 		result1 error
 	}
+	UpdateTerminationReasonStub        func(string, string) error
+	updateTerminationReasonMutex       sync.RWMutex
+	updateTerminationReasonArgsForCall []struct {
+		arg1 string
+		arg2 string
+	}
+	updateTerminationReasonReturns struct {
+		result1 error
+	}
+	updateTerminationReasonReturnsOnCall map[int]struct {
+		result1 error
+	}
 	invocations      map[string][][]interface{}
 	invocationsMutex sync.RWMutex
 }
 
+func (fake *FakeStore) AddEvent(arg1 types.ExecEvent) error {
+	fake.addEventMutex.Lock()
+	ret, specificReturn := fake.addEventReturnsOnCall[len(fake.addEventArgsForCall)]
+	fake.addEventArgsForCall = append(fake.addEventArgsForCall, struct {
+		arg1 types.ExecEvent
+	}{arg1})
+	stub := fake.AddEventStub
+	fakeReturns := fake.addEventReturns
+	fake.recordInvocation("AddEvent", []interface{}{arg1})
+	fake.addEventMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeStore) AddEventCallCount() int {
+	fake.addEventMutex.RLock()
+	defer fake.addEventMutex.RUnlock()
+	return len(fake.addEventArgsForCall)
+}
+
+func (fake *FakeStore) AddEventCalls(stub func(types.ExecEvent) error) {
+	fake.addEventMutex.Lock()
+	defer fake.addEventMutex.Unlock()
+	fake.AddEventStub = stub
+}
+
+func (fake *FakeStore) AddEventArgsForCall(i int) types.ExecEvent {
+	fake.addEventMutex.RLock()
+	defer fake.addEventMutex.RUnlock()
+	argsForCall := fake.addEventArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeStore) AddEventReturns(result1 error) {
+	fake.addEventMutex.Lock()
+	defer fake.addEventMutex.Unlock()
+	fake.AddEventStub = nil
+	fake.addEventReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeStore) AddEventReturnsOnCall(i int, result1 error) {
+	fake.addEventMutex.Lock()
+	defer fake.addEventMutex.Unlock()
+	fake.AddEventStub = nil
+	if fake.addEventReturnsOnCall == nil {
+		fake.addEventReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.addEventReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
 func (fake *FakeStore) Create(arg1 string, arg2 types.Exec) error {
 	fake.createMutex.Lock()
 	ret, specificReturn := fake.createReturnsOnCall[len(fake.createArgsForCall)]
@@ -239,6 +378,69 @@ func (fake *FakeStore) DeleteReturnsOnCall(i int, result1 error) {
 	}{result1}
 }
 
+func (fake *FakeStore) Finish(arg1 string, arg2 types.Status, arg3 *int) error {
+	fake.finishMutex.Lock()
+	ret, specificReturn := fake.finishReturnsOnCall[len(fake.finishArgsForCall)]
+	fake.finishArgsForCall = append(fake.finishArgsForCall, struct {
+		arg1 string
+		arg2 types.Status
+		arg3 *int
+	}{arg1, arg2, arg3})
+	stub := fake.FinishStub
+	fakeReturns := fake.finishReturns
+	fake.recordInvocation("Finish", []interface{}{arg1, arg2, arg3})
+	fake.finishMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeStore) FinishCallCount() int {
+	fake.finishMutex.RLock()
+	defer fake.finishMutex.RUnlock()
+	return len(fake.finishArgsForCall)
+}
+
+func (fake *FakeStore) FinishCalls(stub func(string, types.Status, *int) error) {
+	fake.finishMutex.Lock()
+	defer fake.finishMutex.Unlock()
+	fake.FinishStub = stub
+}
+
+func (fake *FakeStore) FinishArgsForCall(i int) (string, types.Status, *int) {
+	fake.finishMutex.RLock()
+	defer fake.finishMutex.RUnlock()
+	argsForCall := fake.finishArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *FakeStore) FinishReturns(result1 error) {
+	fake.finishMutex.Lock()
+	defer fake.finishMutex.Unlock()
+	fake.FinishStub = nil
+	fake.finishReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeStore) FinishReturnsOnCall(i int, result1 error) {
+	fake.finishMutex.Lock()
+	defer fake.finishMutex.Unlock()
+	fake.FinishStub = nil
+	if fake.finishReturnsOnCall == nil {
+		fake.finishReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.finishReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
 func (fake *FakeStore) Get(arg1 string) (types.Exec, error) {
 	fake.getMutex.Lock()
 	ret, specificReturn := fake.getReturnsOnCall[len(fake.getArgsForCall)]
@@ -433,66 +635,267 @@ func (fake *FakeStore) ListReturnsOnCall(i int, result1 []types.Exec, result2 er
 	}{result1, result2}
 }
 
-func (fake *FakeStore) Update(arg1 string, arg2 types.Exec) error {
-	fake.updateMutex.Lock()
-	ret, specificReturn := fake.updateReturnsOnCall[len(fake.updateArgsForCall)]
-	fake.updateArgsForCall = append(fake.updateArgsForCall, struct {
+func (fake *FakeStore) ListEvents(arg1 string) ([]types.ExecEvent, error) {
+	fake.listEventsMutex.Lock()
+	ret, specificReturn := fake.listEventsReturnsOnCall[len(fake.listEventsArgsForCall)]
+	fake.listEventsArgsForCall = append(fake.listEventsArgsForCall, struct {
 		arg1 string
-		arg2 types.Exec
-	}{arg1, arg2})
-	stub := fake.UpdateStub
-	fakeReturns := fake.updateReturns
-	fake.recordInvocation("Update", []interface{}{arg1, arg2})
-	fake.updateMutex.Unlock()
+	}{arg1})
+	stub := fake.ListEventsStub
+	fakeReturns := fake.listEventsReturns
+	fake.recordInvocation("ListEvents", []interface{}{arg1})
+	fake.listEventsMutex.Unlock()
 	if stub != nil {
-		return stub(arg1, arg2)
+		return stub(arg1)
 	}
 	if specificReturn {
-		return ret.result1
+		return ret.result1, ret.result2
 	}
-	return fakeReturns.result1
+	return fakeReturns.result1, fakeReturns.result2
 }
 
-func (fake *FakeStore) UpdateCallCount() int {
-	fake.updateMutex.RLock()
-	defer fake.updateMutex.RUnlock()
-	return len(fake.updateArgsForCall)
+func (fake *FakeStore) ListEventsCallCount() int {
+	fake.listEventsMutex.RLock()
+	defer fake.listEventsMutex.RUnlock()
+	return len(fake.listEventsArgsForCall)
 }
 
-func (fake *FakeStore) UpdateCalls(stub func(string, types.Exec) error) {
-	fake.updateMutex.Lock()
-	defer fake.updateMutex.Unlock()
-	fake.UpdateStub = stub
+func (fake *FakeStore) ListEventsCalls(stub func(string) ([]types.ExecEvent, error)) {
+	fake.listEventsMutex.Lock()
+	defer fake.listEventsMutex.Unlock()
+	fake.ListEventsStub = stub
 }
 
-func (fake *FakeStore) UpdateArgsForCall(i int) (string, types.Exec) {
-	fake.updateMutex.RLock()
-	defer fake.updateMutex.RUnlock()
-	argsForCall := fake.updateArgsForCall[i]
-	return argsForCall.arg1, argsForCall.arg2
+func (fake *FakeStore) ListEventsArgsForCall(i int) string {
+	fake.listEventsMutex.RLock()
+	defer fake.listEventsMutex.RUnlock()
+	argsForCall := fake.listEventsArgsForCall[i]
+	return argsForCall.arg1
 }
 
-func (fake *FakeStore) UpdateReturns(result1 error) {
-	fake.updateMutex.Lock()
-	defer fake.updateMutex.Unlock()
-	fake.UpdateStub = nil
-	fake.updateReturns = struct {
-		result1 error
-	}{result1}
+func (fake *FakeStore) ListEventsReturns(result1 []types.ExecEvent, result2 error) {
+	fake.listEventsMutex.Lock()
+	defer fake.listEventsMutex.Unlock()
+	fake.ListEventsStub = nil
+	fake.listEventsReturns = struct {
+		result1 []types.ExecEvent
+		result2 error
+	}{result1, result2}
 }
 
-func (fake *FakeStore) UpdateReturnsOnCall(i int, result1 error) {
-	fake.updateMutex.Lock()
-	defer fake.updateMutex.Unlock()
-	fake.UpdateStub = nil
-	if fake.updateReturnsOnCall == nil {
-		fake.updateReturnsOnCall = make(map[int]struct {
-			result1 error
+func (fake *FakeStore) ListEventsReturnsOnCall(i int, result1 []types.ExecEvent, result2 error) {
+	fake.listEventsMutex.Lock()
+	defer fake.listEventsMutex.Unlock()
+	fake.ListEventsStub = nil
+	if fake.listEventsReturnsOnCall == nil {
+		fake.listEventsReturnsOnCall = make(map[int]struct {
+			result1 []types.ExecEvent
+			result2 error
 		})
 	}
-	fake.updateReturnsOnCall[i] = struct {
-		result1 error
-	}{result1}
+	fake.listEventsReturnsOnCall[i] = struct {
+		result1 []types.ExecEvent
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeStore) ListEventsByExecs(arg1 []string) (map[string][]types.ExecEvent, error) {
+	var arg1Copy []string
+	if arg1 != nil {
+		arg1Copy = make([]string, len(arg1))
+		copy(arg1Copy, arg1)
+	}
+	fake.listEventsByExecsMutex.Lock()
+	ret, specificReturn := fake.listEventsByExecsReturnsOnCall[len(fake.listEventsByExecsArgsForCall)]
+	fake.listEventsByExecsArgsForCall = append(fake.listEventsByExecsArgsForCall, struct {
+		arg1 []string
+	}{arg1Copy})
+	stub := fake.ListEventsByExecsStub
+	fakeReturns := fake.listEventsByExecsReturns
+	fake.recordInvocation("ListEventsByExecs", []interface{}{arg1Copy})
+	fake.listEventsByExecsMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeStore) ListEventsByExecsCallCount() int {
+	fake.listEventsByExecsMutex.RLock()
+	defer fake.listEventsByExecsMutex.RUnlock()
+	return len(fake.listEventsByExecsArgsForCall)
+}
+
+func (fake *FakeStore) ListEventsByExecsCalls(stub func([]string) (map[string][]types.ExecEvent, error)) {
+	fake.listEventsByExecsMutex.Lock()
+	defer fake.listEventsByExecsMutex.Unlock()
+	fake.ListEventsByExecsStub = stub
+}
+
+func (fake *FakeStore) ListEventsByExecsArgsForCall(i int) []string {
+	fake.listEventsByExecsMutex.RLock()
+	defer fake.listEventsByExecsMutex.RUnlock()
+	argsForCall := fake.listEventsByExecsArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeStore) ListEventsByExecsReturns(result1 map[string][]types.ExecEvent, result2 error) {
+	fake.listEventsByExecsMutex.Lock()
+	defer fake.listEventsByExecsMutex.Unlock()
+	fake.ListEventsByExecsStub = nil
+	fake.listEventsByExecsReturns = struct {
+		result1 map[string][]types.ExecEvent
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeStore) ListEventsByExecsReturnsOnCall(i int, result1 map[string][]types.ExecEvent, result2 error) {
+	fake.listEventsByExecsMutex.Lock()
+	defer fake.listEventsByExecsMutex.Unlock()
+	fake.ListEventsByExecsStub = nil
+	if fake.listEventsByExecsReturnsOnCall == nil {
+		fake.listEventsByExecsReturnsOnCall = make(map[int]struct {
+			result1 map[string][]types.ExecEvent
+			result2 error
+		})
+	}
+	fake.listEventsByExecsReturnsOnCall[i] = struct {
+		result1 map[string][]types.ExecEvent
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeStore) ListInWindow(arg1 string, arg2 time.Time, arg3 time.Time) ([]types.Exec, error) {
+	fake.listInWindowMutex.Lock()
+	ret, specificReturn := fake.listInWindowReturnsOnCall[len(fake.listInWindowArgsForCall)]
+	fake.listInWindowArgsForCall = append(fake.listInWindowArgsForCall, struct {
+		arg1 string
+		arg2 time.Time
+		arg3 time.Time
+	}{arg1, arg2, arg3})
+	stub := fake.ListInWindowStub
+	fakeReturns := fake.listInWindowReturns
+	fake.recordInvocation("ListInWindow", []interface{}{arg1, arg2, arg3})
+	fake.listInWindowMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeStore) ListInWindowCallCount() int {
+	fake.listInWindowMutex.RLock()
+	defer fake.listInWindowMutex.RUnlock()
+	return len(fake.listInWindowArgsForCall)
+}
+
+func (fake *FakeStore) ListInWindowCalls(stub func(string, time.Time, time.Time) ([]types.Exec, error)) {
+	fake.listInWindowMutex.Lock()
+	defer fake.listInWindowMutex.Unlock()
+	fake.ListInWindowStub = stub
+}
+
+func (fake *FakeStore) ListInWindowArgsForCall(i int) (string, time.Time, time.Time) {
+	fake.listInWindowMutex.RLock()
+	defer fake.listInWindowMutex.RUnlock()
+	argsForCall := fake.listInWindowArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *FakeStore) ListInWindowReturns(result1 []types.Exec, result2 error) {
+	fake.listInWindowMutex.Lock()
+	defer fake.listInWindowMutex.Unlock()
+	fake.ListInWindowStub = nil
+	fake.listInWindowReturns = struct {
+		result1 []types.Exec
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeStore) ListInWindowReturnsOnCall(i int, result1 []types.Exec, result2 error) {
+	fake.listInWindowMutex.Lock()
+	defer fake.listInWindowMutex.Unlock()
+	fake.ListInWindowStub = nil
+	if fake.listInWindowReturnsOnCall == nil {
+		fake.listInWindowReturnsOnCall = make(map[int]struct {
+			result1 []types.Exec
+			result2 error
+		})
+	}
+	fake.listInWindowReturnsOnCall[i] = struct {
+		result1 []types.Exec
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeStore) ListUnfinished(arg1 *types.Provider) ([]types.Exec, error) {
+	fake.listUnfinishedMutex.Lock()
+	ret, specificReturn := fake.listUnfinishedReturnsOnCall[len(fake.listUnfinishedArgsForCall)]
+	fake.listUnfinishedArgsForCall = append(fake.listUnfinishedArgsForCall, struct {
+		arg1 *types.Provider
+	}{arg1})
+	stub := fake.ListUnfinishedStub
+	fakeReturns := fake.listUnfinishedReturns
+	fake.recordInvocation("ListUnfinished", []interface{}{arg1})
+	fake.listUnfinishedMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeStore) ListUnfinishedCallCount() int {
+	fake.listUnfinishedMutex.RLock()
+	defer fake.listUnfinishedMutex.RUnlock()
+	return len(fake.listUnfinishedArgsForCall)
+}
+
+func (fake *FakeStore) ListUnfinishedCalls(stub func(*types.Provider) ([]types.Exec, error)) {
+	fake.listUnfinishedMutex.Lock()
+	defer fake.listUnfinishedMutex.Unlock()
+	fake.ListUnfinishedStub = stub
+}
+
+func (fake *FakeStore) ListUnfinishedArgsForCall(i int) *types.Provider {
+	fake.listUnfinishedMutex.RLock()
+	defer fake.listUnfinishedMutex.RUnlock()
+	argsForCall := fake.listUnfinishedArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeStore) ListUnfinishedReturns(result1 []types.Exec, result2 error) {
+	fake.listUnfinishedMutex.Lock()
+	defer fake.listUnfinishedMutex.Unlock()
+	fake.ListUnfinishedStub = nil
+	fake.listUnfinishedReturns = struct {
+		result1 []types.Exec
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeStore) ListUnfinishedReturnsOnCall(i int, result1 []types.Exec, result2 error) {
+	fake.listUnfinishedMutex.Lock()
+	defer fake.listUnfinishedMutex.Unlock()
+	fake.ListUnfinishedStub = nil
+	if fake.listUnfinishedReturnsOnCall == nil {
+		fake.listUnfinishedReturnsOnCall = make(map[int]struct {
+			result1 []types.Exec
+			result2 error
+		})
+	}
+	fake.listUnfinishedReturnsOnCall[i] = struct {
+		result1 []types.Exec
+		result2 error
+	}{result1, result2}
 }
 
 func (fake *FakeStore) UpdateConnectionInfo(arg1 string, arg2 types.ConnectionInfo) error {
@@ -621,25 +1024,99 @@ func (fake *FakeStore) UpdateStatusReturnsOnCall(i int, result1 error) {
 	}{result1}
 }
 
+func (fake *FakeStore) UpdateTerminationReason(arg1 string, arg2 string) error {
+	fake.updateTerminationReasonMutex.Lock()
+	ret, specificReturn := fake.updateTerminationReasonReturnsOnCall[len(fake.updateTerminationReasonArgsForCall)]
+	fake.updateTerminationReasonArgsForCall = append(fake.updateTerminationReasonArgsForCall, struct {
+		arg1 string
+		arg2 string
+	}{arg1, arg2})
+	stub := fake.UpdateTerminationReasonStub
+	fakeReturns := fake.updateTerminationReasonReturns
+	fake.recordInvocation("UpdateTerminationReason", []interface{}{arg1, arg2})
+	fake.updateTerminationReasonMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeStore) UpdateTerminationReasonCallCount() int {
+	fake.updateTerminationReasonMutex.RLock()
+	defer fake.updateTerminationReasonMutex.RUnlock()
+	return len(fake.updateTerminationReasonArgsForCall)
+}
+
+func (fake *FakeStore) UpdateTerminationReasonCalls(stub func(string, string) error) {
+	fake.updateTerminationReasonMutex.Lock()
+	defer fake.updateTerminationReasonMutex.Unlock()
+	fake.UpdateTerminationReasonStub = stub
+}
+
+func (fake *FakeStore) UpdateTerminationReasonArgsForCall(i int) (string, string) {
+	fake.updateTerminationReasonMutex.RLock()
+	defer fake.updateTerminationReasonMutex.RUnlock()
+	argsForCall := fake.updateTerminationReasonArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeStore) UpdateTerminationReasonReturns(result1 error) {
+	fake.updateTerminationReasonMutex.Lock()
+	defer fake.updateTerminationReasonMutex.Unlock()
+	fake.UpdateTerminationReasonStub = nil
+	fake.updateTerminationReasonReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeStore) UpdateTerminationReasonReturnsOnCall(i int, result1 error) {
+	fake.updateTerminationReasonMutex.Lock()
+	defer fake.updateTerminationReasonMutex.Unlock()
+	fake.UpdateTerminationReasonStub = nil
+	if fake.updateTerminationReasonReturnsOnCall == nil {
+		fake.updateTerminationReasonReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.updateTerminationReasonReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
 func (fake *FakeStore) Invocations() map[string][][]interface{} {
 	fake.invocationsMutex.RLock()
 	defer fake.invocationsMutex.RUnlock()
+	fake.addEventMutex.RLock()
+	defer fake.addEventMutex.RUnlock()
 	fake.createMutex.RLock()
 	defer fake.createMutex.RUnlock()
 	fake.deleteMutex.RLock()
 	defer fake.deleteMutex.RUnlock()
+	fake.finishMutex.RLock()
+	defer fake.finishMutex.RUnlock()
 	fake.getMutex.RLock()
 	defer fake.getMutex.RUnlock()
 	fake.getDriverMutex.RLock()
 	defer fake.getDriverMutex.RUnlock()
 	fake.listMutex.RLock()
 	defer fake.listMutex.RUnlock()
-	fake.updateMutex.RLock()
-	defer fake.updateMutex.RUnlock()
+	fake.listEventsMutex.RLock()
+	defer fake.listEventsMutex.RUnlock()
+	fake.listEventsByExecsMutex.RLock()
+	defer fake.listEventsByExecsMutex.RUnlock()
+	fake.listInWindowMutex.RLock()
+	defer fake.listInWindowMutex.RUnlock()
+	fake.listUnfinishedMutex.RLock()
+	defer fake.listUnfinishedMutex.RUnlock()
 	fake.updateConnectionInfoMutex.RLock()
 	defer fake.updateConnectionInfoMutex.RUnlock()
 	fake.updateStatusMutex.RLock()
 	defer fake.updateStatusMutex.RUnlock()
+	fake.updateTerminationReasonMutex.RLock()
+	defer fake.updateTerminationReasonMutex.RUnlock()
 	copiedInvocations := map[string][][]interface{}{}
 	for key, value := range fake.invocations {
 		copiedInvocations[key] = value
@@ -0,0 +1,232 @@
+package execsrv
+
+import (
+	"bufio"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/unweave/unweave-v1/api/types"
+)
+
+// maxLogLineSize is the longest log line that can be ingested, longer lines fail the batch.
+const maxLogLineSize = 256 * 1024
+
+// ExecLogDriver stores the output of exec commands shipped from their nodes.
+type ExecLogDriver interface {
+	// AppendLogs stores the lines after the ones previously appended for the exec.
+	AppendLogs(ctx context.Context, execID string, logs []types.ExecLogEntry) error
+	// GetLogs returns the lines of the exec written after since, in the order they were
+	// appended, and the cursor after them. Only the lines appended after the cursor are
+	// read, the empty cursor reads them all. Execs without logs have none.
+	GetLogs(ctx context.Context, execID string, since time.Time, cursor string) ([]types.ExecLogEntry, string, error)
+}
+
+// LogIngestToken is the token the node of the exec authenticates with to ship its logs.
+// Drivers that ship logs must be configured with the same key as the LogService.
+func LogIngestToken(key []byte, execID string) string {
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(execID))
+
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// LogService ingests the logs shipped from exec nodes and serves them back. It works for
+// execs of any provider, but only drivers configured to ship logs produce any.
+type LogService struct {
+	store        Store
+	driver       ExecLogDriver
+	key          []byte
+	pollInterval time.Duration
+}
+
+// NewLogService returns a LogService that authenticates nodes with tokens derived from
+// ingestKey, see LogIngestToken. Ingestion is disabled if the key is empty.
+func NewLogService(store Store, driver ExecLogDriver, ingestKey []byte) *LogService {
+	return &LogService{
+		store:        store,
+		driver:       driver,
+		key:          ingestKey,
+		pollInterval: time.Second,
+	}
+}
+
+// Ingest stores the lines read from r as written to stream. Each line is prefixed with
+// its RFC 3339 timestamp, as written by `docker logs --timestamps`. Lines without one are
+// timestamped when they're ingested.
+func (s *LogService) Ingest(ctx context.Context, execID, token, stream string, r io.Reader) error {
+	if len(s.key) == 0 || !hmac.Equal([]byte(token), []byte(LogIngestToken(s.key, execID))) {
+		return &types.Error{
+			Code:    http.StatusUnauthorized,
+			Message: "Invalid log ingest token",
+		}
+	}
+
+	if stream != types.ExecLogStdout && stream != types.ExecLogStderr {
+		return &types.Error{
+			Code:       http.StatusBadRequest,
+			Message:    fmt.Sprintf("Invalid log stream %q", stream),
+			Suggestion: fmt.Sprintf("Use %q or %q", types.ExecLogStdout, types.ExecLogStderr),
+		}
+	}
+
+	exec, err := s.get(execID)
+	if err != nil {
+		return err
+	}
+
+	logs, err := parseLogLines(stream, r)
+	if err != nil {
+		return &types.Error{
+			Code:    http.StatusBadRequest,
+			Message: "Invalid log lines",
+			Err:     err,
+		}
+	}
+
+	if len(logs) == 0 {
+		return nil
+	}
+
+	if err = s.driver.AppendLogs(ctx, exec.ID, logs); err != nil {
+		return fmt.Errorf("append logs: %w", err)
+	}
+
+	return nil
+}
+
+// Logs returns the lines the exec wrote after since.
+func (s *LogService) Logs(ctx context.Context, ref string, since time.Time) ([]types.ExecLogEntry, error) {
+	exec, err := s.get(ref)
+	if err != nil {
+		return nil, err
+	}
+
+	logs, _, err := s.driver.GetLogs(ctx, exec.ID, since, "")
+	if err != nil {
+		return nil, fmt.Errorf("get logs: %w", err)
+	}
+
+	return logs, nil
+}
+
+// Follow calls send with the lines the exec wrote after since and then with new lines as
+// they're shipped. It returns once the exec reaches a terminal state, send fails or the
+// context is cancelled.
+func (s *LogService) Follow(
+	ctx context.Context,
+	ref string,
+	since time.Time,
+	send func([]types.ExecLogEntry) error,
+) error {
+	ticker := time.NewTicker(s.pollInterval)
+	defer ticker.Stop()
+
+	// Streams are shipped separately and can arrive out of timestamp order, so moving
+	// since forward could skip lines. The cursor follows the order lines were appended in.
+	var cursor string
+
+	for {
+		// Read the exec before its logs so nothing shipped before it terminated is missed.
+		exec, err := s.get(ref)
+		if err != nil {
+			return err
+		}
+
+		var logs []types.ExecLogEntry
+
+		logs, cursor, err = s.driver.GetLogs(ctx, exec.ID, since, cursor)
+		if err != nil {
+			return fmt.Errorf("get logs: %w", err)
+		}
+
+		if len(logs) > 0 {
+			if err = send(logs); err != nil {
+				return err
+			}
+		}
+
+		if exec.Status.IsTerminal() {
+			return nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+func (s *LogService) get(ref string) (types.Exec, error) {
+	exec, err := s.store.Get(ref)
+	if err != nil {
+		if errors.Is(err, ErrNotFound) {
+			return types.Exec{}, &types.Error{
+				Code:       http.StatusNotFound,
+				Message:    "Session not found",
+				Suggestion: "Make sure the session id is valid",
+			}
+		}
+		return types.Exec{}, fmt.Errorf("get exec: %w", err)
+	}
+
+	return exec, nil
+}
+
+func parseLogLines(stream string, r io.Reader) ([]types.ExecLogEntry, error) {
+	var logs []types.ExecLogEntry
+
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), maxLogLineSize)
+
+	now := time.Now()
+
+	for scanner.Scan() {
+		entry := types.ExecLogEntry{TimeStamp: now, Stream: stream, Message: scanner.Text()}
+
+		if prefix, msg, ok := strings.Cut(entry.Message, " "); ok {
+			if ts, err := time.Parse(time.RFC3339Nano, prefix); err == nil {
+				entry.TimeStamp = ts
+				entry.Message = msg
+			}
+		}
+
+		logs = append(logs, entry)
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	return logs, nil
+}
+
+// decodeLogs decodes the JSON lines logs read from r written after since.
+func decodeLogs(r io.Reader, since time.Time) ([]types.ExecLogEntry, error) {
+	var logs []types.ExecLogEntry
+
+	dec := json.NewDecoder(r)
+
+	for {
+		var entry types.ExecLogEntry
+		if err := dec.Decode(&entry); err != nil {
+			if errors.Is(err, io.EOF) {
+				return logs, nil
+			}
+			return nil, fmt.Errorf("failed to unmarshal exec log: %w", err)
+		}
+
+		if entry.TimeStamp.After(since) {
+			logs = append(logs, entry)
+		}
+	}
+}
@@ -0,0 +1,218 @@
+package execsrv
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/unweave/unweave-v1/api/types"
+	"github.com/unweave/unweave-v1/blobstore"
+	"github.com/unweave/unweave-v1/tools/random"
+)
+
+// BlobLogDriver is an ExecLogDriver that stores every appended batch of logs as a JSON
+// lines object in a blob store under prefix/<execID>/. Objects are named by the time they
+// were appended so listing them returns the batches in order.
+//
+// Batches are named before they are uploaded, so a slow upload can land after batches
+// named later than it were already read. The cursor holds the keys of the batches read
+// within lateArrivalWindow of the newest one, reads list again from the start of the
+// window and skip the batches in the cursor. Uploads slower than the window are missed.
+type BlobLogDriver struct {
+	store  blobstore.Store
+	prefix string
+}
+
+// lateArrivalWindow is how long after being named a batch can land and still be read.
+const lateArrivalWindow = time.Minute
+
+func NewBlobLogDriver(store blobstore.Store, prefix string) *BlobLogDriver {
+	return &BlobLogDriver{store: store, prefix: prefix}
+}
+
+func (d *BlobLogDriver) AppendLogs(ctx context.Context, execID string, logs []types.ExecLogEntry) error {
+	buf := &bytes.Buffer{}
+	enc := json.NewEncoder(buf)
+
+	for _, entry := range logs {
+		if err := enc.Encode(entry); err != nil {
+			return fmt.Errorf("failed to marshal exec log: %w", err)
+		}
+	}
+
+	// The random suffix keeps batches appended at the same time from overwriting each other.
+	name := fmt.Sprintf("%020d-%s.jsonl", time.Now().UnixNano(), random.GenerateRandomLower(6))
+
+	if err := d.store.Upload(ctx, path.Join(d.dir(execID), name), buf, true); err != nil {
+		return fmt.Errorf("failed to upload exec logs: %w", err)
+	}
+
+	return nil
+}
+
+func (d *BlobLogDriver) GetLogs(
+	ctx context.Context,
+	execID string,
+	since time.Time,
+	cursor string,
+) ([]types.ExecLogEntry, string, error) {
+	dir := d.dir(execID)
+	seen := parseBlobCursor(cursor)
+
+	var startAfter string
+	if len(seen) > 0 {
+		// Keys sort after the bare timestamp they start with.
+		start := newestBatch(seen) - lateArrivalWindow.Nanoseconds()
+		startAfter = path.Join(dir, fmt.Sprintf("%020d", start))
+	}
+
+	listed, err := d.store.ListAfter(ctx, dir+"/", startAfter)
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return nil, cursor, nil
+		}
+		return nil, "", fmt.Errorf("failed to list exec logs: %w", err)
+	}
+
+	var keys []string
+
+	for _, key := range listed {
+		// Some stores list keys relative to the prefix.
+		if !strings.HasPrefix(key, dir+"/") {
+			key = path.Join(dir, key)
+		}
+		if _, ok := seen[key]; !ok {
+			keys = append(keys, key)
+		}
+	}
+
+	if len(keys) == 0 {
+		return nil, cursor, nil
+	}
+
+	sort.Strings(keys)
+
+	tmp, err := os.MkdirTemp("", "unweave-logs-")
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to create temp dir: %w", err)
+	}
+	defer os.RemoveAll(tmp)
+
+	for _, key := range keys {
+		if err = d.store.Download(ctx, dir, key, tmp, true); err != nil {
+			return nil, "", fmt.Errorf("failed to download exec logs %q: %w", key, err)
+		}
+	}
+
+	// Stores lay out downloads differently, the batch names are enough to order them.
+	var files []string
+
+	err = filepath.WalkDir(tmp, func(p string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if !entry.IsDir() {
+			files = append(files, p)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to read exec logs: %w", err)
+	}
+
+	sort.Slice(files, func(i, j int) bool {
+		return filepath.Base(files[i]) < filepath.Base(files[j])
+	})
+
+	var logs []types.ExecLogEntry
+
+	for _, file := range files {
+		batch, err := d.readBatch(file, since)
+		if err != nil {
+			return nil, "", err
+		}
+		logs = append(logs, batch...)
+	}
+
+	for _, key := range keys {
+		seen[key] = struct{}{}
+	}
+
+	return logs, formatBlobCursor(seen), nil
+}
+
+func (d *BlobLogDriver) readBatch(file string, since time.Time) ([]types.ExecLogEntry, error) {
+	f, err := os.Open(file)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open exec logs: %w", err)
+	}
+	defer f.Close()
+
+	return decodeLogs(f, since)
+}
+
+// parseBlobCursor returns the keys of the batches read within the late arrival window.
+// Cursors holding a single key, the last batch read, are valid too.
+func parseBlobCursor(cursor string) map[string]struct{} {
+	seen := make(map[string]struct{})
+
+	if cursor == "" {
+		return seen
+	}
+
+	for _, key := range strings.Split(cursor, ",") {
+		seen[key] = struct{}{}
+	}
+
+	return seen
+}
+
+// formatBlobCursor drops the keys that are too old to be listed again and joins the rest.
+func formatBlobCursor(seen map[string]struct{}) string {
+	oldest := newestBatch(seen) - lateArrivalWindow.Nanoseconds()
+
+	keys := make([]string, 0, len(seen))
+
+	for key := range seen {
+		if batchTime(key) >= oldest {
+			keys = append(keys, key)
+		}
+	}
+
+	sort.Strings(keys)
+
+	return strings.Join(keys, ",")
+}
+
+func newestBatch(keys map[string]struct{}) int64 {
+	var newest int64
+
+	for key := range keys {
+		if t := batchTime(key); t > newest {
+			newest = t
+		}
+	}
+
+	return newest
+}
+
+// batchTime returns the time in nanoseconds the batch was named at, see AppendLogs.
+func batchTime(key string) int64 {
+	name, _, _ := strings.Cut(path.Base(key), "-")
+	t, _ := strconv.ParseInt(name, 10, 64)
+	return t
+}
+
+func (d *BlobLogDriver) dir(execID string) string {
+	return path.Join(d.prefix, execID)
+}
@@ -0,0 +1,109 @@
+package execsrv
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/unweave/unweave-v1/api/types"
+)
+
+// FsLogDriver is an ExecLogDriver that appends the logs of each exec to a JSON lines file
+// in a directory on the filesystem. It's only suitable for a single API instance.
+type FsLogDriver struct {
+	dir string
+	mu  sync.Mutex
+}
+
+func NewFsLogDriver(dir string) *FsLogDriver {
+	return &FsLogDriver{dir: dir}
+}
+
+func (d *FsLogDriver) AppendLogs(_ context.Context, execID string, logs []types.ExecLogEntry) error {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	if err := os.MkdirAll(d.dir, 0755); err != nil {
+		return fmt.Errorf("failed to create exec logs directory: %w", err)
+	}
+
+	f, err := os.OpenFile(d.path(execID), os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		return fmt.Errorf("failed to open exec log file: %w", err)
+	}
+	defer f.Close()
+
+	w := bufio.NewWriter(f)
+	enc := json.NewEncoder(w)
+
+	for _, entry := range logs {
+		if err = enc.Encode(entry); err != nil {
+			return fmt.Errorf("failed to marshal exec log: %w", err)
+		}
+	}
+
+	if err = w.Flush(); err != nil {
+		return fmt.Errorf("failed to write exec logs: %w", err)
+	}
+
+	return nil
+}
+
+// GetLogs reads the exec's log file from the byte offset in the cursor.
+func (d *FsLogDriver) GetLogs(
+	_ context.Context,
+	execID string,
+	since time.Time,
+	cursor string,
+) ([]types.ExecLogEntry, string, error) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	var offset int64
+
+	if cursor != "" {
+		var err error
+		if offset, err = strconv.ParseInt(cursor, 10, 64); err != nil {
+			return nil, "", fmt.Errorf("invalid exec log cursor %q: %w", cursor, err)
+		}
+	}
+
+	f, err := os.Open(d.path(execID))
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return nil, cursor, nil
+		}
+		return nil, "", fmt.Errorf("failed to open exec log file: %w", err)
+	}
+	defer f.Close()
+
+	info, err := f.Stat()
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to stat exec log file: %w", err)
+	}
+
+	if _, err = f.Seek(offset, io.SeekStart); err != nil {
+		return nil, "", fmt.Errorf("failed to seek exec log file: %w", err)
+	}
+
+	// Appends hold the lock, so the file only has whole lines up to its size.
+	logs, err := decodeLogs(io.LimitReader(f, info.Size()-offset), since)
+	if err != nil {
+		return nil, "", err
+	}
+
+	return logs, strconv.FormatInt(info.Size(), 10), nil
+}
+
+func (d *FsLogDriver) path(execID string) string {
+	return filepath.Join(d.dir, filepath.Base(execID)+".jsonl")
+}
@@ -0,0 +1,171 @@
+package execsrv_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"github.com/unweave/unweave-v1/api/types"
+	"github.com/unweave/unweave-v1/blobstore"
+	"github.com/unweave/unweave-v1/services/execsrv"
+)
+
+func TestLogService(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	key := []byte("ingest-key")
+
+	store := execsrv.NewMemoryStore()
+	pub := "ssh-ed25519 AAAA"
+	exec := types.Exec{
+		ID:     "exc_logs",
+		Name:   "logs",
+		Status: types.StatusRunning,
+		Keys:   []types.SSHKey{{Name: "key", PublicKey: &pub}},
+	}
+	require.NoError(t, store.Create("pr_logs", exec))
+
+	srv := execsrv.NewLogService(store, execsrv.NewFsLogDriver(t.TempDir()), key)
+	token := execsrv.LogIngestToken(key, exec.ID)
+
+	var e *types.Error
+
+	err := srv.Ingest(ctx, exec.ID, "wrong", types.ExecLogStdout, strings.NewReader("hi\n"))
+	require.True(t, errors.As(err, &e))
+	require.Equal(t, http.StatusUnauthorized, e.Code)
+
+	err = srv.Ingest(ctx, exec.ID, token, "stdin", strings.NewReader("hi\n"))
+	require.True(t, errors.As(err, &e))
+	require.Equal(t, http.StatusBadRequest, e.Code)
+
+	stdout := "2023-07-01T10:00:00.5Z epoch 1\n2023-07-01T10:00:02Z epoch 2\n"
+	require.NoError(t, srv.Ingest(ctx, exec.ID, token, types.ExecLogStdout, strings.NewReader(stdout)))
+	require.NoError(t, srv.Ingest(ctx, exec.ID, token, types.ExecLogStderr, strings.NewReader("no timestamp")))
+
+	logs, err := srv.Logs(ctx, exec.Name, time.Time{})
+	require.NoError(t, err)
+	require.Len(t, logs, 3)
+	require.Equal(t, "epoch 1", logs[0].Message)
+	require.Equal(t, time.Date(2023, 7, 1, 10, 0, 0, 5e8, time.UTC), logs[0].TimeStamp.UTC())
+	require.Equal(t, types.ExecLogStderr, logs[2].Stream)
+	require.Equal(t, "no timestamp", logs[2].Message)
+
+	logs, err = srv.Logs(ctx, exec.ID, time.Date(2023, 7, 1, 10, 0, 1, 0, time.UTC))
+	require.NoError(t, err)
+	require.Len(t, logs, 2, "should only return lines after since")
+	require.Equal(t, "epoch 2", logs[0].Message)
+
+	_, err = srv.Logs(ctx, "exc_missing", time.Time{})
+	require.True(t, errors.As(err, &e))
+	require.Equal(t, http.StatusNotFound, e.Code)
+
+	require.NoError(t, store.UpdateStatus(exec.ID, types.StatusTerminated, time.Time{}, time.Now()))
+
+	var followed []types.ExecLogEntry
+
+	err = srv.Follow(ctx, exec.ID, time.Time{}, func(logs []types.ExecLogEntry) error {
+		followed = append(followed, logs...)
+		return nil
+	})
+	require.NoError(t, err, "should stop following terminated execs")
+	require.Len(t, followed, 3)
+}
+
+func TestBlobLogDriver(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	driver := execsrv.NewBlobLogDriver(blobstore.NewLocalBlobStore(t.TempDir()), "exec-logs")
+
+	logs, cursor, err := driver.GetLogs(ctx, "exc_blob", time.Time{}, "")
+	require.NoError(t, err)
+	require.Empty(t, logs)
+	require.Empty(t, cursor)
+
+	start := time.Now()
+
+	for i, msg := range []string{"first", "second", "third"} {
+		entry := types.ExecLogEntry{TimeStamp: start.Add(time.Duration(i) * time.Second), Stream: types.ExecLogStdout, Message: msg}
+		require.NoError(t, driver.AppendLogs(ctx, "exc_blob", []types.ExecLogEntry{entry}))
+	}
+
+	logs, cursor, err = driver.GetLogs(ctx, "exc_blob", start, "")
+	require.NoError(t, err)
+	require.Len(t, logs, 2)
+	require.Equal(t, "second", logs[0].Message)
+	require.Equal(t, "third", logs[1].Message)
+
+	logs, cursor, err = driver.GetLogs(ctx, "exc_blob", time.Time{}, cursor)
+	require.NoError(t, err)
+	require.Empty(t, logs, "should not read batches before the cursor again")
+	require.NotEmpty(t, cursor)
+
+	entry := types.ExecLogEntry{TimeStamp: start.Add(time.Minute), Stream: types.ExecLogStderr, Message: "fourth"}
+	require.NoError(t, driver.AppendLogs(ctx, "exc_blob", []types.ExecLogEntry{entry}))
+
+	logs, _, err = driver.GetLogs(ctx, "exc_blob", time.Time{}, cursor)
+	require.NoError(t, err)
+	require.Len(t, logs, 1)
+	require.Equal(t, "fourth", logs[0].Message)
+}
+
+func TestBlobLogDriver_LateArrivals(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	store := blobstore.NewLocalBlobStore(t.TempDir())
+	driver := execsrv.NewBlobLogDriver(store, "exec-logs")
+
+	// A batch named before the one that gets read first, but whose upload finishes later.
+	named := time.Now()
+
+	entry := types.ExecLogEntry{TimeStamp: time.Now(), Stream: types.ExecLogStdout, Message: "on time"}
+	require.NoError(t, driver.AppendLogs(ctx, "exc_late", []types.ExecLogEntry{entry}))
+
+	logs, cursor, err := driver.GetLogs(ctx, "exc_late", time.Time{}, "")
+	require.NoError(t, err)
+	require.Len(t, logs, 1)
+
+	late := fmt.Sprintf("exec-logs/exc_late/%020d-late.jsonl", named.UnixNano())
+	body, err := json.Marshal(types.ExecLogEntry{TimeStamp: time.Now(), Stream: types.ExecLogStdout, Message: "late"})
+	require.NoError(t, err)
+	require.NoError(t, store.Upload(ctx, late, bytes.NewReader(body), true))
+
+	logs, cursor, err = driver.GetLogs(ctx, "exc_late", time.Time{}, cursor)
+	require.NoError(t, err)
+	require.Len(t, logs, 1, "should read batches that landed after later ones were read")
+	require.Equal(t, "late", logs[0].Message)
+
+	logs, _, err = driver.GetLogs(ctx, "exc_late", time.Time{}, cursor)
+	require.NoError(t, err)
+	require.Empty(t, logs, "should not read batches in the window twice")
+}
+
+func TestFsLogDriver_Cursor(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	driver := execsrv.NewFsLogDriver(t.TempDir())
+
+	logs, cursor, err := driver.GetLogs(ctx, "exc_fs", time.Time{}, "")
+	require.NoError(t, err)
+	require.Empty(t, logs)
+
+	for _, msg := range []string{"first", "second"} {
+		entry := types.ExecLogEntry{TimeStamp: time.Now(), Stream: types.ExecLogStdout, Message: msg}
+		require.NoError(t, driver.AppendLogs(ctx, "exc_fs", []types.ExecLogEntry{entry}))
+
+		logs, cursor, err = driver.GetLogs(ctx, "exc_fs", time.Time{}, cursor)
+		require.NoError(t, err)
+		require.Len(t, logs, 1, "should only read the lines after the cursor")
+		require.Equal(t, msg, logs[0].Message)
+	}
+}
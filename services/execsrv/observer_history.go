@@ -0,0 +1,101 @@
+package execsrv
+
+import (
+	"sync"
+
+	"github.com/rs/zerolog/log"
+	"github.com/unweave/unweave-v1/api/types"
+)
+
+// HistoryObserverFactory creates observers that record exec transitions in the Store so
+// that they can be served as the exec's history. Only changes are recorded, repeated
+// updates with the same status and no error are dropped.
+type HistoryObserverFactory struct {
+	store Store
+}
+
+func NewHistoryObserverFactory(store Store) *HistoryObserverFactory {
+	return &HistoryObserverFactory{store: store}
+}
+
+// State returns the factory to register with WithStateObserver.
+func (f *HistoryObserverFactory) State() StateObserverFactory {
+	return StateObserverFactoryFunc(func(exec types.Exec) StateObserver {
+		return &historyStateObserver{historyRecorder{execID: exec.ID, source: "state-informer", store: f.store}}
+	})
+}
+
+// Heartbeat returns the factory to register with WithHeartbeatObserver.
+func (f *HistoryObserverFactory) Heartbeat() HeartbeatObserverFactory {
+	return HeartbeatObserverFactoryFunc(func(exec types.Exec) HeartbeatObserver {
+		return &historyHeartbeatObserver{historyRecorder{execID: exec.ID, source: "heartbeat-informer", store: f.store}}
+	})
+}
+
+type historyRecorder struct {
+	execID string
+	source string
+	store  Store
+
+	mu         sync.Mutex
+	prevStatus types.Status
+}
+
+func (r *historyRecorder) record(status types.Status, err error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if status == r.prevStatus && err == nil {
+		return
+	}
+	r.prevStatus = status
+
+	event := types.ExecEvent{
+		ExecID: r.execID,
+		Status: status,
+		Source: r.source,
+	}
+	if err != nil {
+		event.Error = err.Error()
+	}
+
+	if err := r.store.AddEvent(event); err != nil {
+		log.Warn().
+			Err(err).
+			Str(types.ExecIDCtxKey, r.execID).
+			Msgf("Failed to record %s event", r.source)
+	}
+}
+
+type historyStateObserver struct {
+	historyRecorder
+}
+
+func (o *historyStateObserver) ID() string {
+	return o.execID + "/history"
+}
+
+func (o *historyStateObserver) ExecID() string {
+	return o.execID
+}
+
+func (o *historyStateObserver) Name() string {
+	return "history-observer"
+}
+
+func (o *historyStateObserver) Update(state State) State {
+	o.record(state.Status, state.Error)
+	return state
+}
+
+type historyHeartbeatObserver struct {
+	historyRecorder
+}
+
+func (o *historyHeartbeatObserver) ID() string {
+	return o.execID + "/history"
+}
+
+func (o *historyHeartbeatObserver) Update(heartbeat Heartbeat) {
+	o.record(heartbeat.Status, heartbeat.Error)
+}
@@ -0,0 +1,43 @@
+package execsrv_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/unweave/unweave-v1/api/types"
+	"github.com/unweave/unweave-v1/services/execsrv"
+)
+
+func TestHistoryObserver(t *testing.T) {
+	t.Parallel()
+
+	store := execsrv.NewMemoryStore()
+	exec := newStatsTestExec(t, store)
+	f := execsrv.NewHistoryObserverFactory(store)
+
+	state := f.State().New(exec)
+	heartbeat := f.Heartbeat().New(exec)
+
+	state.Update(execsrv.State{Status: types.StatusInitializing})
+	state.Update(execsrv.State{Status: types.StatusRunning})
+	state.Update(execsrv.State{Status: types.StatusRunning})
+	heartbeat.Update(execsrv.Heartbeat{ExecID: exec.ID, Status: types.StatusRunning})
+	heartbeat.Update(execsrv.Heartbeat{ExecID: exec.ID, Status: types.StatusRunning})
+	heartbeat.Update(execsrv.Heartbeat{
+		ExecID: exec.ID,
+		Status: types.StatusUnknown,
+		Error:  errors.New("no heartbeat after 11 attempts"),
+	})
+
+	events, err := store.ListEvents(exec.ID)
+	require.NoError(t, err)
+	require.Len(t, events, 4, "repeated updates should not be recorded")
+
+	require.Equal(t, types.StatusInitializing, events[0].Status)
+	require.Equal(t, "state-informer", events[0].Source)
+	require.Equal(t, types.StatusRunning, events[1].Status)
+	require.Equal(t, "heartbeat-informer", events[2].Source)
+	require.Equal(t, types.StatusUnknown, events[3].Status)
+	require.Equal(t, "no heartbeat after 11 attempts", events[3].Error)
+}
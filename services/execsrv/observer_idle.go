@@ -0,0 +1,201 @@
+package execsrv
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sync"
+	"time"
+
+	"github.com/rs/zerolog/log"
+	"github.com/unweave/unweave-v1/api/types"
+)
+
+// IdleObserverFactory creates observers that terminate execs once their CPU and GPU
+// utilisation has stayed below a threshold for longer than a timeout. The stats and
+// heartbeat observers it creates for an exec share the same idle tracking.
+//
+// The policy set on an exec overrides the factory defaults field by field. Execs that end
+// up without a timeout or a threshold are never terminated.
+type IdleObserverFactory struct {
+	srv      Service
+	store    Store
+	defaults types.IdlePolicy
+
+	mu       sync.Mutex
+	trackers map[string]*idleTracker
+
+	// Now returns the current time. Defaults to time.Now.
+	Now func() time.Time
+}
+
+func NewIdleObserverFactory(srv Service, store Store, defaults types.IdlePolicy) *IdleObserverFactory {
+	return &IdleObserverFactory{
+		srv:      srv,
+		store:    store,
+		defaults: defaults,
+		trackers: make(map[string]*idleTracker),
+		Now:      time.Now,
+	}
+}
+
+// Stats returns the factory to register with WithStatsObserver.
+func (f *IdleObserverFactory) Stats() StatsObserverFactory {
+	return StatsObserverFactoryFunc(func(exec types.Exec) StatsObserver {
+		return &idleStatsObserver{f.tracker(exec)}
+	})
+}
+
+// Heartbeat returns the factory to register with WithHeartbeatObserver.
+func (f *IdleObserverFactory) Heartbeat() HeartbeatObserverFactory {
+	return HeartbeatObserverFactoryFunc(func(exec types.Exec) HeartbeatObserver {
+		return &idleHeartbeatObserver{f.tracker(exec)}
+	})
+}
+
+func (f *IdleObserverFactory) tracker(exec types.Exec) *idleTracker {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if t, ok := f.trackers[exec.ID]; ok {
+		return t
+	}
+
+	policy := f.defaults
+	if exec.IdlePolicy != nil {
+		if exec.IdlePolicy.Timeout != 0 {
+			policy.Timeout = exec.IdlePolicy.Timeout
+		}
+		if exec.IdlePolicy.Threshold != 0 {
+			policy.Threshold = exec.IdlePolicy.Threshold
+		}
+	}
+
+	t := &idleTracker{execID: exec.ID, policy: policy, factory: f}
+	f.trackers[exec.ID] = t
+
+	return t
+}
+
+func (f *IdleObserverFactory) forget(execID string) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	delete(f.trackers, execID)
+}
+
+// idleTracker records since when an exec has been idle and terminates it once it has been
+// idle for longer than its policy allows.
+type idleTracker struct {
+	execID  string
+	policy  types.IdlePolicy
+	factory *IdleObserverFactory
+
+	mu         sync.Mutex
+	idleSince  time.Time
+	terminated bool
+}
+
+func (t *idleTracker) ID() string {
+	return t.execID
+}
+
+func (t *idleTracker) enabled() bool {
+	return t.policy.Timeout > 0 && t.policy.Threshold > 0
+}
+
+func (t *idleTracker) observeStats(stats Stats) {
+	if !t.enabled() {
+		return
+	}
+
+	t.mu.Lock()
+
+	if t.terminated {
+		t.mu.Unlock()
+		return
+	}
+
+	now := t.factory.Now()
+
+	if math.Max(stats.CPU, stats.GPU) >= t.policy.Threshold {
+		t.idleSince = time.Time{}
+		t.mu.Unlock()
+		return
+	}
+
+	if t.idleSince.IsZero() {
+		t.idleSince = now
+	}
+
+	idleFor := now.Sub(t.idleSince)
+	if idleFor < time.Duration(t.policy.Timeout)*time.Second {
+		t.mu.Unlock()
+		return
+	}
+
+	t.terminated = true
+	t.mu.Unlock()
+
+	t.terminate(idleFor)
+}
+
+func (t *idleTracker) observeHeartbeat(heartbeat Heartbeat) {
+	switch heartbeat.Status {
+	case types.StatusRunning:
+		return
+	case types.StatusTerminated, types.StatusError, types.StatusFailed, types.StatusSuccess:
+		t.factory.forget(t.execID)
+	case types.StatusPending, types.StatusInitializing, types.StatusStopped, types.StatusUnknown:
+	}
+
+	// An exec that isn't running can't be idle, start counting again once it is.
+	t.mu.Lock()
+	t.idleSince = time.Time{}
+	t.mu.Unlock()
+}
+
+func (t *idleTracker) terminate(idleFor time.Duration) {
+	reason := fmt.Sprintf(
+		"idle: cpu and gpu utilisation below %g%% for %s",
+		t.policy.Threshold,
+		idleFor.Round(time.Second),
+	)
+
+	log.Info().
+		Str(types.ExecIDCtxKey, t.execID).
+		Str(types.ObserverCtxKey, "idle-observer").
+		Msgf("Terminating exec, %s", reason)
+
+	if err := t.factory.store.UpdateTerminationReason(t.execID, reason); err != nil {
+		log.Warn().Err(err).Str(types.ExecIDCtxKey, t.execID).Msg("Failed to record termination reason")
+	}
+
+	if err := t.factory.srv.Terminate(context.Background(), t.execID); err != nil {
+		log.Error().Err(err).Str(types.ExecIDCtxKey, t.execID).Msg("Failed to terminate idle exec")
+
+		// Try again on the next stats update.
+		t.mu.Lock()
+		t.terminated = false
+		t.mu.Unlock()
+
+		return
+	}
+
+	t.factory.forget(t.execID)
+}
+
+type idleStatsObserver struct {
+	*idleTracker
+}
+
+func (o *idleStatsObserver) Update(stats Stats) {
+	o.observeStats(stats)
+}
+
+type idleHeartbeatObserver struct {
+	*idleTracker
+}
+
+func (o *idleHeartbeatObserver) Update(heartbeat Heartbeat) {
+	o.observeHeartbeat(heartbeat)
+}
@@ -0,0 +1,112 @@
+package execsrv_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"github.com/unweave/unweave-v1/api/types"
+	"github.com/unweave/unweave-v1/services/execsrv"
+	"github.com/unweave/unweave-v1/services/execsrv/internal/execsrvfakes"
+)
+
+func TestIdleObserver(t *testing.T) {
+	t.Parallel()
+
+	newFactory := func(t *testing.T, defaults types.IdlePolicy) (*execsrv.IdleObserverFactory, *execsrvfakes.FakeService, execsrv.Store, *time.Time) {
+		t.Helper()
+
+		store := execsrv.NewMemoryStore()
+		srv := new(execsrvfakes.FakeService)
+		now := time.Now()
+
+		f := execsrv.NewIdleObserverFactory(srv, store, defaults)
+		f.Now = func() time.Time { return now }
+
+		return f, srv, store, &now
+	}
+
+	t.Run("terminates exec idle for longer than the timeout", func(t *testing.T) {
+		t.Parallel()
+
+		f, srv, store, now := newFactory(t, types.IdlePolicy{Timeout: 60, Threshold: 5})
+		exec := newStatsTestExec(t, store)
+		observer := f.Stats().New(exec)
+
+		observer.Update(execsrv.Stats{CPU: 1, GPU: 2})
+		*now = now.Add(30 * time.Second)
+		observer.Update(execsrv.Stats{CPU: 1, GPU: 2})
+		require.Zero(t, srv.TerminateCallCount())
+
+		*now = now.Add(31 * time.Second)
+		observer.Update(execsrv.Stats{CPU: 1, GPU: 2})
+		require.Equal(t, 1, srv.TerminateCallCount())
+
+		_, id := srv.TerminateArgsForCall(0)
+		require.Equal(t, exec.ID, id)
+
+		got, err := store.Get(exec.ID)
+		require.NoError(t, err)
+		require.Contains(t, got.TerminationReason, "idle")
+	})
+
+	t.Run("activity resets the idle window", func(t *testing.T) {
+		t.Parallel()
+
+		f, srv, store, now := newFactory(t, types.IdlePolicy{Timeout: 60, Threshold: 5})
+		observer := f.Stats().New(newStatsTestExec(t, store))
+
+		observer.Update(execsrv.Stats{CPU: 1})
+		*now = now.Add(50 * time.Second)
+		observer.Update(execsrv.Stats{GPU: 90})
+		*now = now.Add(50 * time.Second)
+		observer.Update(execsrv.Stats{CPU: 1})
+
+		require.Zero(t, srv.TerminateCallCount())
+	})
+
+	t.Run("heartbeat for a non running exec resets the idle window", func(t *testing.T) {
+		t.Parallel()
+
+		f, srv, store, now := newFactory(t, types.IdlePolicy{Timeout: 60, Threshold: 5})
+		exec := newStatsTestExec(t, store)
+		stats := f.Stats().New(exec)
+		heartbeat := f.Heartbeat().New(exec)
+
+		stats.Update(execsrv.Stats{CPU: 1})
+		*now = now.Add(50 * time.Second)
+		heartbeat.Update(execsrv.Heartbeat{ExecID: exec.ID, Status: types.StatusInitializing})
+		*now = now.Add(50 * time.Second)
+		stats.Update(execsrv.Stats{CPU: 1})
+
+		require.Zero(t, srv.TerminateCallCount())
+	})
+
+	t.Run("exec policy overrides the defaults", func(t *testing.T) {
+		t.Parallel()
+
+		f, srv, store, now := newFactory(t, types.IdlePolicy{})
+		exec := newStatsTestExec(t, store)
+		exec.IdlePolicy = &types.IdlePolicy{Timeout: 10, Threshold: 50}
+		observer := f.Stats().New(exec)
+
+		observer.Update(execsrv.Stats{CPU: 40})
+		*now = now.Add(11 * time.Second)
+		observer.Update(execsrv.Stats{CPU: 40})
+
+		require.Equal(t, 1, srv.TerminateCallCount())
+	})
+
+	t.Run("no policy never terminates", func(t *testing.T) {
+		t.Parallel()
+
+		f, srv, store, now := newFactory(t, types.IdlePolicy{})
+		observer := f.Stats().New(newStatsTestExec(t, store))
+
+		observer.Update(execsrv.Stats{})
+		*now = now.Add(24 * time.Hour)
+		observer.Update(execsrv.Stats{})
+
+		require.Zero(t, srv.TerminateCallCount())
+	})
+}
@@ -0,0 +1,76 @@
+package execsrv
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/rs/zerolog/log"
+	"github.com/unweave/unweave-v1/api/types"
+)
+
+// jobObserver finishes a job once the driver reports that its command exited. It's
+// registered by the ExecService for every job exec.
+type jobObserver struct {
+	exec types.Exec
+	srv  *ExecService
+}
+
+func (o *jobObserver) ID() string {
+	return o.exec.ID + "/job"
+}
+
+func (o *jobObserver) ExecID() string {
+	return o.exec.ID
+}
+
+func (o *jobObserver) Name() string {
+	return "job-observer"
+}
+
+func (o *jobObserver) Update(state State) State {
+	if state.Status == types.StatusSuccess || state.Status == types.StatusFailed {
+		o.srv.finishJob(context.Background(), o.exec.ID, state.Status)
+	}
+
+	return state
+}
+
+// finishJob records the exit code of a job whose command exited, terminates its node and
+// finishes the exec with status.
+func (s *ExecService) finishJob(ctx context.Context, id string, status types.Status) {
+	log.Info().
+		Str(types.ExecIDCtxKey, id).
+		Msgf("Finishing job with status %s", status)
+
+	event := types.ExecEvent{ExecID: id, Status: status, Source: "job"}
+
+	var exitCode *int
+
+	if driver, ok := s.driver.(ExitCodeDriver); ok {
+		code, err := driver.ExecExitCode(ctx, id)
+		if err != nil {
+			log.Warn().Err(err).Str(types.ExecIDCtxKey, id).Msg("Failed to get job exit code")
+		} else {
+			exitCode = &code
+		}
+	}
+
+	if exitCode != nil && *exitCode != 0 {
+		event.Error = fmt.Sprintf("command exited with code %d", *exitCode)
+	}
+
+	if err := s.driver.ExecTerminate(ctx, id); err != nil {
+		log.Error().Err(err).Str(types.ExecIDCtxKey, id).Msg("Failed to terminate finished job")
+		event.Error = fmt.Sprintf("failed to terminate node: %v", err)
+	}
+
+	if err := s.store.Finish(id, status, exitCode); err != nil {
+		log.Error().Err(err).Str(types.ExecIDCtxKey, id).Msg("Failed to finish job in store")
+	}
+
+	s.reaper.cancel(id)
+
+	if err := s.store.AddEvent(event); err != nil {
+		log.Warn().Err(err).Str(types.ExecIDCtxKey, id).Msg("Failed to record job result")
+	}
+}
@@ -0,0 +1,71 @@
+package execsrv
+
+import (
+	"sync"
+
+	"github.com/google/uuid"
+)
+
+// StateEvent is a state change of a single exec, as delivered by a StateStream.
+type StateEvent struct {
+	ExecID string
+	State  State
+}
+
+// StateStream fans in the state changes of one or more execs into a single channel. Register
+// the observer returned by Observer on each exec's StateInformer and read the changes from
+// Events until the stream is closed.
+type StateStream struct {
+	id     string
+	events chan StateEvent
+	done   chan struct{}
+	once   sync.Once
+}
+
+func NewStateStream() *StateStream {
+	return &StateStream{
+		id:     uuid.NewString(),
+		events: make(chan StateEvent, 16),
+		done:   make(chan struct{}),
+	}
+}
+
+// Observer returns a StateObserver that forwards the state changes of the exec to the stream.
+func (s *StateStream) Observer(execID string) StateObserver {
+	return &streamObserver{execID: execID, stream: s}
+}
+
+func (s *StateStream) Events() <-chan StateEvent {
+	return s.events
+}
+
+// Close stops the stream. Observers that are still registered drop any further updates.
+func (s *StateStream) Close() {
+	s.once.Do(func() { close(s.done) })
+}
+
+type streamObserver struct {
+	execID string
+	stream *StateStream
+}
+
+func (o *streamObserver) ID() string {
+	return o.stream.id + "/" + o.execID
+}
+
+func (o *streamObserver) ExecID() string {
+	return o.execID
+}
+
+func (o *streamObserver) Name() string {
+	return "stream-observer"
+}
+
+func (o *streamObserver) Update(state State) State {
+	select {
+	case o.stream.events <- StateEvent{ExecID: o.execID, State: state}:
+	case <-o.stream.done:
+	}
+
+	return state
+}
@@ -0,0 +1,48 @@
+package execsrv_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"github.com/unweave/unweave-v1/api/types"
+	"github.com/unweave/unweave-v1/services/execsrv"
+)
+
+func TestStateStream(t *testing.T) {
+	t.Parallel()
+
+	stream := execsrv.NewStateStream()
+	a := stream.Observer("exc_a")
+	b := stream.Observer("exc_b")
+
+	require.NotEqual(t, a.ID(), b.ID())
+	require.Equal(t, a.ID(), stream.Observer("exc_a").ID(), "observers for the same exec should be interchangeable")
+
+	go a.Update(execsrv.State{Status: types.StatusRunning})
+
+	select {
+	case event := <-stream.Events():
+		require.Equal(t, "exc_a", event.ExecID)
+		require.Equal(t, types.StatusRunning, event.State.Status)
+	case <-time.After(time.Second):
+		t.Fatal("should have forwarded the state")
+	}
+
+	stream.Close()
+
+	// Fill the buffer, updates after close must not block the informer.
+	done := make(chan struct{})
+	go func() {
+		for i := 0; i < 32; i++ {
+			b.Update(execsrv.State{Status: types.StatusTerminated})
+		}
+		close(done)
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("updates should not block once the stream is closed")
+	}
+}
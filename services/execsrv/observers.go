@@ -38,6 +38,11 @@ func (o *stateObserver) Name() string {
 func (o *stateObserver) Update(state State) State {
 	log.Info().Str("exec", o.exec.ID).Msgf("State observer received state update: %s", state.Status)
 
+	// Finished jobs keep their final status, the job observer terminates their node.
+	if o.exec.Status == types.StatusSuccess || o.exec.Status == types.StatusFailed {
+		return state
+	}
+
 	switch state.Status {
 	case types.StatusRunning:
 		log.Info().
@@ -72,11 +77,28 @@ func (o *stateObserver) Update(state State) State {
 		if err := o.srv.Terminate(context.Background(), o.ExecID()); err != nil {
 			log.Warn().Err(err).Send()
 		}
+	case types.StatusStopped:
+		log.Info().
+			Str(types.ExecIDCtxKey, o.ExecID()).
+			Str(types.ObserverCtxKey, o.Name()).
+			Msg("Handling exec stop")
+
+		update := db.ExecStatusUpdateParams{
+			ID:     o.ExecID(),
+			Status: db.UnweaveExecStatus(types.StatusStopped),
+		}
+
+		if err := db.Q.ExecStatusUpdate(context.Background(), update); err != nil {
+			log.Warn().Err(err).Send()
+		}
+
+		o.exec.Status = types.StatusStopped
+	case types.StatusFailed,
+		types.StatusSuccess:
+		o.exec.Status = state.Status
 	case types.StatusPending,
 		types.StatusInitializing,
 		types.StatusError,
-		types.StatusFailed,
-		types.StatusSuccess,
 		types.StatusUnknown:
 	}
 
@@ -0,0 +1,30 @@
+package execsrv_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/unweave/unweave-v1/api/types"
+	"github.com/unweave/unweave-v1/services/execsrv"
+	"github.com/unweave/unweave-v1/services/execsrv/internal/execsrvfakes"
+)
+
+func TestStateObserver_KeepsFinalStatus(t *testing.T) {
+	t.Parallel()
+
+	for _, status := range []types.Status{types.StatusSuccess, types.StatusFailed} {
+		srv := new(execsrvfakes.FakeService)
+		observer := execsrv.NewStateObserverFactory(srv).New(types.Exec{ID: "exc_1", Status: types.StatusRunning})
+
+		observer.Update(execsrv.State{Status: status})
+		observer.Update(execsrv.State{Status: types.StatusTerminated})
+
+		require.Zero(t, srv.TerminateCallCount(), "should not terminate a job that finished with %s", status)
+	}
+
+	srv := new(execsrvfakes.FakeService)
+	observer := execsrv.NewStateObserverFactory(srv).New(types.Exec{ID: "exc_1", Status: types.StatusRunning})
+
+	observer.Update(execsrv.State{Status: types.StatusTerminated})
+	require.Equal(t, 1, srv.TerminateCallCount())
+}
@@ -0,0 +1,102 @@
+package execsrv
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/rs/zerolog/log"
+	"github.com/unweave/unweave-v1/api/types"
+)
+
+// DefaultExpiryWarning is how long before an exec expires that expiry observers are
+// warned.
+const DefaultExpiryWarning = 5 * time.Minute
+
+// reapRetryInterval is how long to wait before retrying a failed termination.
+const reapRetryInterval = time.Minute
+
+// ttlReaper terminates execs once they reach their expiry time. Schedules only live in
+// memory, the ExecService rebuilds them from the store on Init.
+type ttlReaper struct {
+	terminate func(ctx context.Context, execID string, reason string) error
+	warn      func(warning ExpiryWarning)
+
+	mu     sync.Mutex
+	timers map[string][]*time.Timer
+}
+
+func newTTLReaper(
+	terminate func(ctx context.Context, execID string, reason string) error,
+	warn func(warning ExpiryWarning),
+) *ttlReaper {
+	return &ttlReaper{
+		terminate: terminate,
+		warn:      warn,
+		timers:    make(map[string][]*time.Timer),
+	}
+}
+
+// schedule replaces any existing schedule for the exec. Execs that have already expired
+// are terminated right away.
+func (r *ttlReaper) schedule(execID string, expiresAt time.Time, warnBefore time.Duration) {
+	r.cancel(execID)
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var timers []*time.Timer
+
+	if warnIn := time.Until(expiresAt.Add(-warnBefore)); warnIn > 0 {
+		timers = append(timers, time.AfterFunc(warnIn, func() {
+			r.warn(ExpiryWarning{ExecID: execID, ExpiresAt: expiresAt})
+		}))
+	}
+
+	timers = append(timers, time.AfterFunc(time.Until(expiresAt), func() {
+		r.reap(execID, expiresAt)
+	}))
+
+	r.timers[execID] = timers
+
+	log.Info().
+		Str(types.ExecIDCtxKey, execID).
+		Msgf("Exec scheduled to expire at %s", expiresAt.Format(time.RFC3339))
+}
+
+func (r *ttlReaper) cancel(execID string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, t := range r.timers[execID] {
+		t.Stop()
+	}
+
+	delete(r.timers, execID)
+}
+
+func (r *ttlReaper) reap(execID string, expiresAt time.Time) {
+	r.mu.Lock()
+	delete(r.timers, execID)
+	r.mu.Unlock()
+
+	log.Info().
+		Str(types.ExecIDCtxKey, execID).
+		Msg("Exec reached its maximum lifetime, terminating")
+
+	reason := fmt.Sprintf("expired: reached maximum lifetime at %s", expiresAt.Format(time.RFC3339))
+
+	if err := r.terminate(context.Background(), execID, reason); err != nil {
+		log.Error().
+			Err(err).
+			Str(types.ExecIDCtxKey, execID).
+			Msgf("Failed to terminate expired exec, retrying in %s", reapRetryInterval)
+
+		r.mu.Lock()
+		r.timers[execID] = []*time.Timer{time.AfterFunc(reapRetryInterval, func() {
+			r.reap(execID, expiresAt)
+		})}
+		r.mu.Unlock()
+	}
+}
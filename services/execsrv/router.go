@@ -3,19 +3,44 @@ package execsrv
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"net/http"
+	"strings"
 
+	"github.com/rs/zerolog/log"
 	"github.com/unweave/unweave-v1/api/types"
 )
 
+//counterfeiter:generate -o internal/execsrvfakes . Service
+
 type Service interface {
 	Provider() types.Provider
 	Create(ctx context.Context, projectID string, creator string, params types.ExecCreateParams) (types.Exec, error)
 	Get(ctx context.Context, execID string) (types.Exec, error)
 	List(ctx context.Context, projectID string) ([]types.Exec, error)
 	Terminate(ctx context.Context, execID string) error
+	Stop(ctx context.Context, execID string) error
+	Start(ctx context.Context, execID string) error
 	Monitor(ctx context.Context, execID string) error
 	RefreshConnectionInfo(ctx context.Context, execID string) (types.Exec, error)
+	// StateInformer returns the informer watching the exec's state. Execs in a terminal
+	// state are no longer watched.
+	StateInformer(ctx context.Context, execID string) (StateInformer, error)
+	// History returns the recorded events of the exec, oldest first.
+	History(ctx context.Context, execID string) ([]types.ExecEvent, error)
+}
+
+// NodeTypeLister lists the node types of a provider, see providersrv.ProviderService.
+type NodeTypeLister interface {
+	Provider() types.Provider
+	ListNodeTypes(ctx context.Context, userID string, filterAvailable bool) ([]types.NodeType, error)
+}
+
+// Scheduler ranks the node types matching a spec across providers, see
+// schedulersrv.Service.
+type Scheduler interface {
+	Match(ctx context.Context, userID string, spec types.HardwareSpec) ([]types.NodeTypeMatch, error)
 }
 
 // DelegatingService is a service that routes requests to the correct provider. In most cases
@@ -25,20 +50,46 @@ type Service interface {
 type DelegatingService struct {
 	store     Store
 	delegates map[types.Provider]Service
+	// providers are the delegates in the order they were registered. It's the order
+	// providers are tried in for types.AnyProvider.
+	providers []types.Provider
+	listers   map[types.Provider]NodeTypeLister
+	scheduler Scheduler
 }
 
-func NewDelegatingService(store Store, services ...Service) Service {
+func NewDelegatingService(store Store, services ...Service) *DelegatingService {
 	delegates := make(map[types.Provider]Service)
+	providers := make([]types.Provider, 0, len(services))
 
 	for i := range services {
 		svc := services[i]
 		delegates[svc.Provider()] = svc
+		providers = append(providers, svc.Provider())
 	}
 
 	return &DelegatingService{
 		store:     store,
 		delegates: delegates,
+		providers: providers,
+		listers:   make(map[types.Provider]NodeTypeLister),
+	}
+}
+
+// WithNodeTypeListers makes Create skip providers without available capacity for the
+// requested hardware when falling back between providers.
+func WithNodeTypeListers(s *DelegatingService, listers ...NodeTypeLister) *DelegatingService {
+	for _, l := range listers {
+		s.listers[l.Provider()] = l
 	}
+
+	return s
+}
+
+// WithScheduler makes Create pick the cheapest available node type matching the spec
+// when no provider is requested.
+func WithScheduler(s *DelegatingService, scheduler Scheduler) *DelegatingService {
+	s.scheduler = scheduler
+	return s
 }
 
 func (s *DelegatingService) Provider() types.Provider {
@@ -51,12 +102,201 @@ func (s *DelegatingService) Create(
 	userID string,
 	params types.ExecCreateParams,
 ) (types.Exec, error) {
-	svc, err := s.service(params.Provider)
+	if len(params.Providers) == 0 && params.Provider == "" && s.scheduler != nil {
+		candidates, err := s.scheduledCandidates(ctx, userID, params.Spec)
+		if err != nil {
+			return types.Exec{}, err
+		}
+
+		return s.createWithFallback(ctx, projectID, userID, params, candidates)
+	}
+
+	if len(params.Providers) == 0 && params.Provider != types.AnyProvider {
+		svc, err := s.service(params.Provider)
+		if err != nil {
+			return types.Exec{}, fmt.Errorf("establish service: %w", err)
+		}
+
+		return svc.Create(ctx, projectID, userID, params)
+	}
+
+	candidates := make([]candidate, 0, len(s.providers))
+	for _, provider := range s.candidates(params) {
+		candidates = append(candidates, candidate{provider: provider, spec: params.Spec})
+	}
+
+	return s.createWithFallback(ctx, projectID, userID, params, candidates)
+}
+
+// candidate is a provider to try creating an exec on and the spec to pass to it.
+type candidate struct {
+	provider types.Provider
+	spec     types.HardwareSpec
+	// scheduled candidates are known to have capacity.
+	scheduled bool
+}
+
+// scheduledCandidates returns a candidate for every available node type matching the
+// spec, cheapest first. The spec passed to each provider is narrowed down to the node type.
+func (s *DelegatingService) scheduledCandidates(
+	ctx context.Context,
+	userID string,
+	spec types.HardwareSpec,
+) ([]candidate, error) {
+	matches, err := s.scheduler.Match(ctx, userID, spec)
 	if err != nil {
-		return types.Exec{}, fmt.Errorf("establish service: %w", err)
+		return nil, fmt.Errorf("match node types: %w", err)
+	}
+
+	var candidates []candidate
+
+	for _, m := range matches {
+		if !m.Available {
+			continue
+		}
+
+		candidates = append(candidates, candidate{
+			provider:  m.NodeType.Provider,
+			spec:      specForNodeType(spec, m.NodeType),
+			scheduled: true,
+		})
+	}
+
+	if len(candidates) == 0 {
+		return nil, &types.Error{
+			Code:       http.StatusServiceUnavailable,
+			Message:    "No available node type matches the requested hardware",
+			Suggestion: "Relax the hardware spec or pick a provider",
+		}
+	}
+
+	return candidates, nil
+}
+
+// specForNodeType sets the GPU or CPU type of the spec to the node type so that
+// providers create that node type.
+func specForNodeType(spec types.HardwareSpec, nt types.NodeType) types.HardwareSpec {
+	if nt.Specs.GPU.Type != "" || nt.Specs.GPU.Count.Max > 0 {
+		spec.GPU.Type = nt.ID
+		return spec
+	}
+
+	if spec.CPU.Type == "" {
+		spec.CPU.Type = nt.Specs.CPU.Type
+	}
+
+	return spec
+}
+
+// createWithFallback tries each candidate in turn until one creates the exec. Providers
+// without available capacity for the requested hardware are skipped. Only capacity and
+// server errors fall back, client errors are returned as is since no other provider will
+// accept the request either. The failed attempts are recorded on the created exec.
+func (s *DelegatingService) createWithFallback(
+	ctx context.Context,
+	projectID string,
+	userID string,
+	params types.ExecCreateParams,
+	candidates []candidate,
+) (types.Exec, error) {
+	var attempts []types.ProviderAttempt
+
+	for _, c := range candidates {
+		provider := c.provider
+
+		svc, err := s.service(provider)
+		if err != nil {
+			attempts = append(attempts, types.ProviderAttempt{Provider: provider, Error: err.Error()})
+			continue
+		}
+
+		if !c.scheduled {
+			if err = s.checkCapacity(ctx, userID, provider, c.spec); err != nil {
+				attempts = append(attempts, types.ProviderAttempt{Provider: provider, Error: err.Error()})
+				continue
+			}
+		}
+
+		p := params
+		p.Provider = provider
+		p.Spec = c.spec
+		p.ProviderAttempts = attempts
+
+		exec, err := svc.Create(ctx, projectID, userID, p)
+		if err == nil {
+			return exec, nil
+		}
+
+		if !shouldFallback(err) {
+			return types.Exec{}, err
+		}
+
+		log.Ctx(ctx).Warn().Err(err).Msgf("Failed to create exec on %s, falling back", provider)
+
+		attempts = append(attempts, types.ProviderAttempt{Provider: provider, Error: err.Error()})
+	}
+
+	failures := make([]string, len(attempts))
+	for i, a := range attempts {
+		failures[i] = fmt.Sprintf("%s: %s", a.Provider, a.Error)
+	}
+
+	return types.Exec{}, &types.Error{
+		Code:       http.StatusServiceUnavailable,
+		Message:    "None of the requested providers could create the session",
+		Suggestion: strings.Join(failures, "; "),
+	}
+}
+
+// shouldFallback reports whether another provider might succeed where one failed with
+// err. Errors without a code are assumed to be server errors.
+func shouldFallback(err error) bool {
+	var e *types.Error
+	if !errors.As(err, &e) {
+		return true
 	}
 
-	return svc.Create(ctx, projectID, userID, params)
+	return e.Code >= http.StatusInternalServerError
+}
+
+// candidates returns the providers to try in order. AnyProvider expands to every
+// registered provider not tried before it.
+func (s *DelegatingService) candidates(params types.ExecCreateParams) []types.Provider {
+	requested := params.Providers
+	if len(requested) == 0 {
+		requested = []types.Provider{params.Provider}
+	}
+
+	var (
+		res  []types.Provider
+		seen = make(map[types.Provider]bool)
+	)
+
+	add := func(p types.Provider) {
+		if !seen[p] {
+			seen[p] = true
+			res = append(res, p)
+		}
+	}
+
+	for _, p := range requested {
+		if p != types.AnyProvider {
+			add(p)
+			continue
+		}
+
+		for _, registered := range s.providers {
+			// The local provider runs sessions on the API host, it's only used when
+			// requested by name.
+			if registered == types.LocalProvider {
+				continue
+			}
+
+			add(registered)
+		}
+	}
+
+	return res
 }
 
 // Get returns a single session irrespective of the provider.
@@ -69,6 +309,11 @@ func (s *DelegatingService) Get(_ context.Context, execID string) (types.Exec, e
 	return exec, nil
 }
 
+// History returns the history of a single session irrespective of the provider.
+func (s *DelegatingService) History(_ context.Context, execID string) ([]types.ExecEvent, error) {
+	return s.store.ListEvents(execID)
+}
+
 // List returns a list of sessions for a given project irrespective of the providers.
 func (s *DelegatingService) List(_ context.Context, projectID string) ([]types.Exec, error) {
 	execs, err := s.store.List(&projectID, nil, false)
@@ -94,6 +339,36 @@ func (s *DelegatingService) Terminate(ctx context.Context, execID string) error
 	return svc.Terminate(ctx, execID)
 }
 
+// Stop routes the exec stop request to the correct service based on the provider.
+func (s *DelegatingService) Stop(ctx context.Context, execID string) error {
+	exec, err := s.store.Get(execID)
+	if err != nil {
+		return fmt.Errorf("failed to get exec: %w", err)
+	}
+
+	svc, err := s.service(exec.Provider)
+	if err != nil {
+		return fmt.Errorf("establish service: %w", err)
+	}
+
+	return svc.Stop(ctx, execID)
+}
+
+// Start routes the exec start request to the correct service based on the provider.
+func (s *DelegatingService) Start(ctx context.Context, execID string) error {
+	exec, err := s.store.Get(execID)
+	if err != nil {
+		return fmt.Errorf("failed to get exec: %w", err)
+	}
+
+	svc, err := s.service(exec.Provider)
+	if err != nil {
+		return fmt.Errorf("establish service: %w", err)
+	}
+
+	return svc.Start(ctx, execID)
+}
+
 // Monitor routes the exec monitoring request to the correct service based on the provider.
 func (s *DelegatingService) Monitor(ctx context.Context, execID string) error {
 	exec, err := s.store.Get(execID)
@@ -123,6 +398,56 @@ func (s *DelegatingService) RefreshConnectionInfo(ctx context.Context, execID st
 	return svc.RefreshConnectionInfo(ctx, execID)
 }
 
+// StateInformer routes the request to the correct service based on the provider.
+func (s *DelegatingService) StateInformer(ctx context.Context, execID string) (StateInformer, error) {
+	exec, err := s.store.Get(execID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get exec: %w", err)
+	}
+
+	svc, err := s.service(exec.Provider)
+	if err != nil {
+		return nil, fmt.Errorf("establish service: %w", err)
+	}
+
+	return svc.StateInformer(ctx, execID)
+}
+
+// checkCapacity returns an error if the provider has no available node type matching the
+// spec. Providers without a NodeTypeLister are assumed to have capacity.
+func (s *DelegatingService) checkCapacity(
+	ctx context.Context,
+	userID string,
+	provider types.Provider,
+	spec types.HardwareSpec,
+) error {
+	lister, ok := s.listers[provider]
+	if !ok {
+		return nil
+	}
+
+	nodeTypes, err := lister.ListNodeTypes(ctx, userID, true)
+	if err != nil {
+		return fmt.Errorf("list node types: %w", err)
+	}
+
+	for _, nt := range nodeTypes {
+		if nodeTypeMatches(nt, spec) {
+			return nil
+		}
+	}
+
+	return fmt.Errorf("no capacity available for the requested hardware")
+}
+
+func nodeTypeMatches(nt types.NodeType, spec types.HardwareSpec) bool {
+	if spec.GPU.Type == "" {
+		return nt.Specs.GPU.Type == "" && nt.Specs.GPU.Count.Max == 0
+	}
+
+	return nt.ID == spec.GPU.Type || nt.Specs.GPU.Type == spec.GPU.Type
+}
+
 func (s *DelegatingService) service(provider types.Provider) (Service, error) {
 	service, ok := s.delegates[provider]
 	if !ok {
@@ -0,0 +1,153 @@
+package execsrv_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/unweave/unweave-v1/api/types"
+	"github.com/unweave/unweave-v1/services/execsrv"
+	"github.com/unweave/unweave-v1/services/execsrv/internal/execsrvfakes"
+)
+
+type nodeTypeLister struct {
+	provider  types.Provider
+	nodeTypes []types.NodeType
+}
+
+func (l nodeTypeLister) Provider() types.Provider { return l.provider }
+
+func (l nodeTypeLister) ListNodeTypes(context.Context, string, bool) ([]types.NodeType, error) {
+	return l.nodeTypes, nil
+}
+
+func newFakeService(provider types.Provider, createErr error) *execsrvfakes.FakeService {
+	svc := new(execsrvfakes.FakeService)
+	svc.ProviderReturns(provider)
+	svc.CreateCalls(func(_ context.Context, _, _ string, params types.ExecCreateParams) (types.Exec, error) {
+		if createErr != nil {
+			return types.Exec{}, createErr
+		}
+
+		return types.Exec{ID: "exc_1", Provider: params.Provider, ProviderAttempts: params.ProviderAttempts}, nil
+	})
+
+	return svc
+}
+
+func TestDelegatingService_CreateWithFallback(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	spec := types.HardwareSpec{GPU: types.GPU{Type: "gpu_1x_a10"}}
+
+	ll := newFakeService(types.LambdaLabsProvider, nil)
+	aws := newFakeService(types.AWSProvider, errors.New("insufficient capacity"))
+	local := newFakeService(types.LocalProvider, nil)
+
+	srv := execsrv.NewDelegatingService(nil, ll, aws, local)
+	srv = execsrv.WithNodeTypeListers(srv, nodeTypeLister{provider: types.LambdaLabsProvider})
+
+	_, err := srv.Create(ctx, "pr_1", "usr_1", types.ExecCreateParams{
+		Providers: []types.Provider{types.AWSProvider, types.AnyProvider},
+		Spec:      spec,
+	})
+	require.Error(t, err, "should not expand any to the local provider")
+	require.Zero(t, local.CreateCallCount())
+
+	exec, err := srv.Create(ctx, "pr_1", "usr_1", types.ExecCreateParams{
+		Providers: []types.Provider{types.AWSProvider, types.AnyProvider, types.LocalProvider},
+		Spec:      spec,
+	})
+	require.NoError(t, err)
+	require.Equal(t, types.LocalProvider, exec.Provider)
+	require.Zero(t, ll.CreateCallCount(), "should skip providers without capacity")
+	require.Equal(t, 2, aws.CreateCallCount())
+	require.Len(t, exec.ProviderAttempts, 2)
+	require.Equal(t, types.AWSProvider, exec.ProviderAttempts[0].Provider)
+	require.Equal(t, "insufficient capacity", exec.ProviderAttempts[0].Error)
+	require.Equal(t, types.LambdaLabsProvider, exec.ProviderAttempts[1].Provider)
+
+	srv = execsrv.WithNodeTypeListers(srv, nodeTypeLister{
+		provider:  types.LambdaLabsProvider,
+		nodeTypes: []types.NodeType{{ID: "gpu_1x_a10"}},
+	})
+
+	exec, err = srv.Create(ctx, "pr_1", "usr_1", types.ExecCreateParams{Provider: types.AnyProvider, Spec: spec})
+	require.NoError(t, err)
+	require.Equal(t, types.LambdaLabsProvider, exec.Provider, "should try providers in registration order")
+	require.Empty(t, exec.ProviderAttempts)
+
+	_, err = srv.Create(ctx, "pr_1", "usr_1", types.ExecCreateParams{Provider: types.AWSProvider, Spec: spec})
+	require.Error(t, err, "should not fall back without a list of providers")
+	require.Equal(t, 3, aws.CreateCallCount())
+
+	var e *types.Error
+
+	_, err = srv.Create(ctx, "pr_1", "usr_1", types.ExecCreateParams{
+		Providers: []types.Provider{types.AWSProvider, types.UnweaveProvider},
+		Spec:      spec,
+	})
+	require.True(t, errors.As(err, &e))
+	require.Equal(t, http.StatusServiceUnavailable, e.Code)
+}
+
+func TestDelegatingService_CreateReturnsClientErrors(t *testing.T) {
+	t.Parallel()
+
+	invalid := &types.Error{Code: http.StatusBadRequest, Message: "Invalid SSH key"}
+
+	aws := newFakeService(types.AWSProvider, invalid)
+	local := newFakeService(types.LocalProvider, nil)
+
+	srv := execsrv.NewDelegatingService(nil, aws, local)
+
+	_, err := srv.Create(context.Background(), "pr_1", "usr_1", types.ExecCreateParams{
+		Providers: []types.Provider{types.AWSProvider, types.LocalProvider},
+	})
+
+	var e *types.Error
+	require.True(t, errors.As(err, &e))
+	require.Same(t, invalid, e, "should return client errors unchanged")
+	require.Zero(t, local.CreateCallCount(), "should not fall back on client errors")
+}
+
+type scheduler []types.NodeTypeMatch
+
+func (s scheduler) Match(context.Context, string, types.HardwareSpec) ([]types.NodeTypeMatch, error) {
+	return s, nil
+}
+
+func TestDelegatingService_CreateScheduled(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+
+	ll := newFakeService(types.LambdaLabsProvider, errors.New("insufficient capacity"))
+	aws := newFakeService(types.AWSProvider, nil)
+
+	srv := execsrv.NewDelegatingService(nil, ll, aws)
+	srv = execsrv.WithScheduler(srv, scheduler{
+		{NodeType: types.NodeType{ID: "gpu_1x_a10", Provider: types.LambdaLabsProvider, Specs: types.HardwareSpec{
+			GPU: types.GPU{Count: types.HardwareRequestRange{Min: 1, Max: 1}},
+		}}, Available: true},
+		{NodeType: types.NodeType{ID: "h100", Provider: types.AWSProvider}, Available: false},
+		{NodeType: types.NodeType{ID: "t4_tensor", Provider: types.AWSProvider, Specs: types.HardwareSpec{
+			GPU: types.GPU{Type: "t4_tensor"},
+		}}, Available: true},
+	})
+
+	exec, err := srv.Create(ctx, "pr_1", "usr_1", types.ExecCreateParams{})
+	require.NoError(t, err)
+	require.Equal(t, types.AWSProvider, exec.Provider)
+	require.Len(t, exec.ProviderAttempts, 1)
+
+	_, _, _, llParams := ll.CreateArgsForCall(0)
+	require.Equal(t, "gpu_1x_a10", llParams.Spec.GPU.Type)
+
+	require.Equal(t, 1, aws.CreateCallCount(), "should skip unavailable node types")
+	_, _, _, awsParams := aws.CreateArgsForCall(0)
+	require.Equal(t, "t4_tensor", awsParams.Spec.GPU.Type)
+}
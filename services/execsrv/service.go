@@ -4,11 +4,13 @@ package execsrv
 import (
 	"context"
 	"fmt"
+	"net/http"
 	"time"
 
 	"github.com/rs/zerolog/log"
 	"github.com/unweave/unweave-v1/api/types"
 	"github.com/unweave/unweave-v1/services/volumesrv"
+	"github.com/unweave/unweave-v1/tools"
 	"github.com/unweave/unweave-v1/tools/random"
 )
 
@@ -28,8 +30,15 @@ type ExecService struct {
 	stateObserverFactories     []StateObserverFactory
 	statsObserverFactories     []StatsObserverFactory
 	heartbeatObserverFactories []HeartbeatObserverFactory
+	expiryObservers            []ExpiryObserver
+	pricer                     Pricer
+
+	reaper *ttlReaper
 
 	ServiceDefaultImage string
+	// ExpiryWarning is how long before an exec expires that expiry observers are warned.
+	// Defaults to DefaultExpiryWarning.
+	ExpiryWarning time.Duration
 }
 
 var _ Service = (*ExecService)(nil)
@@ -49,6 +58,23 @@ func WithHeartbeatObserver(s *ExecService, f HeartbeatObserverFactory) *ExecServ
 	return s
 }
 
+func WithExpiryObserver(s *ExecService, o ExpiryObserver) *ExecService {
+	s.expiryObservers = append(s.expiryObservers, o)
+	return s
+}
+
+// Pricer returns the hourly price in cents of the node type created for a spec, or nil
+// if it's unknown. See costsrv.Pricer.
+type Pricer interface {
+	HourlyPrice(ctx context.Context, userID string, spec types.HardwareSpec) (*int, error)
+}
+
+// WithPricer records the hourly price of execs when they're created.
+func WithPricer(s *ExecService, p Pricer) *ExecService {
+	s.pricer = p
+	return s
+}
+
 func NewService(
 	store Store,
 	driver Driver,
@@ -70,6 +96,8 @@ func NewService(
 		heartbeatObserverFactories: nil,
 	}
 
+	s.reaper = newTTLReaper(s.terminateWithReason, s.warnExpiry)
+
 	return s
 }
 
@@ -93,6 +121,15 @@ func (s *ExecService) Create(
 		image = *params.Image
 	}
 
+	if _, ok := s.driver.(ExitCodeDriver); params.Job && !ok {
+		return types.Exec{}, &types.Error{
+			Code:       http.StatusBadRequest,
+			Message:    fmt.Sprintf("Jobs are not supported by the %s provider", s.provider),
+			Suggestion: "Use a provider that runs containers, e.g. aws or local",
+			Provider:   s.provider,
+		}
+	}
+
 	volumes, err := s.parseVolumes(ctx, projectID, params.Volumes)
 	if err != nil {
 		return types.Exec{}, fmt.Errorf("volume verification failed: %w", err)
@@ -112,6 +149,7 @@ func (s *ExecService) Create(
 		ctx,
 		projectID,
 		image,
+		params.Command,
 		spec,
 		network,
 		volumes,
@@ -149,22 +187,33 @@ func (s *ExecService) Create(
 		Provider: params.Provider,
 		// Full network details are filled in when
 		// the Exec transitions to the Running state.
-		Network: network,
-		Region:  "",
+		Network:    network,
+		Region:     tools.StringInv(params.Region),
+		IdlePolicy: params.IdlePolicy(),
+	}
+	exec.ExpiresAt = params.Expiry(exec.CreatedAt)
+	exec.ProviderAttempts = params.ProviderAttempts
+	exec.Job = params.Job
+
+	if s.pricer != nil {
+		price, err := s.pricer.HourlyPrice(ctx, creator, spec)
+		if err != nil {
+			log.Ctx(ctx).Warn().Err(err).Str(types.ExecIDCtxKey, execID).Msg("Failed to get exec price")
+		}
+
+		exec.HourlyPrice = price
 	}
 
 	if err = s.store.Create(projectID, exec); err != nil {
 		return types.Exec{}, fmt.Errorf("failed to add exec to store: %w", err)
 	}
 
-	informer := s.stateInformerManager.Add(exec)
-	informer.Watch()
-
-	for _, factory := range s.stateObserverFactories {
-		o := factory.New(exec)
-		informer.Register(o)
+	if exec.ExpiresAt != nil {
+		s.reaper.schedule(exec.ID, *exec.ExpiresAt, s.expiryWarning())
 	}
 
+	s.watchState(exec)
+
 	return exec, nil
 }
 
@@ -176,8 +225,15 @@ func (s *ExecService) Get(ctx context.Context, id string) (types.Exec, error) {
 	return exec, err
 }
 
+func (s *ExecService) History(_ context.Context, id string) ([]types.ExecEvent, error) {
+	return s.store.ListEvents(id)
+}
+
+// Init resumes watching the execs of the provider after a restart and reschedules their
+// expiry. Stopped execs are watched too so that their state is tracked once they're
+// started again.
 func (s *ExecService) Init() error {
-	execs, err := s.store.List(nil, &s.provider, true)
+	execs, err := s.store.ListUnfinished(&s.provider)
 	if err != nil {
 		return fmt.Errorf("failed to init StateInformer, failed list all execs: %w", err)
 	}
@@ -197,15 +253,13 @@ func (s *ExecService) Init() error {
 			continue
 		}
 
-		informer := s.stateInformerManager.Add(exec)
-		informer.Watch()
-
-		for _, factory := range s.stateObserverFactories {
-			o := factory.New(exec)
-			log.Debug().Msgf("Registering observer %q for exec %q", o.Name(), exec.ID)
-			informer.Register(o)
+		// Stopped execs still expire, their disk is kept until they're terminated.
+		if exec.ExpiresAt != nil {
+			s.reaper.schedule(exec.ID, *exec.ExpiresAt, s.expiryWarning())
 		}
 
+		s.watchState(exec)
+
 		shouldMonitor := exec.Status == types.StatusRunning ||
 			exec.Status == types.StatusInitializing ||
 			exec.Status == types.StatusPending
@@ -260,10 +314,12 @@ func (s *ExecService) Terminate(ctx context.Context, id string) error {
 		}
 	}
 
-	// We don't want to overwrite the status if it's already terminated, failed or errored
+	// We don't want to overwrite the status if it's already terminated, failed, errored or
+	// a job that succeeded.
 	if exec.Status == types.StatusTerminated ||
 		exec.Status == types.StatusFailed ||
-		exec.Status == types.StatusError {
+		exec.Status == types.StatusError ||
+		exec.Status == types.StatusSuccess {
 		return nil
 	}
 
@@ -281,10 +337,142 @@ func (s *ExecService) Terminate(ctx context.Context, id string) error {
 		return fmt.Errorf("failed to delete shared volumes in store: %w", err)
 	}
 
+	s.reaper.cancel(exec.ID)
+
+	event := types.ExecEvent{
+		ExecID: exec.ID,
+		Status: types.StatusTerminated,
+		Source: "terminate",
+		Error:  exec.TerminationReason,
+	}
+	if err = s.store.AddEvent(event); err != nil {
+		log.Ctx(ctx).Warn().Err(err).Str(types.ExecIDCtxKey, exec.ID).Msg("Failed to record termination")
+	}
+
 	// TODO Clean up SSH keys associated with the terminated exec
 	return nil
 }
 
+func (s *ExecService) recordEvent(ctx context.Context, execID string, status types.Status, source string) {
+	event := types.ExecEvent{ExecID: execID, Status: status, Source: source}
+	if err := s.store.AddEvent(event); err != nil {
+		log.Ctx(ctx).Warn().Err(err).Str(types.ExecIDCtxKey, execID).Msgf("Failed to record %s", source)
+	}
+}
+
+// Stop stops a running exec. Unlike Terminate, the exec keeps its disk and can be started
+// again with Start.
+func (s *ExecService) Stop(ctx context.Context, id string) error {
+	exec, err := s.store.Get(id)
+	if err != nil {
+		return fmt.Errorf("failed to get exec from store: %w", err)
+	}
+
+	if exec.Status != types.StatusRunning {
+		return &types.Error{
+			Code:       http.StatusConflict,
+			Message:    fmt.Sprintf("Cannot stop session in state %q", exec.Status),
+			Suggestion: "Only running sessions can be stopped",
+		}
+	}
+
+	log.Ctx(ctx).
+		Info().
+		Str(types.ExecIDCtxKey, exec.ID).
+		Msg("Stopping exec")
+
+	if err = s.driver.ExecStop(ctx, exec.ID); err != nil {
+		return fmt.Errorf("failed to stop exec: %w", err)
+	}
+
+	if err = s.store.UpdateStatus(exec.ID, types.StatusStopped, time.Time{}, time.Time{}); err != nil {
+		return fmt.Errorf("failed to update exec status in store: %w", err)
+	}
+
+	// Stopped execs don't accrue cost, see costsrv.Accrued.
+	s.recordEvent(ctx, exec.ID, types.StatusStopped, "stop")
+
+	return nil
+}
+
+// Start starts an exec previously stopped with Stop. The exec goes through initializing
+// again and gets new connection info once it's running.
+func (s *ExecService) Start(ctx context.Context, id string) error {
+	exec, err := s.store.Get(id)
+	if err != nil {
+		return fmt.Errorf("failed to get exec from store: %w", err)
+	}
+
+	if exec.Status != types.StatusStopped {
+		return &types.Error{
+			Code:       http.StatusConflict,
+			Message:    fmt.Sprintf("Cannot start session in state %q", exec.Status),
+			Suggestion: "Only stopped sessions can be started",
+		}
+	}
+
+	log.Ctx(ctx).
+		Info().
+		Str(types.ExecIDCtxKey, exec.ID).
+		Msg("Starting exec")
+
+	if err = s.driver.ExecStart(ctx, exec.ID); err != nil {
+		return fmt.Errorf("failed to start exec: %w", err)
+	}
+
+	if err = s.store.UpdateStatus(exec.ID, types.StatusInitializing, time.Time{}, time.Time{}); err != nil {
+		return fmt.Errorf("failed to update exec status in store: %w", err)
+	}
+
+	s.recordEvent(ctx, exec.ID, types.StatusInitializing, "start")
+
+	// The stats informer exits while the exec is stopped.
+	exec.Status = types.StatusInitializing
+	s.monitor(exec)
+
+	return nil
+}
+
+func (s *ExecService) StateInformer(_ context.Context, id string) (StateInformer, error) {
+	informer, ok := s.stateInformerManager.Get(id)
+	if !ok {
+		return nil, &types.Error{
+			Code:       http.StatusNotFound,
+			Message:    "Session state is not being watched",
+			Suggestion: "Sessions that have terminated have no further state changes",
+		}
+	}
+
+	return informer, nil
+}
+
+// terminateWithReason records why the exec is being terminated before terminating it.
+func (s *ExecService) terminateWithReason(ctx context.Context, id string, reason string) error {
+	if err := s.store.UpdateTerminationReason(id, reason); err != nil {
+		log.Ctx(ctx).Warn().Err(err).Str(types.ExecIDCtxKey, id).Msg("Failed to record termination reason")
+	}
+
+	return s.Terminate(ctx, id)
+}
+
+func (s *ExecService) warnExpiry(warning ExpiryWarning) {
+	log.Info().
+		Str(types.ExecIDCtxKey, warning.ExecID).
+		Msgf("Exec expires at %s", warning.ExpiresAt.Format(time.RFC3339))
+
+	for _, o := range s.expiryObservers {
+		o := o
+		go o.Update(warning)
+	}
+}
+
+func (s *ExecService) expiryWarning() time.Duration {
+	if s.ExpiryWarning == 0 {
+		return DefaultExpiryWarning
+	}
+	return s.ExpiryWarning
+}
+
 // Monitor starts monitoring an exec by registering observers to the stats and heartbeat
 // informers.
 func (s *ExecService) Monitor(ctx context.Context, execID string) error {
@@ -293,6 +481,34 @@ func (s *ExecService) Monitor(ctx context.Context, execID string) error {
 		return fmt.Errorf("failed to get exec from store: %w", err)
 	}
 
+	s.monitor(exec)
+
+	return nil
+}
+
+// monitor registers the stats and heartbeat observers of the exec. Informers still
+// watching the exec are reused.
+func (s *ExecService) monitor(exec types.Exec) {
+	s.watchStats(exec)
+	s.watchHeartbeat(exec)
+}
+
+func (s *ExecService) watchState(exec types.Exec) {
+	informer := s.stateInformerManager.Add(exec)
+	informer.Watch()
+
+	for _, factory := range s.stateObserverFactories {
+		o := factory.New(exec)
+		log.Debug().Msgf("Registering observer %q for exec %q", o.Name(), exec.ID)
+		informer.Register(o)
+	}
+
+	if exec.Job {
+		informer.Register(&jobObserver{exec: exec, srv: s})
+	}
+}
+
+func (s *ExecService) watchStats(exec types.Exec) {
 	stInformer := s.statsInformerManager.Add(exec)
 	stInformer.Watch()
 
@@ -300,17 +516,20 @@ func (s *ExecService) Monitor(ctx context.Context, execID string) error {
 		o := factory.New(exec)
 		stInformer.Register(o)
 	}
+}
 
-	if s.heartbeatInformerManager != nil {
-		hbInformer := s.heartbeatInformerManager.Add(exec)
-		hbInformer.Watch()
+func (s *ExecService) watchHeartbeat(exec types.Exec) {
+	if s.heartbeatInformerManager == nil {
+		return
+	}
 
-		for _, factory := range s.heartbeatObserverFactories {
-			o := factory.New(exec)
-			hbInformer.Register(o)
-		}
+	hbInformer := s.heartbeatInformerManager.Add(exec)
+	hbInformer.Watch()
+
+	for _, factory := range s.heartbeatObserverFactories {
+		o := factory.New(exec)
+		hbInformer.Register(o)
 	}
-	return nil
 }
 
 func (s *ExecService) RefreshConnectionInfo(ctx context.Context, execID string) (types.Exec, error) {
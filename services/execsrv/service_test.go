@@ -0,0 +1,350 @@
+package execsrv_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"github.com/unweave/unweave-v1/api/types"
+	"github.com/unweave/unweave-v1/services/execsrv"
+	"github.com/unweave/unweave-v1/services/execsrv/internal/execsrvfakes"
+)
+
+// noopStateInformers keeps the state informer out of tests that don't need it.
+type noopStateInformers struct{}
+
+func (noopStateInformers) Add(types.Exec) execsrv.StateInformer     { return noopStateInformer{} }
+func (noopStateInformers) Get(string) (execsrv.StateInformer, bool) { return nil, false }
+func (noopStateInformers) Remove(string)                            {}
+
+type noopStateInformer struct{}
+
+func (noopStateInformer) Register(execsrv.StateObserver)   {}
+func (noopStateInformer) Unregister(execsrv.StateObserver) {}
+func (noopStateInformer) Watch()                           {}
+
+func newTestService(store execsrv.Store, driver *execsrvfakes.FakeDriver) *execsrv.ExecService {
+	driver.ExecProviderReturns(types.LocalProvider)
+	driver.ExecDriverNameReturns(types.LocalProvider.String())
+
+	stats := execsrv.NewPollingStatsInformerManager(store, driver)
+
+	return execsrv.NewService(store, driver, nil, noopStateInformers{}, stats, nil)
+}
+
+func TestExecService_TerminatesExpiredExec(t *testing.T) {
+	t.Parallel()
+
+	store := execsrv.NewMemoryStore()
+	driver := new(execsrvfakes.FakeDriver)
+	driver.ExecCreateReturns("exc_expirestest", nil)
+
+	terminated := make(chan struct{})
+	driver.ExecTerminateCalls(func(context.Context, string) error {
+		close(terminated)
+		return nil
+	})
+
+	warned := make(chan execsrv.ExpiryWarning, 1)
+	observer := new(execsrvfakes.FakeExpiryObserver)
+	observer.UpdateCalls(func(w execsrv.ExpiryWarning) { warned <- w })
+
+	srv := newTestService(store, driver)
+	srv.ExpiryWarning = 50 * time.Millisecond
+	srv = execsrv.WithExpiryObserver(srv, observer)
+
+	expiresAt := time.Now().Add(100 * time.Millisecond)
+	exec, err := srv.Create(context.Background(), "pr_expirestest", "acc_test", types.ExecCreateParams{
+		Provider:     types.LocalProvider,
+		SSHKeyName:   "key",
+		SSHPublicKey: "ssh-ed25519 AAAA",
+		ExpiresAt:    &expiresAt,
+	})
+	require.NoError(t, err)
+	require.Equal(t, expiresAt, *exec.ExpiresAt)
+
+	select {
+	case w := <-warned:
+		require.Equal(t, exec.ID, w.ExecID)
+	case <-terminated:
+		t.Fatal("should have warned before terminating")
+	case <-time.After(time.Second):
+		t.Fatal("should have warned before expiry")
+	}
+
+	select {
+	case <-terminated:
+	case <-time.After(time.Second):
+		t.Fatal("should have terminated the exec once expired")
+	}
+
+	require.Eventually(t, func() bool {
+		got, err := store.Get(exec.ID)
+		return err == nil && got.Status == types.StatusTerminated
+	}, time.Second, 5*time.Millisecond)
+
+	got, err := store.Get(exec.ID)
+	require.NoError(t, err)
+	require.Contains(t, got.TerminationReason, "expired")
+}
+
+func TestExecService_InitReapsExecsThatExpiredWhileDown(t *testing.T) {
+	t.Parallel()
+
+	for _, status := range []types.Status{types.StatusInitializing, types.StatusStopped} {
+		status := status
+
+		t.Run(string(status), func(t *testing.T) {
+			t.Parallel()
+
+			store := execsrv.NewMemoryStore()
+			driver := new(execsrvfakes.FakeDriver)
+
+			terminated := make(chan string, 1)
+			driver.ExecTerminateCalls(func(_ context.Context, id string) error {
+				terminated <- id
+				return nil
+			})
+
+			pub := "ssh-ed25519 AAAA"
+			expiredAt := time.Now().Add(-time.Minute)
+			require.NoError(t, store.Create("pr_expirestest", types.Exec{
+				ID:        "exc_expiredwhiledown",
+				Name:      "expired-while-down",
+				Status:    status,
+				Keys:      []types.SSHKey{{Name: "key", PublicKey: &pub}},
+				Provider:  types.LocalProvider,
+				ExpiresAt: &expiredAt,
+			}))
+
+			srv := newTestService(store, driver)
+			require.NoError(t, srv.Init())
+
+			select {
+			case id := <-terminated:
+				require.Equal(t, "exc_expiredwhiledown", id)
+			case <-time.After(time.Second):
+				t.Fatal("should have terminated the exec that expired before Init")
+			}
+		})
+	}
+}
+
+func TestExecService_InitWatchesStoppedExecs(t *testing.T) {
+	t.Parallel()
+
+	store := execsrv.NewMemoryStore()
+	driver := new(execsrvfakes.FakeDriver)
+	driver.ExecProviderReturns(types.LocalProvider)
+	driver.ExecDriverNameReturns(types.LocalProvider.String())
+
+	pub := "ssh-ed25519 AAAA"
+	require.NoError(t, store.Create("pr_stoppedtest", types.Exec{
+		ID:       "exc_stoppedwhiledown",
+		Name:     "stopped-while-down",
+		Status:   types.StatusStopped,
+		Keys:     []types.SSHKey{{Name: "key", PublicKey: &pub}},
+		Provider: types.LocalProvider,
+	}))
+
+	informer := &recordingStateInformer{}
+	stats := execsrv.NewPollingStatsInformerManager(store, driver)
+
+	srv := execsrv.NewService(store, driver, nil, recordingStateInformers{informer}, stats, nil)
+	require.NoError(t, srv.Init())
+	require.Equal(t, 1, informer.watched, "should watch the state of stopped execs so their start is tracked")
+}
+
+func TestExecService_StopStart(t *testing.T) {
+	t.Parallel()
+
+	store := execsrv.NewMemoryStore()
+	driver := new(execsrvfakes.FakeDriver)
+
+	pub := "ssh-ed25519 AAAA"
+	require.NoError(t, store.Create("pr_stopstarttest", types.Exec{
+		ID:       "exc_stopstarttest",
+		Name:     "stop-start",
+		Status:   types.StatusInitializing,
+		Keys:     []types.SSHKey{{Name: "key", PublicKey: &pub}},
+		Provider: types.LocalProvider,
+	}))
+
+	srv := newTestService(store, driver)
+	ctx := context.Background()
+
+	var e *types.Error
+
+	err := srv.Start(ctx, "exc_stopstarttest")
+	require.True(t, errors.As(err, &e))
+	require.Equal(t, http.StatusConflict, e.Code, "should not start an exec that isn't stopped")
+
+	err = srv.Stop(ctx, "exc_stopstarttest")
+	require.True(t, errors.As(err, &e))
+	require.Equal(t, http.StatusConflict, e.Code, "should not stop an exec that isn't running")
+	require.Zero(t, driver.ExecStopCallCount())
+
+	require.NoError(t, store.UpdateStatus("exc_stopstarttest", types.StatusRunning, time.Now(), time.Time{}))
+
+	require.NoError(t, srv.Stop(ctx, "exc_stopstarttest"))
+	require.Equal(t, 1, driver.ExecStopCallCount())
+
+	got, err := store.Get("exc_stopstarttest")
+	require.NoError(t, err)
+	require.Equal(t, types.StatusStopped, got.Status)
+
+	require.NoError(t, srv.Start(ctx, "exc_stopstarttest"))
+	require.Equal(t, 1, driver.ExecStartCallCount())
+
+	got, err = store.Get("exc_stopstarttest")
+	require.NoError(t, err)
+	require.Equal(t, types.StatusInitializing, got.Status)
+
+	events, err := store.ListEvents("exc_stopstarttest")
+	require.NoError(t, err)
+	require.Len(t, events, 2, "should record stops and starts for cost accrual")
+	require.Equal(t, types.StatusStopped, events[0].Status)
+	require.Equal(t, types.StatusInitializing, events[1].Status)
+}
+
+// recordingHeartbeatInformers counts the heartbeat informers added for execs.
+type recordingHeartbeatInformers struct {
+	added    int
+	informer *recordingHeartbeatInformer
+}
+
+func (r *recordingHeartbeatInformers) Add(types.Exec) execsrv.HeartbeatInformer {
+	r.added++
+	return r.informer
+}
+func (r *recordingHeartbeatInformers) Remove(string) {}
+
+type recordingHeartbeatInformer struct {
+	watched   int
+	observers []execsrv.HeartbeatObserver
+}
+
+func (r *recordingHeartbeatInformer) Register(o execsrv.HeartbeatObserver) {
+	r.observers = append(r.observers, o)
+}
+func (r *recordingHeartbeatInformer) Unregister(execsrv.HeartbeatObserver) {}
+func (r *recordingHeartbeatInformer) Watch()                               { r.watched++ }
+
+func TestExecService_StartMonitorsHeartbeat(t *testing.T) {
+	t.Parallel()
+
+	store := execsrv.NewMemoryStore()
+	driver := new(execsrvfakes.FakeDriver)
+	driver.ExecProviderReturns(types.LocalProvider)
+
+	pub := "ssh-ed25519 AAAA"
+	require.NoError(t, store.Create("pr_heartbeattest", types.Exec{
+		ID:       "exc_heartbeattest",
+		Name:     "heartbeat",
+		Status:   types.StatusStopped,
+		Keys:     []types.SSHKey{{Name: "key", PublicKey: &pub}},
+		Provider: types.LocalProvider,
+	}))
+
+	heartbeats := &recordingHeartbeatInformers{informer: &recordingHeartbeatInformer{}}
+	stats := execsrv.NewPollingStatsInformerManager(store, driver)
+
+	srv := execsrv.NewService(store, driver, nil, noopStateInformers{}, stats, heartbeats)
+	srv = execsrv.WithHeartbeatObserver(srv, execsrv.HeartbeatObserverFactoryFunc(func(types.Exec) execsrv.HeartbeatObserver {
+		return new(execsrvfakes.FakeHeartbeatObserver)
+	}))
+
+	require.NoError(t, srv.Start(context.Background(), "exc_heartbeattest"))
+	require.Equal(t, 1, heartbeats.added, "should watch the heartbeat of the started exec")
+	require.Equal(t, 1, heartbeats.informer.watched)
+	require.Len(t, heartbeats.informer.observers, 1, "should register the heartbeat observers again")
+}
+
+// recordingStateInformers hands out a single informer that records its observers.
+type recordingStateInformers struct {
+	informer *recordingStateInformer
+}
+
+func (r recordingStateInformers) Add(types.Exec) execsrv.StateInformer     { return r.informer }
+func (r recordingStateInformers) Get(string) (execsrv.StateInformer, bool) { return r.informer, true }
+func (r recordingStateInformers) Remove(string)                            {}
+
+type recordingStateInformer struct {
+	observers []execsrv.StateObserver
+	watched   int
+}
+
+func (r *recordingStateInformer) Register(o execsrv.StateObserver) {
+	r.observers = append(r.observers, o)
+}
+func (r *recordingStateInformer) Unregister(execsrv.StateObserver) {}
+func (r *recordingStateInformer) Watch()                           { r.watched++ }
+
+func (r *recordingStateInformer) inform(state execsrv.State) {
+	for _, o := range r.observers {
+		o.Update(state)
+	}
+}
+
+type exitCodeDriver struct {
+	*execsrvfakes.FakeDriver
+	exitCode int
+}
+
+func (d exitCodeDriver) ExecExitCode(context.Context, string) (int, error) {
+	return d.exitCode, nil
+}
+
+func TestExecService_Job(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	params := types.ExecCreateParams{
+		Provider:     types.LocalProvider,
+		SSHKeyName:   "key",
+		SSHPublicKey: "ssh-ed25519 AAAA",
+		Command:      []string{"python", "train.py"},
+		Job:          true,
+	}
+
+	var e *types.Error
+
+	_, err := newTestService(execsrv.NewMemoryStore(), new(execsrvfakes.FakeDriver)).Create(ctx, "pr_jobtest", "usr_1", params)
+	require.True(t, errors.As(err, &e))
+	require.Equal(t, http.StatusBadRequest, e.Code, "should reject jobs on drivers without exit codes")
+
+	store := execsrv.NewMemoryStore()
+	fake := new(execsrvfakes.FakeDriver)
+	fake.ExecProviderReturns(types.LocalProvider)
+	fake.ExecCreateReturns("exc_jobtest", nil)
+
+	informer := &recordingStateInformer{}
+	stats := execsrv.NewPollingStatsInformerManager(store, fake)
+	srv := execsrv.NewService(store, exitCodeDriver{FakeDriver: fake, exitCode: 3}, nil, recordingStateInformers{informer}, stats, nil)
+
+	exec, err := srv.Create(ctx, "pr_jobtest", "usr_1", params)
+	require.NoError(t, err)
+	require.True(t, exec.Job)
+
+	_, _, _, command, _, _, _, _, _ := fake.ExecCreateArgsForCall(0)
+	require.Equal(t, params.Command, command)
+
+	informer.inform(execsrv.State{Status: types.StatusRunning})
+	require.Zero(t, fake.ExecTerminateCallCount(), "should only finish jobs once the command exits")
+
+	informer.inform(execsrv.State{Status: types.StatusFailed})
+	require.Equal(t, 1, fake.ExecTerminateCallCount())
+
+	got, err := store.Get(exec.ID)
+	require.NoError(t, err)
+	require.Equal(t, types.StatusFailed, got.Status)
+	require.Equal(t, 3, *got.ExitCode)
+	require.NotNil(t, got.ExitedAt)
+
+	result := types.NewExecResult(got)
+	require.Equal(t, 3, *result.ExitCode)
+	require.NotNil(t, result.Duration)
+}
@@ -49,7 +49,11 @@ func (p postgresStore) Create(projectID string, exec types.Exec) error {
 	}
 
 	metadata, err := json.Marshal(&types.NodeMetadataV1{
-		HTTPService: exec.Network.HTTPService,
+		HTTPService:      exec.Network.HTTPService,
+		IdlePolicy:       exec.IdlePolicy,
+		ProviderAttempts: exec.ProviderAttempts,
+		HourlyPrice:      exec.HourlyPrice,
+		Job:              exec.Job,
 	})
 	if err != nil {
 		return fmt.Errorf("failed to marshal metadata to JSON: %w", err)
@@ -73,7 +77,15 @@ func (p postgresStore) Create(projectID string, exec types.Exec) error {
 		Provider:     exec.Provider.String(),
 		CommitID:     db.NullStringFrom(exec.CommitID),
 		GitRemoteUrl: db.NullStringFrom(exec.GitURL),
-		Command:      []string{},
+		Command:      exec.Command,
+	}
+
+	if params.Command == nil {
+		params.Command = []string{}
+	}
+
+	if exec.ExpiresAt != nil {
+		params.ExpiresAt = db.NullTimeFrom(*exec.ExpiresAt)
 	}
 
 	if err = p.db.ExecCreate(ctx, params); err != nil {
@@ -169,6 +181,45 @@ func (p postgresStore) List(projectID *string, filterProvider *types.Provider, f
 		return nil, fmt.Errorf("failed to list execs: %w", err)
 	}
 
+	return p.toExecs(ctx, execs)
+}
+
+func (p postgresStore) ListUnfinished(filterProvider *types.Provider) ([]types.Exec, error) {
+	ctx := context.Background()
+
+	var provider sql.NullString
+	if filterProvider != nil {
+		provider = sql.NullString{String: filterProvider.String(), Valid: true}
+	}
+
+	execs, err := p.db.ExecListUnfinished(ctx, provider)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list unfinished execs: %w", err)
+	}
+
+	return p.toExecs(ctx, execs)
+}
+
+func (p postgresStore) ListInWindow(projectID string, from, to time.Time) ([]types.Exec, error) {
+	execs, err := p.db.ExecListInWindow(context.Background(), db.ExecListInWindowParams{
+		ProjectID: projectID,
+		ToTime:    to,
+		FromTime:  from,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list execs in window: %w", err)
+	}
+
+	res := make([]types.Exec, len(execs))
+	for idx, exec := range execs {
+		res[idx] = dbExecToExec(exec, nil, nil)
+	}
+
+	return res, nil
+}
+
+// toExecs loads the keys and volumes of the execs.
+func (p postgresStore) toExecs(ctx context.Context, execs []db.UnweaveExec) ([]types.Exec, error) {
 	res := make([]types.Exec, len(execs))
 
 	for idx, exec := range execs {
@@ -211,8 +262,25 @@ func (p postgresStore) Delete(id string) error {
 	return nil
 }
 
-func (p postgresStore) Update(id string, exec types.Exec) error {
-	panic("implement me")
+func (p postgresStore) Finish(id string, status types.Status, exitCode *int) error {
+	ctx := context.Background()
+
+	if exitCode != nil {
+		params := db.ExecUpdateExitCodeParams{ID: id, ExitCode: int32(*exitCode)}
+		if err := p.db.ExecUpdateExitCode(ctx, params); err != nil {
+			return fmt.Errorf("failed to update exit code: %w", err)
+		}
+	}
+
+	if err := p.db.ExecVolumeDelete(ctx, id); err != nil {
+		return fmt.Errorf("failed to unassign volumes for exec with error: %w", err)
+	}
+
+	if err := p.UpdateStatus(id, status, time.Time{}, time.Now()); err != nil {
+		return fmt.Errorf("failed to update exec status in store: %w", err)
+	}
+
+	return nil
 }
 
 // UpdateStatus updates exec status and relevant timestamps.
@@ -246,6 +314,75 @@ func (p postgresStore) UpdateConnectionInfo(execID string, connInfo types.Connec
 	return nil
 }
 
+func (p postgresStore) UpdateTerminationReason(execID string, reason string) error {
+	params := db.ExecUpdateTerminationReasonParams{
+		ID:                execID,
+		TerminationReason: reason,
+	}
+	if err := p.db.ExecUpdateTerminationReason(context.Background(), params); err != nil {
+		return fmt.Errorf("failed to update termination reason: %w", err)
+	}
+
+	return nil
+}
+
+func (p postgresStore) AddEvent(event types.ExecEvent) error {
+	params := db.ExecEventCreateParams{
+		ExecID: event.ExecID,
+		Status: string(event.Status),
+		Source: event.Source,
+		Error:  sql.NullString{String: event.Error, Valid: event.Error != ""},
+	}
+	if err := p.db.ExecEventCreate(context.Background(), params); err != nil {
+		return fmt.Errorf("failed to add exec event: %w", err)
+	}
+
+	return nil
+}
+
+func (p postgresStore) ListEvents(execID string) ([]types.ExecEvent, error) {
+	rows, err := p.db.ExecEventList(context.Background(), execID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list exec events: %w", err)
+	}
+
+	events := make([]types.ExecEvent, len(rows))
+	for i, row := range rows {
+		events[i] = dbExecEventToExecEvent(row)
+	}
+
+	return events, nil
+}
+
+func (p postgresStore) ListEventsByExecs(execIDs []string) (map[string][]types.ExecEvent, error) {
+	res := make(map[string][]types.ExecEvent, len(execIDs))
+
+	if len(execIDs) == 0 {
+		return res, nil
+	}
+
+	rows, err := p.db.ExecEventListByExecs(context.Background(), execIDs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list exec events: %w", err)
+	}
+
+	for _, row := range rows {
+		res[row.ExecID] = append(res[row.ExecID], dbExecEventToExecEvent(row))
+	}
+
+	return res, nil
+}
+
+func dbExecEventToExecEvent(row db.UnweaveExecEvent) types.ExecEvent {
+	return types.ExecEvent{
+		ExecID:    row.ExecID,
+		Status:    types.Status(row.Status),
+		Source:    row.Source,
+		Error:     row.Error.String,
+		CreatedAt: row.CreatedAt,
+	}
+}
+
 func (p postgresStore) addSSHKeyToExec(ctx context.Context, exec types.Exec, keys []db.UnweaveSshKey) error {
 	for _, key := range keys {
 		err := p.db.ExecSSHKeyInsert(ctx, db.ExecSSHKeyInsertParams{
@@ -326,6 +463,11 @@ func dbExecToExec(dbe db.UnweaveExec, volumes []types.ExecVolume, keys []types.S
 		exitedAt = &dbe.ExitedAt.Time
 	}
 
+	var expiresAt *time.Time
+	if dbe.ExpiresAt.Valid {
+		expiresAt = &dbe.ExpiresAt.Time
+	}
+
 	metadataFromJSON, err := types.NodeMetadataFromJSON(dbe.Metadata)
 	if err != nil {
 		log.Err(err).Msg("failed to properly unmarshal node metadata, metadata will not be parsed")
@@ -339,24 +481,55 @@ func dbExecToExec(dbe db.UnweaveExec, volumes []types.ExecVolume, keys []types.S
 		spec = new(types.HardwareSpec)
 	}
 
+	var (
+		idlePolicy        *types.IdlePolicy
+		terminationReason string
+		providerAttempts  []types.ProviderAttempt
+		hourlyPrice       *int
+		job               bool
+		exitCode          *int
+	)
+
+	if metadataFromJSON != nil {
+		idlePolicy = metadataFromJSON.IdlePolicy
+		terminationReason = metadataFromJSON.TerminationReason
+		providerAttempts = metadataFromJSON.ProviderAttempts
+		hourlyPrice = metadataFromJSON.HourlyPrice
+		job = metadataFromJSON.Job
+		exitCode = metadataFromJSON.ExitCode
+	}
+
+	var readyAt *time.Time
+	if dbe.ReadyAt.Valid {
+		readyAt = &dbe.ReadyAt.Time
+	}
+
 	return types.Exec{
-		ID:        dbe.ID,
-		Name:      dbe.Name,
-		CreatedAt: dbe.CreatedAt,
-		ExitedAt:  exitedAt,
-		CreatedBy: dbe.CreatedBy,
-		Image:     dbe.Image,
-		BuildID:   bid,
-		Status:    types.Status(dbe.Status),
-		Command:   dbe.Command,
-		Keys:      keys,
-		Volumes:   volumes,
-		Network:   metadataFromJSON.GetExecNetwork(),
-		Spec:      *spec,
-		CommitID:  commitID,
-		GitURL:    githubRemoteURL,
-		Region:    dbe.Region,
-		Provider:  types.Provider(dbe.Provider),
+		ID:                dbe.ID,
+		Name:              dbe.Name,
+		CreatedAt:         dbe.CreatedAt,
+		ReadyAt:           readyAt,
+		ExitedAt:          exitedAt,
+		CreatedBy:         dbe.CreatedBy,
+		Image:             dbe.Image,
+		BuildID:           bid,
+		Status:            types.Status(dbe.Status),
+		Command:           dbe.Command,
+		Keys:              keys,
+		Volumes:           volumes,
+		Network:           metadataFromJSON.GetExecNetwork(),
+		Spec:              *spec,
+		CommitID:          commitID,
+		GitURL:            githubRemoteURL,
+		Region:            dbe.Region,
+		Provider:          types.Provider(dbe.Provider),
+		IdlePolicy:        idlePolicy,
+		TerminationReason: terminationReason,
+		ExpiresAt:         expiresAt,
+		ProviderAttempts:  providerAttempts,
+		HourlyPrice:       hourlyPrice,
+		Job:               job,
+		ExitCode:          exitCode,
 	}
 }
 
@@ -0,0 +1,49 @@
+package execsrv_test
+
+import (
+	"testing"
+
+	"github.com/unweave/unweave-v1/db"
+	"github.com/unweave/unweave-v1/db/dbtest"
+	"github.com/unweave/unweave-v1/services/execsrv"
+	"github.com/unweave/unweave-v1/services/execsrv/execsrvtest"
+	"github.com/unweave/unweave-v1/tools/random"
+)
+
+func TestMemoryStore(t *testing.T) {
+	t.Parallel()
+
+	execsrvtest.TestStore(t, func(t *testing.T) execsrv.Store {
+		return execsrv.NewMemoryStore()
+	}, execsrvtest.StoreFixtures{
+		ProjectID:      "pr_memoryproject",
+		OtherProjectID: "pr_otherproject",
+		CreatedBy:      "acc_memoryaccount",
+		VolumeID:       "uwv_memoryvolume",
+	})
+}
+
+func TestPostgresStore(t *testing.T) {
+	t.Parallel()
+
+	conn := dbtest.Connect(t)
+	querier := db.New(conn)
+
+	fx := execsrvtest.StoreFixtures{
+		ProjectID:      dbtest.CreateProject(t, conn),
+		OtherProjectID: dbtest.CreateProject(t, conn),
+		CreatedBy:      dbtest.CreateAccount(t, conn),
+		VolumeID:       "uwv_" + random.GenerateRandomLower(12),
+	}
+
+	if _, err := conn.Exec(
+		"insert into unweave.volume (id, project_id, provider, name, size) values ($1, $2, 'local', $1, 10)",
+		fx.VolumeID, fx.ProjectID,
+	); err != nil {
+		t.Fatalf("failed to create volume: %v", err)
+	}
+
+	execsrvtest.TestStore(t, func(t *testing.T) execsrv.Store {
+		return execsrv.NewPostgresStoreDB(querier)
+	}, fx)
+}
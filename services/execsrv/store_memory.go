@@ -0,0 +1,341 @@
+package execsrv
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/unweave/unweave-v1/api/types"
+)
+
+// memoryStore is a thread-safe in-memory Store. It's meant for tests and single node
+// deployments where running Postgres isn't worth it. Nothing is persisted across restarts.
+type memoryStore struct {
+	mu       sync.RWMutex
+	execs    map[string]types.Exec
+	projects map[string]string // exec ID -> project ID
+	events   map[string][]types.ExecEvent
+}
+
+func NewMemoryStore() Store {
+	return &memoryStore{
+		execs:    make(map[string]types.Exec),
+		projects: make(map[string]string),
+		events:   make(map[string][]types.ExecEvent),
+	}
+}
+
+func (m *memoryStore) Create(projectID string, exec types.Exec) error {
+	if projectID == "" {
+		return fmt.Errorf("an Exec must be attached to a project")
+	}
+
+	publicKeys := filterNullPublicKeys(exec.Keys)
+	if len(publicKeys) > 1 || len(publicKeys) == 0 {
+		return fmt.Errorf("an Exec must be created with one and only one SSH public key")
+	}
+	if exec.Name == "" {
+		return fmt.Errorf("an Exec must be named")
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if _, ok := m.execs[exec.ID]; ok {
+		return ErrAlreadyExists
+	}
+
+	for id, e := range m.execs {
+		if m.projects[id] == projectID && e.Name == exec.Name {
+			return ErrAlreadyExists
+		}
+	}
+
+	if exec.CreatedAt.IsZero() {
+		exec.CreatedAt = time.Now()
+	}
+
+	// Only the HTTP service is known at creation, the rest of the network is set once
+	// the exec is running.
+	exec.Network = types.ExecNetwork{HTTPService: exec.Network.HTTPService}
+	exec.Keys = publicKeys
+
+	m.execs[exec.ID] = copyExec(exec)
+	m.projects[exec.ID] = projectID
+
+	return nil
+}
+
+func (m *memoryStore) Get(id string) (types.Exec, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	exec, ok := m.get(id)
+	if !ok {
+		return types.Exec{}, ErrNotFound
+	}
+
+	return copyExec(exec), nil
+}
+
+// get looks up an exec by ID or name. Callers must hold the lock.
+func (m *memoryStore) get(idOrName string) (types.Exec, bool) {
+	if exec, ok := m.execs[idOrName]; ok {
+		return exec, true
+	}
+
+	for _, exec := range m.execs {
+		if exec.Name == idOrName {
+			return exec, true
+		}
+	}
+
+	return types.Exec{}, false
+}
+
+func (m *memoryStore) GetDriver(id string) (string, error) {
+	exec, err := m.Get(id)
+	if err != nil {
+		return "", err
+	}
+
+	return exec.Provider.String(), nil
+}
+
+func (m *memoryStore) List(filterProject *string, filterProvider *types.Provider, filterActive bool) ([]types.Exec, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	res := make([]types.Exec, 0, len(m.execs))
+
+	for id, exec := range m.execs {
+		if filterProject != nil && m.projects[id] != *filterProject {
+			continue
+		}
+		if filterProvider != nil && exec.Provider != *filterProvider {
+			continue
+		}
+		if filterActive && !isActive(exec.Status) {
+			continue
+		}
+
+		res = append(res, copyExec(exec))
+	}
+
+	return res, nil
+}
+
+func (m *memoryStore) ListUnfinished(filterProvider *types.Provider) ([]types.Exec, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	var res []types.Exec
+
+	for _, exec := range m.execs {
+		if filterProvider != nil && exec.Provider != *filterProvider {
+			continue
+		}
+		if !isActive(exec.Status) && exec.Status != types.StatusStopped {
+			continue
+		}
+
+		res = append(res, copyExec(exec))
+	}
+
+	return res, nil
+}
+
+func (m *memoryStore) ListInWindow(projectID string, from, to time.Time) ([]types.Exec, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	var res []types.Exec
+
+	for id, exec := range m.execs {
+		if m.projects[id] != projectID || exec.CreatedAt.After(to) {
+			continue
+		}
+		if exec.ExitedAt != nil && exec.ExitedAt.Before(from) {
+			continue
+		}
+
+		res = append(res, copyExec(exec))
+	}
+
+	return res, nil
+}
+
+func isActive(status types.Status) bool {
+	return status == types.StatusPending ||
+		status == types.StatusInitializing ||
+		status == types.StatusRunning
+}
+
+func (m *memoryStore) Delete(id string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	exec, ok := m.execs[id]
+	if !ok {
+		return nil
+	}
+
+	// Execs are soft deleted, same as in the postgres store.
+	now := time.Now()
+	exec.Volumes = nil
+	exec.Status = types.StatusTerminated
+	exec.ExitedAt = &now
+	m.execs[id] = exec
+
+	return nil
+}
+
+func (m *memoryStore) Finish(id string, status types.Status, exitCode *int) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	exec, ok := m.execs[id]
+	if !ok {
+		return nil
+	}
+
+	now := time.Now()
+	exec.Volumes = nil
+	exec.Status = status
+	exec.ExitedAt = &now
+	exec.ExitCode = exitCode
+	m.execs[id] = exec
+
+	return nil
+}
+
+func (m *memoryStore) UpdateStatus(id string, status types.Status, setReadyAt, setExitedAt time.Time) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	exec, ok := m.execs[id]
+	if !ok {
+		return nil
+	}
+
+	exec.Status = status
+	if !setReadyAt.IsZero() {
+		exec.ReadyAt = &setReadyAt
+	}
+	if !setExitedAt.IsZero() {
+		exec.ExitedAt = &setExitedAt
+	}
+	m.execs[id] = exec
+
+	return nil
+}
+
+func (m *memoryStore) UpdateConnectionInfo(execID string, info types.ConnectionInfo) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	exec, ok := m.execs[execID]
+	if !ok {
+		return nil
+	}
+
+	exec.Network.Host = info.Host
+	exec.Network.Port = info.Port
+	exec.Network.User = info.User
+	m.execs[execID] = exec
+
+	return nil
+}
+
+func (m *memoryStore) UpdateTerminationReason(execID string, reason string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	exec, ok := m.execs[execID]
+	if !ok {
+		return nil
+	}
+
+	exec.TerminationReason = reason
+	m.execs[execID] = exec
+
+	return nil
+}
+
+func (m *memoryStore) AddEvent(event types.ExecEvent) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if _, ok := m.execs[event.ExecID]; !ok {
+		return ErrNotFound
+	}
+
+	if event.CreatedAt.IsZero() {
+		event.CreatedAt = time.Now()
+	}
+	m.events[event.ExecID] = append(m.events[event.ExecID], event)
+
+	return nil
+}
+
+func (m *memoryStore) ListEvents(execID string) ([]types.ExecEvent, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	return append([]types.ExecEvent(nil), m.events[execID]...), nil
+}
+
+func (m *memoryStore) ListEventsByExecs(execIDs []string) (map[string][]types.ExecEvent, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	res := make(map[string][]types.ExecEvent, len(execIDs))
+
+	for _, id := range execIDs {
+		if events, ok := m.events[id]; ok {
+			res[id] = append([]types.ExecEvent(nil), events...)
+		}
+	}
+
+	return res, nil
+}
+
+// copyExec returns a copy of the exec that doesn't share slices or pointers with the
+// original so that callers can't mutate the store's state.
+func copyExec(exec types.Exec) types.Exec {
+	exec.Command = append([]string(nil), exec.Command...)
+	exec.Keys = append([]types.SSHKey(nil), exec.Keys...)
+	exec.Volumes = append([]types.ExecVolume(nil), exec.Volumes...)
+
+	if exec.ReadyAt != nil {
+		readyAt := *exec.ReadyAt
+		exec.ReadyAt = &readyAt
+	}
+
+	if exec.ExitedAt != nil {
+		exitedAt := *exec.ExitedAt
+		exec.ExitedAt = &exitedAt
+	}
+
+	if exec.ExitCode != nil {
+		exitCode := *exec.ExitCode
+		exec.ExitCode = &exitCode
+	}
+
+	if exec.ExpiresAt != nil {
+		expiresAt := *exec.ExpiresAt
+		exec.ExpiresAt = &expiresAt
+	}
+
+	if exec.Network.HTTPService != nil {
+		svc := *exec.Network.HTTPService
+		exec.Network.HTTPService = &svc
+	}
+
+	if exec.IdlePolicy != nil {
+		policy := *exec.IdlePolicy
+		exec.IdlePolicy = &policy
+	}
+
+	return exec
+}
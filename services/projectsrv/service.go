@@ -0,0 +1,78 @@
+package projectsrv
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/unweave/unweave-v1/api/types"
+	"github.com/unweave/unweave-v1/db"
+)
+
+var errProjectNotFound = &types.Error{
+	Code:       http.StatusNotFound,
+	Message:    "Project not found",
+	Suggestion: "Make sure the project id is valid",
+}
+
+type Store interface {
+	AccountCreate(ctx context.Context, id string) error
+	ProjectAccountAdd(ctx context.Context, arg db.ProjectAccountAddParams) error
+	ProjectAccountRemove(ctx context.Context, arg db.ProjectAccountRemoveParams) (int64, error)
+	ProjectGet(ctx context.Context, id string) (db.UnweaveProject, error)
+}
+
+// Service manages which accounts have access to a project, see middleware.WithProjectCtx.
+type Service struct {
+	store Store
+}
+
+func NewService(store Store) *Service {
+	return &Service{store: store}
+}
+
+// AddAccount gives the account access to the project. The account is created if this is
+// the first time it's seen. Adding an existing member is a no-op.
+func (s *Service) AddAccount(ctx context.Context, projectID, accountID string) error {
+	if _, err := s.store.ProjectGet(ctx, projectID); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return errProjectNotFound
+		}
+		return fmt.Errorf("get project: %w", err)
+	}
+
+	if err := s.store.AccountCreate(ctx, accountID); err != nil {
+		return fmt.Errorf("create account: %w", err)
+	}
+
+	err := s.store.ProjectAccountAdd(ctx, db.ProjectAccountAddParams{
+		ProjectID: projectID,
+		AccountID: accountID,
+	})
+	if err != nil {
+		return fmt.Errorf("add project account: %w", err)
+	}
+
+	return nil
+}
+
+func (s *Service) RemoveAccount(ctx context.Context, projectID, accountID string) error {
+	n, err := s.store.ProjectAccountRemove(ctx, db.ProjectAccountRemoveParams{
+		ProjectID: projectID,
+		AccountID: accountID,
+	})
+	if err != nil {
+		return fmt.Errorf("remove project account: %w", err)
+	}
+
+	if n == 0 {
+		return &types.Error{
+			Code:    http.StatusNotFound,
+			Message: "Account is not a member of the project",
+		}
+	}
+
+	return nil
+}
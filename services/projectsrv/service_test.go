@@ -0,0 +1,101 @@
+package projectsrv_test
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/unweave/unweave-v1/api/types"
+	"github.com/unweave/unweave-v1/db"
+	"github.com/unweave/unweave-v1/services/projectsrv"
+)
+
+type memStore struct {
+	projects map[string]bool
+	accounts map[string]bool
+	members  map[db.ProjectAccountAddParams]bool
+}
+
+func newMemStore(projects ...string) *memStore {
+	m := &memStore{
+		projects: map[string]bool{},
+		accounts: map[string]bool{},
+		members:  map[db.ProjectAccountAddParams]bool{},
+	}
+	for _, p := range projects {
+		m.projects[p] = true
+	}
+
+	return m
+}
+
+func (m *memStore) AccountCreate(_ context.Context, id string) error {
+	m.accounts[id] = true
+	return nil
+}
+
+func (m *memStore) ProjectAccountAdd(_ context.Context, arg db.ProjectAccountAddParams) error {
+	if !m.projects[arg.ProjectID] || !m.accounts[arg.AccountID] {
+		return errors.New("foreign key violation")
+	}
+
+	m.members[arg] = true
+	return nil
+}
+
+func (m *memStore) ProjectAccountRemove(_ context.Context, arg db.ProjectAccountRemoveParams) (int64, error) {
+	key := db.ProjectAccountAddParams{ProjectID: arg.ProjectID, AccountID: arg.AccountID}
+	if !m.members[key] {
+		return 0, nil
+	}
+
+	delete(m.members, key)
+	return 1, nil
+}
+
+func (m *memStore) ProjectGet(_ context.Context, id string) (db.UnweaveProject, error) {
+	if !m.projects[id] {
+		return db.UnweaveProject{}, sql.ErrNoRows
+	}
+
+	return db.UnweaveProject{ID: id}, nil
+}
+
+func TestService_AddRemoveAccount(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	store := newMemStore("pr_1")
+	srv := projectsrv.NewService(store)
+
+	require.NoError(t, srv.AddAccount(ctx, "pr_1", "acc_1"))
+	require.True(t, store.accounts["acc_1"])
+	require.True(t, store.members[db.ProjectAccountAddParams{ProjectID: "pr_1", AccountID: "acc_1"}])
+
+	// Adding twice is a no-op.
+	require.NoError(t, srv.AddAccount(ctx, "pr_1", "acc_1"))
+
+	require.NoError(t, srv.RemoveAccount(ctx, "pr_1", "acc_1"))
+	require.Empty(t, store.members)
+
+	var e *types.Error
+	err := srv.RemoveAccount(ctx, "pr_1", "acc_1")
+	require.ErrorAs(t, err, &e)
+	require.Equal(t, http.StatusNotFound, e.Code)
+}
+
+func TestService_AddAccountUnknownProject(t *testing.T) {
+	t.Parallel()
+
+	store := newMemStore()
+	srv := projectsrv.NewService(store)
+
+	var e *types.Error
+	err := srv.AddAccount(context.Background(), "pr_missing", "acc_1")
+	require.ErrorAs(t, err, &e)
+	require.Equal(t, http.StatusNotFound, e.Code)
+	require.Empty(t, store.accounts)
+}
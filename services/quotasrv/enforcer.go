@@ -0,0 +1,51 @@
+package quotasrv
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/unweave/unweave-v1/api/types"
+	"github.com/unweave/unweave-v1/services/execsrv"
+)
+
+// EnforcingService is an execsrv.Service that rejects creating or starting sessions that
+// would breach their project's quotas. Checks aren't serialized with the wrapped service so concurrent
+// requests can overshoot a quota by the sessions racing each other.
+type EnforcingService struct {
+	execsrv.Service
+
+	quotas *Service
+}
+
+var _ execsrv.Service = (*EnforcingService)(nil)
+
+func NewEnforcingService(next execsrv.Service, quotas *Service) *EnforcingService {
+	return &EnforcingService{Service: next, quotas: quotas}
+}
+
+func (s *EnforcingService) Create(
+	ctx context.Context,
+	projectID string,
+	creator string,
+	params types.ExecCreateParams,
+) (types.Exec, error) {
+	if err := s.quotas.Check(ctx, projectID, params.Spec); err != nil {
+		return types.Exec{}, err
+	}
+
+	return s.Service.Create(ctx, projectID, creator, params)
+}
+
+// Start checks the quotas again since stopped sessions don't count towards them.
+func (s *EnforcingService) Start(ctx context.Context, id string) error {
+	exec, err := s.Service.Get(ctx, id)
+	if err != nil {
+		return fmt.Errorf("get exec: %w", err)
+	}
+
+	if err := s.quotas.CheckExec(ctx, exec); err != nil {
+		return err
+	}
+
+	return s.Service.Start(ctx, id)
+}
@@ -0,0 +1,208 @@
+package quotasrv
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"math"
+	"net/http"
+	"time"
+
+	"github.com/unweave/unweave-v1/api/types"
+	"github.com/unweave/unweave-v1/db"
+	"github.com/unweave/unweave-v1/services/costsrv"
+)
+
+var errProjectNotFound = &types.Error{
+	Code:       http.StatusNotFound,
+	Message:    "Project not found",
+	Suggestion: "Make sure the project id is valid",
+}
+
+type Store interface {
+	ExecGet(ctx context.Context, idOrName string) (db.UnweaveExec, error)
+	ProjectGet(ctx context.Context, id string) (db.UnweaveProject, error)
+	ProjectQuotasUpdate(ctx context.Context, arg db.ProjectQuotasUpdateParams) (int64, error)
+}
+
+type ExecStore interface {
+	costsrv.ExecStore
+	List(filterProject *string, filterProvider *types.Provider, filterActive bool) ([]types.Exec, error)
+}
+
+// Service manages the quotas of projects and checks new sessions against them.
+type Service struct {
+	store Store
+	execs ExecStore
+	costs *costsrv.Service
+}
+
+func NewService(store Store, execs ExecStore) *Service {
+	return &Service{store: store, execs: execs, costs: costsrv.NewService(execs)}
+}
+
+func (s *Service) Get(ctx context.Context, projectID string) (types.ProjectQuotas, error) {
+	project, err := s.store.ProjectGet(ctx, projectID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return types.ProjectQuotas{}, errProjectNotFound
+		}
+		return types.ProjectQuotas{}, fmt.Errorf("get project: %w", err)
+	}
+
+	quotas := types.ProjectQuotas{
+		MaxConcurrentSessions: intFromNull(project.MaxConcurrentSessions),
+		MaxGPUs:               intFromNull(project.MaxGpus),
+	}
+	if cents := intFromNull(project.MonthlySpendLimitCents); cents != nil {
+		limit := float64(*cents) / 100
+		quotas.MonthlySpendLimit = &limit
+	}
+
+	return quotas, nil
+}
+
+// Set replaces all the quotas of the project.
+func (s *Service) Set(ctx context.Context, projectID string, quotas types.ProjectQuotas) (types.ProjectQuotas, error) {
+	params := db.ProjectQuotasUpdateParams{
+		ID:                    projectID,
+		MaxConcurrentSessions: nullFromInt(quotas.MaxConcurrentSessions),
+		MaxGpus:               nullFromInt(quotas.MaxGPUs),
+	}
+	if quotas.MonthlySpendLimit != nil {
+		cents := int(math.Round(*quotas.MonthlySpendLimit * 100))
+		params.MonthlySpendLimitCents = nullFromInt(&cents)
+	}
+
+	n, err := s.store.ProjectQuotasUpdate(ctx, params)
+	if err != nil {
+		return types.ProjectQuotas{}, fmt.Errorf("update project quotas: %w", err)
+	}
+	if n == 0 {
+		return types.ProjectQuotas{}, errProjectNotFound
+	}
+
+	return s.Get(ctx, projectID)
+}
+
+// Usage returns the project's current consumption counted against its quotas. Only
+// pending, initializing and running sessions count towards concurrency and GPUs.
+func (s *Service) Usage(ctx context.Context, projectID string) (types.QuotaUsage, error) {
+	active, err := s.execs.List(&projectID, nil, true)
+	if err != nil {
+		return types.QuotaUsage{}, fmt.Errorf("list active execs: %w", err)
+	}
+
+	usage := types.QuotaUsage{ConcurrentSessions: len(active)}
+	for _, exec := range active {
+		usage.GPUs += gpuCount(exec.Spec)
+	}
+
+	now := time.Now().UTC()
+	monthStart := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, time.UTC)
+
+	report, err := s.costs.Usage(ctx, projectID, monthStart, now)
+	if err != nil {
+		return types.QuotaUsage{}, fmt.Errorf("get monthly usage: %w", err)
+	}
+	usage.MonthlySpend = report.Cost
+
+	return usage, nil
+}
+
+func (s *Service) Report(ctx context.Context, projectID string) (types.ProjectQuotasResponse, error) {
+	quotas, err := s.Get(ctx, projectID)
+	if err != nil {
+		return types.ProjectQuotasResponse{}, err
+	}
+
+	usage, err := s.Usage(ctx, projectID)
+	if err != nil {
+		return types.ProjectQuotasResponse{}, err
+	}
+
+	return types.ProjectQuotasResponse{Quotas: quotas, Usage: usage}, nil
+}
+
+// Check returns a types.Error if starting a session with the spec would breach any of
+// the project's quotas.
+func (s *Service) Check(ctx context.Context, projectID string, spec types.HardwareSpec) error {
+	quotas, err := s.Get(ctx, projectID)
+	if err != nil {
+		return err
+	}
+
+	if quotas == (types.ProjectQuotas{}) {
+		return nil
+	}
+
+	usage, err := s.Usage(ctx, projectID)
+	if err != nil {
+		return err
+	}
+
+	if max := quotas.MaxConcurrentSessions; max != nil && usage.ConcurrentSessions+1 > *max {
+		return &types.Error{
+			Code:       http.StatusForbidden,
+			Message:    fmt.Sprintf("Project is limited to %d concurrent sessions, %d are active", *max, usage.ConcurrentSessions),
+			Suggestion: "Terminate or stop a session, or ask an admin to raise the project's session quota",
+		}
+	}
+
+	if max := quotas.MaxGPUs; max != nil {
+		if requested := gpuCount(spec); requested > 0 && usage.GPUs+requested > *max {
+			return &types.Error{
+				Code: http.StatusForbidden,
+				Message: fmt.Sprintf(
+					"Project is limited to %d GPUs, %d are in use and %d were requested",
+					*max, usage.GPUs, requested,
+				),
+				Suggestion: "Request fewer GPUs, terminate a GPU session, or ask an admin to raise the project's GPU quota",
+			}
+		}
+	}
+
+	if limit := quotas.MonthlySpendLimit; limit != nil && usage.MonthlySpend >= *limit {
+		return &types.Error{
+			Code:       http.StatusForbidden,
+			Message:    fmt.Sprintf("Project has spent $%.2f of its $%.2f monthly limit", usage.MonthlySpend, *limit),
+			Suggestion: "Wait until next month or ask an admin to raise the project's spend limit",
+		}
+	}
+
+	return nil
+}
+
+// CheckExec returns a types.Error if starting the existing exec again would breach any of
+// its project's quotas.
+func (s *Service) CheckExec(ctx context.Context, exec types.Exec) error {
+	row, err := s.store.ExecGet(ctx, exec.ID)
+	if err != nil {
+		return fmt.Errorf("get exec project: %w", err)
+	}
+
+	return s.Check(ctx, row.ProjectID, exec.Spec)
+}
+
+func gpuCount(spec types.HardwareSpec) int {
+	return types.SetSpecDefaultValues(spec).GPU.Count.Min
+}
+
+func intFromNull(n sql.NullInt32) *int {
+	if !n.Valid {
+		return nil
+	}
+
+	v := int(n.Int32)
+
+	return &v
+}
+
+func nullFromInt(v *int) sql.NullInt32 {
+	if v == nil {
+		return sql.NullInt32{}
+	}
+
+	return sql.NullInt32{Int32: int32(*v), Valid: true}
+}
@@ -0,0 +1,225 @@
+package quotasrv_test
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"github.com/unweave/unweave-v1/api/types"
+	"github.com/unweave/unweave-v1/db"
+	"github.com/unweave/unweave-v1/services/execsrv"
+	"github.com/unweave/unweave-v1/services/quotasrv"
+)
+
+type projectStore map[string]db.UnweaveProject
+
+// ExecGet puts every exec in pr_1.
+func (s projectStore) ExecGet(_ context.Context, id string) (db.UnweaveExec, error) {
+	return db.UnweaveExec{ID: id, ProjectID: "pr_1"}, nil
+}
+
+func (s projectStore) ProjectGet(_ context.Context, id string) (db.UnweaveProject, error) {
+	p, ok := s[id]
+	if !ok {
+		return db.UnweaveProject{}, sql.ErrNoRows
+	}
+
+	return p, nil
+}
+
+func (s projectStore) ProjectQuotasUpdate(_ context.Context, arg db.ProjectQuotasUpdateParams) (int64, error) {
+	p, ok := s[arg.ID]
+	if !ok {
+		return 0, nil
+	}
+
+	p.MaxConcurrentSessions = arg.MaxConcurrentSessions
+	p.MaxGpus = arg.MaxGpus
+	p.MonthlySpendLimitCents = arg.MonthlySpendLimitCents
+	s[arg.ID] = p
+
+	return 1, nil
+}
+
+type execStore []types.Exec
+
+func (s execStore) List(_ *string, _ *types.Provider, filterActive bool) ([]types.Exec, error) {
+	var res []types.Exec
+
+	for _, exec := range s {
+		if filterActive && exec.Status != types.StatusRunning {
+			continue
+		}
+
+		res = append(res, exec)
+	}
+
+	return res, nil
+}
+
+func (s execStore) ListInWindow(string, time.Time, time.Time) ([]types.Exec, error) {
+	return s, nil
+}
+
+func (s execStore) ListEventsByExecs([]string) (map[string][]types.ExecEvent, error) {
+	return nil, nil
+}
+
+type execService struct {
+	execsrv.Service
+	created int
+	started int
+	execs   execStore
+}
+
+func (s *execService) Create(context.Context, string, string, types.ExecCreateParams) (types.Exec, error) {
+	s.created++
+	return types.Exec{ID: "exc_new"}, nil
+}
+
+func (s *execService) Get(_ context.Context, id string) (types.Exec, error) {
+	for _, exec := range s.execs {
+		if exec.ID == id {
+			return exec, nil
+		}
+	}
+
+	return types.Exec{}, errors.New("not found")
+}
+
+func (s *execService) Start(context.Context, string) error {
+	s.started++
+	return nil
+}
+
+func intPtr(v int) *int { return &v }
+
+func TestService_SetAndReport(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	price := 200
+
+	store := projectStore{"pr_1": {ID: "pr_1"}}
+	execs := execStore{
+		{ID: "exc_1", Status: types.StatusRunning, CreatedAt: time.Now().Add(-time.Hour), HourlyPrice: &price,
+			Spec: types.HardwareSpec{GPU: types.GPU{Type: "a100", Count: types.HardwareRequestRange{Min: 2}}}},
+		{ID: "exc_2", Status: types.StatusTerminated, CreatedAt: time.Now().Add(-time.Hour)},
+	}
+	srv := quotasrv.NewService(store, execs)
+
+	limit := 12.345
+	quotas, err := srv.Set(ctx, "pr_1", types.ProjectQuotas{MaxGPUs: intPtr(4), MonthlySpendLimit: &limit})
+	require.NoError(t, err)
+	require.Nil(t, quotas.MaxConcurrentSessions)
+	require.Equal(t, 4, *quotas.MaxGPUs)
+	require.InDelta(t, 12.35, *quotas.MonthlySpendLimit, 0.0001, "should round the limit to cents")
+
+	report, err := srv.Report(ctx, "pr_1")
+	require.NoError(t, err)
+	require.Equal(t, 1, report.Usage.ConcurrentSessions)
+	require.Equal(t, 2, report.Usage.GPUs)
+
+	var e *types.Error
+
+	_, err = srv.Set(ctx, "pr_2", types.ProjectQuotas{})
+	require.True(t, errors.As(err, &e))
+	require.Equal(t, http.StatusNotFound, e.Code)
+}
+
+func TestEnforcingService_Create(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	price := 1000
+
+	store := projectStore{"pr_1": {ID: "pr_1"}}
+	execs := execStore{
+		{ID: "exc_1", Status: types.StatusRunning, CreatedAt: time.Now().Add(-time.Minute), HourlyPrice: &price,
+			Spec: types.HardwareSpec{GPU: types.GPU{Type: "a100"}}},
+	}
+	quotas := quotasrv.NewService(store, execs)
+	next := &execService{}
+	srv := quotasrv.NewEnforcingService(next, quotas)
+
+	gpuParams := types.ExecCreateParams{Spec: types.HardwareSpec{GPU: types.GPU{Type: "a100"}}}
+
+	_, err := srv.Create(ctx, "pr_1", "usr_1", gpuParams)
+	require.NoError(t, err, "should allow anything without quotas")
+
+	for _, tc := range []struct {
+		name   string
+		quotas types.ProjectQuotas
+		params types.ExecCreateParams
+		err    bool
+	}{
+		{name: "sessions", quotas: types.ProjectQuotas{MaxConcurrentSessions: intPtr(1)}, err: true},
+		{name: "gpus", quotas: types.ProjectQuotas{MaxGPUs: intPtr(1)}, params: gpuParams, err: true},
+		{name: "cpu only", quotas: types.ProjectQuotas{MaxGPUs: intPtr(1)}},
+		{name: "spend", quotas: types.ProjectQuotas{MonthlySpendLimit: new(float64)}, err: true},
+	} {
+		_, err = quotas.Set(ctx, "pr_1", tc.quotas)
+		require.NoError(t, err)
+
+		created := next.created
+
+		_, err = srv.Create(ctx, "pr_1", "usr_1", tc.params)
+		if !tc.err {
+			require.NoError(t, err, tc.name)
+			continue
+		}
+
+		var e *types.Error
+		require.True(t, errors.As(err, &e), tc.name)
+		require.Equal(t, http.StatusForbidden, e.Code, tc.name)
+		require.NotEmpty(t, e.Suggestion, tc.name)
+		require.Equal(t, created, next.created, "should not create the exec when breaching %s", tc.name)
+	}
+}
+
+func TestEnforcingService_Start(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	a100 := types.HardwareSpec{GPU: types.GPU{Type: "a100", Count: types.HardwareRequestRange{Min: 2}}}
+
+	store := projectStore{"pr_1": {ID: "pr_1"}}
+	execs := execStore{
+		{ID: "exc_1", Status: types.StatusRunning, CreatedAt: time.Now(), Spec: a100},
+		{ID: "exc_2", Status: types.StatusStopped, CreatedAt: time.Now(), Spec: a100},
+	}
+	quotas := quotasrv.NewService(store, execs)
+	next := &execService{execs: execs}
+	srv := quotasrv.NewEnforcingService(next, quotas)
+
+	for _, tc := range []struct {
+		name   string
+		quotas types.ProjectQuotas
+		err    bool
+	}{
+		{name: "sessions", quotas: types.ProjectQuotas{MaxConcurrentSessions: intPtr(1)}, err: true},
+		{name: "gpus", quotas: types.ProjectQuotas{MaxGPUs: intPtr(3)}, err: true},
+		{name: "within quotas", quotas: types.ProjectQuotas{MaxConcurrentSessions: intPtr(2), MaxGPUs: intPtr(4)}},
+	} {
+		_, err := quotas.Set(ctx, "pr_1", tc.quotas)
+		require.NoError(t, err)
+
+		started := next.started
+
+		err = srv.Start(ctx, "exc_2")
+		if !tc.err {
+			require.NoError(t, err, tc.name)
+			require.Equal(t, started+1, next.started)
+			continue
+		}
+
+		var e *types.Error
+		require.True(t, errors.As(err, &e), tc.name)
+		require.Equal(t, http.StatusForbidden, e.Code, tc.name)
+		require.Equal(t, started, next.started, "should not start the exec when breaching %s", tc.name)
+	}
+}
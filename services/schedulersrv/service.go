@@ -0,0 +1,135 @@
+package schedulersrv
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/rs/zerolog/log"
+	"github.com/unweave/unweave-v1/api/types"
+	"github.com/unweave/unweave-v1/services/providersrv"
+)
+
+// Service matches hardware specs against the node types of every registered provider.
+type Service struct {
+	providers []*providersrv.ProviderService
+}
+
+func NewService(providers ...*providersrv.ProviderService) *Service {
+	return &Service{providers: providers}
+}
+
+// Match returns the node types satisfying the spec ranked by availability and then by
+// hourly price, cheapest first. Node types without a price are ranked after priced ones.
+// Providers that fail to list their node types are skipped.
+func (s *Service) Match(ctx context.Context, userID string, spec types.HardwareSpec) ([]types.NodeTypeMatch, error) {
+	var matches []types.NodeTypeMatch
+
+	for _, provider := range s.providers {
+		res, err := s.match(ctx, provider, userID, spec)
+		if err != nil {
+			log.Ctx(ctx).Warn().Err(err).Msgf("Failed to match node types of %s", provider.Provider())
+			continue
+		}
+
+		matches = append(matches, res...)
+	}
+
+	sort.SliceStable(matches, func(i, j int) bool {
+		return less(matches[i], matches[j])
+	})
+
+	return matches, nil
+}
+
+func (s *Service) match(
+	ctx context.Context,
+	provider *providersrv.ProviderService,
+	userID string,
+	spec types.HardwareSpec,
+) ([]types.NodeTypeMatch, error) {
+	nodeTypes, err := provider.ListNodeTypes(ctx, userID, false)
+	if err != nil {
+		return nil, fmt.Errorf("list node types: %w", err)
+	}
+
+	available, err := provider.ListNodeTypes(ctx, userID, true)
+	if err != nil {
+		return nil, fmt.Errorf("list available node types: %w", err)
+	}
+
+	isAvailable := make(map[string]bool, len(available))
+	for _, nt := range available {
+		isAvailable[nt.ID] = true
+	}
+
+	var matches []types.NodeTypeMatch
+
+	for _, nt := range nodeTypes {
+		if !Matches(nt, spec) {
+			continue
+		}
+
+		if nt.Provider == "" {
+			nt.Provider = provider.Provider()
+		}
+
+		matches = append(matches, types.NodeTypeMatch{NodeType: nt, Available: isAvailable[nt.ID]})
+	}
+
+	return matches, nil
+}
+
+// Matches returns true if the node type satisfies every range of the spec. A zero max
+// in the spec means there's no upper bound.
+func Matches(nt types.NodeType, spec types.HardwareSpec) bool {
+	if spec.GPU.Type != "" && spec.GPU.Type != nt.ID && spec.GPU.Type != nt.Specs.GPU.Type {
+		return false
+	}
+
+	if spec.CPU.Type != "" && spec.CPU.Type != nt.Specs.CPU.Type {
+		return false
+	}
+
+	return overlaps(nt.Specs.GPU.Count, spec.GPU.Count) &&
+		overlaps(nt.Specs.GPU.RAM, spec.GPU.RAM) &&
+		overlaps(nt.Specs.CPU.HardwareRequestRange, spec.CPU.HardwareRequestRange) &&
+		overlaps(nt.Specs.RAM, spec.RAM) &&
+		overlaps(nt.Specs.HDD, spec.HDD)
+}
+
+// overlaps returns true if the node offers a size within the requested range.
+func overlaps(offered, requested types.HardwareRequestRange) bool {
+	offeredMax := offered.Max
+	if offeredMax < offered.Min {
+		offeredMax = offered.Min
+	}
+
+	if offeredMax < requested.Min {
+		return false
+	}
+
+	return requested.Max == 0 || offered.Min <= requested.Max
+}
+
+func less(a, b types.NodeTypeMatch) bool {
+	if a.Available != b.Available {
+		return a.Available
+	}
+
+	switch {
+	case a.NodeType.Price == nil && b.NodeType.Price == nil:
+	case a.NodeType.Price == nil:
+		return false
+	case b.NodeType.Price == nil:
+		return true
+	case *a.NodeType.Price != *b.NodeType.Price:
+		return *a.NodeType.Price < *b.NodeType.Price
+	}
+
+	if a.NodeType.Provider != b.NodeType.Provider {
+		return a.NodeType.Provider < b.NodeType.Provider
+	}
+
+	return a.NodeType.ID < b.NodeType.ID
+}
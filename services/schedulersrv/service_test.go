@@ -0,0 +1,110 @@
+package schedulersrv_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/unweave/unweave-v1/api/types"
+	"github.com/unweave/unweave-v1/services/providersrv"
+	"github.com/unweave/unweave-v1/services/schedulersrv"
+)
+
+type driver struct {
+	provider  types.Provider
+	nodeTypes []types.NodeType
+	available map[string]bool
+}
+
+func (d driver) Provider() types.Provider { return d.provider }
+
+func (d driver) ProviderListNodeTypes(_ context.Context, _ string, filterAvailable bool) ([]types.NodeType, error) {
+	if !filterAvailable {
+		return d.nodeTypes, nil
+	}
+
+	var res []types.NodeType
+
+	for _, nt := range d.nodeTypes {
+		if d.available[nt.ID] {
+			res = append(res, nt)
+		}
+	}
+
+	return res, nil
+}
+
+func gpuNode(provider types.Provider, id string, price, gpus, gpuMem int) types.NodeType {
+	return types.NodeType{
+		ID:       id,
+		Price:    &price,
+		Provider: provider,
+		Specs: types.HardwareSpec{
+			GPU: types.GPU{
+				Count: types.HardwareRequestRange{Min: gpus, Max: gpus},
+				RAM:   types.HardwareRequestRange{Min: gpuMem, Max: gpuMem},
+			},
+		},
+	}
+}
+
+func TestService_Match(t *testing.T) {
+	t.Parallel()
+
+	ll := driver{
+		provider: types.LambdaLabsProvider,
+		nodeTypes: []types.NodeType{
+			gpuNode(types.LambdaLabsProvider, "gpu_1x_a10", 60, 1, 24),
+			gpuNode(types.LambdaLabsProvider, "gpu_1x_a100", 110, 1, 40),
+			gpuNode(types.LambdaLabsProvider, "gpu_8x_a100", 880, 8, 320),
+			gpuNode(types.LambdaLabsProvider, "gpu_1x_h100", 199, 1, 80),
+		},
+		available: map[string]bool{"gpu_1x_a100": true, "gpu_1x_h100": true},
+	}
+
+	aws := driver{
+		provider: types.AWSProvider,
+		nodeTypes: []types.NodeType{
+			gpuNode(types.AWSProvider, "t4_tensor", 52, 1, 16),
+			{
+				ID:       "a100",
+				Provider: types.AWSProvider,
+				Specs: types.HardwareSpec{GPU: types.GPU{
+					Count: types.HardwareRequestRange{Min: 8, Max: 8},
+					RAM:   types.HardwareRequestRange{Min: 320, Max: 320},
+				}},
+			},
+		},
+		available: map[string]bool{"t4_tensor": true, "a100": true},
+	}
+
+	srv := schedulersrv.NewService(
+		providersrv.NewProviderService(ll),
+		providersrv.NewProviderService(aws),
+	)
+
+	matches, err := srv.Match(context.Background(), "usr_1", types.HardwareSpec{
+		GPU: types.GPU{RAM: types.HardwareRequestRange{Min: 24}},
+	})
+	require.NoError(t, err)
+
+	ids := make([]string, len(matches))
+	for i, m := range matches {
+		ids[i] = m.NodeType.ID
+	}
+
+	// Available first, cheapest first and unpriced last.
+	require.Equal(t, []string{"gpu_1x_a100", "gpu_1x_h100", "a100", "gpu_1x_a10", "gpu_8x_a100"}, ids)
+	require.True(t, matches[0].Available)
+	require.False(t, matches[3].Available)
+
+	matches, err = srv.Match(context.Background(), "usr_1", types.HardwareSpec{
+		GPU: types.GPU{
+			Count: types.HardwareRequestRange{Min: 1, Max: 1},
+			RAM:   types.HardwareRequestRange{Min: 16, Max: 40},
+		},
+	})
+	require.NoError(t, err)
+	require.Len(t, matches, 3, "should respect the max of the ranges")
+	require.Equal(t, "t4_tensor", matches[0].NodeType.ID, "should rank across providers by price")
+}
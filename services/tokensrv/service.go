@@ -0,0 +1,183 @@
+package tokensrv
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"database/sql"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/rs/zerolog/log"
+	"github.com/unweave/unweave-v1/api/types"
+	"github.com/unweave/unweave-v1/db"
+	"go.jetpack.io/typeid"
+)
+
+const (
+	tokenPrefix      = "uwt_"
+	tokenSecretBytes = 32
+	// DefaultTokenTTL is the lifetime of tokens created without an explicit expiry.
+	DefaultTokenTTL = 90 * 24 * time.Hour
+)
+
+var errUnauthorized = &types.Error{
+	Code:       http.StatusUnauthorized,
+	Message:    "Invalid or expired access token",
+	Suggestion: "Create a new access token and pass it as a Bearer token in the Authorization header",
+}
+
+type Store interface {
+	AccessTokenCreate(ctx context.Context, arg db.AccessTokenCreateParams) error
+	AccessTokenGetByHash(ctx context.Context, tokenHash string) (db.UnweaveAccessToken, error)
+	AccessTokenList(ctx context.Context, accountID string) ([]db.UnweaveAccessToken, error)
+	AccessTokenRevoke(ctx context.Context, arg db.AccessTokenRevokeParams) (int64, error)
+	AccessTokenTouch(ctx context.Context, id string) error
+	AccountCreate(ctx context.Context, id string) error
+}
+
+type Service struct {
+	store Store
+}
+
+func NewService(store Store) *Service {
+	return &Service{store: store}
+}
+
+// Create generates a new access token for the account. The plain text token is only
+// ever returned here, only its hash is persisted.
+func (s *Service) Create(
+	ctx context.Context,
+	accountID string,
+	params types.AccessTokenCreateParams,
+) (types.AccessTokenCreateResponse, error) {
+	token, err := generateToken()
+	if err != nil {
+		return types.AccessTokenCreateResponse{}, fmt.Errorf("generate token: %w", err)
+	}
+
+	expiresAt := time.Now().Add(DefaultTokenTTL)
+	if params.ExpiresAt != nil {
+		expiresAt = *params.ExpiresAt
+	}
+
+	tokenID := typeid.Must(typeid.New("tok")).String()
+
+	err = s.store.AccessTokenCreate(ctx, db.AccessTokenCreateParams{
+		ID:          tokenID,
+		Name:        params.Name,
+		AccountID:   accountID,
+		TokenHash:   hashToken(token),
+		DisplayText: displayText(token),
+		ExpiresAt:   expiresAt,
+	})
+	if err != nil {
+		return types.AccessTokenCreateResponse{}, fmt.Errorf("create token: %w", err)
+	}
+
+	return types.AccessTokenCreateResponse{
+		ID:        tokenID,
+		Token:     token,
+		Name:      params.Name,
+		ExpiresAt: expiresAt,
+	}, nil
+}
+
+// Mint is Create for an account that may not exist yet. It's how the first token of an
+// account is issued, by an admin or the token command, since Create needs a token to call.
+func (s *Service) Mint(
+	ctx context.Context,
+	accountID string,
+	params types.AccessTokenCreateParams,
+) (types.AccessTokenCreateResponse, error) {
+	if err := s.store.AccountCreate(ctx, accountID); err != nil {
+		return types.AccessTokenCreateResponse{}, fmt.Errorf("create account: %w", err)
+	}
+
+	return s.Create(ctx, accountID, params)
+}
+
+func (s *Service) List(ctx context.Context, accountID string) ([]types.UserAccessToken, error) {
+	tokens, err := s.store.AccessTokenList(ctx, accountID)
+	if err != nil {
+		return nil, fmt.Errorf("list tokens: %w", err)
+	}
+
+	res := make([]types.UserAccessToken, len(tokens))
+	for idx, t := range tokens {
+		res[idx] = types.NewUserAccessToken(t.AccountID, t.ID, t.Name, t.DisplayText, t.ExpiresAt)
+	}
+
+	return res, nil
+}
+
+func (s *Service) Revoke(ctx context.Context, accountID, tokenID string) error {
+	n, err := s.store.AccessTokenRevoke(ctx, db.AccessTokenRevokeParams{
+		ID:        tokenID,
+		AccountID: accountID,
+	})
+	if err != nil {
+		return fmt.Errorf("revoke token: %w", err)
+	}
+
+	if n == 0 {
+		return &types.Error{
+			Code:    http.StatusNotFound,
+			Message: "Access token not found",
+		}
+	}
+
+	return nil
+}
+
+// Authenticate resolves a plain text bearer token to the token it was issued as. Unknown,
+// revoked and expired tokens all return the same unauthorized error.
+func (s *Service) Authenticate(ctx context.Context, token string) (types.UserAccessToken, error) {
+	if !strings.HasPrefix(token, tokenPrefix) {
+		return types.UserAccessToken{}, errUnauthorized
+	}
+
+	t, err := s.store.AccessTokenGetByHash(ctx, hashToken(token))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return types.UserAccessToken{}, errUnauthorized
+		}
+
+		return types.UserAccessToken{}, fmt.Errorf("get token: %w", err)
+	}
+
+	if !t.ExpiresAt.After(time.Now()) {
+		return types.UserAccessToken{}, errUnauthorized
+	}
+
+	if err = s.store.AccessTokenTouch(ctx, t.ID); err != nil {
+		// Not critical, the request is still authenticated.
+		log.Ctx(ctx).Warn().Err(err).Msgf("Failed to update last used time for token %s", t.ID)
+	}
+
+	return types.NewUserAccessToken(t.AccountID, t.ID, t.Name, t.DisplayText, t.ExpiresAt), nil
+}
+
+func generateToken() (string, error) {
+	buf := make([]byte, tokenSecretBytes)
+	if _, err := rand.Read(buf); err != nil {
+		return "", err
+	}
+
+	return tokenPrefix + base64.RawURLEncoding.EncodeToString(buf), nil
+}
+
+func hashToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+
+	return hex.EncodeToString(sum[:])
+}
+
+func displayText(token string) string {
+	return tokenPrefix + "..." + token[len(token)-4:]
+}
@@ -0,0 +1,154 @@
+package tokensrv_test
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"github.com/unweave/unweave-v1/api/types"
+	"github.com/unweave/unweave-v1/db"
+	"github.com/unweave/unweave-v1/services/tokensrv"
+)
+
+type memStore struct {
+	tokens   map[string]db.UnweaveAccessToken
+	accounts map[string]bool
+}
+
+func (m *memStore) AccessTokenCreate(_ context.Context, arg db.AccessTokenCreateParams) error {
+	m.tokens[arg.ID] = db.UnweaveAccessToken{
+		ID:          arg.ID,
+		Name:        arg.Name,
+		AccountID:   arg.AccountID,
+		TokenHash:   arg.TokenHash,
+		DisplayText: arg.DisplayText,
+		ExpiresAt:   arg.ExpiresAt,
+		CreatedAt:   time.Now(),
+	}
+
+	return nil
+}
+
+func (m *memStore) AccessTokenGetByHash(_ context.Context, tokenHash string) (db.UnweaveAccessToken, error) {
+	for _, t := range m.tokens {
+		if t.TokenHash == tokenHash && !t.RevokedAt.Valid {
+			return t, nil
+		}
+	}
+
+	return db.UnweaveAccessToken{}, sql.ErrNoRows
+}
+
+func (m *memStore) AccessTokenList(_ context.Context, accountID string) ([]db.UnweaveAccessToken, error) {
+	var res []db.UnweaveAccessToken
+
+	for _, t := range m.tokens {
+		if t.AccountID == accountID && !t.RevokedAt.Valid {
+			res = append(res, t)
+		}
+	}
+
+	return res, nil
+}
+
+func (m *memStore) AccessTokenRevoke(_ context.Context, arg db.AccessTokenRevokeParams) (int64, error) {
+	t, ok := m.tokens[arg.ID]
+	if !ok || t.AccountID != arg.AccountID || t.RevokedAt.Valid {
+		return 0, nil
+	}
+
+	t.RevokedAt = db.NullTimeFrom(time.Now())
+	m.tokens[arg.ID] = t
+
+	return 1, nil
+}
+
+func (m *memStore) AccessTokenTouch(_ context.Context, id string) error {
+	t := m.tokens[id]
+	t.LastUsedAt = db.NullTimeFrom(time.Now())
+	m.tokens[id] = t
+
+	return nil
+}
+
+func (m *memStore) AccountCreate(_ context.Context, id string) error {
+	m.accounts[id] = true
+
+	return nil
+}
+
+func requireUnauthorized(t *testing.T, err error) {
+	t.Helper()
+
+	var e *types.Error
+
+	require.True(t, errors.As(err, &e))
+	require.Equal(t, http.StatusUnauthorized, e.Code)
+}
+
+func TestService(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	store := &memStore{tokens: map[string]db.UnweaveAccessToken{}, accounts: map[string]bool{}}
+	srv := tokensrv.NewService(store)
+
+	created, err := srv.Create(ctx, "acc_1", types.AccessTokenCreateParams{Name: "ci"})
+	require.NoError(t, err)
+	require.WithinDuration(t, time.Now().Add(tokensrv.DefaultTokenTTL), created.ExpiresAt, time.Minute)
+
+	stored := store.tokens[created.ID]
+	require.NotEqual(t, created.Token, stored.TokenHash, "token must not be stored in plain text")
+
+	token, err := srv.Authenticate(ctx, created.Token)
+	require.NoError(t, err)
+	require.Equal(t, "acc_1", token.UserID)
+	require.Equal(t, created.ID, token.ID)
+	require.True(t, store.tokens[created.ID].LastUsedAt.Valid)
+
+	_, err = srv.Authenticate(ctx, created.Token+"x")
+	requireUnauthorized(t, err)
+
+	// Revoking another account's token is not allowed.
+	require.Error(t, srv.Revoke(ctx, "acc_2", created.ID))
+	require.NoError(t, srv.Revoke(ctx, "acc_1", created.ID))
+
+	_, err = srv.Authenticate(ctx, created.Token)
+	requireUnauthorized(t, err)
+
+	tokens, err := srv.List(ctx, "acc_1")
+	require.NoError(t, err)
+	require.Empty(t, tokens)
+
+	// Expired tokens are rejected.
+	expiry := time.Now().Add(time.Hour)
+	expiring, err := srv.Create(ctx, "acc_1", types.AccessTokenCreateParams{Name: "short", ExpiresAt: &expiry})
+	require.NoError(t, err)
+
+	stored = store.tokens[expiring.ID]
+	stored.ExpiresAt = time.Now().Add(-time.Second)
+	store.tokens[expiring.ID] = stored
+
+	_, err = srv.Authenticate(ctx, expiring.Token)
+	requireUnauthorized(t, err)
+}
+
+func TestService_Mint(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	store := &memStore{tokens: map[string]db.UnweaveAccessToken{}, accounts: map[string]bool{}}
+	srv := tokensrv.NewService(store)
+
+	created, err := srv.Mint(ctx, "acc_new", types.AccessTokenCreateParams{Name: "bootstrap"})
+	require.NoError(t, err)
+	require.True(t, store.accounts["acc_new"])
+
+	token, err := srv.Authenticate(ctx, created.Token)
+	require.NoError(t, err)
+	require.Equal(t, "acc_new", token.UserID)
+}
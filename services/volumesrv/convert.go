@@ -14,6 +14,7 @@ func volumeFromDB(volume db.UnweaveVolume) types.Volume {
 			CreatedAt: volume.CreatedAt,
 			UpdatedAt: volume.UpdatedAt,
 		},
-		Provider: types.Provider(volume.Provider),
+		Provider:  types.Provider(volume.Provider),
+		ProjectID: volume.ProjectID,
 	}
 }
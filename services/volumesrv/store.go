@@ -10,10 +10,16 @@ import (
 	"github.com/unweave/unweave-v1/db"
 )
 
-type postgresStore struct{}
+type postgresStore struct {
+	db db.Querier
+}
 
 func NewPostgresStore() Store {
-	return postgresStore{}
+	return NewPostgresStoreDB(db.Q)
+}
+
+func NewPostgresStoreDB(querier db.Querier) Store {
+	return postgresStore{db: querier}
 }
 
 func (p postgresStore) VolumeAdd(projectID string, provider types.Provider, id string, name string, size int) error {
@@ -25,7 +31,7 @@ func (p postgresStore) VolumeAdd(projectID string, provider types.Provider, id s
 		Name:      name,
 		Size:      int32(size),
 	}
-	_, err := db.Q.VolumeCreate(ctx, params)
+	_, err := p.db.VolumeCreate(ctx, params)
 	if err != nil {
 		return fmt.Errorf("failed to create volume in db: %w", err)
 	}
@@ -34,7 +40,7 @@ func (p postgresStore) VolumeAdd(projectID string, provider types.Provider, id s
 }
 
 func (p postgresStore) VolumeList(projectID string) ([]types.Volume, error) {
-	vols, err := db.Q.VolumeList(context.Background(), projectID)
+	vols, err := p.db.VolumeList(context.Background(), projectID)
 	if err != nil {
 		if err == sql.ErrNoRows {
 			return nil, nil
@@ -51,7 +57,7 @@ func (p postgresStore) VolumeList(projectID string) ([]types.Volume, error) {
 }
 
 func (p postgresStore) VolumeGet(projectID, idOrName string) (types.Volume, error) {
-	vol, err := db.Q.VolumeGet(context.Background(), db.VolumeGetParams{
+	vol, err := p.db.VolumeGet(context.Background(), db.VolumeGetParams{
 		ProjectID: projectID,
 		ID:        idOrName,
 	})
@@ -71,7 +77,7 @@ func (p postgresStore) VolumeGet(projectID, idOrName string) (types.Volume, erro
 
 func (p postgresStore) VolumeDelete(id string) error {
 	ctx := context.Background()
-	if err := db.Q.VolumeDelete(ctx, id); err != nil {
+	if err := p.db.VolumeDelete(ctx, id); err != nil {
 		if err == sql.ErrNoRows {
 			return &types.Error{
 				Code:    http.StatusNotFound,
@@ -92,7 +98,7 @@ func (p postgresStore) VolumeUpdate(id string, volume types.Volume) error {
 		Size: int32(volume.Size),
 	}
 
-	err := db.Q.VolumeUpdate(ctx, params)
+	err := p.db.VolumeUpdate(ctx, params)
 	if err != nil {
 		if err == sql.ErrNoRows {
 			return &types.Error{
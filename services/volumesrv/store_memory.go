@@ -0,0 +1,100 @@
+package volumesrv
+
+import (
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/unweave/unweave-v1/api/types"
+)
+
+// memoryStore is a thread-safe in-memory Store for tests and single node deployments.
+type memoryStore struct {
+	mu      sync.RWMutex
+	volumes map[string]types.Volume
+}
+
+func NewMemoryStore() Store {
+	return &memoryStore{volumes: make(map[string]types.Volume)}
+}
+
+func (m *memoryStore) VolumeAdd(projectID string, provider types.Provider, id, name string, size int) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if _, ok := m.volumes[id]; ok {
+		return &types.Error{
+			Code:    http.StatusConflict,
+			Message: "Volume already exists",
+		}
+	}
+
+	now := time.Now()
+	m.volumes[id] = types.Volume{
+		ID:        id,
+		Name:      name,
+		Size:      size,
+		State:     types.VolumeState{CreatedAt: now, UpdatedAt: now},
+		Provider:  provider,
+		ProjectID: projectID,
+	}
+
+	return nil
+}
+
+func (m *memoryStore) VolumeList(projectID string) ([]types.Volume, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	var out []types.Volume
+
+	for _, v := range m.volumes {
+		if v.ProjectID == projectID {
+			out = append(out, v)
+		}
+	}
+
+	return out, nil
+}
+
+func (m *memoryStore) VolumeGet(projectID, idOrName string) (types.Volume, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	for _, v := range m.volumes {
+		if v.ProjectID == projectID && (v.ID == idOrName || v.Name == idOrName) {
+			return v, nil
+		}
+	}
+
+	return types.Volume{}, &types.Error{
+		Code:    http.StatusNotFound,
+		Message: "Volume not found",
+	}
+}
+
+func (m *memoryStore) VolumeDelete(id string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	delete(m.volumes, id)
+
+	return nil
+}
+
+func (m *memoryStore) VolumeUpdate(id string, volume types.Volume) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	v, ok := m.volumes[id]
+	if !ok {
+		return nil
+	}
+
+	// Only the size can be updated, same as in the postgres store.
+	v.Size = volume.Size
+	v.State.UpdatedAt = time.Now()
+	m.volumes[id] = v
+
+	return nil
+}
@@ -0,0 +1,35 @@
+package volumesrv_test
+
+import (
+	"testing"
+
+	"github.com/unweave/unweave-v1/db"
+	"github.com/unweave/unweave-v1/db/dbtest"
+	"github.com/unweave/unweave-v1/services/volumesrv"
+	"github.com/unweave/unweave-v1/services/volumesrv/volumesrvtest"
+)
+
+func TestMemoryStore(t *testing.T) {
+	t.Parallel()
+
+	volumesrvtest.TestStore(t, func(t *testing.T) volumesrv.Store {
+		return volumesrv.NewMemoryStore()
+	}, volumesrvtest.StoreFixtures{
+		ProjectID:      "pr_memoryproject",
+		OtherProjectID: "pr_otherproject",
+	})
+}
+
+func TestPostgresStore(t *testing.T) {
+	t.Parallel()
+
+	conn := dbtest.Connect(t)
+	querier := db.New(conn)
+
+	volumesrvtest.TestStore(t, func(t *testing.T) volumesrv.Store {
+		return volumesrv.NewPostgresStoreDB(querier)
+	}, volumesrvtest.StoreFixtures{
+		ProjectID:      dbtest.CreateProject(t, conn),
+		OtherProjectID: dbtest.CreateProject(t, conn),
+	})
+}
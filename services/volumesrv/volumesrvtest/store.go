@@ -0,0 +1,111 @@
+// Package volumesrvtest contains a conformance suite that every volumesrv.Store
+// implementation should pass.
+package volumesrvtest
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/unweave/unweave-v1/api/types"
+	"github.com/unweave/unweave-v1/services/volumesrv"
+	"github.com/unweave/unweave-v1/tools/random"
+)
+
+// StoreFixtures are the records that must exist before a volume can be stored.
+type StoreFixtures struct {
+	ProjectID      string
+	OtherProjectID string
+}
+
+// TestStore runs the store conformance suite against the store returned by newStore.
+func TestStore(t *testing.T, newStore func(t *testing.T) volumesrv.Store, fx StoreFixtures) {
+	t.Helper()
+
+	newID := func() string { return "uwv_" + random.GenerateRandomLower(12) }
+	newName := func() string { return "vol-" + random.GenerateRandomLower(8) }
+
+	t.Run("add and get", func(t *testing.T) {
+		store := newStore(t)
+		id, name := newID(), newName()
+
+		require.NoError(t, store.VolumeAdd(fx.ProjectID, types.LocalProvider, id, name, 10))
+
+		for _, ref := range []string{id, name} {
+			got, err := store.VolumeGet(fx.ProjectID, ref)
+			require.NoError(t, err)
+			require.Equal(t, id, got.ID)
+			require.Equal(t, name, got.Name)
+			require.Equal(t, 10, got.Size)
+			require.Equal(t, types.LocalProvider, got.Provider)
+			require.Equal(t, fx.ProjectID, got.ProjectID)
+		}
+
+		require.Error(t, store.VolumeAdd(fx.ProjectID, types.LocalProvider, id, newName(), 10))
+	})
+
+	t.Run("get is scoped to project", func(t *testing.T) {
+		store := newStore(t)
+		id := newID()
+
+		require.NoError(t, store.VolumeAdd(fx.ProjectID, types.LocalProvider, id, newName(), 10))
+
+		_, err := store.VolumeGet(fx.OtherProjectID, id)
+		requireNotFound(t, err)
+
+		_, err = store.VolumeGet(fx.ProjectID, newID())
+		requireNotFound(t, err)
+	})
+
+	t.Run("list", func(t *testing.T) {
+		store := newStore(t)
+		inProject, otherProject := newID(), newID()
+
+		require.NoError(t, store.VolumeAdd(fx.ProjectID, types.LocalProvider, inProject, newName(), 10))
+		require.NoError(t, store.VolumeAdd(fx.OtherProjectID, types.LocalProvider, otherProject, newName(), 10))
+
+		vols, err := store.VolumeList(fx.ProjectID)
+		require.NoError(t, err)
+
+		ids := make(map[string]bool, len(vols))
+		for _, v := range vols {
+			ids[v.ID] = true
+		}
+
+		require.True(t, ids[inProject])
+		require.False(t, ids[otherProject])
+	})
+
+	t.Run("update size", func(t *testing.T) {
+		store := newStore(t)
+		id, name := newID(), newName()
+
+		require.NoError(t, store.VolumeAdd(fx.ProjectID, types.LocalProvider, id, name, 10))
+		require.NoError(t, store.VolumeUpdate(id, types.Volume{Name: "ignored", Size: 20}))
+
+		got, err := store.VolumeGet(fx.ProjectID, id)
+		require.NoError(t, err)
+		require.Equal(t, 20, got.Size)
+		require.Equal(t, name, got.Name)
+	})
+
+	t.Run("delete", func(t *testing.T) {
+		store := newStore(t)
+		id := newID()
+
+		require.NoError(t, store.VolumeAdd(fx.ProjectID, types.LocalProvider, id, newName(), 10))
+		require.NoError(t, store.VolumeDelete(id))
+
+		_, err := store.VolumeGet(fx.ProjectID, id)
+		requireNotFound(t, err)
+	})
+}
+
+func requireNotFound(t *testing.T, err error) {
+	t.Helper()
+
+	var e *types.Error
+	require.True(t, errors.As(err, &e), "expected *types.Error, got %v", err)
+	require.Equal(t, http.StatusNotFound, e.Code)
+}
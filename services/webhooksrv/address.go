@@ -0,0 +1,123 @@
+package webhooksrv
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/netip"
+	"net/url"
+	"syscall"
+	"time"
+
+	"github.com/unweave/unweave-v1/api/types"
+)
+
+// Resolver looks up the addresses of a webhook's host. *net.Resolver implements it.
+type Resolver interface {
+	LookupNetIP(ctx context.Context, network, host string) ([]netip.Addr, error)
+}
+
+var errPrivateAddress = errors.New("address is not publicly routable")
+
+// sharedAddressSpace is the carrier-grade NAT range (RFC 6598), cloud providers use it for
+// internal services.
+var sharedAddressSpace = netip.MustParsePrefix("100.64.0.0/10")
+
+// isPublic reports whether deliveries may be sent to the address. Webhook urls are user
+// supplied, so loopback, private, link-local (e.g. cloud metadata services) and other
+// non-routable addresses are rejected to keep webhooks from reaching internal services.
+func isPublic(addr netip.Addr) bool {
+	addr = addr.Unmap()
+
+	return addr.IsValid() &&
+		!addr.IsLoopback() &&
+		!addr.IsPrivate() &&
+		!addr.IsLinkLocalUnicast() &&
+		!addr.IsLinkLocalMulticast() &&
+		!addr.IsInterfaceLocalMulticast() &&
+		!addr.IsMulticast() &&
+		!addr.IsUnspecified() &&
+		!sharedAddressSpace.Contains(addr)
+}
+
+// newClient returns a client that refuses to connect to non-public addresses. The check
+// runs on the address being dialed, after DNS resolution, so hosts that resolved to a
+// public address when the webhook was created can't be rebound to an internal one.
+func newClient() *http.Client {
+	dialer := &net.Dialer{
+		Timeout: 10 * time.Second,
+		Control: func(_, address string, _ syscall.RawConn) error {
+			host, _, err := net.SplitHostPort(address)
+			if err != nil {
+				return err
+			}
+
+			addr, err := netip.ParseAddr(host)
+			if err != nil {
+				return err
+			}
+
+			if !isPublic(addr) {
+				return fmt.Errorf("dial %s: %w", address, errPrivateAddress)
+			}
+
+			return nil
+		},
+	}
+
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.DialContext = dialer.DialContext
+	// A proxy would connect to the webhook on our behalf, bypassing the dialer check.
+	transport.Proxy = nil
+
+	return &http.Client{Timeout: 10 * time.Second, Transport: transport}
+}
+
+// checkURL resolves the host of the webhook url and rejects it if any of its addresses
+// isn't public. Deliveries are checked again when dialing, see newClient.
+func (s *Service) checkURL(ctx context.Context, raw string) error {
+	if s.AllowPrivate {
+		return nil
+	}
+
+	u, err := url.Parse(raw)
+	if err != nil {
+		return &types.Error{
+			Code:    http.StatusBadRequest,
+			Message: "Invalid webhook url",
+			Err:     err,
+		}
+	}
+
+	host := u.Hostname()
+
+	var addrs []netip.Addr
+
+	if addr, err := netip.ParseAddr(host); err == nil {
+		addrs = []netip.Addr{addr}
+	} else {
+		addrs, err = s.Resolver.LookupNetIP(ctx, "ip", host)
+		if err != nil || len(addrs) == 0 {
+			return &types.Error{
+				Code:       http.StatusBadRequest,
+				Message:    fmt.Sprintf("Failed to resolve webhook host %q", host),
+				Suggestion: "Make sure the host has a public DNS record",
+				Err:        err,
+			}
+		}
+	}
+
+	for _, addr := range addrs {
+		if !isPublic(addr) {
+			return &types.Error{
+				Code:       http.StatusBadRequest,
+				Message:    fmt.Sprintf("Webhook host %q resolves to a non-public address", host),
+				Suggestion: "Use a url that is reachable from the internet",
+			}
+		}
+	}
+
+	return nil
+}
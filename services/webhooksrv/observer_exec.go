@@ -0,0 +1,101 @@
+package webhooksrv
+
+import (
+	"context"
+	"sync"
+
+	"github.com/rs/zerolog/log"
+	"github.com/unweave/unweave-v1/api/types"
+	"github.com/unweave/unweave-v1/db"
+	"github.com/unweave/unweave-v1/services/execsrv"
+)
+
+// ExecStore looks up the project an exec belongs to.
+type ExecStore interface {
+	ExecGet(ctx context.Context, idOrName string) (db.UnweaveExec, error)
+}
+
+// NewExecObserverFactory returns a StateObserverFactory that notifies project webhooks
+// when an exec starts running, terminates or fails. Register it with
+// execsrv.WithStateObserver.
+func NewExecObserverFactory(srv *Service, execs execsrv.Service, store ExecStore) execsrv.StateObserverFactory {
+	return execsrv.StateObserverFactoryFunc(func(exec types.Exec) execsrv.StateObserver {
+		return &execObserver{execID: exec.ID, srv: srv, execs: execs, store: store}
+	})
+}
+
+type execObserver struct {
+	execID string
+	srv    *Service
+	execs  execsrv.Service
+	store  ExecStore
+
+	mu         sync.Mutex
+	prevStatus types.Status
+}
+
+func (o *execObserver) ID() string {
+	return o.execID + "/webhook"
+}
+
+func (o *execObserver) ExecID() string {
+	return o.execID
+}
+
+func (o *execObserver) Name() string {
+	return "webhook-observer"
+}
+
+func (o *execObserver) Update(state execsrv.State) execsrv.State {
+	o.mu.Lock()
+	changed := state.Status != o.prevStatus
+	o.prevStatus = state.Status
+	o.mu.Unlock()
+
+	if !changed {
+		return state
+	}
+
+	var event types.WebhookEvent
+
+	switch state.Status {
+	case types.StatusRunning:
+		event = types.WebhookEventExecRunning
+	case types.StatusTerminated:
+		event = types.WebhookEventExecTerminated
+	case types.StatusFailed, types.StatusError:
+		event = types.WebhookEventExecFailed
+	case types.StatusPending,
+		types.StatusInitializing,
+		types.StatusStopped,
+		types.StatusSuccess,
+		types.StatusUnknown:
+		return state
+	}
+
+	ctx := context.Background()
+
+	dbExec, err := o.store.ExecGet(ctx, o.execID)
+	if err != nil {
+		log.Warn().Err(err).Str(types.ExecIDCtxKey, o.execID).Msg("Failed to get exec project for webhooks")
+		return state
+	}
+
+	exec, err := o.execs.Get(ctx, o.execID)
+	if err != nil {
+		log.Warn().Err(err).Str(types.ExecIDCtxKey, o.execID).Msg("Failed to get exec for webhooks")
+		return state
+	}
+
+	// The store can lag behind the informer.
+	exec.Status = state.Status
+
+	data := types.WebhookExecData{Session: exec}
+	if state.Error != nil {
+		data.Error = state.Error.Error()
+	}
+
+	o.srv.Notify(ctx, dbExec.ProjectID, event, data)
+
+	return state
+}
@@ -0,0 +1,45 @@
+package webhooksrv
+
+import (
+	"context"
+
+	"github.com/rs/zerolog/log"
+	"github.com/unweave/unweave-v1/api/types"
+	"github.com/unweave/unweave-v1/services/execsrv"
+)
+
+// NewExpiryObserver returns an ExpiryObserver that notifies project webhooks shortly
+// before an exec reaches its maximum lifetime. Register it with execsrv.WithExpiryObserver.
+func NewExpiryObserver(srv *Service, execs execsrv.Service, store ExecStore) execsrv.ExpiryObserver {
+	return &expiryObserver{srv: srv, execs: execs, store: store}
+}
+
+type expiryObserver struct {
+	srv   *Service
+	execs execsrv.Service
+	store ExecStore
+}
+
+func (o *expiryObserver) ID() string {
+	return "webhook-expiry-observer"
+}
+
+func (o *expiryObserver) Update(warning execsrv.ExpiryWarning) {
+	ctx := context.Background()
+
+	dbExec, err := o.store.ExecGet(ctx, warning.ExecID)
+	if err != nil {
+		log.Warn().Err(err).Str(types.ExecIDCtxKey, warning.ExecID).Msg("Failed to get exec project for webhooks")
+		return
+	}
+
+	exec, err := o.execs.Get(ctx, warning.ExecID)
+	if err != nil {
+		log.Warn().Err(err).Str(types.ExecIDCtxKey, warning.ExecID).Msg("Failed to get exec for webhooks")
+		return
+	}
+
+	exec.ExpiresAt = &warning.ExpiresAt
+
+	o.srv.Notify(ctx, dbExec.ProjectID, types.WebhookEventExecExpiring, types.WebhookExecData{Session: exec})
+}
@@ -0,0 +1,422 @@
+package webhooksrv
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"database/sql"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/rs/zerolog/log"
+	"github.com/unweave/unweave-v1/api/types"
+	"github.com/unweave/unweave-v1/db"
+	"go.jetpack.io/typeid"
+)
+
+const (
+	secretPrefix = "whsec_"
+	secretBytes  = 32
+
+	// SignatureHeader holds the hex encoded HMAC-SHA256 of the delivery's timestamp and
+	// body, see Sign.
+	SignatureHeader = "X-Unweave-Signature"
+	// TimestampHeader holds the Unix time in seconds the delivery attempt was signed at.
+	TimestampHeader = "X-Unweave-Timestamp"
+	EventHeader     = "X-Unweave-Event"
+	DeliveryHeader  = "X-Unweave-Delivery"
+
+	// SignatureTolerance is how far the timestamp of a delivery may be from the receiver's
+	// clock. Receivers should reject deliveries outside of it so captured deliveries can't
+	// be replayed, see Verify.
+	SignatureTolerance = 5 * time.Minute
+
+	// DefaultMaxAttempts is how many times a delivery is attempted before giving up.
+	DefaultMaxAttempts = 5
+	// DefaultBackoff is the wait before the first retry. It doubles on every attempt.
+	DefaultBackoff = 2 * time.Second
+)
+
+var errNotFound = &types.Error{
+	Code:    http.StatusNotFound,
+	Message: "Webhook not found",
+}
+
+type Store interface {
+	WebhookCreate(ctx context.Context, arg db.WebhookCreateParams) error
+	WebhookDelete(ctx context.Context, arg db.WebhookDeleteParams) (int64, error)
+	WebhookDeliveryCreate(ctx context.Context, arg db.WebhookDeliveryCreateParams) error
+	WebhookDeliveryList(ctx context.Context, webhookID string) ([]db.UnweaveWebhookDelivery, error)
+	WebhookGet(ctx context.Context, arg db.WebhookGetParams) (db.UnweaveWebhook, error)
+	WebhookList(ctx context.Context, projectID string) ([]db.UnweaveWebhook, error)
+	WebhookListForEvent(ctx context.Context, arg db.WebhookListForEventParams) ([]db.UnweaveWebhook, error)
+	WebhookUpdate(ctx context.Context, arg db.WebhookUpdateParams) (int64, error)
+}
+
+type Service struct {
+	store Store
+
+	// Client sends the deliveries. Defaults to a client with a 10 second timeout that
+	// refuses to connect to non-public addresses.
+	Client *http.Client
+	// Resolver looks up webhook hosts on create and update. Defaults to net.DefaultResolver.
+	Resolver Resolver
+	// AllowPrivate skips the check that webhook urls resolve to public addresses on create
+	// and update. Only meant for tests and local development, the default Client still
+	// refuses to deliver to them.
+	AllowPrivate bool
+	// MaxAttempts defaults to DefaultMaxAttempts.
+	MaxAttempts int
+	// Backoff defaults to DefaultBackoff.
+	Backoff time.Duration
+}
+
+func NewService(store Store) *Service {
+	return &Service{
+		store:       store,
+		Client:      newClient(),
+		Resolver:    net.DefaultResolver,
+		MaxAttempts: DefaultMaxAttempts,
+		Backoff:     DefaultBackoff,
+	}
+}
+
+// Create adds a webhook to the project. The secret used to sign deliveries is only ever
+// returned here.
+func (s *Service) Create(
+	ctx context.Context,
+	projectID string,
+	params types.WebhookCreateParams,
+) (types.WebhookCreateResponse, error) {
+	if err := s.checkURL(ctx, params.URL); err != nil {
+		return types.WebhookCreateResponse{}, err
+	}
+
+	secret, err := generateSecret()
+	if err != nil {
+		return types.WebhookCreateResponse{}, fmt.Errorf("generate secret: %w", err)
+	}
+
+	webhookID := typeid.Must(typeid.New("whk")).String()
+
+	err = s.store.WebhookCreate(ctx, db.WebhookCreateParams{
+		ID:        webhookID,
+		ProjectID: projectID,
+		Url:       params.URL,
+		Secret:    secret,
+		Events:    eventsToStrings(params.Events),
+	})
+	if err != nil {
+		return types.WebhookCreateResponse{}, fmt.Errorf("create webhook: %w", err)
+	}
+
+	webhook, err := s.Get(ctx, projectID, webhookID)
+	if err != nil {
+		return types.WebhookCreateResponse{}, err
+	}
+
+	return types.WebhookCreateResponse{Webhook: webhook, Secret: secret}, nil
+}
+
+func (s *Service) Get(ctx context.Context, projectID, webhookID string) (types.Webhook, error) {
+	webhook, err := s.store.WebhookGet(ctx, db.WebhookGetParams{ID: webhookID, ProjectID: projectID})
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return types.Webhook{}, errNotFound
+		}
+
+		return types.Webhook{}, fmt.Errorf("get webhook: %w", err)
+	}
+
+	return dbWebhookToType(webhook), nil
+}
+
+func (s *Service) List(ctx context.Context, projectID string) ([]types.Webhook, error) {
+	webhooks, err := s.store.WebhookList(ctx, projectID)
+	if err != nil {
+		return nil, fmt.Errorf("list webhooks: %w", err)
+	}
+
+	res := make([]types.Webhook, len(webhooks))
+	for idx, w := range webhooks {
+		res[idx] = dbWebhookToType(w)
+	}
+
+	return res, nil
+}
+
+func (s *Service) Update(
+	ctx context.Context,
+	projectID, webhookID string,
+	params types.WebhookUpdateParams,
+) (types.Webhook, error) {
+	webhook, err := s.Get(ctx, projectID, webhookID)
+	if err != nil {
+		return types.Webhook{}, err
+	}
+
+	if params.URL != nil {
+		if err := s.checkURL(ctx, *params.URL); err != nil {
+			return types.Webhook{}, err
+		}
+		webhook.URL = *params.URL
+	}
+	if len(params.Events) > 0 {
+		webhook.Events = params.Events
+	}
+
+	n, err := s.store.WebhookUpdate(ctx, db.WebhookUpdateParams{
+		ID:        webhookID,
+		ProjectID: projectID,
+		Url:       webhook.URL,
+		Events:    eventsToStrings(webhook.Events),
+	})
+	if err != nil {
+		return types.Webhook{}, fmt.Errorf("update webhook: %w", err)
+	}
+
+	if n == 0 {
+		return types.Webhook{}, errNotFound
+	}
+
+	return webhook, nil
+}
+
+func (s *Service) Delete(ctx context.Context, projectID, webhookID string) error {
+	n, err := s.store.WebhookDelete(ctx, db.WebhookDeleteParams{ID: webhookID, ProjectID: projectID})
+	if err != nil {
+		return fmt.Errorf("delete webhook: %w", err)
+	}
+
+	if n == 0 {
+		return errNotFound
+	}
+
+	return nil
+}
+
+// Deliveries returns the most recent delivery attempts of the webhook, newest first.
+func (s *Service) Deliveries(ctx context.Context, projectID, webhookID string) ([]types.WebhookDelivery, error) {
+	if _, err := s.Get(ctx, projectID, webhookID); err != nil {
+		return nil, err
+	}
+
+	deliveries, err := s.store.WebhookDeliveryList(ctx, webhookID)
+	if err != nil {
+		return nil, fmt.Errorf("list deliveries: %w", err)
+	}
+
+	res := make([]types.WebhookDelivery, len(deliveries))
+	for idx, d := range deliveries {
+		res[idx] = types.WebhookDelivery{
+			ID:         d.ID,
+			Event:      types.WebhookEvent(d.Event),
+			Attempt:    int(d.Attempt),
+			StatusCode: int(d.StatusCode.Int32),
+			Error:      d.Error.String,
+			CreatedAt:  d.CreatedAt,
+		}
+	}
+
+	return res, nil
+}
+
+// Notify delivers the event to every webhook of the project subscribed to it. Deliveries
+// happen in the background and are retried with exponential backoff, every attempt is
+// recorded in the delivery log.
+func (s *Service) Notify(ctx context.Context, projectID string, event types.WebhookEvent, data any) {
+	webhooks, err := s.store.WebhookListForEvent(ctx, db.WebhookListForEventParams{
+		ProjectID: projectID,
+		Event:     string(event),
+	})
+	if err != nil {
+		log.Ctx(ctx).Error().Err(err).Msgf("Failed to list webhooks for event %q", event)
+		return
+	}
+
+	if len(webhooks) == 0 {
+		return
+	}
+
+	raw, err := json.Marshal(data)
+	if err != nil {
+		log.Ctx(ctx).Error().Err(err).Msgf("Failed to marshal webhook data for event %q", event)
+		return
+	}
+
+	for _, webhook := range webhooks {
+		payload := types.WebhookPayload{
+			ID:        typeid.Must(typeid.New("whd")).String(),
+			Event:     event,
+			ProjectID: projectID,
+			CreatedAt: time.Now(),
+			Data:      raw,
+		}
+
+		//nolint:contextcheck
+		go s.deliver(context.Background(), webhook, payload)
+	}
+}
+
+func (s *Service) deliver(ctx context.Context, webhook db.UnweaveWebhook, payload types.WebhookPayload) {
+	body, err := json.Marshal(payload)
+	if err != nil {
+		log.Error().Err(err).Str("webhook", webhook.ID).Msg("Failed to marshal webhook payload")
+		return
+	}
+
+	backoff := s.Backoff
+
+	for attempt := 1; attempt <= s.MaxAttempts; attempt++ {
+		statusCode, err := s.send(ctx, webhook, payload, body)
+
+		s.recordDelivery(ctx, webhook, payload, body, attempt, statusCode, err)
+
+		if err == nil {
+			return
+		}
+
+		log.Warn().
+			Err(err).
+			Str("webhook", webhook.ID).
+			Msgf("Webhook delivery attempt %d of %d failed", attempt, s.MaxAttempts)
+
+		if attempt < s.MaxAttempts {
+			time.Sleep(backoff)
+			backoff *= 2
+		}
+	}
+}
+
+func (s *Service) send(
+	ctx context.Context,
+	webhook db.UnweaveWebhook,
+	payload types.WebhookPayload,
+	body []byte,
+) (int, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, webhook.Url, bytes.NewReader(body))
+	if err != nil {
+		return 0, fmt.Errorf("build request: %w", err)
+	}
+
+	timestamp := strconv.FormatInt(time.Now().Unix(), 10)
+
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(TimestampHeader, timestamp)
+	req.Header.Set(SignatureHeader, Sign(webhook.Secret, timestamp, body))
+	req.Header.Set(EventHeader, string(payload.Event))
+	req.Header.Set(DeliveryHeader, payload.ID)
+
+	resp, err := s.Client.Do(req)
+	if err != nil {
+		return 0, fmt.Errorf("post: %w", err)
+	}
+	defer resp.Body.Close()
+
+	_, _ = io.Copy(io.Discard, resp.Body)
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return resp.StatusCode, fmt.Errorf("unexpected status code %d", resp.StatusCode)
+	}
+
+	return resp.StatusCode, nil
+}
+
+func (s *Service) recordDelivery(
+	ctx context.Context,
+	webhook db.UnweaveWebhook,
+	payload types.WebhookPayload,
+	body []byte,
+	attempt int,
+	statusCode int,
+	deliveryErr error,
+) {
+	params := db.WebhookDeliveryCreateParams{
+		ID:         typeid.Must(typeid.New("wha")).String(),
+		WebhookID:  webhook.ID,
+		Event:      string(payload.Event),
+		Payload:    body,
+		Attempt:    int32(attempt),
+		StatusCode: sql.NullInt32{Int32: int32(statusCode), Valid: statusCode != 0},
+	}
+	if deliveryErr != nil {
+		params.Error = sql.NullString{String: deliveryErr.Error(), Valid: true}
+	}
+
+	if err := s.store.WebhookDeliveryCreate(ctx, params); err != nil {
+		log.Warn().Err(err).Str("webhook", webhook.ID).Msg("Failed to record webhook delivery")
+	}
+}
+
+// Sign returns the signature sent in the SignatureHeader. It signs the timestamp sent in
+// the TimestampHeader and the body joined by a dot, so a delivery can't be replayed with
+// another timestamp.
+func Sign(secret, timestamp string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(timestamp))
+	mac.Write([]byte("."))
+	mac.Write(body)
+
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// Verify checks the signature of a delivery received at now. Deliveries signed more than
+// SignatureTolerance away from now are rejected.
+func Verify(secret, signature, timestamp string, body []byte, now time.Time) error {
+	unix, err := strconv.ParseInt(timestamp, 10, 64)
+	if err != nil {
+		return fmt.Errorf("parse timestamp: %w", err)
+	}
+
+	if d := now.Sub(time.Unix(unix, 0)); d > SignatureTolerance || d < -SignatureTolerance {
+		return fmt.Errorf("timestamp %s is outside of the tolerance", timestamp)
+	}
+
+	if !hmac.Equal([]byte(signature), []byte(Sign(secret, timestamp, body))) {
+		return errors.New("signature mismatch")
+	}
+
+	return nil
+}
+
+func generateSecret() (string, error) {
+	buf := make([]byte, secretBytes)
+	if _, err := rand.Read(buf); err != nil {
+		return "", err
+	}
+
+	return secretPrefix + base64.RawURLEncoding.EncodeToString(buf), nil
+}
+
+func eventsToStrings(events []types.WebhookEvent) []string {
+	res := make([]string, len(events))
+	for idx, e := range events {
+		res[idx] = string(e)
+	}
+
+	return res
+}
+
+func dbWebhookToType(w db.UnweaveWebhook) types.Webhook {
+	events := make([]types.WebhookEvent, len(w.Events))
+	for idx, e := range w.Events {
+		events[idx] = types.WebhookEvent(e)
+	}
+
+	return types.Webhook{
+		ID:        w.ID,
+		URL:       w.Url,
+		Events:    events,
+		CreatedAt: w.CreatedAt,
+	}
+}
@@ -0,0 +1,424 @@
+package webhooksrv_test
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"net/netip"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"github.com/unweave/unweave-v1/api/types"
+	"github.com/unweave/unweave-v1/db"
+	"github.com/unweave/unweave-v1/services/execsrv"
+	"github.com/unweave/unweave-v1/services/webhooksrv"
+)
+
+type memStore struct {
+	mu         sync.Mutex
+	webhooks   map[string]db.UnweaveWebhook
+	deliveries []db.UnweaveWebhookDelivery
+}
+
+func newMemStore() *memStore {
+	return &memStore{webhooks: make(map[string]db.UnweaveWebhook)}
+}
+
+func (m *memStore) WebhookCreate(_ context.Context, arg db.WebhookCreateParams) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.webhooks[arg.ID] = db.UnweaveWebhook{
+		ID:        arg.ID,
+		ProjectID: arg.ProjectID,
+		Url:       arg.Url,
+		Secret:    arg.Secret,
+		Events:    arg.Events,
+		CreatedAt: time.Now(),
+	}
+
+	return nil
+}
+
+func (m *memStore) WebhookDelete(_ context.Context, arg db.WebhookDeleteParams) (int64, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	w, ok := m.webhooks[arg.ID]
+	if !ok || w.ProjectID != arg.ProjectID || w.DeletedAt.Valid {
+		return 0, nil
+	}
+
+	w.DeletedAt = sql.NullTime{Time: time.Now(), Valid: true}
+	m.webhooks[arg.ID] = w
+
+	return 1, nil
+}
+
+func (m *memStore) WebhookDeliveryCreate(_ context.Context, arg db.WebhookDeliveryCreateParams) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.deliveries = append(m.deliveries, db.UnweaveWebhookDelivery{
+		ID:         arg.ID,
+		WebhookID:  arg.WebhookID,
+		Event:      arg.Event,
+		Payload:    arg.Payload,
+		Attempt:    arg.Attempt,
+		StatusCode: arg.StatusCode,
+		Error:      arg.Error,
+		CreatedAt:  time.Now(),
+	})
+
+	return nil
+}
+
+func (m *memStore) WebhookDeliveryList(_ context.Context, webhookID string) ([]db.UnweaveWebhookDelivery, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	var res []db.UnweaveWebhookDelivery
+
+	for i := len(m.deliveries) - 1; i >= 0; i-- {
+		if m.deliveries[i].WebhookID == webhookID {
+			res = append(res, m.deliveries[i])
+		}
+	}
+
+	return res, nil
+}
+
+func (m *memStore) WebhookGet(_ context.Context, arg db.WebhookGetParams) (db.UnweaveWebhook, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	w, ok := m.webhooks[arg.ID]
+	if !ok || w.ProjectID != arg.ProjectID || w.DeletedAt.Valid {
+		return db.UnweaveWebhook{}, sql.ErrNoRows
+	}
+
+	return w, nil
+}
+
+func (m *memStore) WebhookList(_ context.Context, projectID string) ([]db.UnweaveWebhook, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	var res []db.UnweaveWebhook
+
+	for _, w := range m.webhooks {
+		if w.ProjectID == projectID && !w.DeletedAt.Valid {
+			res = append(res, w)
+		}
+	}
+
+	return res, nil
+}
+
+func (m *memStore) WebhookListForEvent(ctx context.Context, arg db.WebhookListForEventParams) ([]db.UnweaveWebhook, error) {
+	webhooks, _ := m.WebhookList(ctx, arg.ProjectID)
+
+	var res []db.UnweaveWebhook
+
+	for _, w := range webhooks {
+		for _, e := range w.Events {
+			if e == arg.Event {
+				res = append(res, w)
+			}
+		}
+	}
+
+	return res, nil
+}
+
+func (m *memStore) WebhookUpdate(_ context.Context, arg db.WebhookUpdateParams) (int64, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	w, ok := m.webhooks[arg.ID]
+	if !ok || w.ProjectID != arg.ProjectID || w.DeletedAt.Valid {
+		return 0, nil
+	}
+
+	w.Url = arg.Url
+	w.Events = arg.Events
+	m.webhooks[arg.ID] = w
+
+	return 1, nil
+}
+
+// staticResolver resolves hosts from a fixed table so the tests don't depend on DNS.
+type staticResolver map[string]string
+
+func (r staticResolver) LookupNetIP(_ context.Context, _, host string) ([]netip.Addr, error) {
+	addr, ok := r[host]
+	if !ok {
+		return nil, &net.DNSError{Err: "no such host", Name: host, IsNotFound: true}
+	}
+
+	return []netip.Addr{netip.MustParseAddr(addr)}, nil
+}
+
+func TestService_CRUD(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	srv := webhooksrv.NewService(newMemStore())
+	srv.Resolver = staticResolver{"example.com": "93.184.216.34"}
+
+	created, err := srv.Create(ctx, "pr_1", types.WebhookCreateParams{
+		URL:    "https://example.com/hook",
+		Events: []types.WebhookEvent{types.WebhookEventExecRunning},
+	})
+	require.NoError(t, err)
+	require.NotEmpty(t, created.Secret)
+	require.Equal(t, "https://example.com/hook", created.Webhook.URL)
+
+	_, err = srv.Get(ctx, "pr_2", created.Webhook.ID)
+	require.Error(t, err, "webhooks should be scoped to their project")
+
+	url := "https://example.com/other"
+	updated, err := srv.Update(ctx, "pr_1", created.Webhook.ID, types.WebhookUpdateParams{URL: &url})
+	require.NoError(t, err)
+	require.Equal(t, url, updated.URL)
+	require.Equal(t, []types.WebhookEvent{types.WebhookEventExecRunning}, updated.Events)
+
+	webhooks, err := srv.List(ctx, "pr_1")
+	require.NoError(t, err)
+	require.Len(t, webhooks, 1)
+
+	require.NoError(t, srv.Delete(ctx, "pr_1", created.Webhook.ID))
+	require.Error(t, srv.Delete(ctx, "pr_1", created.Webhook.ID))
+
+	webhooks, err = srv.List(ctx, "pr_1")
+	require.NoError(t, err)
+	require.Empty(t, webhooks)
+}
+
+func TestService_Notify(t *testing.T) {
+	t.Parallel()
+
+	var (
+		calls    atomic.Int32
+		received = make(chan *http.Request, 1)
+		bodies   = make(chan []byte, 1)
+	)
+
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// Fail the first attempt to exercise the retry.
+		if calls.Add(1) == 1 {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		body, _ := io.ReadAll(r.Body)
+		received <- r
+		bodies <- body
+	}))
+	t.Cleanup(server.Close)
+
+	ctx := context.Background()
+	store := newMemStore()
+	srv := webhooksrv.NewService(store)
+	srv.Client = server.Client()
+	srv.AllowPrivate = true
+	srv.Backoff = time.Millisecond
+
+	created, err := srv.Create(ctx, "pr_1", types.WebhookCreateParams{
+		URL:    server.URL,
+		Events: []types.WebhookEvent{types.WebhookEventExecTerminated},
+	})
+	require.NoError(t, err)
+
+	srv.Notify(ctx, "pr_1", types.WebhookEventExecRunning, nil)
+	srv.Notify(ctx, "pr_1", types.WebhookEventExecTerminated, types.WebhookExecData{Session: types.Exec{ID: "exc_1"}})
+
+	var (
+		req  *http.Request
+		body []byte
+	)
+
+	select {
+	case req = <-received:
+		body = <-bodies
+	case <-time.After(time.Second):
+		t.Fatal("should have delivered the webhook")
+	}
+
+	timestamp := req.Header.Get(webhooksrv.TimestampHeader)
+	signature := req.Header.Get(webhooksrv.SignatureHeader)
+	require.Equal(t, webhooksrv.Sign(created.Secret, timestamp, body), signature)
+	require.NoError(t, webhooksrv.Verify(created.Secret, signature, timestamp, body, time.Now()))
+
+	later := time.Now().Add(webhooksrv.SignatureTolerance + time.Minute)
+	require.Error(t, webhooksrv.Verify(created.Secret, signature, timestamp, body, later), "should reject replayed deliveries")
+
+	replayed := strconv.FormatInt(later.Unix(), 10)
+	require.Error(t, webhooksrv.Verify(created.Secret, signature, replayed, body, later), "should reject deliveries with a new timestamp")
+	require.Equal(t, string(types.WebhookEventExecTerminated), req.Header.Get(webhooksrv.EventHeader))
+
+	var payload types.WebhookPayload
+	require.NoError(t, json.Unmarshal(body, &payload))
+	require.Equal(t, types.WebhookEventExecTerminated, payload.Event)
+	require.Equal(t, "pr_1", payload.ProjectID)
+
+	var data types.WebhookExecData
+	require.NoError(t, json.Unmarshal(payload.Data, &data))
+	require.Equal(t, "exc_1", data.Session.ID)
+
+	require.Eventually(t, func() bool {
+		deliveries, err := srv.Deliveries(ctx, "pr_1", created.Webhook.ID)
+		return err == nil && len(deliveries) == 2
+	}, time.Second, 5*time.Millisecond)
+
+	deliveries, err := srv.Deliveries(ctx, "pr_1", created.Webhook.ID)
+	require.NoError(t, err)
+	require.Equal(t, 2, deliveries[0].Attempt)
+	require.Equal(t, http.StatusOK, deliveries[0].StatusCode)
+	require.Equal(t, 1, deliveries[1].Attempt)
+	require.Equal(t, http.StatusInternalServerError, deliveries[1].StatusCode)
+	require.NotEmpty(t, deliveries[1].Error)
+	require.EqualValues(t, 2, calls.Load(), "should not deliver events the webhook isn't subscribed to")
+}
+
+func TestService_RejectsPrivateAddresses(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	srv := webhooksrv.NewService(newMemStore())
+	srv.Resolver = staticResolver{
+		"example.com":  "93.184.216.34",
+		"internal.dev": "10.0.0.5",
+	}
+
+	for _, url := range []string{
+		"https://127.0.0.1/hook",
+		"https://[::1]/hook",
+		"https://10.1.2.3/hook",
+		"https://169.254.169.254/latest/meta-data",
+		"https://100.64.0.1/hook",
+		"https://[::ffff:192.168.0.1]/hook",
+		"https://internal.dev/hook",
+		"https://unknown.dev/hook",
+	} {
+		_, err := srv.Create(ctx, "pr_1", types.WebhookCreateParams{URL: url})
+		require.Error(t, err, url)
+
+		var e *types.Error
+		require.ErrorAs(t, err, &e)
+		require.Equal(t, http.StatusBadRequest, e.Code, url)
+	}
+
+	created, err := srv.Create(ctx, "pr_1", types.WebhookCreateParams{URL: "https://example.com/hook"})
+	require.NoError(t, err)
+
+	private := "https://internal.dev/hook"
+	_, err = srv.Update(ctx, "pr_1", created.Webhook.ID, types.WebhookUpdateParams{URL: &private})
+	require.Error(t, err)
+
+	webhook, err := srv.Get(ctx, "pr_1", created.Webhook.ID)
+	require.NoError(t, err)
+	require.Equal(t, "https://example.com/hook", webhook.URL)
+}
+
+func TestService_DefaultClientRefusesPrivateAddresses(t *testing.T) {
+	t.Parallel()
+
+	var calls atomic.Int32
+
+	// Stands in for a host that resolved to a public address when the webhook was created
+	// and was rebound to an internal one since.
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+	}))
+	t.Cleanup(server.Close)
+
+	ctx := context.Background()
+	srv := webhooksrv.NewService(newMemStore())
+	srv.AllowPrivate = true
+	srv.MaxAttempts = 1
+
+	created, err := srv.Create(ctx, "pr_1", types.WebhookCreateParams{
+		URL:    server.URL,
+		Events: []types.WebhookEvent{types.WebhookEventExecRunning},
+	})
+	require.NoError(t, err)
+
+	srv.Notify(ctx, "pr_1", types.WebhookEventExecRunning, nil)
+
+	require.Eventually(t, func() bool {
+		deliveries, err := srv.Deliveries(ctx, "pr_1", created.Webhook.ID)
+		return err == nil && len(deliveries) == 1
+	}, time.Second, 5*time.Millisecond)
+
+	deliveries, err := srv.Deliveries(ctx, "pr_1", created.Webhook.ID)
+	require.NoError(t, err)
+	require.Contains(t, deliveries[0].Error, "not publicly routable")
+	require.Zero(t, calls.Load())
+}
+
+type execStore struct{}
+
+func (execStore) ExecGet(_ context.Context, id string) (db.UnweaveExec, error) {
+	return db.UnweaveExec{ID: id, ProjectID: "pr_1"}, nil
+}
+
+type execService struct {
+	execsrv.Service
+}
+
+func (execService) Get(_ context.Context, id string) (types.Exec, error) {
+	return types.Exec{ID: id}, nil
+}
+
+func TestExpiryObserver(t *testing.T) {
+	t.Parallel()
+
+	bodies := make(chan []byte, 1)
+
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		bodies <- body
+	}))
+	t.Cleanup(server.Close)
+
+	ctx := context.Background()
+	srv := webhooksrv.NewService(newMemStore())
+	srv.Client = server.Client()
+	srv.AllowPrivate = true
+
+	_, err := srv.Create(ctx, "pr_1", types.WebhookCreateParams{
+		URL:    server.URL,
+		Events: []types.WebhookEvent{types.WebhookEventExecExpiring},
+	})
+	require.NoError(t, err)
+
+	expiresAt := time.Now().Add(5 * time.Minute).UTC().Truncate(time.Second)
+	observer := webhooksrv.NewExpiryObserver(srv, execService{}, execStore{})
+	observer.Update(execsrv.ExpiryWarning{ExecID: "exc_1", ExpiresAt: expiresAt})
+
+	var body []byte
+
+	select {
+	case body = <-bodies:
+	case <-time.After(time.Second):
+		t.Fatal("should have delivered the expiry warning")
+	}
+
+	var payload types.WebhookPayload
+	require.NoError(t, json.Unmarshal(body, &payload))
+	require.Equal(t, types.WebhookEventExecExpiring, payload.Event)
+
+	var data types.WebhookExecData
+	require.NoError(t, json.Unmarshal(payload.Data, &data))
+	require.Equal(t, "exc_1", data.Session.ID)
+	require.True(t, expiresAt.Equal(*data.Session.ExpiresAt))
+}
@@ -0,0 +1,64 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/unweave/unweave-v1/api/types"
+	"github.com/unweave/unweave-v1/db"
+	"github.com/unweave/unweave-v1/services/projectsrv"
+	"github.com/unweave/unweave-v1/services/tokensrv"
+)
+
+const tokenUsage = `Usage: unweave token create -account <id> [-name <name>] [-project <id>]
+
+Creates an access token for the account, creating the account if it doesn't exist. This
+is how the first token is issued on a new deployment, the admin routes need one to call.`
+
+// runTokenCommand handles the token subcommand and returns the process exit code.
+func runTokenCommand(ctx context.Context, args []string) int {
+	if len(args) == 0 || args[0] != "create" {
+		fmt.Fprintln(os.Stderr, tokenUsage)
+		return 2
+	}
+
+	fs := flag.NewFlagSet("token create", flag.ContinueOnError)
+	fs.Usage = func() { fmt.Fprintln(os.Stderr, tokenUsage) }
+
+	accountID := fs.String("account", "", "account to create the token for")
+	name := fs.String("name", "bootstrap", "name of the token")
+	projectID := fs.String("project", "", "project to give the account access to")
+
+	if err := fs.Parse(args[1:]); err != nil {
+		return 2
+	}
+	if *accountID == "" {
+		fs.Usage()
+		return 2
+	}
+
+	params := types.AccessTokenCreateParams{Name: *name}
+	res, err := tokensrv.NewService(db.Q).Mint(ctx, *accountID, params)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Failed to create access token: %v\n", err)
+		return 1
+	}
+
+	if *projectID != "" {
+		if err = projectsrv.NewService(db.Q).AddAccount(ctx, *projectID, *accountID); err != nil {
+			fmt.Fprintf(os.Stderr, "Failed to add account to project: %v\n", err)
+			return 1
+		}
+	}
+
+	enc := json.NewEncoder(os.Stdout)
+	enc.SetIndent("", "  ")
+	if err = enc.Encode(res); err != nil {
+		return 1
+	}
+
+	return 0
+}